@@ -0,0 +1,62 @@
+package api
+
+import (
+	"database/sql"
+	db "inventory_management/db/sqlc"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listAuditLogRequest struct {
+	EntityType string    `form:"entity_type" binding:"omitempty,oneof=category unit good stock_movement warehouse location user reservation supplier good_supplier purchase_order purchase_order_line sales_order sales_order_line transfer_order transfer_order_line count_session count_session_item lot"`
+	EntityID   string    `form:"entity_id"`
+	Actor      string    `form:"actor"`
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00" binding:"omitempty,gtfield=From"`
+	PageID     int32     `form:"page_id" binding:"required,min=1"`
+	PageSize   int32     `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listAuditLog lists the audit log from the newest entry, the time range includes from and excludes to
+func (server *Server) listAuditLog(c *gin.Context) {
+	var req listAuditLogRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListAuditLogsParams{
+		EntityType: sql.NullString{
+			String: req.EntityType,
+			Valid:  req.EntityType != "",
+		},
+		EntityID: sql.NullString{
+			String: req.EntityID,
+			Valid:  req.EntityID != "",
+		},
+		Actor: sql.NullString{
+			String: req.Actor,
+			Valid:  req.Actor != "",
+		},
+		FromTime: sql.NullTime{
+			Time:  req.From,
+			Valid: !req.From.IsZero(),
+		},
+		ToTime: sql.NullTime{
+			Time:  req.To,
+			Valid: !req.To.IsZero(),
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	logs, err := server.store.ListAuditLogs(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
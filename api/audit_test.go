@@ -0,0 +1,210 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditLog(t *testing.T) {
+	n := 5
+	logs := make([]db.AuditLog, n)
+	for i := 0; i < n; i++ {
+		logs[i] = randomAuditLog()
+	}
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	testCases := []struct {
+		name          string
+		query         map[string]string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: map[string]string{
+				"page_id":   "1",
+				"page_size": fmt.Sprint(n),
+			},
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAuditLogsParams{
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Eq(arg)).Times(1).Return(logs, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAuditLogs(t, recorder.Body, logs)
+			},
+		},
+		{
+			name: "Filters",
+			query: map[string]string{
+				"entity_type": db.EntityCategory,
+				"entity_id":   "7",
+				"actor":       "admin",
+				"from":        from.Format(time.RFC3339),
+				"to":          to.Format(time.RFC3339),
+				"page_id":     "2",
+				"page_size":   fmt.Sprint(n),
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAuditLogsParams{
+					EntityType: sql.NullString{String: db.EntityCategory, Valid: true},
+					EntityID:   sql.NullString{String: "7", Valid: true},
+					Actor:      sql.NullString{String: "admin", Valid: true},
+					FromTime:   sql.NullTime{Time: from, Valid: true},
+					ToTime:     sql.NullTime{Time: to, Valid: true},
+					Limit:      int32(n),
+					Offset:     int32(n),
+				}
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Eq(arg)).Times(1).Return(logs, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: map[string]string{
+				"page_id":   "1",
+				"page_size": fmt.Sprint(n),
+			},
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(1).Return([]db.AuditLog{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEntityType",
+			query: map[string]string{
+				"entity_type": "invoice",
+				"page_id":     "1",
+				"page_size":   fmt.Sprint(n),
+			},
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidTimeRange",
+			query: map[string]string{
+				"from":      to.Format(time.RFC3339),
+				"to":        from.Format(time.RFC3339),
+				"page_id":   "1",
+				"page_size": fmt.Sprint(n),
+			},
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			query: map[string]string{
+				"page_id":   "1",
+				"page_size": "10000",
+			},
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			query: map[string]string{
+				"page_id":   "1",
+				"page_size": fmt.Sprint(n),
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditLogs(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/audit", nil)
+			require.NoError(t, err)
+
+			q := request.URL.Query()
+			for key, value := range tc.query {
+				q.Add(key, value)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomAuditLog() db.AuditLog {
+	return db.AuditLog{
+		ID:         util.RandomInt(1, 1000),
+		Actor:      util.RandomName(),
+		Action:     db.AuditActionUpdate,
+		EntityType: db.EntityUnit,
+		EntityID:   fmt.Sprint(util.RandomInt(1, 1000)),
+		Before:     json.RawMessage(`{"unit_name":"box"}`),
+		After:      json.RawMessage(`{"unit_name":"crate"}`),
+	}
+}
+
+func requireBodyMatchAuditLogs(t *testing.T, body *bytes.Buffer, logs []db.AuditLog) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotLogs []db.AuditLog
+	err = json.Unmarshal(data, &gotLogs)
+	require.NoError(t, err)
+	require.Equal(t, logs, gotLogs)
+}
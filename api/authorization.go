@@ -0,0 +1,283 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Permissions are written as resource:action
+const (
+	permCategoriesRead        = "categories:read"
+	permCategoriesCreate      = "categories:create"
+	permCategoriesUpdate      = "categories:update"
+	permCategoriesDelete      = "categories:delete"
+	permUnitsRead             = "units:read"
+	permUnitsCreate           = "units:create"
+	permUnitsUpdate           = "units:update"
+	permUnitsDelete           = "units:delete"
+	permGoodsRead             = "goods:read"
+	permGoodsCreate           = "goods:create"
+	permGoodsUpdate           = "goods:update"
+	permGoodsDelete           = "goods:delete"
+	permStockRead             = "stock:read"
+	permStockCreate           = "stock:create"
+	permWarehousesRead        = "warehouses:read"
+	permWarehousesCreate      = "warehouses:create"
+	permWarehousesUpdate      = "warehouses:update"
+	permWarehousesDelete      = "warehouses:delete"
+	permLocationsRead         = "locations:read"
+	permLocationsCreate       = "locations:create"
+	permLocationsUpdate       = "locations:update"
+	permLocationsDelete       = "locations:delete"
+	permReservationsRead      = "reservations:read"
+	permReservationsCreate    = "reservations:create"
+	permReservationsRelease   = "reservations:release"
+	permSuppliersRead         = "suppliers:read"
+	permSuppliersCreate       = "suppliers:create"
+	permSuppliersUpdate       = "suppliers:update"
+	permSuppliersDelete       = "suppliers:delete"
+	permPurchaseOrdersRead    = "purchase_orders:read"
+	permPurchaseOrdersCreate  = "purchase_orders:create"
+	permPurchaseOrdersReceive = "purchase_orders:receive"
+	permSalesOrdersRead       = "sales_orders:read"
+	permSalesOrdersCreate     = "sales_orders:create"
+	permSalesOrdersShip       = "sales_orders:ship"
+	permTransferOrdersRead    = "transfer_orders:read"
+	permTransferOrdersCreate  = "transfer_orders:create"
+	permTransferOrdersShip    = "transfer_orders:ship"
+	permCountSessionsRead     = "count_sessions:read"
+	permCountSessionsCreate   = "count_sessions:create"
+	permCountSessionsCount    = "count_sessions:count"
+	permCountSessionsApprove  = "count_sessions:approve"
+	permLabelTemplatesRead    = "label_templates:read"
+	permLabelTemplatesCreate  = "label_templates:create"
+	permLabelTemplatesUpdate  = "label_templates:update"
+	permLabelTemplatesDelete  = "label_templates:delete"
+	permUsersUpdate           = "users:update"
+	permAuditRead             = "audit:read"
+	permReportsRead           = "reports:read"
+)
+
+var (
+	errOutOfScope           = errors.New("the category is outside of the scope of the user")
+	errIncludeDeletedDenied = errors.New("only admins can see deleted rows")
+	errScopedListDenied     = errors.New("users with a scope must filter the list")
+)
+
+// readPermissions can be used by every role
+var readPermissions = []string{
+	permCategoriesRead,
+	permUnitsRead,
+	permGoodsRead,
+	permStockRead,
+	permWarehousesRead,
+	permLocationsRead,
+	permReservationsRead,
+	permSuppliersRead,
+	permPurchaseOrdersRead,
+	permSalesOrdersRead,
+	permTransferOrdersRead,
+	permCountSessionsRead,
+	permLabelTemplatesRead,
+}
+
+// managePermissions are the inventory permissions of a warehouse manager
+var managePermissions = append([]string{
+	permCategoriesCreate, permCategoriesUpdate, permCategoriesDelete,
+	permUnitsCreate, permUnitsUpdate, permUnitsDelete,
+	permGoodsCreate, permGoodsUpdate, permGoodsDelete,
+	permStockCreate,
+	permWarehousesCreate, permWarehousesUpdate, permWarehousesDelete,
+	permLocationsCreate, permLocationsUpdate, permLocationsDelete,
+	permReservationsCreate, permReservationsRelease,
+	permSuppliersCreate, permSuppliersUpdate, permSuppliersDelete,
+	permPurchaseOrdersCreate, permPurchaseOrdersReceive,
+	permSalesOrdersCreate, permSalesOrdersShip,
+	permTransferOrdersCreate, permTransferOrdersShip,
+	permCountSessionsCreate, permCountSessionsCount, permCountSessionsApprove,
+	permLabelTemplatesCreate, permLabelTemplatesUpdate, permLabelTemplatesDelete,
+	permReportsRead,
+}, readPermissions...)
+
+// clerkPermissions let a clerk book stock and hold it for orders
+var clerkPermissions = append([]string{
+	permGoodsCreate, permGoodsUpdate,
+	permStockCreate,
+	permReservationsCreate, permReservationsRelease,
+	permPurchaseOrdersReceive,
+	permSalesOrdersCreate, permSalesOrdersShip,
+	permTransferOrdersShip,
+	permCountSessionsCount,
+}, readPermissions...)
+
+// rolePermissions maps every role to the set of permissions granted to it, db.RoleNone has none
+var rolePermissions = map[string]map[string]bool{
+	db.RoleAdmin:            permissionSet(append([]string{permUsersUpdate, permAuditRead}, managePermissions...)...),
+	db.RoleWarehouseManager: permissionSet(managePermissions...),
+	db.RoleClerk:            permissionSet(clerkPermissions...),
+	db.RoleAuditor:          permissionSet(append([]string{permAuditRead, permReportsRead}, readPermissions...)...),
+}
+
+func permissionSet(permissions ...string) map[string]bool {
+	set := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		set[permission] = true
+	}
+	return set
+}
+
+// hasPermission reports whether the role is granted the permission, unknown roles have none
+func hasPermission(role string, permission string) bool {
+	return rolePermissions[role][permission]
+}
+
+// authorize creates a gin middleware rejecting the tokens whose role lacks the permission,
+// it must run after authMiddleware
+func authorize(permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		if !hasPermission(authPayload.Role, permission) {
+			err := fmt.Errorf("role %s does not have the %s permission", authPayload.Role, permission)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// inScope reports whether the category belongs to the scope of the token
+func inScope(authPayload *token.Payload, category db.Category) bool {
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+	for _, categoryID := range authPayload.Scope.Categories {
+		if categoryID == category.ID {
+			return true
+		}
+	}
+	return inSectionScope(authPayload, category.SectionName)
+}
+
+// inSectionScope reports whether the categories of the section belong to the scope of the token
+func inSectionScope(authPayload *token.Payload, sectionName string) bool {
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+	for _, section := range authPayload.Scope.Sections {
+		if section == sectionName {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeCategory checks the category against the scope of the request and writes
+// the error response when the request must stop. Unscoped requests never hit the store.
+func (server *Server) authorizeCategory(c *gin.Context, categoryID int64) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+	for _, id := range authPayload.Scope.Categories {
+		if id == categoryID {
+			return true
+		}
+	}
+	if len(authPayload.Scope.Sections) == 0 {
+		c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+		return false
+	}
+
+	// deleted categories keep their scope, so they can be restored by their users
+	category, err := server.store.GetCategoryIncludingDeleted(c, categoryID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	if !inSectionScope(authPayload, category.SectionName) {
+		c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+		return false
+	}
+	return true
+}
+
+// authorizeGood checks the category of the good against the scope of the request and writes
+// the error response when the request must stop. Unscoped requests never hit the store.
+func (server *Server) authorizeGood(c *gin.Context, goodID int64) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+
+	good, err := server.store.GetGoodIncludingDeleted(c, goodID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	return server.authorizeCategory(c, good.Category)
+}
+
+// authorizeGoods checks the scope of the request against every good, each good is checked once.
+// It writes the error response for the first good out of scope.
+func (server *Server) authorizeGoods(c *gin.Context, goodIDs []int64) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+
+	checked := make(map[int64]bool, len(goodIDs))
+	for _, goodID := range goodIDs {
+		if checked[goodID] {
+			continue
+		}
+		if !server.authorizeGood(c, goodID) {
+			return false
+		}
+		checked[goodID] = true
+	}
+	return true
+}
+
+// includeDeletedRequest is the query option asking for deleted rows as well
+type includeDeletedRequest struct {
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// authorizeIncludeDeleted lets only admins see deleted rows and writes the error response otherwise
+func authorizeIncludeDeleted(c *gin.Context, includeDeleted bool) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if includeDeleted && authPayload.Role != db.RoleAdmin {
+		c.JSON(http.StatusForbidden, errorResponse(errIncludeDeletedDenied))
+		return false
+	}
+	return true
+}
+
+// authorizeUnfilteredList rejects the lists spanning every category for scoped users and writes the error response
+func authorizeUnfilteredList(c *gin.Context, filtered bool) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !filtered && authPayload.Role != db.RoleAdmin && !authPayload.Scope.IsEmpty() {
+		c.JSON(http.StatusForbidden, errorResponse(errScopedListDenied))
+		return false
+	}
+	return true
+}
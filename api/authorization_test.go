@@ -0,0 +1,405 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolePermissions(t *testing.T) {
+	testCases := []struct {
+		role    string
+		allowed []string
+		denied  []string
+	}{
+		{
+			role:    db.RoleAdmin,
+			allowed: []string{permUsersUpdate, permUnitsDelete, permGoodsUpdate, permStockCreate},
+		},
+		{
+			role:    db.RoleWarehouseManager,
+			allowed: []string{permCategoriesDelete, permUnitsDelete, permWarehousesCreate, permStockCreate},
+			denied:  []string{permUsersUpdate},
+		},
+		{
+			role:    db.RoleClerk,
+			allowed: []string{permGoodsCreate, permGoodsUpdate, permStockCreate, permLocationsRead},
+			denied:  []string{permGoodsDelete, permUnitsDelete, permCategoriesCreate, permUsersUpdate},
+		},
+		{
+			role:    db.RoleAuditor,
+			allowed: []string{permCategoriesRead, permGoodsRead, permStockRead, permWarehousesRead},
+			denied:  []string{permGoodsUpdate, permStockCreate, permUnitsCreate, permUsersUpdate},
+		},
+		{
+			role:   db.RoleNone,
+			denied: []string{permCategoriesRead, permGoodsRead, permAuditRead, permReportsRead},
+		},
+		{
+			role:   "unknown",
+			denied: []string{permGoodsRead},
+		},
+	}
+
+	for _, tc := range testCases {
+		for _, permission := range tc.allowed {
+			require.True(t, hasPermission(tc.role, permission), "%s %s", tc.role, permission)
+		}
+		for _, permission := range tc.denied {
+			require.False(t, hasPermission(tc.role, permission), "%s %s", tc.role, permission)
+		}
+	}
+}
+
+func TestAuthorizeMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		role          string
+		method        string
+		url           string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ClerkDeleteGood",
+			role:   db.RoleClerk,
+			method: http.MethodDelete,
+			url:    "/goods/1",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "AuditorCreateReceipt",
+			role:   db.RoleAuditor,
+			method: http.MethodPost,
+			url:    "/goods/1/receipts",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ManagerDeleteUnit",
+			role:   db.RoleWarehouseManager,
+			method: http.MethodDelete,
+			url:    "/units/0",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				// past the permission check the invalid id is rejected by the handler
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "AuditorGetGood",
+			role:   db.RoleAuditor,
+			method: http.MethodGet,
+			url:    "/goods/0",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// no stub is set, the request never reaches the store
+			store := mockdb.NewMockStore(ctrl)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(tc.method, tc.url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCategoryScope(t *testing.T) {
+	category := randomCategory()
+	other := randomCategory()
+	other.SectionName = category.SectionName + "x"
+
+	testCases := []struct {
+		name          string
+		scope         token.Scope
+		categoryID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "CategoryInScope",
+			scope:      token.Scope{Categories: []int64{category.ID}},
+			categoryID: category.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, category)
+			},
+		},
+		{
+			name:       "SectionInScope",
+			scope:      token.Scope{Sections: []string{category.SectionName}},
+			categoryID: category.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "OutOfScope",
+			scope:      token.Scope{Sections: []string{category.SectionName}},
+			categoryID: other.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(other.ID)).Times(1).Return(other, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/categories/%d", tc.categoryID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListCategoryScope(t *testing.T) {
+	n := 5
+	categories := make([]db.Category, n)
+	for i := 0; i < n; i++ {
+		categories[i] = randomCategory()
+		// random ids may collide
+		categories[i].ID = int64(i + 1)
+	}
+	scope := token.Scope{Categories: []int64{categories[1].ID, categories[3].ID}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().ListCategories(gomock.Any(), gomock.Any()).Times(1).Return(categories, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/categories?page_id=1&page_size=5", nil)
+	require.NoError(t, err)
+
+	addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, scope, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	requireBodyMatchCategories(t, recorder.Body, []db.Category{categories[1], categories[3]})
+}
+
+func TestCreateCategoryScope(t *testing.T) {
+	category := randomCategory()
+
+	testCases := []struct {
+		name          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "SectionInScope",
+			scope: token.Scope{Sections: []string{category.SectionName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Any()).Times(1).Return(category, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "SectionOutOfScope",
+			scope: token.Scope{Sections: []string{category.SectionName + "x"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "OnlyCategoriesInScope",
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"category_name": category.CategoryName,
+				"section_name":  category.SectionName,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/categories", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleWarehouseManager, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestGoodScope(t *testing.T) {
+	category := randomCategory()
+	good := randomGood()
+	good.Category = category.ID
+	movement := randomStockMovement(good)
+
+	testCases := []struct {
+		name          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "CategoryInScope",
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(1).Return([]db.StockMovement{movement}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := ioutil.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var gotMovements []db.StockMovement
+				err = json.Unmarshal(data, &gotMovements)
+				require.NoError(t, err)
+				require.Len(t, gotMovements, 1)
+			},
+		},
+		{
+			name:  "CategoryOutOfScope",
+			scope: token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "SectionInScope",
+			scope: token.Scope{Sections: []string{category.SectionName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().GetCategoryIncludingDeleted(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(1).Return([]db.StockMovement{movement}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "GoodNotFound",
+			scope: token.Scope{Sections: []string{category.SectionName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(db.Good{}, sql.ErrNoRows)
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "CategoryInternalError",
+			scope: token.Scope{Sections: []string{category.SectionName}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().GetCategoryIncludingDeleted(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrConnDone)
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/goods/%d/movements?page_id=1&page_size=5", good.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var errLookupKey = errors.New("look a good up either by barcode or by sku")
+
+type barcodeRequest struct {
+	Barcode   string `json:"barcode" binding:"required"`
+	Symbology string `json:"symbology" binding:"required,oneof=ean13 upca code128"`
+}
+
+// newBarcodeEntries checks the check digits of the barcodes, which the binding cannot do
+func newBarcodeEntries(barcodes []barcodeRequest) ([]db.BarcodeEntry, error) {
+	var entries []db.BarcodeEntry
+	for _, barcode := range barcodes {
+		if err := util.ValidateBarcode(barcode.Symbology, barcode.Barcode); err != nil {
+			return nil, err
+		}
+		entries = append(entries, db.BarcodeEntry{
+			Barcode:   barcode.Barcode,
+			Symbology: barcode.Symbology,
+		})
+	}
+	return entries, nil
+}
+
+type createGoodBarcodeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) createGoodBarcode(c *gin.Context) {
+	var req createGoodBarcodeRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqBarcode barcodeRequest
+	if err := c.ShouldBindJSON(&reqBarcode); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := util.ValidateBarcode(reqBarcode.Symbology, reqBarcode.Barcode); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateGoodBarcodeTxParams{
+		CreateGoodBarcodeParams: db.CreateGoodBarcodeParams{
+			GoodID:    req.ID,
+			Barcode:   reqBarcode.Barcode,
+			Symbology: reqBarcode.Symbology,
+		},
+		Actor: authPayload.Username,
+	}
+
+	barcode, err := server.store.CreateGoodBarcodeTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, barcode)
+}
+
+type listGoodBarcodeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listGoodBarcode(c *gin.Context) {
+	var req listGoodBarcodeRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	barcodes, err := server.store.ListGoodBarcodes(c, req.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, barcodes)
+}
+
+type deleteGoodBarcodeRequest struct {
+	ID      int64  `uri:"id" binding:"required,min=1"`
+	Barcode string `uri:"barcode" binding:"required"`
+}
+
+func (server *Server) deleteGoodBarcode(c *gin.Context) {
+	var req deleteGoodBarcodeRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteGoodBarcodeTxParams{
+		DeleteGoodBarcodeParams: db.DeleteGoodBarcodeParams{
+			GoodID:  req.ID,
+			Barcode: req.Barcode,
+		},
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteGoodBarcodeTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "barcode deleted successfully",
+	})
+}
+
+type setGoodSkuRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type setGoodSkuRequestJson struct {
+	// an empty sku clears it
+	Sku string `json:"sku" binding:"max=64"`
+}
+
+func (server *Server) setGoodSku(c *gin.Context) {
+	var req setGoodSkuRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqSku setGoodSkuRequestJson
+	if err := c.ShouldBindJSON(&reqSku); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.SetGoodSkuTxParams{
+		SetGoodSkuParams: db.SetGoodSkuParams{
+			ID: req.ID,
+			Sku: sql.NullString{
+				String: reqSku.Sku,
+				Valid:  reqSku.Sku != "",
+			},
+		},
+		Version: version,
+		Actor:   authPayload.Username,
+	}
+
+	good, err := server.store.SetGoodSkuTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, newGoodStock(good))
+}
+
+type lookupGoodRequest struct {
+	Barcode string `form:"barcode"`
+	Sku     string `form:"sku"`
+}
+
+// goodLookupResponse is the good a scan resolves to with its balances and all of its barcodes
+type goodLookupResponse struct {
+	goodResponse
+	Barcodes []db.GoodBarcode `json:"barcodes"`
+}
+
+func (server *Server) lookupGood(c *gin.Context) {
+	var req lookupGoodRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if (req.Barcode == "") == (req.Sku == "") {
+		c.JSON(http.StatusBadRequest, errorResponse(errLookupKey))
+		return
+	}
+
+	var good db.Good
+	var err error
+	if req.Barcode != "" {
+		good, err = server.getGoodByBarcode(c, req.Barcode)
+	} else {
+		good, err = server.store.GetGoodBySku(c, sql.NullString{String: req.Sku, Valid: true})
+	}
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeCategory(c, good.Category) {
+		return
+	}
+
+	balances, err := server.store.ListGoodBalances(c, good.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	barcodes, err := server.store.ListGoodBarcodes(c, good.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, goodLookupResponse{
+		goodResponse: newGoodResponse(good, balances),
+		Barcodes:     barcodes,
+	})
+}
+
+// getGoodByBarcode gets the good labelled with the barcode. Scanners report UPC-A barcodes
+// as EAN-13 with a leading zero, so such a scan also finds the UPC-A barcode without it.
+func (server *Server) getGoodByBarcode(c *gin.Context, barcode string) (db.Good, error) {
+	goodBarcode, err := server.store.GetGoodBarcode(c, barcode)
+	if err == sql.ErrNoRows && len(barcode) == 13 && strings.HasPrefix(barcode, "0") {
+		goodBarcode, err = server.store.GetGoodBarcode(c, barcode[1:])
+	}
+	if err != nil {
+		return db.Good{}, err
+	}
+
+	return server.store.GetGood(c, goodBarcode.GoodID)
+}
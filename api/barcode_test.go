@@ -0,0 +1,639 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGoodBarcode(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	barcode := randomGoodBarcode(good.ID)
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   barcode.Barcode,
+				"symbology": barcode.Symbology,
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateGoodBarcodeTxParams{
+					CreateGoodBarcodeParams: db.CreateGoodBarcodeParams{
+						GoodID:    good.ID,
+						Barcode:   barcode.Barcode,
+						Symbology: barcode.Symbology,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(barcode, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.GoodBarcode
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, barcode, got)
+			},
+		},
+		{
+			name:   "Code128",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   "PART-0042",
+				"symbology": util.BarcodeCode128,
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(1).Return(barcode, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "WrongCheckDigit",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   "4006381333932",
+				"symbology": util.BarcodeEAN13,
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "UnknownSymbology",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   barcode.Barcode,
+				"symbology": "qr",
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DuplicateBarcode",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   barcode.Barcode,
+				"symbology": barcode.Symbology,
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(1).Return(db.GoodBarcode{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   barcode.Barcode,
+				"symbology": barcode.Symbology,
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(1).Return(db.GoodBarcode{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "AuditorForbidden",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   barcode.Barcode,
+				"symbology": barcode.Symbology,
+			},
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			body: gin.H{
+				"barcode":   barcode.Barcode,
+				"symbology": barcode.Symbology,
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(1).Return(db.GoodBarcode{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/barcodes", tc.goodID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListGoodBarcode(t *testing.T) {
+	good := randomGood()
+	barcodes := []db.GoodBarcode{randomGoodBarcode(good.ID), randomGoodBarcode(good.ID)}
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(barcodes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.GoodBarcode
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, barcodes, got)
+			},
+		},
+		{
+			name:   "InvalidID",
+			goodID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/barcodes", tc.goodID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteGoodBarcode(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	barcode := randomGoodBarcode(good.ID)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DeleteGoodBarcodeTxParams{
+					DeleteGoodBarcodeParams: db.DeleteGoodBarcodeParams{
+						GoodID:  good.ID,
+						Barcode: barcode.Barcode,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().DeleteGoodBarcodeTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoodBarcodeTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/barcodes/%s", good.ID, barcode.Barcode)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, db.RoleClerk, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetGoodSku(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	updated := good
+	updated.Sku = sql.NullString{String: util.RandomString(8), Valid: true}
+	updated.Version++
+
+	ifMatch := fmt.Sprintf(`"%d"`, good.Version)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		ifMatch       string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			body:    gin.H{"sku": updated.Sku.String},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetGoodSkuTxParams{
+					SetGoodSkuParams: db.SetGoodSkuParams{
+						ID:  good.ID,
+						Sku: updated.Sku,
+					},
+					Version: good.Version,
+					Actor:   actor,
+				}
+				store.EXPECT().SetGoodSkuTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, fmt.Sprintf(`"%d"`, updated.Version), recorder.Header().Get("ETag"))
+
+				var got goodStock
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, updated.Sku, got.Sku)
+			},
+		},
+		{
+			name:    "Clear",
+			body:    gin.H{"sku": ""},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetGoodSkuTxParams{
+					SetGoodSkuParams: db.SetGoodSkuParams{
+						ID: good.ID,
+					},
+					Version: good.Version,
+					Actor:   actor,
+				}
+				store.EXPECT().SetGoodSkuTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MissingIfMatch",
+			body: gin.H{"sku": updated.Sku.String},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodSkuTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "DuplicateSku",
+			body:    gin.H{"sku": updated.Sku.String},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodSkuTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "VersionMismatch",
+			body:    gin.H{"sku": updated.Sku.String},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodSkuTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, db.ErrVersionMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			body:    gin.H{"sku": updated.Sku.String},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodSkuTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/sku", good.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, db.RoleWarehouseManager, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestLookupGood(t *testing.T) {
+	good := randomGood()
+	good.Sku = sql.NullString{String: util.RandomString(8), Valid: true}
+	barcode := randomGoodBarcode(good.ID)
+	upca := db.GoodBarcode{
+		ID:        util.RandomInt(1, 1000),
+		GoodID:    good.ID,
+		Barcode:   "036000291452",
+		Symbology: util.BarcodeUPCA,
+	}
+	barcodes := []db.GoodBarcode{barcode, upca}
+	balances := []db.ListGoodBalancesRow{
+		{WarehouseID: util.RandomInt(1, 1000), Amount: good.Amount},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "ByBarcode",
+			query: "barcode=" + barcode.Barcode,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Eq(barcode.Barcode)).Times(1).Return(barcode, nil)
+				store.EXPECT().GetGood(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(balances, nil)
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(barcodes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, fmt.Sprintf(`"%d"`, good.Version), recorder.Header().Get("ETag"))
+
+				var got goodLookupResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, good.ID, got.ID)
+				require.Equal(t, good.Amount, got.Total)
+				require.Equal(t, barcodes, got.Barcodes)
+			},
+		},
+		{
+			name:  "UPCAScannedAsEAN13",
+			query: "barcode=0" + upca.Barcode,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Eq("0"+upca.Barcode)).Times(1).Return(db.GoodBarcode{}, sql.ErrNoRows)
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Eq(upca.Barcode)).Times(1).Return(upca, nil)
+				store.EXPECT().GetGood(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(balances, nil)
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(barcodes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "BySku",
+			query: "sku=" + good.Sku.String,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Eq(good.Sku)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(balances, nil)
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(barcodes, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownBarcode",
+			// without a leading zero, so it is not tried as UPC-A as well
+			query: "barcode=4006381333931",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Eq("4006381333931")).Times(1).Return(db.GoodBarcode{}, sql.ErrNoRows)
+				store.EXPECT().GetGood(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "DeletedGood",
+			query: "barcode=" + barcode.Barcode,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Any()).Times(1).Return(barcode, nil)
+				store.EXPECT().GetGood(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(db.Good{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingKey",
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "BothKeys",
+			query: "barcode=" + barcode.Barcode + "&sku=" + good.Sku.String,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			query: "sku=" + good.Sku.String,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Any()).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "sku=" + good.Sku.String,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Any()).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Any()).Times(1).Return(balances, nil)
+				store.EXPECT().ListGoodBarcodes(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/goods/lookup?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomGoodBarcode(goodID int64) db.GoodBarcode {
+	return db.GoodBarcode{
+		ID:        util.RandomInt(1, 1000),
+		GoodID:    goodID,
+		Barcode:   util.RandomEAN13(),
+		Symbology: util.BarcodeEAN13,
+	}
+}
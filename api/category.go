@@ -2,7 +2,9 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	db "inventory_management/db/sqlc"
+	"inventory_management/token"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,18 +22,28 @@ func (server *Server) createCategory(c *gin.Context) {
 		return
 	}
 
-	arg := db.CreateCategoryParams{
-		CategoryName: req.CategoryName,
-		SectionName:  req.SectionName,
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !inSectionScope(authPayload, req.SectionName) {
+		c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+		return
+	}
+
+	arg := db.CreateCategoryTxParams{
+		CreateCategoryParams: db.CreateCategoryParams{
+			CategoryName: req.CategoryName,
+			SectionName:  req.SectionName,
+		},
+		Actor: authPayload.Username,
 	}
 
-	category, err := server.store.CreateCategory(c, arg)
+	category, err := server.store.CreateCategoryTx(c, arg)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	setETag(c, category.Version)
 	c.JSON(http.StatusOK, category)
 }
 
@@ -46,7 +58,23 @@ func (server *Server) getCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := server.store.GetCategory(c, req.ID)
+	var reqDeleted includeDeletedRequest
+	if err := c.ShouldBindQuery(&reqDeleted); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeIncludeDeleted(c, reqDeleted.IncludeDeleted) {
+		return
+	}
+
+	var category db.Category
+	var err error
+	if reqDeleted.IncludeDeleted {
+		category, err = server.store.GetCategoryIncludingDeleted(c, req.ID)
+	} else {
+		category, err = server.store.GetCategory(c, req.ID)
+	}
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -57,12 +85,20 @@ func (server *Server) getCategory(c *gin.Context) {
 		return
 	}
 
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !inScope(authPayload, category) {
+		c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+		return
+	}
+
+	setETag(c, category.Version)
 	c.JSON(http.StatusOK, category)
 }
 
 type listCategoryRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageID         int32 `form:"page_id" binding:"required,min=1"`
+	PageSize       int32 `form:"page_size" binding:"required,min=5,max=10"`
+	IncludeDeleted bool  `form:"include_deleted"`
 }
 
 func (server *Server) listCategory(c *gin.Context) {
@@ -72,9 +108,14 @@ func (server *Server) listCategory(c *gin.Context) {
 		return
 	}
 
+	if !authorizeIncludeDeleted(c, req.IncludeDeleted) {
+		return
+	}
+
 	arg := db.ListCategoriesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		IncludeDeleted: req.IncludeDeleted,
+		Limit:          req.PageSize,
+		Offset:         (req.PageID - 1) * req.PageSize,
 	}
 	categories, err := server.store.ListCategories(c, arg)
 
@@ -83,6 +124,16 @@ func (server *Server) listCategory(c *gin.Context) {
 		return
 	}
 
+	// scoped users only see their own categories of the page
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	visible := make([]db.Category, 0, len(categories))
+	for _, category := range categories {
+		if inScope(authPayload, category) {
+			visible = append(visible, category)
+		}
+	}
+	categories = visible
+
 	c.JSON(http.StatusOK, categories)
 }
 
@@ -108,23 +159,107 @@ func (server *Server) updateCategory(c *gin.Context) {
 		return
 	}
 
-	arg := db.UpdateCategoryParams{
-		ID:           req.ID,
-		CategoryName: reqUpdate.CategoryName,
-		SectionName:  reqUpdate.SectionName,
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if !server.authorizeCategory(c, req.ID) {
+		return
+	}
+
+	// the category must stay within the scope once it is updated
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !inScope(authPayload, db.Category{ID: req.ID, SectionName: reqUpdate.SectionName}) {
+		c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+		return
+	}
+
+	arg := db.UpdateCategoryTxParams{
+		UpdateCategoryParams: db.UpdateCategoryParams{
+			ID:           req.ID,
+			CategoryName: reqUpdate.CategoryName,
+			SectionName:  reqUpdate.SectionName,
+			Version:      version,
+		},
+		Actor: authPayload.Username,
 	}
 
-	category, err2 := server.store.UpdateCategory(c, arg)
+	category, err2 := server.store.UpdateCategoryTx(c, arg)
 
 	if err2 != nil {
 		if err2 == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, errorResponse(err2))
 			return
 		}
+		if errors.Is(err2, db.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, errorResponse(err2))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err2))
 		return
 	}
 
+	setETag(c, category.Version)
+	c.JSON(http.StatusOK, category)
+}
+
+type setCategoryCostingMethodRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type setCategoryCostingMethodRequestJson struct {
+	CostingMethod string `json:"costing_method" binding:"required,oneof=fifo average"`
+}
+
+func (server *Server) setCategoryCostingMethod(c *gin.Context) {
+	var req setCategoryCostingMethodRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqMethod setCategoryCostingMethodRequestJson
+	if err := c.ShouldBindJSON(&reqMethod); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if !server.authorizeCategory(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.SetCategoryCostingMethodTxParams{
+		SetCategoryCostingMethodParams: db.SetCategoryCostingMethodParams{
+			ID:            req.ID,
+			CostingMethod: reqMethod.CostingMethod,
+			Version:       version,
+		},
+		Actor: authPayload.Username,
+	}
+
+	category, err := server.store.SetCategoryCostingMethodTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, category.Version)
 	c.JSON(http.StatusOK, category)
 }
 
@@ -139,7 +274,17 @@ func (server *Server) deleteCategory(c *gin.Context) {
 		return
 	}
 
-	err := server.store.DeleteCategory(c, req.ID)
+	if !server.authorizeCategory(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteCategoryTx(c, arg)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -154,3 +299,39 @@ func (server *Server) deleteCategory(c *gin.Context) {
 		"message": "category deleted successfuly",
 	})
 }
+
+type restoreCategoryRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) restoreCategory(c *gin.Context) {
+	var req restoreCategoryRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeCategory(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RestoreTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	category, err := server.store.RestoreCategoryTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, category.Version)
+	c.JSON(http.StatusOK, category)
+}
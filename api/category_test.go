@@ -7,12 +7,14 @@ import (
 	"fmt"
 	mockdb "inventory_management/db/mock"
 	db "inventory_management/db/sqlc"
+	"inventory_management/token"
 	"inventory_management/util"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -37,7 +39,7 @@ func TestGetCategory(t *testing.T) {
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchCategory(t, recorder.Body, category)
-
+				require.Equal(t, fmt.Sprintf(`"%d"`, category.Version), recorder.Header().Get("ETag"))
 			},
 		},
 		{
@@ -82,12 +84,13 @@ func TestGetCategory(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 			url := fmt.Sprintf("/categories/%d", tc.categoryID)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 
 			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
@@ -97,6 +100,7 @@ func TestGetCategory(t *testing.T) {
 }
 
 func TestCreateCategory(t *testing.T) {
+	actor := util.RandomName()
 	category := randomCategory()
 
 	testCases := []struct {
@@ -112,11 +116,14 @@ func TestCreateCategory(t *testing.T) {
 				"section_name":  category.SectionName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateCategoryParams{
-					CategoryName: category.CategoryName,
-					SectionName:  category.SectionName,
+				arg := db.CreateCategoryTxParams{
+					CreateCategoryParams: db.CreateCategoryParams{
+						CategoryName: category.CategoryName,
+						SectionName:  category.SectionName,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().CreateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(category, nil)
+				store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(category, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -130,11 +137,14 @@ func TestCreateCategory(t *testing.T) {
 				"section_name":  category.SectionName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateCategoryParams{
-					CategoryName: category.CategoryName,
-					SectionName:  category.SectionName,
+				arg := db.CreateCategoryTxParams{
+					CreateCategoryParams: db.CreateCategoryParams{
+						CategoryName: category.CategoryName,
+						SectionName:  category.SectionName,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().CreateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrConnDone)
+				store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -147,7 +157,7 @@ func TestCreateCategory(t *testing.T) {
 				"section_name":  "",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -165,7 +175,7 @@ func TestCreateCategory(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			// Marshal body data to JSON
@@ -176,6 +186,7 @@ func TestCreateCategory(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -278,7 +289,7 @@ func TestListCategory(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			url := "/categories"
@@ -291,6 +302,7 @@ func TestListCategory(t *testing.T) {
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
 			request.URL.RawQuery = q.Encode()
 
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -299,51 +311,65 @@ func TestListCategory(t *testing.T) {
 }
 
 func TestUpdateCategory(t *testing.T) {
-
+	actor := util.RandomName()
 	category := randomCategory()
 	categoryUpdate := randomCategory()
 
+	ifMatch := fmt.Sprintf(`"%d"`, category.Version)
+
 	testCases := []struct {
 		name          string
 		CategoryID    int64
 		body          gin.H
+		ifMatch       string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
 			name: "OK",
 			CategoryID: category.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": categoryUpdate.CategoryName,
 				"section_name":  categoryUpdate.SectionName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.UpdateCategoryParams{
-					ID:           category.ID,
-					CategoryName: categoryUpdate.CategoryName,
-					SectionName:  categoryUpdate.SectionName,
+				arg := db.UpdateCategoryTxParams{
+					UpdateCategoryParams: db.UpdateCategoryParams{
+						ID:           category.ID,
+						CategoryName: categoryUpdate.CategoryName,
+						SectionName:  categoryUpdate.SectionName,
+						Version:      category.Version,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(categoryUpdate, nil)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(categoryUpdate, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchCategoryRequest(t, recorder.Body, categoryUpdate)
+				require.Equal(t, fmt.Sprintf(`"%d"`, categoryUpdate.Version), recorder.Header().Get("ETag"))
 			},
 		},
 		{
 			name: "InternalError",
 			CategoryID: category.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": categoryUpdate.CategoryName,
 				"section_name":  categoryUpdate.SectionName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.UpdateCategoryParams{
-					ID:           category.ID,
-					CategoryName: categoryUpdate.CategoryName,
-					SectionName:  categoryUpdate.SectionName,
+				arg := db.UpdateCategoryTxParams{
+					UpdateCategoryParams: db.UpdateCategoryParams{
+						ID:           category.ID,
+						CategoryName: categoryUpdate.CategoryName,
+						SectionName:  categoryUpdate.SectionName,
+						Version:      category.Version,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrConnDone)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -352,12 +378,13 @@ func TestUpdateCategory(t *testing.T) {
 		{
 			name: "InvalidContext",
 			CategoryID: category.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": "",
 				"section_name":  "",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -366,12 +393,13 @@ func TestUpdateCategory(t *testing.T) {
 		{
 			name:       "InvalidID",
 			CategoryID: 0,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": "",
 				"section_name":  "",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -380,22 +408,71 @@ func TestUpdateCategory(t *testing.T) {
 		{
 			name:       "NotFound",
 			CategoryID: category.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": categoryUpdate.CategoryName,
 				"section_name":  categoryUpdate.SectionName,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.UpdateCategoryParams{
-					ID:           category.ID,
-					CategoryName: categoryUpdate.CategoryName,
-					SectionName:  categoryUpdate.SectionName,
+				arg := db.UpdateCategoryTxParams{
+					UpdateCategoryParams: db.UpdateCategoryParams{
+						ID:           category.ID,
+						CategoryName: categoryUpdate.CategoryName,
+						SectionName:  categoryUpdate.SectionName,
+						Version:      category.Version,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrNoRows)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
+		{
+			name:       "MissingIfMatch",
+			CategoryID: category.ID,
+			body: gin.H{
+				"category_name": categoryUpdate.CategoryName,
+				"section_name":  categoryUpdate.SectionName,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidIfMatch",
+			CategoryID: category.ID,
+			body: gin.H{
+				"category_name": categoryUpdate.CategoryName,
+				"section_name":  categoryUpdate.SectionName,
+			},
+			ifMatch:    "W/\"1\"",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "VersionMismatch",
+			CategoryID: category.ID,
+			body: gin.H{
+				"category_name": categoryUpdate.CategoryName,
+				"section_name":  categoryUpdate.SectionName,
+			},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, db.ErrVersionMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -408,7 +485,7 @@ func TestUpdateCategory(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			// Marshal body data to JSON
@@ -419,6 +496,10 @@ func TestUpdateCategory(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -428,6 +509,7 @@ func TestUpdateCategory(t *testing.T) {
 }
 
 func TestDeleteCategory(t *testing.T) {
+	actor := util.RandomName()
 	category := randomCategory()
 
 	testCases := []struct {
@@ -440,7 +522,7 @@ func TestDeleteCategory(t *testing.T) {
 			name:       "OK",
 			categoryID: category.ID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(nil)
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: category.ID, Actor: actor})).Times(1).Return(nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -450,7 +532,7 @@ func TestDeleteCategory(t *testing.T) {
 			name:       "NotFound",
 			categoryID: category.ID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(sql.ErrNoRows)
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: category.ID, Actor: actor})).Times(1).Return(sql.ErrNoRows)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -460,7 +542,7 @@ func TestDeleteCategory(t *testing.T) {
 			name:       "InternalError",
 			categoryID: category.ID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(sql.ErrConnDone)
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: category.ID, Actor: actor})).Times(1).Return(sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -470,7 +552,7 @@ func TestDeleteCategory(t *testing.T) {
 			name:       "InvalidID",
 			categoryID: 0,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteCategoryTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -488,12 +570,13 @@ func TestDeleteCategory(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 			url := fmt.Sprintf("/categories/%d", tc.categoryID)
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 
 			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
@@ -508,6 +591,8 @@ func randomCategory() db.Category {
 		ID:           util.RandomInt(1, 1000),
 		CategoryName: util.RandomName(),
 		SectionName:  util.RandomName(),
+		CostingMethod: db.CostingMethodFIFO,
+		Version:      util.RandomInt(1, 100),
 	}
 }
 
@@ -541,3 +626,341 @@ func requireBodyMatchCategoryRequest(t *testing.T, body *bytes.Buffer, category
 	require.Equal(t, category.CategoryName, gotCategory.CategoryName)
 	require.Equal(t, category.SectionName, gotCategory.SectionName)
 }
+
+func TestRestoreCategory(t *testing.T) {
+	actor := util.RandomName()
+	category := randomCategory()
+
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RestoreTxParams{
+					ID:    category.ID,
+					Actor: actor,
+				}
+				store.EXPECT().RestoreCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(category, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, category)
+			},
+		},
+		{
+			name:       "NotDeleted",
+			categoryID: category.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreCategoryTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			categoryID: category.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreCategoryTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			categoryID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreCategoryTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/categories/%d/restore", tc.categoryID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCategoryIncludeDeleted(t *testing.T) {
+	category := randomCategory()
+	category.DeletedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	testCases := []struct {
+		name          string
+		url           string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "GetAdmin",
+			url:  fmt.Sprintf("/categories/%d?include_deleted=true", category.ID),
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategoryIncludingDeleted(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategory(t, recorder.Body, category)
+			},
+		},
+		{
+			name: "GetNotAdmin",
+			url:  fmt.Sprintf("/categories/%d?include_deleted=true", category.ID),
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategoryIncludingDeleted(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ListAdmin",
+			url:  "/categories?page_id=1&page_size=5&include_deleted=true",
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCategoriesParams{
+					IncludeDeleted: true,
+					Limit:          5,
+					Offset:         0,
+				}
+				store.EXPECT().ListCategories(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Category{category}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchCategories(t, recorder.Body, []db.Category{category})
+			},
+		},
+		{
+			name: "ListNotAdmin",
+			url:  "/categories?page_id=1&page_size=5&include_deleted=true",
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidIncludeDeleted",
+			url:  "/categories?page_id=1&page_size=5&include_deleted=maybe",
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestSetCategoryCostingMethod(t *testing.T) {
+	actor := util.RandomName()
+	category := randomCategory()
+	updated := category
+	updated.CostingMethod = db.CostingMethodAverage
+	updated.Version++
+
+	ifMatch := fmt.Sprintf(`"%d"`, category.Version)
+
+	testCases := []struct {
+		name          string
+		categoryID    int64
+		body          gin.H
+		ifMatch       string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetCategoryCostingMethodTxParams{
+					SetCategoryCostingMethodParams: db.SetCategoryCostingMethodParams{
+						ID:            category.ID,
+						CostingMethod: db.CostingMethodAverage,
+						Version:       category.Version,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, fmt.Sprintf(`"%d"`, updated.Version), recorder.Header().Get("ETag"))
+
+				var got db.Category
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.CostingMethodAverage, got.CostingMethod)
+			},
+		},
+		{
+			name:       "InvalidMethod",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": "lifo"},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			categoryID: 0,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "MissingIfMatch",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:       "OutOfScope",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			ifMatch:    ifMatch,
+			scope:      token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "VersionMismatch",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, db.ErrVersionMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			categoryID: category.ID,
+			body:       gin.H{"costing_method": db.CostingMethodAverage},
+			ifMatch:    ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCategoryCostingMethodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Category{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/categories/%d/costing-method", tc.categoryID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, db.RoleWarehouseManager, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
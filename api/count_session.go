@@ -0,0 +1,395 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type countSessionResponse struct {
+	db.CountSession
+	Items []db.CountSessionItem `json:"items"`
+}
+
+type startCountSessionRequest struct {
+	WarehouseID int64 `json:"warehouse_id" binding:"required,min=1"`
+	// exactly one of category, section and location is counted
+	CategoryID  int64  `json:"category_id" binding:"omitempty,min=1"`
+	SectionName string `json:"section_name"`
+	LocationID  int64  `json:"location_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) startCountSession(c *gin.Context) {
+	var req startCountSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	switch {
+	case req.CategoryID > 0:
+		if !server.authorizeCategory(c, req.CategoryID) {
+			return
+		}
+	case req.SectionName != "":
+		if !inSectionScope(authPayload, req.SectionName) {
+			c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+			return
+		}
+	default:
+		// the bins of a location hold goods of any category
+		if authPayload.Role != db.RoleAdmin && !authPayload.Scope.IsEmpty() {
+			c.JSON(http.StatusForbidden, errorResponse(errOutOfScope))
+			return
+		}
+	}
+
+	arg := db.StartCountSessionTxParams{
+		CreateCountSessionParams: db.CreateCountSessionParams{
+			WarehouseID: req.WarehouseID,
+			CategoryID: sql.NullInt64{
+				Int64: req.CategoryID,
+				Valid: req.CategoryID > 0,
+			},
+			SectionName: sql.NullString{
+				String: req.SectionName,
+				Valid:  req.SectionName != "",
+			},
+			LocationID: sql.NullInt64{
+				Int64: req.LocationID,
+				Valid: req.LocationID > 0,
+			},
+		},
+		Actor: authPayload.Username,
+	}
+
+	result, err := server.store.StartCountSessionTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCountScope) || errors.Is(err, db.ErrInvalidLocation) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, countSessionResponse{CountSession: result.CountSession, Items: result.Items})
+}
+
+type countSessionRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getCountSession(c *gin.Context) {
+	var req countSessionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	countSession, items, ok := server.loadCountSession(c, req.ID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, countSessionResponse{CountSession: countSession, Items: items})
+}
+
+// loadCountSession reads a count session with its items and writes the error response if that fails
+// or if a good of the items is out of the scope of the user
+func (server *Server) loadCountSession(c *gin.Context, id int64) (db.CountSession, []db.CountSessionItem, bool) {
+	countSession, err := server.store.GetCountSession(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return countSession, nil, false
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return countSession, nil, false
+	}
+
+	items, err := server.store.ListCountSessionItems(c, id)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return countSession, nil, false
+	}
+
+	goodIDs := make([]int64, len(items))
+	for i, item := range items {
+		goodIDs[i] = item.GoodID
+	}
+	if !server.authorizeGoods(c, goodIDs) {
+		return countSession, nil, false
+	}
+
+	return countSession, items, true
+}
+
+type listCountSessionRequest struct {
+	Status      string `form:"status" binding:"omitempty,oneof=open approved cancelled"`
+	WarehouseID int64  `form:"warehouse_id" binding:"omitempty,min=1"`
+	PageID      int32  `form:"page_id" binding:"required,min=1"`
+	PageSize    int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listCountSession(c *gin.Context) {
+	var req listCountSessionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListCountSessionsParams{
+		Status: sql.NullString{
+			String: req.Status,
+			Valid:  req.Status != "",
+		},
+		WarehouseID: sql.NullInt64{
+			Int64: req.WarehouseID,
+			Valid: req.WarehouseID > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	countSessions, err := server.store.ListCountSessions(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, countSessions)
+}
+
+type countEntryJson struct {
+	ItemID int64 `json:"item_id" binding:"required,min=1"`
+	// in the unit of the good, zero when nothing was found
+	Counted *int64 `json:"counted" binding:"required,min=0"`
+}
+
+type recordCountsRequestJson struct {
+	Counts []countEntryJson `json:"counts" binding:"required,min=1,dive"`
+}
+
+func (server *Server) recordCounts(c *gin.Context) {
+	var req countSessionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqCounts recordCountsRequestJson
+	if err := c.ShouldBindJSON(&reqCounts); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	counts := make([]db.CountEntry, len(reqCounts.Counts))
+	itemIDs := make(map[int64]bool, len(reqCounts.Counts))
+	for i, count := range reqCounts.Counts {
+		counts[i] = db.CountEntry{
+			ItemID:  count.ItemID,
+			Counted: *count.Counted,
+		}
+		itemIDs[count.ItemID] = true
+	}
+
+	if !server.authorizeCountSessionItems(c, req.ID, itemIDs) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RecordCountsTxParams{
+		ID:     req.ID,
+		Counts: counts,
+		Actor:  authPayload.Username,
+	}
+
+	result, err := server.store.RecordCountsTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCountSessionStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidCountItem) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, countSessionResponse{CountSession: result.CountSession, Items: result.Items})
+}
+
+// countVariance is an item of a count session with the difference of its count from the snapshot
+type countVariance struct {
+	db.CountSessionItem
+	// counted minus snapshot, zero while the item is not counted
+	Variance int64 `json:"variance"`
+}
+
+type countVarianceResponse struct {
+	CountSession db.CountSession `json:"count_session"`
+	Items        []countVariance `json:"items"`
+	Counted      int             `json:"counted"`
+	Uncounted    int             `json:"uncounted"`
+	// sum of the variances of all counted items
+	NetVariance int64 `json:"net_variance"`
+}
+
+func newCountVarianceResponse(countSession db.CountSession, items []db.CountSessionItem) countVarianceResponse {
+	rsp := countVarianceResponse{
+		CountSession: countSession,
+		Items:        make([]countVariance, len(items)),
+	}
+	for i, item := range items {
+		rsp.Items[i] = countVariance{CountSessionItem: item}
+		if !item.Counted.Valid {
+			rsp.Uncounted++
+			continue
+		}
+		rsp.Counted++
+		rsp.Items[i].Variance = item.Counted.Int64 - item.Snapshot
+		rsp.NetVariance += rsp.Items[i].Variance
+	}
+	return rsp
+}
+
+func (server *Server) getCountVariances(c *gin.Context) {
+	var req countSessionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	countSession, items, ok := server.loadCountSession(c, req.ID)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, newCountVarianceResponse(countSession, items))
+}
+
+type approveCountSessionResponse struct {
+	countVarianceResponse
+	Movements []db.StockMovement `json:"movements"`
+}
+
+func (server *Server) approveCountSession(c *gin.Context) {
+	var req countSessionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeCountSessionItems(c, req.ID, nil) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CountSessionStatusTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	result, err := server.store.ApproveCountSessionTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCountSessionStatus) || errors.Is(err, db.ErrCountIncomplete) || errors.Is(err, db.ErrSerialMismatch) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, approveCountSessionResponse{
+		countVarianceResponse: newCountVarianceResponse(result.CountSession, result.Items),
+		Movements:             result.Movements,
+	})
+}
+
+func (server *Server) cancelCountSession(c *gin.Context) {
+	var req countSessionRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CountSessionStatusTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	countSession, err := server.store.CancelCountSessionTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrCountSessionStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, countSession)
+}
+
+// authorizeCountSessionItems checks the scope of the user against the goods of the given items of a count session,
+// all items are checked when none are given
+func (server *Server) authorizeCountSessionItems(c *gin.Context, countSessionID int64, itemIDs map[int64]bool) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+
+	items, err := server.store.ListCountSessionItems(c, countSessionID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	checked := make(map[int64]bool, len(items))
+	for _, item := range items {
+		if itemIDs != nil && !itemIDs[item.ID] || checked[item.GoodID] {
+			continue
+		}
+		if !server.authorizeGood(c, item.GoodID) {
+			return false
+		}
+		checked[item.GoodID] = true
+	}
+	return true
+}
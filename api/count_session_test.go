@@ -0,0 +1,761 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartCountSession(t *testing.T) {
+	actor := util.RandomName()
+	category := randomCategory()
+	countSession := randomCountSession(db.CountSessionStatusOpen)
+	countSession.CategoryID = sql.NullInt64{Int64: category.ID, Valid: true}
+	item := randomCountSessionItem(countSession.ID, randomGood())
+
+	arg := db.StartCountSessionTxParams{
+		CreateCountSessionParams: db.CreateCountSessionParams{
+			WarehouseID: countSession.WarehouseID,
+			CategoryID:  countSession.CategoryID,
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			body: gin.H{"warehouse_id": countSession.WarehouseID, "category_id": category.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.CountSessionTxResult{
+					CountSession: countSession,
+					Items:        []db.CountSessionItem{item},
+				}
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got countSessionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, countSession, got.CountSession)
+				require.Equal(t, []db.CountSessionItem{item}, got.Items)
+			},
+		},
+		{
+			name:  "CategoryInScope",
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			body:  gin.H{"warehouse_id": countSession.WarehouseID, "category_id": category.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "SectionOutOfScope",
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Sections: []string{category.SectionName}},
+			body:  gin.H{"warehouse_id": countSession.WarehouseID, "section_name": category.SectionName + "x"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "LocationWithScope",
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			body:  gin.H{"warehouse_id": countSession.WarehouseID, "location_id": util.RandomInt(1, 1000)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			role: db.RoleClerk,
+			body: gin.H{"warehouse_id": countSession.WarehouseID, "category_id": category.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NoScope",
+			role: db.RoleWarehouseManager,
+			body: gin.H{"warehouse_id": countSession.WarehouseID},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StartCountSessionTxParams{
+					CreateCountSessionParams: db.CreateCountSessionParams{WarehouseID: countSession.WarehouseID},
+					Actor:                    actor,
+				}
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, db.ErrCountScope)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleWarehouseManager,
+			body: gin.H{"warehouse_id": countSession.WarehouseID, "category_id": category.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			body: gin.H{"warehouse_id": countSession.WarehouseID, "category_id": category.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "NoWarehouse",
+			role: db.RoleWarehouseManager,
+			body: gin.H{"category_id": category.ID},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StartCountSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/count-sessions", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListCountSession(t *testing.T) {
+	n := 5
+	countSessions := make([]db.CountSession, n)
+	for i := range countSessions {
+		countSessions[i] = randomCountSession(db.CountSessionStatusOpen)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=open", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCountSessionsParams{
+					Status: sql.NullString{String: db.CountSessionStatusOpen, Valid: true},
+					Limit:  int32(n),
+				}
+				store.EXPECT().ListCountSessions(gomock.Any(), gomock.Eq(arg)).Times(1).Return(countSessions, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.CountSession
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, countSessions, got)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=lost", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCountSessions(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCountSessions(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/count-sessions?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRecordCounts(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	countSession := randomCountSession(db.CountSessionStatusOpen)
+	item := randomCountSessionItem(countSession.ID, good)
+	counted := item
+	counted.Counted = sql.NullInt64{Int64: item.Snapshot + 1, Valid: true}
+	counted.CountedBy = actor
+
+	body := gin.H{
+		"counts": []gin.H{
+			{"item_id": item.ID, "counted": counted.Counted.Int64},
+		},
+	}
+	arg := db.RecordCountsTxParams{
+		ID:     countSession.ID,
+		Counts: []db.CountEntry{{ItemID: item.ID, Counted: counted.Counted.Int64}},
+		Actor:  actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.CountSessionTxResult{
+					CountSession: countSession,
+					Items:        []db.CountSessionItem{counted},
+				}
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got countSessionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, []db.CountSessionItem{counted}, got.Items)
+			},
+		},
+		{
+			name: "CountedZero",
+			role: db.RoleClerk,
+			body: gin.H{
+				"counts": []gin.H{
+					{"item_id": item.ID, "counted": 0},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RecordCountsTxParams{
+					ID:     countSession.ID,
+					Counts: []db.CountEntry{{ItemID: item.ID}},
+					Actor:  actor,
+				}
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCountSessionItems(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return([]db.CountSessionItem{item}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AuditorForbidden",
+			role: db.RoleAuditor,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Closed",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, db.ErrCountSessionStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ForeignItem",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, db.ErrInvalidCountItem)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSessionTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingCounted",
+			role: db.RoleClerk,
+			body: gin.H{
+				"counts": []gin.H{
+					{"item_id": item.ID},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeCounted",
+			role: db.RoleClerk,
+			body: gin.H{
+				"counts": []gin.H{
+					{"item_id": item.ID, "counted": -1},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RecordCountsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/count-sessions/%d/counts", countSession.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetCountVariances(t *testing.T) {
+	good := randomGood()
+	countSession := randomCountSession(db.CountSessionStatusOpen)
+	over := randomCountSessionItem(countSession.ID, good)
+	over.Counted = sql.NullInt64{Int64: over.Snapshot + 3, Valid: true}
+	under := randomCountSessionItem(countSession.ID, randomGood())
+	under.Counted = sql.NullInt64{Int64: under.Snapshot - 1, Valid: true}
+	uncounted := randomCountSessionItem(countSession.ID, randomGood())
+	items := []db.CountSessionItem{over, under, uncounted}
+
+	testCases := []struct {
+		name          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCountSession(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(countSession, nil)
+				store.EXPECT().ListCountSessionItems(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(items, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got countVarianceResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, countSession, got.CountSession)
+				require.Len(t, got.Items, 3)
+				require.Equal(t, int64(3), got.Items[0].Variance)
+				require.Equal(t, int64(-1), got.Items[1].Variance)
+				require.Zero(t, got.Items[2].Variance)
+				require.Equal(t, 2, got.Counted)
+				require.Equal(t, 1, got.Uncounted)
+				require.Equal(t, int64(2), got.NetVariance)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCountSession(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(countSession, nil)
+				store.EXPECT().ListCountSessionItems(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(items, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCountSession(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(db.CountSession{}, sql.ErrNoRows)
+				store.EXPECT().ListCountSessionItems(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCountSession(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(countSession, nil)
+				store.EXPECT().ListCountSessionItems(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/count-sessions/%d/variances", countSession.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestApproveCountSession(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	countSession := randomCountSession(db.CountSessionStatusApproved)
+	item := randomCountSessionItem(countSession.ID, good)
+	item.Counted = sql.NullInt64{Int64: item.Snapshot - 2, Valid: true}
+	arg := db.CountSessionStatusTxParams{ID: countSession.ID, Actor: actor}
+	result := db.ApproveCountSessionTxResult{
+		CountSessionTxResult: db.CountSessionTxResult{
+			CountSession: countSession,
+			Items:        []db.CountSessionItem{item},
+		},
+		Movements: []db.StockMovement{randomStockMovement(good)},
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got approveCountSessionResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.CountSessionStatusApproved, got.CountSession.Status)
+				require.Equal(t, int64(-2), got.NetVariance)
+				require.Len(t, got.Movements, 1)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCountSessionItems(gomock.Any(), gomock.Eq(countSession.ID)).Times(1).Return([]db.CountSessionItem{item}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Incomplete",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ApproveCountSessionTxResult{}, db.ErrCountIncomplete)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "SerializedVariance",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ApproveCountSessionTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientStock",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ApproveCountSessionTxResult{}, db.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ApproveCountSessionTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ApproveCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ApproveCountSessionTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/count-sessions/%d/approve", countSession.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCancelCountSession(t *testing.T) {
+	actor := util.RandomName()
+	countSession := randomCountSession(db.CountSessionStatusCancelled)
+	arg := db.CountSessionStatusTxParams{ID: countSession.ID, Actor: actor}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(countSession, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.CountSession
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, countSession, got)
+			},
+		},
+		{
+			name: "Approved",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSession{}, db.ErrCountSessionStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelCountSessionTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.CountSession{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/count-sessions/%d/cancel", countSession.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, db.RoleWarehouseManager, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomCountSession(status string) db.CountSession {
+	return db.CountSession{
+		ID:          util.RandomInt(1, 1000),
+		WarehouseID: util.RandomInt(1, 1000),
+		SectionName: sql.NullString{String: util.RandomName(), Valid: true},
+		Status:      status,
+	}
+}
+
+func randomCountSessionItem(countSessionID int64, good db.Good) db.CountSessionItem {
+	return db.CountSessionItem{
+		ID:             util.RandomInt(1, 1000),
+		CountSessionID: countSessionID,
+		GoodID:         good.ID,
+		Snapshot:       util.RandomInt(2, 100),
+	}
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errMissingIfMatch = errors.New("the If-Match header is required, it must hold the ETag of the last read")
+	errInvalidIfMatch = errors.New("the If-Match header must hold a single ETag returned by the server")
+)
+
+// setETag sends the version of the row as a strong ETag
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// ifMatchVersion reads the version the update is based on from the If-Match header
+// and writes the error response when the header is missing or is not one of our ETags
+func ifMatchVersion(c *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, errorResponse(errMissingIfMatch))
+		return 0, false
+	}
+
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		c.JSON(http.StatusBadRequest, errorResponse(errInvalidIfMatch))
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, errorResponse(errInvalidIfMatch))
+		return 0, false
+	}
+	return version, true
+}
@@ -0,0 +1,430 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xuri/excelize/v2"
+)
+
+// exportBatchSize is how many rows an export reads from the store at a time
+const exportBatchSize = 500
+
+// exportContentTypes are the media types of the export formats
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"xlsx":   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"ndjson": "application/x-ndjson",
+}
+
+var (
+	goodExportColumns = []string{
+		"id", "model", "good_desc", "sku", "category", "section", "unit",
+		"amount", "reserved", "available", "in_transit", "stock_value", "created_at", "deleted_at",
+	}
+	categoryExportColumns = []string{"id", "category_name", "section_name", "costing_method", "version", "deleted_at"}
+	unitExportColumns     = []string{"id", "unit_name", "unit_value", "unit_family", "version", "deleted_at"}
+)
+
+// exportBatch reads the batch of rows after the row afterID and returns the values of its rows in the order
+// of the columns, the id to read the next batch after and whether there may be more rows
+type exportBatch func(afterID int64) (rows [][]interface{}, lastID int64, more bool, err error)
+
+// exportWriter writes the rows of an export in one of the export formats
+type exportWriter interface {
+	// writeRow writes the values of a row in the order of the columns
+	writeRow(values []interface{}) error
+	// flush sends the rows written so far to the client
+	flush() error
+	// close writes whatever the format keeps back until the last row
+	close() error
+	// discard drops what the format keeps back, it is called once the export is done or has failed
+	discard()
+}
+
+type exportGoodRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx ndjson"`
+	goodFilterRequest
+}
+
+// exportGood streams the goods with the names of their category and unit as a CSV, XLSX or NDJSON file.
+// It takes the filters of the list of goods, scoped users have to export one of their categories.
+func (server *Server) exportGood(c *gin.Context) {
+	var req exportGoodRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := req.validate(); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGoodFilter(c, req.goodFilterRequest) {
+		return
+	}
+
+	filter := req.params()
+	streamExport(c, req.Format, "goods", goodExportColumns, func(afterID int64) ([][]interface{}, int64, bool, error) {
+		goods, err := server.store.ExportGoods(c, db.ExportGoodsParams{
+			AfterID:        afterID,
+			Category:       filter.Category,
+			Section:        filter.Section,
+			Unit:           filter.Unit,
+			ModelPrefix:    filter.ModelPrefix,
+			MinAmount:      filter.MinAmount,
+			MaxAmount:      filter.MaxAmount,
+			CreatedFrom:    filter.CreatedFrom,
+			CreatedTo:      filter.CreatedTo,
+			WarehouseID:    filter.WarehouseID,
+			IncludeDeleted: filter.IncludeDeleted,
+			Limit:          exportBatchSize,
+		})
+		if err != nil || len(goods) == 0 {
+			return nil, 0, false, err
+		}
+
+		rows := make([][]interface{}, len(goods))
+		for i, good := range goods {
+			rows[i] = []interface{}{
+				good.ID, good.Model, good.GoodDesc, nullExportString(good.Sku), good.CategoryName, good.SectionName, good.UnitName,
+				good.Amount, good.Reserved, good.Amount - good.Reserved, good.InTransit, good.StockValue, good.CreatedAt, nullExportTime(good.DeletedAt),
+			}
+		}
+		return rows, goods[len(goods)-1].ID, len(goods) == exportBatchSize, nil
+	})
+}
+
+type exportCategoryRequest struct {
+	Format         string `form:"format" binding:"omitempty,oneof=csv xlsx ndjson"`
+	IncludeDeleted bool   `form:"include_deleted"`
+}
+
+// exportCategory streams the categories as a CSV, XLSX or NDJSON file, scoped users only get their own
+func (server *Server) exportCategory(c *gin.Context) {
+	var req exportCategoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeIncludeDeleted(c, req.IncludeDeleted) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	streamExport(c, req.Format, "categories", categoryExportColumns, func(afterID int64) ([][]interface{}, int64, bool, error) {
+		categories, err := server.store.ExportCategories(c, db.ExportCategoriesParams{
+			AfterID:        afterID,
+			IncludeDeleted: req.IncludeDeleted,
+			Limit:          exportBatchSize,
+		})
+		if err != nil || len(categories) == 0 {
+			return nil, 0, false, err
+		}
+
+		rows := make([][]interface{}, 0, len(categories))
+		for _, category := range categories {
+			if !inScope(authPayload, category) {
+				continue
+			}
+			rows = append(rows, []interface{}{
+				category.ID, category.CategoryName, category.SectionName, category.CostingMethod, category.Version, nullExportTime(category.DeletedAt),
+			})
+		}
+		return rows, categories[len(categories)-1].ID, len(categories) == exportBatchSize, nil
+	})
+}
+
+type exportUnitRequest struct {
+	Format         string `form:"format" binding:"omitempty,oneof=csv xlsx ndjson"`
+	IncludeDeleted bool   `form:"include_deleted"`
+}
+
+// exportUnit streams the units as a CSV, XLSX or NDJSON file
+func (server *Server) exportUnit(c *gin.Context) {
+	var req exportUnitRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeIncludeDeleted(c, req.IncludeDeleted) {
+		return
+	}
+
+	streamExport(c, req.Format, "units", unitExportColumns, func(afterID int64) ([][]interface{}, int64, bool, error) {
+		units, err := server.store.ExportUnits(c, db.ExportUnitsParams{
+			AfterID:        afterID,
+			IncludeDeleted: req.IncludeDeleted,
+			Limit:          exportBatchSize,
+		})
+		if err != nil || len(units) == 0 {
+			return nil, 0, false, err
+		}
+
+		rows := make([][]interface{}, len(units))
+		for i, unit := range units {
+			rows[i] = []interface{}{
+				unit.ID, unit.UnitName, unit.UnitValue, unit.UnitFamily, unit.Version, nullExportTime(unit.DeletedAt),
+			}
+		}
+		return rows, units[len(units)-1].ID, len(units) == exportBatchSize, nil
+	})
+}
+
+// streamExport writes the rows of the batches as the response, the format defaults to CSV. Only one batch
+// is held at a time and every batch is sent before the next one is read, except for XLSX where the workbook
+// buffers its rows on disk and is only sent once it is complete. Every batch is read on its own, so an export
+// taken while the rows change is not one snapshot.
+func streamExport(c *gin.Context, format string, name string, columns []string, batch exportBatch) {
+	if format == "" {
+		format = "csv"
+	}
+
+	c.Header("Content-Type", exportContentTypes[format])
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+
+	writer, err := newExportWriter(c, format, columns)
+	if err != nil {
+		abortExport(c, err)
+		return
+	}
+	defer writer.discard()
+
+	var afterID int64
+	for {
+		rows, lastID, more, err := batch(afterID)
+		if err != nil {
+			abortExport(c, err)
+			return
+		}
+
+		for _, values := range rows {
+			if err := writer.writeRow(values); err != nil {
+				abortExport(c, err)
+				return
+			}
+		}
+		if !more {
+			break
+		}
+
+		if err := writer.flush(); err != nil {
+			abortExport(c, err)
+			return
+		}
+		afterID = lastID
+	}
+
+	if err := writer.close(); err != nil {
+		abortExport(c, err)
+	}
+}
+
+// abortExport responds with the error while nothing of the file has been sent. Once the file has started
+// the status cannot change any more, the error is logged and the file is cut short.
+func abortExport(c *gin.Context, err error) {
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Disposition")
+		c.Writer.Header().Del("Content-Type")
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// newExportWriter starts an export in the format with a header of the columns
+func newExportWriter(c *gin.Context, format string, columns []string) (exportWriter, error) {
+	switch format {
+	case "xlsx":
+		return newXLSXExportWriter(c, columns)
+	case "ndjson":
+		return &ndjsonExportWriter{c: c, columns: columns}, nil
+	default:
+		return newCSVExportWriter(c, columns)
+	}
+}
+
+// nullExportString is the value of a nullable text column, nil when it is null
+func nullExportString(value sql.NullString) interface{} {
+	if !value.Valid {
+		return nil
+	}
+	return value.String
+}
+
+// nullExportTime is the value of a nullable time column, nil when it is null
+func nullExportTime(value sql.NullTime) interface{} {
+	if !value.Valid {
+		return nil
+	}
+	return value.Time
+}
+
+// csvExportWriter writes a header line and a line per row, null values are empty
+type csvExportWriter struct {
+	c      *gin.Context
+	writer *csv.Writer
+}
+
+func newCSVExportWriter(c *gin.Context, columns []string) (*csvExportWriter, error) {
+	writer := &csvExportWriter{c: c, writer: csv.NewWriter(c.Writer)}
+	return writer, writer.writer.Write(columns)
+}
+
+func (writer *csvExportWriter) writeRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch value := value.(type) {
+		case nil:
+		case string:
+			record[i] = value
+		case int64:
+			record[i] = strconv.FormatInt(value, 10)
+		case time.Time:
+			record[i] = value.Format(time.RFC3339)
+		default:
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return writer.writer.Write(record)
+}
+
+func (writer *csvExportWriter) flush() error {
+	writer.writer.Flush()
+	writer.c.Writer.Flush()
+	return writer.writer.Error()
+}
+
+func (writer *csvExportWriter) close() error {
+	return writer.flush()
+}
+
+func (writer *csvExportWriter) discard() {}
+
+// ndjsonExportWriter writes a JSON object per line with the columns as its keys in their order
+type ndjsonExportWriter struct {
+	c       *gin.Context
+	columns []string
+}
+
+func (writer *ndjsonExportWriter) writeRow(values []interface{}) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		key, err := json.Marshal(writer.columns[i])
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		line.Write(key)
+		line.WriteByte(':')
+		line.Write(data)
+	}
+	line.WriteString("}\n")
+
+	_, err := writer.c.Writer.Write(line.Bytes())
+	return err
+}
+
+func (writer *ndjsonExportWriter) flush() error {
+	writer.c.Writer.Flush()
+	return nil
+}
+
+func (writer *ndjsonExportWriter) close() error {
+	return writer.flush()
+}
+
+func (writer *ndjsonExportWriter) discard() {}
+
+// xlsxExportWriter writes the rows to the first sheet of a workbook, with the header in the first row.
+// The stream writer of the workbook keeps its rows on disk once they grow large.
+type xlsxExportWriter struct {
+	c         *gin.Context
+	book      *excelize.File
+	stream    *excelize.StreamWriter
+	timeStyle int
+	row       int
+}
+
+func newXLSXExportWriter(c *gin.Context, columns []string) (*xlsxExportWriter, error) {
+	book := excelize.NewFile()
+	stream, err := book.NewStreamWriter(book.GetSheetName(0))
+	if err != nil {
+		book.Close()
+		return nil, err
+	}
+
+	// 22 is the built in date and time format
+	timeStyle, err := book.NewStyle(&excelize.Style{NumFmt: 22})
+	if err != nil {
+		book.Close()
+		return nil, err
+	}
+
+	writer := &xlsxExportWriter{c: c, book: book, stream: stream, timeStyle: timeStyle}
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	if err := writer.writeRow(header); err != nil {
+		book.Close()
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (writer *xlsxExportWriter) writeRow(values []interface{}) error {
+	writer.row++
+	cell, err := excelize.CoordinatesToCellName(1, writer.row)
+	if err != nil {
+		return err
+	}
+
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		if value, ok := value.(time.Time); ok {
+			cells[i] = excelize.Cell{StyleID: writer.timeStyle, Value: value}
+			continue
+		}
+		cells[i] = value
+	}
+	return writer.stream.SetRow(cell, cells)
+}
+
+// flush sends nothing, the workbook can only be sent once it is complete
+func (writer *xlsxExportWriter) flush() error {
+	return nil
+}
+
+func (writer *xlsxExportWriter) close() error {
+	if err := writer.stream.Flush(); err != nil {
+		return err
+	}
+	return writer.book.Write(writer.c.Writer)
+}
+
+// discard removes the files the workbook keeps its rows in, it is also called after the workbook is sent
+func (writer *xlsxExportWriter) discard() {
+	writer.book.Close()
+}
@@ -0,0 +1,423 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestExportGood(t *testing.T) {
+	category := randomCategory()
+
+	// a full batch and the rest, so the export reads twice
+	goods := make([]db.ExportGoodsRow, exportBatchSize+1)
+	for i := range goods {
+		goods[i] = randomGoodExport(int64(i+1), category)
+	}
+	goods[0].Sku = sql.NullString{String: util.RandomString(8), Valid: true}
+	firstBatch := goods[:exportBatchSize]
+	lastBatch := goods[exportBatchSize:]
+
+	testCases := []struct {
+		name          string
+		query         string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "CSV",
+			query: fmt.Sprintf("?category=%d&warehouse_id=3&model_prefix=DR", category.ID),
+			role:  db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportGoodsParams{
+					Category:    sql.NullInt64{Int64: category.ID, Valid: true},
+					ModelPrefix: sql.NullString{String: "DR", Valid: true},
+					WarehouseID: sql.NullInt64{Int64: 3, Valid: true},
+					Limit:       exportBatchSize,
+				}
+				first := store.EXPECT().ExportGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(firstBatch, nil)
+
+				arg.AfterID = firstBatch[len(firstBatch)-1].ID
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Eq(arg)).Times(1).After(first).Return(lastBatch, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, exportContentTypes["csv"], recorder.Header().Get("Content-Type"))
+				require.Equal(t, `attachment; filename="goods.csv"`, recorder.Header().Get("Content-Disposition"))
+
+				records := requireBodyExportCSV(t, recorder.Body)
+				require.Len(t, records, len(goods)+1)
+				require.Equal(t, goodExportColumns, records[0])
+
+				good := goods[0]
+				require.Equal(t, []string{
+					strconv.FormatInt(good.ID, 10), good.Model, good.GoodDesc, good.Sku.String,
+					good.CategoryName, good.SectionName, good.UnitName,
+					strconv.FormatInt(good.Amount, 10), strconv.FormatInt(good.Reserved, 10),
+					strconv.FormatInt(good.Amount-good.Reserved, 10), "0", "0",
+					good.CreatedAt.Format(time.RFC3339), "",
+				}, records[1])
+				require.Equal(t, strconv.FormatInt(goods[len(goods)-1].ID, 10), records[len(records)-1][0])
+			},
+		},
+		{
+			name:  "NDJSON",
+			query: "?format=ndjson",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(1).Return(lastBatch, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, exportContentTypes["ndjson"], recorder.Header().Get("Content-Type"))
+
+				scanner := bufio.NewScanner(recorder.Body)
+				require.True(t, scanner.Scan())
+
+				var got map[string]interface{}
+				require.NoError(t, json.Unmarshal(scanner.Bytes(), &got))
+				require.Len(t, got, len(goodExportColumns))
+				require.Equal(t, lastBatch[0].Model, got["model"])
+				require.Equal(t, lastBatch[0].CategoryName, got["category"])
+				require.Nil(t, got["sku"])
+				require.False(t, scanner.Scan())
+			},
+		},
+		{
+			name:  "XLSX",
+			query: "?format=xlsx",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(1).Return(lastBatch, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `attachment; filename="goods.xlsx"`, recorder.Header().Get("Content-Disposition"))
+
+				book, err := excelize.OpenReader(recorder.Body)
+				require.NoError(t, err)
+				defer book.Close()
+
+				rows, err := book.GetRows(book.GetSheetName(0))
+				require.NoError(t, err)
+				require.Len(t, rows, 2)
+				require.Equal(t, goodExportColumns, rows[0])
+				require.Equal(t, lastBatch[0].Model, rows[1][1])
+			},
+		},
+		{
+			name:  "NoGoods",
+			role:  db.RoleAdmin,
+			query: "?include_deleted=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportGoodsParams{
+					IncludeDeleted: true,
+					Limit:          exportBatchSize,
+				}
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.ExportGoodsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				records := requireBodyExportCSV(t, recorder.Body)
+				require.Equal(t, [][]string{goodExportColumns}, records)
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: "?format=pdf",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedWithoutCategory",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			query: fmt.Sprintf("?category=%d", category.ID),
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "IncludeDeletedNotAdmin",
+			query: "?include_deleted=true",
+			role:  db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.Empty(t, recorder.Header().Get("Content-Disposition"))
+			},
+		},
+		{
+			name: "InternalErrorAfterFirstBatch",
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				first := store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(1).Return(firstBatch, nil)
+				store.EXPECT().ExportGoods(gomock.Any(), gomock.Any()).Times(1).After(first).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// the first batch is already sent, the file is cut short
+				require.Equal(t, http.StatusOK, recorder.Code)
+				records := requireBodyExportCSV(t, recorder.Body)
+				require.Len(t, records, len(firstBatch)+1)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/goods/export"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExportCategory(t *testing.T) {
+	category1 := randomCategory()
+	category2 := randomCategory()
+	category2.ID = category1.ID + 1
+
+	testCases := []struct {
+		name          string
+		query         string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportCategoriesParams{Limit: exportBatchSize}
+				store.EXPECT().ExportCategories(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Category{category1, category2}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				records := requireBodyExportCSV(t, recorder.Body)
+				require.Len(t, records, 3)
+				require.Equal(t, categoryExportColumns, records[0])
+				require.Equal(t, category1.CategoryName, records[1][1])
+				require.Equal(t, category2.SectionName, records[2][2])
+			},
+		},
+		{
+			name:  "ScopedUserOnlyGetsOwnCategories",
+			query: "?format=csv",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{category2.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportCategories(gomock.Any(), gomock.Any()).Times(1).Return([]db.Category{category1, category2}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				records := requireBodyExportCSV(t, recorder.Body)
+				require.Len(t, records, 2)
+				require.Equal(t, strconv.FormatInt(category2.ID, 10), records[1][0])
+			},
+		},
+		{
+			name:  "IncludeDeletedNotAdmin",
+			query: "?include_deleted=true",
+			role:  db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportCategories(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportCategories(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/categories/export"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExportUnit(t *testing.T) {
+	unit := randomUnit()
+
+	testCases := []struct {
+		name          string
+		query         string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?format=ndjson",
+			role:  db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportUnitsParams{Limit: exportBatchSize}
+				store.EXPECT().ExportUnits(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Unit{unit}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, `attachment; filename="units.ndjson"`, recorder.Header().Get("Content-Disposition"))
+
+				expected := fmt.Sprintf(`{"id":%d,"unit_name":%q,"unit_value":%d,"unit_family":%q,"version":%d,"deleted_at":null}`+"\n",
+					unit.ID, unit.UnitName, unit.UnitValue, unit.UnitFamily, unit.Version)
+				require.Equal(t, expected, recorder.Body.String())
+			},
+		},
+		{
+			name:  "IncludeDeleted",
+			query: "?include_deleted=true",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ExportUnitsParams{IncludeDeleted: true, Limit: exportBatchSize}
+				store.EXPECT().ExportUnits(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Unit{unit}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Len(t, requireBodyExportCSV(t, recorder.Body), 2)
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: "?format=xml",
+			role:  db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExportUnits(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/units/export"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomGoodExport(id int64, category db.Category) db.ExportGoodsRow {
+	good := randomGood()
+	return db.ExportGoodsRow{
+		ID:           id,
+		Model:        good.Model,
+		GoodDesc:     good.GoodDesc,
+		CategoryName: category.CategoryName,
+		SectionName:  category.SectionName,
+		UnitName:     util.RandomName(),
+		Amount:       good.Amount,
+		Reserved:     good.Reserved,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func requireBodyExportCSV(t *testing.T, body *bytes.Buffer) [][]string {
+	records, err := csv.NewReader(body).ReadAll()
+	require.NoError(t, err)
+	return records
+}
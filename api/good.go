@@ -2,18 +2,42 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	db "inventory_management/db/sqlc"
+	"inventory_management/token"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 )
 
 type createGoodRequest struct {
-	Category int64  `json:"category" binding:"required"`
-	Model    string `json:"model" binding:"required"`
-	Unit     int64  `json:"unit" binding:"required"`
-	Amount   int64  `json:"amount" binding:"required"`
-	GoodDesc string `json:"good_desc" binding:"required"`
+	Category  int64  `json:"category" binding:"required"`
+	Model     string `json:"model" binding:"required"`
+	Unit      int64  `json:"unit" binding:"required"`
+	Warehouse int64  `json:"warehouse" binding:"required,min=1"`
+	Amount    int64  `json:"amount" binding:"required"`
+	// unit the amount is given in, defaults to the unit of the good
+	AmountUnit int64  `json:"amount_unit" binding:"omitempty,min=1"`
+	GoodDesc   string `json:"good_desc" binding:"required"`
+	// units of serialized goods are received with one serial number each, starting with the ones of the amount
+	Serialized bool     `json:"serialized"`
+	Serials    []string `json:"serials" binding:"omitempty,dive,required"`
+	// cost of one unit of the amount as given, the amount is valued at the average cost of the good without one
+	UnitCost *int64 `json:"unit_cost" binding:"omitempty,min=0"`
+	// the sku and the barcodes are unique among all goods
+	Sku      string           `json:"sku" binding:"max=64"`
+	Barcodes []barcodeRequest `json:"barcodes" binding:"omitempty,dive"`
+	stockLevelsRequest
+}
+
+// stockLevelsRequest are the optional stock levels of a good, the safety stock may not exceed
+// the reorder point and the reorder point may not exceed the max level
+type stockLevelsRequest struct {
+	ReorderPoint int64 `json:"reorder_point" binding:"gtefield=SafetyStock"`
+	SafetyStock  int64 `json:"safety_stock" binding:"min=0"`
+	MaxLevel     int64 `json:"max_level" binding:"gtefield=ReorderPoint"`
 }
 
 func (server *Server) createGood(c *gin.Context) {
@@ -23,28 +47,123 @@ func (server *Server) createGood(c *gin.Context) {
 		return
 	}
 
-	arg := db.CreateGoodParams{
-		Category: req.Category,
-		Model:    req.Model,
-		Unit:     req.Unit,
-		Amount:   req.Amount,
-		GoodDesc: req.GoodDesc,
+	barcodes, err := newBarcodeEntries(req.Barcodes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.UnitCost != nil {
+		_, err = db.MultiplyUnitCost(*req.UnitCost, req.Amount)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	if !server.authorizeCategory(c, req.Category) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateGoodTxParams{
+		CreateGoodParams: db.CreateGoodParams{
+			Category:     req.Category,
+			Model:        req.Model,
+			Unit:         req.Unit,
+			Amount:       req.Amount,
+			GoodDesc:     req.GoodDesc,
+			ReorderPoint: req.ReorderPoint,
+			SafetyStock:  req.SafetyStock,
+			MaxLevel:     req.MaxLevel,
+			Serialized:   req.Serialized,
+			Sku: sql.NullString{
+				String: req.Sku,
+				Valid:  req.Sku != "",
+			},
+		},
+		Warehouse:  req.Warehouse,
+		AmountUnit: req.AmountUnit,
+		Serials:    req.Serials,
+		Barcodes:   barcodes,
+		Actor:      authPayload.Username,
+	}
+	if req.UnitCost != nil {
+		arg.UnitCost = sql.NullInt64{Int64: *req.UnitCost, Valid: true}
 	}
 
-	good, err := server.store.CreateGood(c, arg)
+	good, err := server.store.CreateGoodTx(c, arg)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if isConversionError(err) || errors.Is(err, db.ErrSerialMismatch) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, good)
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, newGoodStock(good))
 }
 
 type getGoodRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+// goodStock is a good with the stock it has on hand and the part of it that is not reserved
+type goodStock struct {
+	db.Good
+	OnHand    int64 `json:"on_hand"`
+	Available int64 `json:"available"`
+}
+
+func newGoodStock(good db.Good) goodStock {
+	return goodStock{
+		Good:      good,
+		OnHand:    good.Amount,
+		Available: good.Amount - good.Reserved,
+	}
+}
+
+func newGoodStocks(goods []db.Good) []goodStock {
+	rsp := make([]goodStock, len(goods))
+	for i, good := range goods {
+		rsp[i] = newGoodStock(good)
+	}
+	return rsp
+}
+
+// goodResponse is a good with its balance in every warehouse
+type goodResponse struct {
+	goodStock
+	Balances []db.ListGoodBalancesRow `json:"balances"`
+	Total    int64                    `json:"total"`
+}
+
+func newGoodResponse(good db.Good, balances []db.ListGoodBalancesRow) goodResponse {
+	rsp := goodResponse{
+		goodStock: newGoodStock(good),
+		Balances:  balances,
+	}
+	for _, balance := range balances {
+		rsp.Total += balance.Amount
+	}
+	return rsp
+}
+
 func (server *Server) getGood(c *gin.Context) {
 	var req getGoodRequest
 	if err := c.ShouldBindUri(&req); err != nil {
@@ -52,7 +171,23 @@ func (server *Server) getGood(c *gin.Context) {
 		return
 	}
 
-	good, err := server.store.GetGood(c, req.ID)
+	var reqDeleted includeDeletedRequest
+	if err := c.ShouldBindQuery(&reqDeleted); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeIncludeDeleted(c, reqDeleted.IncludeDeleted) {
+		return
+	}
+
+	var good db.Good
+	var err error
+	if reqDeleted.IncludeDeleted {
+		good, err = server.store.GetGoodIncludingDeleted(c, req.ID)
+	} else {
+		good, err = server.store.GetGood(c, req.ID)
+	}
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -63,16 +198,145 @@ func (server *Server) getGood(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, good)
+	if !server.authorizeCategory(c, good.Category) {
+		return
+	}
+
+	balances, err := server.store.ListGoodBalances(c, good.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, newGoodResponse(good, balances))
+}
+
+// maxGoodSortKeys is how many sort keys a list of goods takes, ListGoods sorts by at most three
+const maxGoodSortKeys = 3
+
+var (
+	errInvalidAmountRange = errors.New("min_amount cannot be above max_amount")
+	errInvalidGoodSort    = errors.New("sort takes up to three of id, model, amount and created_at, each at most once and prefixed with - to sort descending")
+)
+
+// goodSortKeys are the columns the goods can be sorted by
+var goodSortKeys = map[string]bool{
+	"id":         true,
+	"model":      true,
+	"amount":     true,
+	"created_at": true,
+}
+
+// goodFilterRequest are the filters of the lists of goods, every filter is optional and the given ones all apply
+type goodFilterRequest struct {
+	Category    int64  `form:"category" binding:"omitempty,min=1"`
+	Section     string `form:"section"`
+	Unit        int64  `form:"unit" binding:"omitempty,min=1"`
+	ModelPrefix string `form:"model_prefix"`
+	MinAmount   *int64 `form:"min_amount"`
+	MaxAmount   *int64 `form:"max_amount"`
+	// the days the goods were created in, both days included
+	CreatedFrom string `form:"created_from" binding:"omitempty,datetime=2006-01-02"`
+	CreatedTo   string `form:"created_to" binding:"omitempty,datetime=2006-01-02"`
+	WarehouseID int64  `form:"warehouse_id" binding:"omitempty,min=1"`
+	// only admins may list deleted goods
+	IncludeDeleted bool `form:"include_deleted"`
+}
+
+// validate checks the ranges of the filter, which the binding cannot compare
+func (req goodFilterRequest) validate() error {
+	if req.MinAmount != nil && req.MaxAmount != nil && *req.MinAmount > *req.MaxAmount {
+		return errInvalidAmountRange
+	}
+	if req.CreatedFrom != "" && req.CreatedTo != "" && parseDate(req.CreatedTo).Time.Before(parseDate(req.CreatedFrom).Time) {
+		return errInvalidPeriod
+	}
+	return nil
+}
+
+// authorizeGoodFilter lets scoped users only filter by one of their categories and only admins see deleted goods,
+// it writes the error response
+func (server *Server) authorizeGoodFilter(c *gin.Context, req goodFilterRequest) bool {
+	if !authorizeUnfilteredList(c, req.Category > 0) {
+		return false
+	}
+
+	if req.Category > 0 && !server.authorizeCategory(c, req.Category) {
+		return false
+	}
+
+	return authorizeIncludeDeleted(c, req.IncludeDeleted)
+}
+
+// params are the parameters of ListGoods with the filters set
+func (req goodFilterRequest) params() db.ListGoodsParams {
+	arg := db.ListGoodsParams{
+		Category: sql.NullInt64{
+			Int64: req.Category,
+			Valid: req.Category > 0,
+		},
+		Section: sql.NullString{
+			String: req.Section,
+			Valid:  req.Section != "",
+		},
+		Unit: sql.NullInt64{
+			Int64: req.Unit,
+			Valid: req.Unit > 0,
+		},
+		ModelPrefix: sql.NullString{
+			String: req.ModelPrefix,
+			Valid:  req.ModelPrefix != "",
+		},
+		CreatedFrom: parseDate(req.CreatedFrom),
+		WarehouseID: sql.NullInt64{
+			Int64: req.WarehouseID,
+			Valid: req.WarehouseID > 0,
+		},
+		IncludeDeleted: req.IncludeDeleted,
+	}
+	if req.MinAmount != nil {
+		arg.MinAmount = sql.NullInt64{Int64: *req.MinAmount, Valid: true}
+	}
+	if req.MaxAmount != nil {
+		arg.MaxAmount = sql.NullInt64{Int64: *req.MaxAmount, Valid: true}
+	}
+	// the last day is included
+	if createdTo := parseDate(req.CreatedTo); createdTo.Valid {
+		arg.CreatedTo = sql.NullTime{Time: createdTo.Time.AddDate(0, 0, 1), Valid: true}
+	}
+	return arg
+}
+
+// parseGoodSort splits a comma separated list of sort keys like -amount,model
+func parseGoodSort(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	keys := strings.Split(value, ",")
+	if len(keys) > maxGoodSortKeys {
+		return nil, errInvalidGoodSort
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		column := strings.TrimPrefix(key, "-")
+		if !goodSortKeys[column] || seen[column] {
+			return nil, errInvalidGoodSort
+		}
+		seen[column] = true
+	}
+	return keys, nil
 }
 
 type listGoodRequest struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
-}
-
-type listGoodRequestCategory struct {
-	Category int64 `json:"category" binding:"required"`
+	// comma separated columns to sort by, a leading - sorts descending, the id breaks ties
+	Sort string `form:"sort"`
+	goodFilterRequest
 }
 
 func (server *Server) listGood(c *gin.Context) {
@@ -82,17 +346,28 @@ func (server *Server) listGood(c *gin.Context) {
 		return
 	}
 
-	var reqCat listGoodRequestCategory
-	if err := c.ShouldBindJSON(&reqCat); err != nil {
+	if err := req.validate(); err != nil {
 		c.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.ListGoodsParams{
-		Category: reqCat.Category,
-		Limit:    req.PageSize,
-		Offset:   (req.PageID - 1) * req.PageSize,
+	sortKeys, err := parseGoodSort(req.Sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
 	}
+
+	if !server.authorizeGoodFilter(c, req.goodFilterRequest) {
+		return
+	}
+
+	arg := req.params()
+	// the sort keys left out are empty and sort nothing
+	sortKeys = append(sortKeys, make([]string, maxGoodSortKeys-len(sortKeys))...)
+	arg.Sort1, arg.Sort2, arg.Sort3 = sortKeys[0], sortKeys[1], sortKeys[2]
+	arg.Limit = req.PageSize
+	arg.Offset = (req.PageID - 1) * req.PageSize
+
 	goods, err := server.store.ListGoods(c, arg)
 
 	if err != nil {
@@ -100,7 +375,7 @@ func (server *Server) listGood(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, goods)
+	c.JSON(http.StatusOK, newGoodStocks(goods))
 }
 
 type updateGoodRequest struct {
@@ -108,8 +383,13 @@ type updateGoodRequest struct {
 }
 
 type updateGoodRequestJson struct {
-	Unit   int64 `json:"unit" binding:"required"`
-	Amount int64 `json:"amount" binding:"required"`
+	Unit      int64 `json:"unit" binding:"required"`
+	Warehouse int64 `json:"warehouse" binding:"required,min=1"`
+	Amount    int64 `json:"amount" binding:"min=0"`
+	// unit the amount is given in, defaults to the unit of the good
+	AmountUnit int64 `json:"amount_unit" binding:"omitempty,min=1"`
+	// units added or removed by the change of the amount of a serialized good
+	Serials []string `json:"serials" binding:"omitempty,dive,required"`
 }
 
 func (server *Server) updateGood(c *gin.Context) {
@@ -125,26 +405,110 @@ func (server *Server) updateGood(c *gin.Context) {
 		return
 	}
 
-	arg := db.UpdateGoodParams{
-		ID:     req.ID,
-		Unit:   reqUpdate.Unit,
-		Amount: reqUpdate.Amount,
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
 	}
 
-	good, err2 := server.store.UpdateGood(c, arg)
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateGoodTxParams{
+		ID:         req.ID,
+		Unit:       reqUpdate.Unit,
+		Warehouse:  reqUpdate.Warehouse,
+		Amount:     reqUpdate.Amount,
+		AmountUnit: reqUpdate.AmountUnit,
+		Serials:    reqUpdate.Serials,
+		Version:    version,
+		Actor:      authPayload.Username,
+	}
+
+	good, err2 := server.store.UpdateGoodTx(c, arg)
 
 	if err2 != nil {
 		if err2 == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, errorResponse(err2))
 			return
 		}
+		if isConversionError(err2) || errors.Is(err2, db.ErrSerialMismatch) {
+			c.JSON(http.StatusBadRequest, errorResponse(err2))
+			return
+		}
+		if errors.Is(err2, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err2))
+			return
+		}
+		if errors.Is(err2, db.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, errorResponse(err2))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err2))
 		return
 	}
 
-	c.JSON(http.StatusOK, good)
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, newGoodStock(good))
+}
+
+type setGoodStockLevelsRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
+func (server *Server) setGoodStockLevels(c *gin.Context) {
+	var req setGoodStockLevelsRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqLevels stockLevelsRequest
+	if err := c.ShouldBindJSON(&reqLevels); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.SetGoodStockLevelsTxParams{
+		SetGoodStockLevelsParams: db.SetGoodStockLevelsParams{
+			ID:           req.ID,
+			ReorderPoint: reqLevels.ReorderPoint,
+			SafetyStock:  reqLevels.SafetyStock,
+			MaxLevel:     reqLevels.MaxLevel,
+		},
+		Version: version,
+		Actor:   authPayload.Username,
+	}
+
+	good, err := server.store.SetGoodStockLevelsTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, newGoodStock(good))
+}
 
 type deleteGoodRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
@@ -157,7 +521,17 @@ func (server *Server) deleteGood(c *gin.Context) {
 		return
 	}
 
-	err := server.store.DeleteGood(c, req.ID)
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteGoodTx(c, arg)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -172,3 +546,39 @@ func (server *Server) deleteGood(c *gin.Context) {
 		"message": "unit deleted successfuly",
 	})
 }
+
+type restoreGoodRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) restoreGood(c *gin.Context) {
+	var req restoreGoodRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RestoreTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	good, err := server.store.RestoreGoodTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, good.Version)
+	c.JSON(http.StatusOK, newGoodStock(good))
+}
@@ -0,0 +1,499 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/lib/pq"
+	"github.com/xuri/excelize/v2"
+)
+
+// maxImportRows limits the goods of one import, they are all created in a single transaction
+const maxImportRows = 2000
+
+// unresolvedID stands in for a category or unit whose name did not resolve while the row is validated,
+// so the required rules do not repeat the error of the name
+const unresolvedID = -1
+
+var (
+	errImportFormat   = errors.New("the file must be a .csv or an .xlsx file")
+	errImportEmpty    = errors.New("the file has no goods below its header row")
+	errImportTooLarge = fmt.Errorf("an import holds at most %d goods", maxImportRows)
+	errImportInvalid  = errors.New("the import has invalid rows, no good was created")
+)
+
+// importColumns are the columns an import file may have, named in its header row like the fields of
+// createGoodRequest. Categories and units are given by name, the section tells apart categories sharing a name.
+// Serialized goods cannot be imported, their serial numbers do not fit in a row.
+var importColumns = map[string]bool{
+	"category":      true,
+	"section":       false,
+	"model":         true,
+	"unit":          true,
+	"amount":        true,
+	"good_desc":     true,
+	"unit_cost":     false,
+	"sku":           false,
+	"barcode":       false,
+	"symbology":     false,
+	"reorder_point": false,
+	"safety_stock":  false,
+	"max_level":     false,
+}
+
+type importGoodRequest struct {
+	// warehouse the initial amounts of all goods are booked into
+	Warehouse int64 `form:"warehouse" binding:"required,min=1"`
+	// validates the file without creating any good
+	DryRun bool `form:"dry_run"`
+}
+
+// importRowError are the problems of one row of an import file
+type importRowError struct {
+	// row of the file, the header is row 1
+	Row    int      `json:"row"`
+	Errors []string `json:"errors"`
+}
+
+// importReport is the result of an import, the goods are only created when no row has errors
+type importReport struct {
+	DryRun  bool             `json:"dry_run"`
+	Rows    int              `json:"rows"`
+	Created int              `json:"created"`
+	Errors  []importRowError `json:"errors"`
+}
+
+// importGoods creates the goods of an uploaded CSV or XLSX file. Every row is validated like a request
+// to create a good and the errors are reported by row. The goods are created in a single transaction
+// unless the import is a dry run or any row has errors.
+func (server *Server) importGoods(c *gin.Context) {
+	var req importGoodRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	records, err := readImportFile(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	columns, err := importHeader(records)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err = server.store.GetWarehouse(c, req.Warehouse)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	importer := goodImporter{
+		server:      server,
+		authPayload: authPayload,
+		warehouse:   req.Warehouse,
+		columns:     columns,
+		categories:  make(map[[2]string][]db.Category),
+		units:       make(map[string][]db.Unit),
+		skus:        make(map[string]int),
+		barcodes:    make(map[string]int),
+	}
+
+	report := importReport{
+		DryRun: req.DryRun,
+		Errors: []importRowError{},
+	}
+	var goods []db.CreateGoodTxParams
+	// the row of the file of every good
+	var rows []int
+	for i, record := range records[1:] {
+		if isBlankRecord(record) {
+			continue
+		}
+		row := i + 2
+		report.Rows++
+
+		good, problems, err := importer.parse(c, row, record)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if len(problems) > 0 {
+			report.Errors = append(report.Errors, importRowError{Row: row, Errors: problems})
+			continue
+		}
+		goods = append(goods, good)
+		rows = append(rows, row)
+	}
+
+	if report.Rows == 0 {
+		c.JSON(http.StatusBadRequest, errorResponse(errImportEmpty))
+		return
+	}
+
+	if req.DryRun {
+		c.JSON(http.StatusOK, report)
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":  errImportInvalid.Error(),
+			"report": report,
+		})
+		return
+	}
+
+	arg := db.ImportGoodsTxParams{
+		Goods: goods,
+		Actor: authPayload.Username,
+	}
+
+	created, err := server.store.ImportGoodsTx(c, arg)
+
+	if err != nil {
+		var rowErr *db.ImportRowError
+		if errors.As(err, &rowErr) && isImportProblem(rowErr.Err) {
+			report.Errors = append(report.Errors, importRowError{
+				Row:    rows[rowErr.Index],
+				Errors: []string{rowErr.Err.Error()},
+			})
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":  errImportInvalid.Error(),
+				"report": report,
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	report.Created = len(created)
+	c.JSON(http.StatusOK, report)
+}
+
+// isImportProblem reports whether the error of the good that stopped an import lies in its row rather than in the store
+func isImportProblem(err error) bool {
+	if err == sql.ErrNoRows || isConversionError(err) {
+		return true
+	}
+	pqErr, ok := err.(*pq.Error)
+	return ok && (pqErr.Code.Name() == "unique_violation" || pqErr.Code.Name() == "check_violation")
+}
+
+// readImportFile reads the records of a CSV file or of the first sheet of an XLSX file
+func readImportFile(header *multipart.FileHeader) ([][]string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".csv":
+		reader := csv.NewReader(file)
+		// rows may leave out their trailing empty cells
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		return reader.ReadAll()
+	case ".xlsx":
+		book, err := excelize.OpenReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer book.Close()
+		return book.GetRows(book.GetSheetName(0))
+	}
+
+	return nil, errImportFormat
+}
+
+// importHeader maps the columns named in the header row to their index
+func importHeader(records [][]string) (map[string]int, error) {
+	if len(records) < 2 {
+		return nil, errImportEmpty
+	}
+	if len(records) > maxImportRows+1 {
+		return nil, errImportTooLarge
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		// spreadsheets save CSV files with a byte order mark
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if name == "" {
+			continue
+		}
+		if _, ok := importColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column %q appears twice", name)
+		}
+		columns[name] = i
+	}
+
+	for name, required := range importColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	return columns, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// goodImporter turns the rows of an import into goods. Names are looked up once for all rows,
+// and skus and barcodes are checked against the earlier rows as well as the stored goods.
+type goodImporter struct {
+	server      *Server
+	authPayload *token.Payload
+	warehouse   int64
+	columns     map[string]int
+	// categories by name and section
+	categories map[[2]string][]db.Category
+	units      map[string][]db.Unit
+	// the row every sku and barcode first appeared in
+	skus     map[string]int
+	barcodes map[string]int
+}
+
+// parse reads a row as a good. The problems of the row are returned along with it,
+// the error is only returned when the store fails.
+func (importer *goodImporter) parse(c *gin.Context, row int, record []string) (db.CreateGoodTxParams, []string, error) {
+	cell := func(name string) string {
+		i, ok := importer.columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var problems []string
+	number := func(name string) int64 {
+		value := cell(name)
+		if value == "" {
+			return 0
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s must be a whole number", name))
+		}
+		return n
+	}
+
+	req := createGoodRequest{
+		Model:     cell("model"),
+		Warehouse: importer.warehouse,
+		Amount:    number("amount"),
+		GoodDesc:  cell("good_desc"),
+		Sku:       cell("sku"),
+		stockLevelsRequest: stockLevelsRequest{
+			ReorderPoint: number("reorder_point"),
+			SafetyStock:  number("safety_stock"),
+			MaxLevel:     number("max_level"),
+		},
+	}
+	if cell("unit_cost") != "" {
+		unitCost := number("unit_cost")
+		req.UnitCost = &unitCost
+	}
+	if cell("barcode") != "" || cell("symbology") != "" {
+		req.Barcodes = []barcodeRequest{{
+			Barcode:   cell("barcode"),
+			Symbology: cell("symbology"),
+		}}
+	}
+
+	category, problem, err := importer.category(c, cell("category"), cell("section"))
+	if err != nil {
+		return db.CreateGoodTxParams{}, nil, err
+	}
+	req.Category = unresolvedID
+	if problem != "" {
+		problems = append(problems, problem)
+	} else if !inScope(importer.authPayload, category) {
+		problems = append(problems, errOutOfScope.Error())
+	} else {
+		req.Category = category.ID
+	}
+
+	unit, problem, err := importer.unit(c, cell("unit"))
+	if err != nil {
+		return db.CreateGoodTxParams{}, nil, err
+	}
+	req.Unit = unresolvedID
+	if problem != "" {
+		problems = append(problems, problem)
+	} else {
+		req.Unit = unit.ID
+	}
+
+	// the same rules as a request to create a good
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		problems = append(problems, strings.Split(err.Error(), "\n")...)
+	}
+	barcodes, err := newBarcodeEntries(req.Barcodes)
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	if req.UnitCost != nil {
+		_, err = db.MultiplyUnitCost(*req.UnitCost, req.Amount)
+		if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	problems, err = importer.checkUnique(c, row, req.Sku, barcodes, problems)
+	if err != nil {
+		return db.CreateGoodTxParams{}, nil, err
+	}
+
+	arg := db.CreateGoodTxParams{
+		CreateGoodParams: db.CreateGoodParams{
+			Category:     req.Category,
+			Model:        req.Model,
+			Unit:         req.Unit,
+			Amount:       req.Amount,
+			GoodDesc:     req.GoodDesc,
+			ReorderPoint: req.ReorderPoint,
+			SafetyStock:  req.SafetyStock,
+			MaxLevel:     req.MaxLevel,
+			Sku: sql.NullString{
+				String: req.Sku,
+				Valid:  req.Sku != "",
+			},
+		},
+		Warehouse: req.Warehouse,
+		Barcodes:  barcodes,
+	}
+	if req.UnitCost != nil {
+		arg.UnitCost = sql.NullInt64{Int64: *req.UnitCost, Valid: true}
+	}
+
+	return arg, problems, nil
+}
+
+// category resolves the name of a category, the problem tells why it does not resolve to exactly one
+func (importer *goodImporter) category(c *gin.Context, name, section string) (db.Category, string, error) {
+	if name == "" {
+		return db.Category{}, "category is required", nil
+	}
+
+	key := [2]string{name, section}
+	categories, ok := importer.categories[key]
+	if !ok {
+		var err error
+		categories, err = importer.server.store.ListCategoriesByName(c, db.ListCategoriesByNameParams{
+			CategoryName: name,
+			SectionName: sql.NullString{
+				String: section,
+				Valid:  section != "",
+			},
+		})
+		if err != nil {
+			return db.Category{}, "", err
+		}
+		importer.categories[key] = categories
+	}
+
+	switch len(categories) {
+	case 0:
+		return db.Category{}, fmt.Sprintf("unknown category %q", name), nil
+	case 1:
+		return categories[0], "", nil
+	}
+	return db.Category{}, fmt.Sprintf("category %q is in several sections, the section must be given", name), nil
+}
+
+// unit resolves the name of a unit, the problem tells why it does not resolve to exactly one
+func (importer *goodImporter) unit(c *gin.Context, name string) (db.Unit, string, error) {
+	if name == "" {
+		return db.Unit{}, "unit is required", nil
+	}
+
+	units, ok := importer.units[name]
+	if !ok {
+		var err error
+		units, err = importer.server.store.ListUnitsByName(c, name)
+		if err != nil {
+			return db.Unit{}, "", err
+		}
+		importer.units[name] = units
+	}
+
+	switch len(units) {
+	case 0:
+		return db.Unit{}, fmt.Sprintf("unknown unit %q", name), nil
+	case 1:
+		return units[0], "", nil
+	}
+	return db.Unit{}, fmt.Sprintf("unit %q is ambiguous, several units have that name", name), nil
+}
+
+// checkUnique adds the problems of a sku or barcodes that an earlier row or a stored good already has
+func (importer *goodImporter) checkUnique(c *gin.Context, row int, sku string, barcodes []db.BarcodeEntry, problems []string) ([]string, error) {
+	if sku != "" {
+		if first, ok := importer.skus[sku]; ok {
+			problems = append(problems, fmt.Sprintf("sku %q is already used in row %d", sku, first))
+		} else {
+			importer.skus[sku] = row
+			_, err := importer.server.store.GetGoodBySku(c, sql.NullString{String: sku, Valid: true})
+			if err == nil {
+				problems = append(problems, fmt.Sprintf("sku %q is already used by a good", sku))
+			} else if err != sql.ErrNoRows {
+				return nil, err
+			}
+		}
+	}
+
+	for _, barcode := range barcodes {
+		if first, ok := importer.barcodes[barcode.Barcode]; ok {
+			problems = append(problems, fmt.Sprintf("barcode %q is already used in row %d", barcode.Barcode, first))
+			continue
+		}
+		importer.barcodes[barcode.Barcode] = row
+		_, err := importer.server.store.GetGoodBarcode(c, barcode.Barcode)
+		if err == nil {
+			problems = append(problems, fmt.Sprintf("barcode %q is already used by a good", barcode.Barcode))
+		} else if err != sql.ErrNoRows {
+			return nil, err
+		}
+	}
+
+	return problems, nil
+}
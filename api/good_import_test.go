@@ -0,0 +1,461 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestImportGoods(t *testing.T) {
+	actor := util.RandomName()
+	warehouse := randomWarehouse()
+	category := randomCategory()
+	unit := randomUnit()
+	barcode := util.RandomEAN13()
+	sku := util.RandomString(8)
+
+	header := "category,model,unit,amount,good_desc,unit_cost,sku,barcode,symbology"
+	row1 := fmt.Sprintf("%s,drill,%s,5,cordless drill,1250,%s,%s,ean13", category.CategoryName, unit.UnitName, sku, barcode)
+	row2 := fmt.Sprintf("%s,saw,%s,3,hand saw,,,,", category.CategoryName, unit.UnitName)
+	validCSV := strings.Join([]string{header, row1, ",,,,,,,,", row2}, "\n")
+
+	unitCost := int64(1250)
+	goods := []db.CreateGoodTxParams{
+		{
+			CreateGoodParams: db.CreateGoodParams{
+				Category: category.ID,
+				Model:    "drill",
+				Unit:     unit.ID,
+				Amount:   5,
+				GoodDesc: "cordless drill",
+				Sku:      sql.NullString{String: sku, Valid: true},
+			},
+			Warehouse: warehouse.ID,
+			UnitCost:  sql.NullInt64{Int64: unitCost, Valid: true},
+			Barcodes:  []db.BarcodeEntry{{Barcode: barcode, Symbology: util.BarcodeEAN13}},
+		},
+		{
+			CreateGoodParams: db.CreateGoodParams{
+				Category: category.ID,
+				Model:    "saw",
+				Unit:     unit.ID,
+				Amount:   3,
+				GoodDesc: "hand saw",
+			},
+			Warehouse: warehouse.ID,
+		},
+	}
+
+	// names and identifiers of the valid file resolve like this
+	resolve := func(store *mockdb.MockStore) {
+		store.EXPECT().GetWarehouse(gomock.Any(), gomock.Eq(warehouse.ID)).Times(1).Return(warehouse, nil)
+		store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Eq(db.ListCategoriesByNameParams{CategoryName: category.CategoryName})).
+			Times(1).Return([]db.Category{category}, nil)
+		store.EXPECT().ListUnitsByName(gomock.Any(), gomock.Eq(unit.UnitName)).Times(1).Return([]db.Unit{unit}, nil)
+		store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Eq(sql.NullString{String: sku, Valid: true})).Times(1).Return(db.Good{}, sql.ErrNoRows)
+		store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Eq(barcode)).Times(1).Return(db.GoodBarcode{}, sql.ErrNoRows)
+	}
+
+	testCases := []struct {
+		name          string
+		filename      string
+		content       []byte
+		query         string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			filename: "goods.csv",
+			content:  []byte(validCSV),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				arg := db.ImportGoodsTxParams{
+					Goods: goods,
+					Actor: actor,
+				}
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(make([]db.Good, 2), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.Equal(t, 2, report.Rows)
+				require.Equal(t, 2, report.Created)
+				require.Empty(t, report.Errors)
+			},
+		},
+		{
+			name:     "XLSX",
+			filename: "goods.xlsx",
+			content:  newImportXLSX(t, validCSV),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(1).Return(make([]db.Good, 2), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, 2, requireBodyImportReport(t, recorder.Body).Created)
+			},
+		},
+		{
+			name:     "DryRun",
+			filename: "goods.csv",
+			content:  []byte(validCSV),
+			query:    "&dry_run=true",
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.True(t, report.DryRun)
+				require.Equal(t, 2, report.Rows)
+				require.Zero(t, report.Created)
+				require.Empty(t, report.Errors)
+			},
+		},
+		{
+			name:     "DryRunRowErrors",
+			filename: "goods.csv",
+			content: []byte(strings.Join([]string{
+				header,
+				row1,
+				// unknown category
+				fmt.Sprintf("nowhere,saw,%s,3,hand saw,,,,", unit.UnitName),
+				// sku of the first row, wrong check digit and no description
+				fmt.Sprintf("%s,nail,%s,3,,,%s,4006381333932,ean13", category.CategoryName, unit.UnitName, sku),
+				// amount is not a number
+				fmt.Sprintf("%s,screw,%s,many,screws,,,,", category.CategoryName, unit.UnitName),
+			}, "\n")),
+			query: "&dry_run=true",
+			role:  db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Eq(db.ListCategoriesByNameParams{CategoryName: "nowhere"})).
+					Times(1).Return([]db.Category{}, nil)
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.Equal(t, 4, report.Rows)
+				require.Len(t, report.Errors, 3)
+
+				require.Equal(t, 3, report.Errors[0].Row)
+				require.Equal(t, []string{`unknown category "nowhere"`}, report.Errors[0].Errors)
+
+				require.Equal(t, 4, report.Errors[1].Row)
+				problems := strings.Join(report.Errors[1].Errors, "\n")
+				require.Contains(t, problems, "GoodDesc")
+				require.Contains(t, problems, util.ErrInvalidBarcode.Error())
+				require.Contains(t, problems, "already used in row 2")
+
+				require.Equal(t, 5, report.Errors[2].Row)
+				require.Contains(t, report.Errors[2].Errors, "amount must be a whole number")
+			},
+		},
+		{
+			name:     "RowErrors",
+			filename: "goods.csv",
+			content:  []byte(strings.Join([]string{header, row1, row1}, "\n")),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:     "ExistingSku",
+			filename: "goods.csv",
+			content:  []byte(strings.Join([]string{header, row1}, "\n")),
+			query:    "&dry_run=true",
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(warehouse, nil)
+				store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Category{category}, nil)
+				store.EXPECT().ListUnitsByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Unit{unit}, nil)
+				store.EXPECT().GetGoodBySku(gomock.Any(), gomock.Any()).Times(1).Return(randomGood(), nil)
+				store.EXPECT().GetGoodBarcode(gomock.Any(), gomock.Any()).Times(1).Return(db.GoodBarcode{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.Len(t, report.Errors, 1)
+				require.Equal(t, []string{fmt.Sprintf("sku %q is already used by a good", sku)}, report.Errors[0].Errors)
+			},
+		},
+		{
+			name:     "ValueOverflow",
+			filename: "goods.csv",
+			content:  []byte(header + "\n" + fmt.Sprintf("%s,saw,%s,3,hand saw,%d,,,", category.CategoryName, unit.UnitName, int64(math.MaxInt64/2))),
+			query:    "&dry_run=true",
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(warehouse, nil)
+				store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Category{category}, nil)
+				store.EXPECT().ListUnitsByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Unit{unit}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.Len(t, report.Errors, 1)
+				require.Len(t, report.Errors[0].Errors, 1)
+				require.Contains(t, report.Errors[0].Errors[0], db.ErrAmountOverflow.Error())
+			},
+		},
+		{
+			name:     "AmbiguousCategory",
+			filename: "goods.csv",
+			content:  []byte(strings.Join([]string{header, row2}, "\n")),
+			query:    "&dry_run=true",
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(warehouse, nil)
+				store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Category{category, randomCategory()}, nil)
+				store.EXPECT().ListUnitsByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Unit{unit}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.Len(t, report.Errors, 1)
+				require.Len(t, report.Errors[0].Errors, 1)
+				require.Contains(t, report.Errors[0].Errors[0], "the section must be given")
+			},
+		},
+		{
+			name:     "SectionGiven",
+			filename: "goods.csv",
+			content:  []byte("category,section,model,unit,amount,good_desc\n" + fmt.Sprintf("%s,%s,saw,%s,3,hand saw", category.CategoryName, category.SectionName, unit.UnitName)),
+			query:    "&dry_run=true",
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCategoriesByNameParams{
+					CategoryName: category.CategoryName,
+					SectionName:  sql.NullString{String: category.SectionName, Valid: true},
+				}
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(warehouse, nil)
+				store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Category{category}, nil)
+				store.EXPECT().ListUnitsByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Unit{unit}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Empty(t, requireBodyImportReport(t, recorder.Body).Errors)
+			},
+		},
+		{
+			name:     "OutOfScope",
+			filename: "goods.csv",
+			content:  []byte(strings.Join([]string{header, row2}, "\n")),
+			query:    "&dry_run=true",
+			role:     db.RoleClerk,
+			scope:    token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(warehouse, nil)
+				store.EXPECT().ListCategoriesByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Category{category}, nil)
+				store.EXPECT().ListUnitsByName(gomock.Any(), gomock.Any()).Times(1).Return([]db.Unit{unit}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				report := requireBodyImportReport(t, recorder.Body)
+				require.Len(t, report.Errors, 1)
+				require.Equal(t, []string{errOutOfScope.Error()}, report.Errors[0].Errors)
+			},
+		},
+		{
+			name:     "RowFailsInTransaction",
+			filename: "goods.csv",
+			content:  []byte(validCSV),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				err := &db.ImportRowError{Index: 1, Err: &pq.Error{Code: "23505"}}
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(1).Return(nil, err)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+				var body struct {
+					Report importReport `json:"report"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+				require.Len(t, body.Report.Errors, 1)
+				// the blank row is skipped, the second good is in row 4
+				require.Equal(t, 4, body.Report.Errors[0].Row)
+				require.Zero(t, body.Report.Created)
+			},
+		},
+		{
+			name:     "InternalError",
+			filename: "goods.csv",
+			content:  []byte(validCSV),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				resolve(store)
+				err := &db.ImportRowError{Index: 0, Err: sql.ErrConnDone}
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(1).Return(nil, err)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "WarehouseNotFound",
+			filename: "goods.csv",
+			content:  []byte(validCSV),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(db.Warehouse{}, sql.ErrNoRows)
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "MissingColumn",
+			filename: "goods.csv",
+			content:  []byte("category,model,unit,amount\nTools,saw,piece,3"),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "good_desc")
+			},
+		},
+		{
+			name:     "UnknownColumn",
+			filename: "goods.csv",
+			content:  []byte(header + ",colour\n" + row2 + ",red"),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "NoRows",
+			filename: "goods.csv",
+			content:  []byte(header + "\n,,,\n"),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(1).Return(warehouse, nil)
+				store.EXPECT().ImportGoodsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "UnsupportedFormat",
+			filename: "goods.txt",
+			content:  []byte(validCSV),
+			role:     db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "AuditorForbidden",
+			filename: "goods.csv",
+			content:  []byte(validCSV),
+			role:     db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			part, err := writer.CreateFormFile("file", tc.filename)
+			require.NoError(t, err)
+			_, err = part.Write(tc.content)
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+
+			url := fmt.Sprintf("/goods/import?warehouse=%d%s", warehouse.ID, tc.query)
+			request, err := http.NewRequest(http.MethodPost, url, &body)
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+// newImportXLSX writes the CSV lines into the first sheet of a workbook
+func newImportXLSX(t *testing.T, lines string) []byte {
+	book := excelize.NewFile()
+	defer book.Close()
+
+	sheet := book.GetSheetName(0)
+	for i, line := range strings.Split(lines, "\n") {
+		for j, value := range strings.Split(line, ",") {
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			require.NoError(t, err)
+			require.NoError(t, book.SetCellStr(sheet, cell, value))
+		}
+	}
+
+	buffer, err := book.WriteToBuffer()
+	require.NoError(t, err)
+	return buffer.Bytes()
+}
+
+func requireBodyImportReport(t *testing.T, body *bytes.Buffer) importReport {
+	var report importReport
+	err := json.Unmarshal(body.Bytes(), &report)
+	require.NoError(t, err)
+	return report
+}
@@ -7,20 +7,32 @@ import (
 	"fmt"
 	mockdb "inventory_management/db/mock"
 	db "inventory_management/db/sqlc"
+	"inventory_management/token"
 	"inventory_management/util"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/http/httptest"
 
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
 func TestGetGood(t *testing.T) {
 	good := randomGood()
+	balances := []db.ListGoodBalancesRow{
+		{
+			GoodID:        good.ID,
+			WarehouseID:   util.RandomInt(1, 1000),
+			Amount:        good.Amount,
+			WarehouseName: util.RandomName(),
+		},
+	}
 
 	testCases := []struct {
 		name          string
@@ -33,10 +45,12 @@ func TestGetGood(t *testing.T) {
 			goodID: good.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetGood(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(balances, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchGood(t, recorder.Body, good)
+				requireBodyMatchGoodResponse(t, recorder.Body, good, balances)
+				require.Equal(t, fmt.Sprintf(`"%d"`, good.Version), recorder.Header().Get("ETag"))
 			},
 		},
 		{
@@ -44,6 +58,7 @@ func TestGetGood(t *testing.T) {
 			goodID: good.ID,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().GetGood(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(db.Good{}, sql.ErrNoRows)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
@@ -59,6 +74,17 @@ func TestGetGood(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:   "BalancesInternalError",
+			goodID: good.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGood(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodBalances(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return([]db.ListGoodBalancesRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name:   "InvalidID",
 			goodID: 0,
@@ -81,12 +107,13 @@ func TestGetGood(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 			url := fmt.Sprintf("/goods/%d", tc.goodID)
 			request, err := http.NewRequest(http.MethodGet, url, nil)
 
 			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
@@ -104,59 +131,179 @@ func TestListGoods(t *testing.T) {
 		goods[i] = randomGood()
 	}
 	type Query struct {
-		pageID   int
-		pageSize int
+		pageID      int
+		pageSize    int
+		warehouseID int
+		// the other parameters of the query string
+		filters map[string]string
 	}
 
 	testCases := []struct {
 		name          string
 		query         Query
+		role          string
+		scope         token.Scope
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recoder *httptest.ResponseRecorder)
 	}{
-		// {
-		// 	name: "OK",
-		// 	query: Query{
-		// 		pageID:   1,
-		// 		pageSize: n,
-		// 	},
-		// 	buildStubs: func(store *mockdb.MockStore) {
-		// 		arg := db.ListGoodsParams{
-		// 			Category: goods[1].Category,
-		// 			Limit:    int32(n),
-		// 			Offset:   0,
-		// 		}
-		// 		store.EXPECT().ListGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(goods, nil)
-		// 	},
-		// 	checkResponse: func(recorder *httptest.ResponseRecorder) {
-		// 		require.Equal(t, http.StatusOK, recorder.Code)
-		// 		requireBodyMatchGoods(t, recorder.Body, goods)
-		// 	},
-		// },
-		// {
-		// 	name: "InternalError",
-		// 	query: Query{
-		// 		pageID:   1,
-		// 		pageSize: n,
-		// 	},
-		// 	buildStubs: func(store *mockdb.MockStore) {
-		// 		arg := db.ListGoodsParams{
-		// 			Category: goods[1].Category,
-		// 			Limit:    int32(n),
-		// 			Offset:   0,
-		// 		}
-		// 		store.EXPECT().ListGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Good{}, sql.ErrConnDone)
-		// 	},
-		// 	checkResponse: func(recorder *httptest.ResponseRecorder) {
-		// 		require.Equal(t, http.StatusInternalServerError, recorder.Code)
-		// 	},
-		// },
+		{
+			name: "OK",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"category": fmt.Sprint(goods[1].Category),
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGoodsParams{
+					Category: sql.NullInt64{Int64: goods[1].Category, Valid: true},
+					Limit:    int32(n),
+					Offset:   0,
+				}
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(goods, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGoods(t, recorder.Body, goods)
+			},
+		},
+		{
+			name: "AllFiltersAndSort",
+			query: Query{
+				pageID:      2,
+				pageSize:    n,
+				warehouseID: 4,
+				filters: map[string]string{
+					"section":      "tools",
+					"unit":         "3",
+					"model_prefix": "DR-",
+					"min_amount":   "0",
+					"max_amount":   "20",
+					"created_from": "2024-01-01",
+					"created_to":   "2024-01-31",
+					"sort":         "-amount,model",
+				},
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGoodsParams{
+					Section:     sql.NullString{String: "tools", Valid: true},
+					Unit:        sql.NullInt64{Int64: 3, Valid: true},
+					ModelPrefix: sql.NullString{String: "DR-", Valid: true},
+					MinAmount:   sql.NullInt64{Int64: 0, Valid: true},
+					MaxAmount:   sql.NullInt64{Int64: 20, Valid: true},
+					CreatedFrom: sql.NullTime{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					// the last day is included
+					CreatedTo:   sql.NullTime{Time: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+					WarehouseID: sql.NullInt64{Int64: 4, Valid: true},
+					Sort1:       "-amount",
+					Sort2:       "model",
+					Limit:       int32(n),
+					Offset:      int32(n),
+				}
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(goods, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGoods(t, recorder.Body, goods)
+			},
+		},
+		{
+			name: "ScopedCategory",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"category": fmt.Sprint(goods[1].Category),
+				},
+			},
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{goods[1].Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(1).Return(goods, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ScopedWithoutCategory",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{goods[1].Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "IncludeDeletedNotAdmin",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"include_deleted": "true",
+				},
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"category": fmt.Sprint(goods[1].Category),
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGoodsParams{
+					Category: sql.NullInt64{Int64: goods[1].Category, Valid: true},
+					Limit:    int32(n),
+					Offset:   0,
+				}
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Good{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidPageID",
 			query: Query{
 				pageID:   -1,
 				pageSize: n,
 			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidWarehouseID",
+			query: Query{
+				pageID:      1,
+				pageSize:    n,
+				warehouseID: -1,
+			},
+			role: db.RoleAdmin,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
 			},
@@ -170,6 +317,111 @@ func TestListGoods(t *testing.T) {
 				pageID:   1,
 				pageSize: 10000,
 			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAmountRange",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"min_amount": "10",
+					"max_amount": "5",
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCreatedPeriod",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"created_from": "2024-02-01",
+					"created_to":   "2024-01-01",
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCreatedDate",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"created_from": "yesterday",
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownSortKey",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"sort": "good_desc",
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "RepeatedSortKey",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"sort": "model,-model",
+				},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TooManySortKeys",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				filters: map[string]string{
+					"sort": "model,amount,created_at,id",
+				},
+			},
+			role: db.RoleAdmin,
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().ListGoods(gomock.Any(), gomock.Any()).Times(0)
 			},
@@ -188,7 +440,7 @@ func TestListGoods(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			url := "/goods"
@@ -199,8 +451,15 @@ func TestListGoods(t *testing.T) {
 			q := request.URL.Query()
 			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.warehouseID != 0 {
+				q.Add("warehouse_id", fmt.Sprintf("%d", tc.query.warehouseID))
+			}
+			for key, value := range tc.query.filters {
+				q.Add(key, value)
+			}
 			request.URL.RawQuery = q.Encode()
 
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, tc.scope, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -209,7 +468,10 @@ func TestListGoods(t *testing.T) {
 }
 
 func TestCreateGood(t *testing.T) {
+	actor := util.RandomName()
 	good := randomGood()
+	warehouse := randomWarehouse()
+	amountUnit := randomUnit()
 
 	testCases := []struct {
 		name          string
@@ -223,108 +485,899 @@ func TestCreateGood(t *testing.T) {
 				"category":  good.Category,
 				"model":     good.Model,
 				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
 				"amount":    good.Amount,
 				"good_desc": good.GoodDesc,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateGoodParams{
-					Category: int64(good.Category),
-					Model:    good.Model,
-					Unit:     int64(good.Unit),
-					Amount:   int64(good.Amount),
-					GoodDesc: good.GoodDesc,
+				arg := db.CreateGoodTxParams{
+					CreateGoodParams: db.CreateGoodParams{
+						Category: int64(good.Category),
+						Model:    good.Model,
+						Unit:     int64(good.Unit),
+						Amount:   int64(good.Amount),
+						GoodDesc: good.GoodDesc,
+					},
+					Warehouse: warehouse.ID,
+					Actor:     actor,
 				}
-				store.EXPECT().CreateGood(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchGoodRequest(t, recorder.Body, good)
 			},
 		},
-		// {
-		// 	name: "InternalError",
-		// 	body: gin.H{
-		// 		"category_name": category.CategoryName,
-		// 		"section_name":  category.SectionName,
-		// 	},
-		// 	buildStubs: func(store *mockdb.MockStore) {
-		// 		arg := db.CreateCategoryParams{
-		// 			CategoryName: category.CategoryName,
-		// 			SectionName:  category.SectionName,
-		// 		}
-		// 		store.EXPECT().CreateCategory(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrConnDone)
-		// 	},
-		// 	checkResponse: func(recorder *httptest.ResponseRecorder) {
-		// 		require.Equal(t, http.StatusInternalServerError, recorder.Code)
-		// 	},
-		// },
-		// {
-		// 	name: "InvalidContext",
-		// 	body: gin.H{
-		// 		"category_name": "",
-		// 		"section_name":  "",
-		// 	},
-		// 	buildStubs: func(store *mockdb.MockStore) {
-		// 		store.EXPECT().CreateCategory(gomock.Any(), gomock.Any()).Times(0)
-		// 	},
-		// 	checkResponse: func(recorder *httptest.ResponseRecorder) {
-		// 		require.Equal(t, http.StatusBadRequest, recorder.Code)
-		// 	},
-		// },
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(tc.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			defer ctrl.Finish()
-
-			store := mockdb.NewMockStore(ctrl)
-			tc.buildStubs(store)
-
-			server := NewServer(store)
-			recorder := httptest.NewRecorder()
-
-			// Marshal body data to JSON
-			data, err := json.Marshal(tc.body)
-			require.NoError(t, err)
-
-			url := "/goods"
-			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
-			require.NoError(t, err)
-
-			server.router.ServeHTTP(recorder, request)
-			tc.checkResponse(recorder)
-		})
-
-	}
-
-}
-
-func randomGood() db.Good {
-	g_category := randomCategory()
-	g_unit := randomUnit()
-	return db.Good{
-		ID:       util.RandomInt(1, 1000),
-		Category: g_category.ID,
-		Model:    util.RandomName(),
-		Unit:     g_unit.ID,
-		Amount:   util.RandomInt(5, 9),
-		GoodDesc: util.RandomName(),
-	}
-}
-
-func requireBodyMatchGood(t *testing.T, body *bytes.Buffer, good db.Good) {
-	data, err := ioutil.ReadAll(body)
-	require.NoError(t, err)
-
+		{
+			name: "InternalError",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
+				"amount":    good.Amount,
+				"good_desc": good.GoodDesc,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "WithUnitCost",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
+				"amount":    good.Amount,
+				"good_desc": good.GoodDesc,
+				"unit_cost": 1250,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateGoodTxParams{
+					CreateGoodParams: db.CreateGoodParams{
+						Category: int64(good.Category),
+						Model:    good.Model,
+						Unit:     int64(good.Unit),
+						Amount:   int64(good.Amount),
+						GoodDesc: good.GoodDesc,
+					},
+					Warehouse: warehouse.ID,
+					UnitCost:  sql.NullInt64{Int64: 1250, Valid: true},
+					Actor:     actor,
+				}
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ValueOverflow",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
+				"amount":    2,
+				"good_desc": good.GoodDesc,
+				"unit_cost": int64(math.MaxInt64/2 + 1),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "WithIdentifiers",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
+				"amount":    good.Amount,
+				"good_desc": good.GoodDesc,
+				"sku":       "SKU-42",
+				"barcodes": []gin.H{
+					{"barcode": "4006381333931", "symbology": util.BarcodeEAN13},
+					{"barcode": "036000291452", "symbology": util.BarcodeUPCA},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateGoodTxParams{
+					CreateGoodParams: db.CreateGoodParams{
+						Category: int64(good.Category),
+						Model:    good.Model,
+						Unit:     int64(good.Unit),
+						Amount:   int64(good.Amount),
+						GoodDesc: good.GoodDesc,
+						Sku:      sql.NullString{String: "SKU-42", Valid: true},
+					},
+					Warehouse: warehouse.ID,
+					Barcodes: []db.BarcodeEntry{
+						{Barcode: "4006381333931", Symbology: util.BarcodeEAN13},
+						{Barcode: "036000291452", Symbology: util.BarcodeUPCA},
+					},
+					Actor: actor,
+				}
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidBarcode",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
+				"amount":    good.Amount,
+				"good_desc": good.GoodDesc,
+				"barcodes": []gin.H{
+					{"barcode": "036000291453", "symbology": util.BarcodeUPCA},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateSku",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"warehouse": warehouse.ID,
+				"amount":    good.Amount,
+				"good_desc": good.GoodDesc,
+				"sku":       "SKU-42",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Serialized",
+			body: gin.H{
+				"category":   good.Category,
+				"model":      good.Model,
+				"unit":       good.Unit,
+				"warehouse":  warehouse.ID,
+				"amount":     2,
+				"good_desc":  good.GoodDesc,
+				"serialized": true,
+				"serials":    []string{"SN-1", "SN-2"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateGoodTxParams{
+					CreateGoodParams: db.CreateGoodParams{
+						Category:   int64(good.Category),
+						Model:      good.Model,
+						Unit:       int64(good.Unit),
+						Amount:     2,
+						GoodDesc:   good.GoodDesc,
+						Serialized: true,
+					},
+					Warehouse: warehouse.ID,
+					Serials:   []string{"SN-1", "SN-2"},
+					Actor:     actor,
+				}
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SerialMismatch",
+			body: gin.H{
+				"category":   good.Category,
+				"model":      good.Model,
+				"unit":       good.Unit,
+				"warehouse":  warehouse.ID,
+				"amount":     2,
+				"good_desc":  good.GoodDesc,
+				"serialized": true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AmountInOtherUnit",
+			body: gin.H{
+				"category":    good.Category,
+				"model":       good.Model,
+				"unit":        good.Unit,
+				"warehouse":   warehouse.ID,
+				"amount":      good.Amount,
+				"amount_unit": amountUnit.ID,
+				"good_desc":   good.GoodDesc,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateGoodTxParams{
+					CreateGoodParams: db.CreateGoodParams{
+						Category: int64(good.Category),
+						Model:    good.Model,
+						Unit:     int64(good.Unit),
+						Amount:   int64(good.Amount),
+						GoodDesc: good.GoodDesc,
+					},
+					Warehouse:  warehouse.ID,
+					AmountUnit: amountUnit.ID,
+					Actor:      actor,
+				}
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGoodRequest(t, recorder.Body, good)
+			},
+		},
+		{
+			name: "IncompatibleUnits",
+			body: gin.H{
+				"category":    good.Category,
+				"model":       good.Model,
+				"unit":        good.Unit,
+				"warehouse":   warehouse.ID,
+				"amount":      good.Amount,
+				"amount_unit": amountUnit.ID,
+				"good_desc":   good.GoodDesc,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, db.ErrIncompatibleUnits)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingWarehouse",
+			body: gin.H{
+				"category":  good.Category,
+				"model":     good.Model,
+				"unit":      good.Unit,
+				"amount":    good.Amount,
+				"good_desc": good.GoodDesc,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		// {
+		// 	name: "InternalError",
+		// 	body: gin.H{
+		// 		"category_name": category.CategoryName,
+		// 		"section_name":  category.SectionName,
+		// 	},
+		// 	buildStubs: func(store *mockdb.MockStore) {
+		// 		arg := db.CreateCategoryParams{
+		// 			CategoryName: category.CategoryName,
+		// 			SectionName:  category.SectionName,
+		// 		}
+		// 		store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Category{}, sql.ErrConnDone)
+		// 	},
+		// 	checkResponse: func(recorder *httptest.ResponseRecorder) {
+		// 		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		// 	},
+		// },
+		// {
+		// 	name: "InvalidContext",
+		// 	body: gin.H{
+		// 		"category_name": "",
+		// 		"section_name":  "",
+		// 	},
+		// 	buildStubs: func(store *mockdb.MockStore) {
+		// 		store.EXPECT().CreateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
+		// 	},
+		// 	checkResponse: func(recorder *httptest.ResponseRecorder) {
+		// 		require.Equal(t, http.StatusBadRequest, recorder.Code)
+		// 	},
+		// },
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/goods"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestUpdateGood(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	warehouse := randomWarehouse()
+	unit := randomUnit()
+	amount := util.RandomInt(0, 20)
+
+	updatedGood := good
+	updatedGood.Unit = unit.ID
+	updatedGood.Amount = amount
+
+	ifMatch := fmt.Sprintf(`"%d"`, good.Version)
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		body          gin.H
+		ifMatch       string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateGoodTxParams{
+					ID:        good.ID,
+					Unit:      unit.ID,
+					Warehouse: warehouse.ID,
+					Amount:    amount,
+					Version:   good.Version,
+					Actor:     actor,
+				}
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updatedGood, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGood(t, recorder.Body, updatedGood)
+				require.Equal(t, fmt.Sprintf(`"%d"`, updatedGood.Version), recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:    "NotFound",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "SerialMismatch",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+				"serials":   []string{"SN-1", "SN-2"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateGoodTxParams{
+					ID:        good.ID,
+					Unit:      unit.ID,
+					Warehouse: warehouse.ID,
+					Amount:    amount,
+					Serials:   []string{"SN-1", "SN-2"},
+					Version:   good.Version,
+					Actor:     actor,
+				}
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Good{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "FractionalAmount",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":        unit.ID,
+				"warehouse":   warehouse.ID,
+				"amount":      amount,
+				"amount_unit": unit.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateGoodTxParams{
+					ID:         good.ID,
+					Unit:       unit.ID,
+					Warehouse:  warehouse.ID,
+					Amount:     amount,
+					AmountUnit: unit.ID,
+					Version:    good.Version,
+					Actor:      actor,
+				}
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Good{}, db.ErrFractionalAmount)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:    "MissingWarehouse",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":   unit.ID,
+				"amount": amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "NegativeAmount",
+			goodID:  good.ID,
+			ifMatch: ifMatch,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "MissingIfMatch",
+			goodID: good.ID,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidIfMatch",
+			goodID: good.ID,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+			},
+			ifMatch: "W/\"1\"",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "VersionMismatch",
+			goodID: good.ID,
+			body: gin.H{
+				"unit":      unit.ID,
+				"warehouse": warehouse.ID,
+				"amount":    amount,
+			},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, db.ErrVersionMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d", tc.goodID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestSetGoodStockLevels(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+
+	updatedGood := good
+	updatedGood.SafetyStock = 2
+	updatedGood.ReorderPoint = 5
+	updatedGood.MaxLevel = 20
+	updatedGood.Version = good.Version + 1
+
+	ifMatch := fmt.Sprintf(`"%d"`, good.Version)
+	body := gin.H{
+		"safety_stock":  updatedGood.SafetyStock,
+		"reorder_point": updatedGood.ReorderPoint,
+		"max_level":     updatedGood.MaxLevel,
+	}
+	arg := db.SetGoodStockLevelsTxParams{
+		SetGoodStockLevelsParams: db.SetGoodStockLevelsParams{
+			ID:           good.ID,
+			ReorderPoint: updatedGood.ReorderPoint,
+			SafetyStock:  updatedGood.SafetyStock,
+			MaxLevel:     updatedGood.MaxLevel,
+		},
+		Version: good.Version,
+		Actor:   actor,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		ifMatch       string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			body:    body,
+			ifMatch: ifMatch,
+			role:    db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(updatedGood, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGood(t, recorder.Body, updatedGood)
+				require.Equal(t, fmt.Sprintf(`"%d"`, updatedGood.Version), recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:    "Disabled",
+			body:    gin.H{},
+			ifMatch: ifMatch,
+			role:    db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetGoodStockLevelsTxParams{
+					SetGoodStockLevelsParams: db.SetGoodStockLevelsParams{ID: good.ID},
+					Version:                  good.Version,
+					Actor:                    actor,
+				}
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SafetyStockAboveReorderPoint",
+			body: gin.H{
+				"safety_stock":  6,
+				"reorder_point": 5,
+				"max_level":     20,
+			},
+			ifMatch: ifMatch,
+			role:    db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ReorderPointAboveMaxLevel",
+			body: gin.H{
+				"reorder_point": 5,
+				"max_level":     4,
+			},
+			ifMatch: ifMatch,
+			role:    db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeSafetyStock",
+			body: gin.H{
+				"safety_stock": -1,
+			},
+			ifMatch: ifMatch,
+			role:    db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingIfMatch",
+			body: body,
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:    "VersionMismatch",
+			body:    body,
+			ifMatch: ifMatch,
+			role:    db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Good{}, db.ErrVersionMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			body:    body,
+			ifMatch: ifMatch,
+			role:    db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Good{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "AuditorForbidden",
+			body:    body,
+			ifMatch: ifMatch,
+			role:    db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGoodStockLevelsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/stock-levels", good.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRestoreGood(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RestoreTxParams{
+					ID:    good.ID,
+					Actor: actor,
+				}
+				store.EXPECT().RestoreGoodTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGood(t, recorder.Body, good)
+			},
+		},
+		{
+			name:   "NotDeleted",
+			goodID: good.ID,
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreGoodTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Good{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "Forbidden",
+			goodID: good.ID,
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreGoodTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/goods/%d/restore", tc.goodID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomGood() db.Good {
+	g_category := randomCategory()
+	g_unit := randomUnit()
+	return db.Good{
+		ID:       util.RandomInt(1, 1000),
+		Category: g_category.ID,
+		Model:    util.RandomName(),
+		Unit:     g_unit.ID,
+		Amount:   util.RandomInt(5, 9),
+		Reserved: util.RandomInt(0, 4),
+		GoodDesc: util.RandomName(),
+		Version:  util.RandomInt(1, 100),
+	}
+}
+
+func requireBodyMatchGood(t *testing.T, body *bytes.Buffer, good db.Good) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
 	var gotGood db.Good
 	err = json.Unmarshal(data, &gotGood)
 	require.NoError(t, err)
 	require.Equal(t, good, gotGood)
 }
 
+func requireBodyMatchGoodResponse(t *testing.T, body *bytes.Buffer, good db.Good, balances []db.ListGoodBalancesRow) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotGood goodResponse
+	err = json.Unmarshal(data, &gotGood)
+	require.NoError(t, err)
+	require.Equal(t, good, gotGood.Good)
+	require.Equal(t, good.Amount, gotGood.OnHand)
+	require.Equal(t, good.Amount-good.Reserved, gotGood.Available)
+	require.Equal(t, balances, gotGood.Balances)
+
+	var total int64
+	for _, balance := range balances {
+		total += balance.Amount
+	}
+	require.Equal(t, total, gotGood.Total)
+}
+
 func requireBodyMatchGoods(t *testing.T, body *bytes.Buffer, goods []db.Good) {
 	data, err := ioutil.ReadAll(body)
 	require.NoError(t, err)
@@ -0,0 +1,407 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	db "inventory_management/db/sqlc"
+	"inventory_management/label"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var errNoLabelCode = errors.New("the good has neither a barcode nor a sku that can be printed as one")
+
+// labelContentTypes are the media types of the label formats
+var labelContentTypes = map[string]string{
+	"png": "image/png",
+	"pdf": "application/pdf",
+	"zpl": "application/zpl",
+}
+
+type createLabelTemplateRequest struct {
+	TemplateName string `json:"template_name" binding:"required"`
+	WidthMM      int32  `json:"width_mm" binding:"required,min=10,max=190"`
+	HeightMM     int32  `json:"height_mm" binding:"required,min=10,max=277"`
+	DPI          int32  `json:"dpi" binding:"required,oneof=152 203 300 600"`
+	CodeType     string `json:"code_type" binding:"required,oneof=barcode qr"`
+	ShowModel    bool   `json:"show_model"`
+	ShowCategory bool   `json:"show_category"`
+	ShowUnit     bool   `json:"show_unit"`
+}
+
+func (server *Server) createLabelTemplate(c *gin.Context) {
+	var req createLabelTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateLabelTemplateTxParams{
+		CreateLabelTemplateParams: db.CreateLabelTemplateParams{
+			TemplateName: req.TemplateName,
+			WidthMm:      req.WidthMM,
+			HeightMm:     req.HeightMM,
+			Dpi:          req.DPI,
+			CodeType:     req.CodeType,
+			ShowModel:    req.ShowModel,
+			ShowCategory: req.ShowCategory,
+			ShowUnit:     req.ShowUnit,
+		},
+		Actor: authPayload.Username,
+	}
+
+	template, err := server.store.CreateLabelTemplateTx(c, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+type getLabelTemplateRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getLabelTemplate(c *gin.Context) {
+	var req getLabelTemplateRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	template, err := server.store.GetLabelTemplate(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+type listLabelTemplateRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listLabelTemplate(c *gin.Context) {
+	var req listLabelTemplateRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListLabelTemplatesParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	templates, err := server.store.ListLabelTemplates(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+type updateLabelTemplateRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) updateLabelTemplate(c *gin.Context) {
+	var req updateLabelTemplateRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqUpdate createLabelTemplateRequest
+	if err := c.ShouldBindJSON(&reqUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateLabelTemplateTxParams{
+		UpdateLabelTemplateParams: db.UpdateLabelTemplateParams{
+			ID:           req.ID,
+			TemplateName: reqUpdate.TemplateName,
+			WidthMm:      reqUpdate.WidthMM,
+			HeightMm:     reqUpdate.HeightMM,
+			Dpi:          reqUpdate.DPI,
+			CodeType:     reqUpdate.CodeType,
+			ShowModel:    reqUpdate.ShowModel,
+			ShowCategory: reqUpdate.ShowCategory,
+			ShowUnit:     reqUpdate.ShowUnit,
+		},
+		Actor: authPayload.Username,
+	}
+
+	template, err := server.store.UpdateLabelTemplateTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+type deleteLabelTemplateRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteLabelTemplate(c *gin.Context) {
+	var req deleteLabelTemplateRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteLabelTemplateTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "label template deleted successfully",
+	})
+}
+
+type getGoodLabelRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type getGoodLabelRequestFormat struct {
+	// png when not given
+	Format string `form:"format" binding:"omitempty,oneof=png pdf zpl"`
+	// the default template when not given
+	TemplateID int64 `form:"template_id" binding:"omitempty,min=1"`
+}
+
+func (server *Server) getGoodLabel(c *gin.Context) {
+	var req getGoodLabelRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqFormat getGoodLabelRequestFormat
+	if err := c.ShouldBindQuery(&reqFormat); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if reqFormat.Format == "" {
+		reqFormat.Format = "png"
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	template, ok := server.labelTemplate(c, reqFormat.TemplateID)
+	if !ok {
+		return
+	}
+
+	row, err := server.store.GetGoodLabel(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	goodLabel, err := newLabel(row)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		return
+	}
+
+	renderLabels(c, fmt.Sprintf("good-%d", req.ID), reqFormat.Format, template, []label.Label{goodLabel})
+}
+
+type listCategoryLabelRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listCategoryLabelRequestFormat struct {
+	// pdf when not given, a single PNG cannot hold a batch
+	Format     string `form:"format" binding:"omitempty,oneof=pdf zpl"`
+	TemplateID int64  `form:"template_id" binding:"omitempty,min=1"`
+}
+
+// listCategoryLabel prints the labels of every good of a category in one document
+func (server *Server) listCategoryLabel(c *gin.Context) {
+	var req listCategoryLabelRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqFormat listCategoryLabelRequestFormat
+	if err := c.ShouldBindQuery(&reqFormat); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if reqFormat.Format == "" {
+		reqFormat.Format = "pdf"
+	}
+
+	if !server.authorizeCategory(c, req.ID) {
+		return
+	}
+
+	_, err := server.store.GetCategory(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	template, ok := server.labelTemplate(c, reqFormat.TemplateID)
+	if !ok {
+		return
+	}
+
+	rows, err := server.store.ListCategoryLabels(c, req.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	labels := make([]label.Label, 0, len(rows))
+	for _, row := range rows {
+		goodLabel, err := newLabel(db.GetGoodLabelRow(row))
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		labels = append(labels, goodLabel)
+	}
+
+	renderLabels(c, fmt.Sprintf("category-%d", req.ID), reqFormat.Format, template, labels)
+}
+
+// labelTemplate gets the template with the id, or the default template for the id zero,
+// and writes the error response when it cannot
+func (server *Server) labelTemplate(c *gin.Context, id int64) (label.Template, bool) {
+	if id == 0 {
+		return label.DefaultTemplate(), true
+	}
+
+	template, err := server.store.GetLabelTemplate(c, id)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return label.Template{}, false
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return label.Template{}, false
+	}
+
+	return label.Template{
+		WidthMM:      int(template.WidthMm),
+		HeightMM:     int(template.HeightMm),
+		DPI:          int(template.Dpi),
+		CodeType:     template.CodeType,
+		ShowModel:    template.ShowModel,
+		ShowCategory: template.ShowCategory,
+		ShowUnit:     template.ShowUnit,
+	}, true
+}
+
+// newLabel puts the first barcode of the good on its label, or its sku as Code 128 when it has no barcode
+func newLabel(row db.GetGoodLabelRow) (label.Label, error) {
+	goodLabel := label.Label{
+		Model:    row.Model,
+		Category: row.CategoryName,
+		Unit:     row.UnitName,
+	}
+
+	switch {
+	case row.Barcode.Valid:
+		goodLabel.Code = row.Barcode.String
+		goodLabel.Symbology = row.Symbology.String
+	case row.Sku.Valid && util.ValidateBarcode(util.BarcodeCode128, row.Sku.String) == nil:
+		goodLabel.Code = row.Sku.String
+		goodLabel.Symbology = util.BarcodeCode128
+	default:
+		return goodLabel, fmt.Errorf("%w: good %d", errNoLabelCode, row.GoodID)
+	}
+
+	return goodLabel, nil
+}
+
+// renderLabels writes the labels in the format as the response, a PNG holds the first label only
+func renderLabels(c *gin.Context, name string, format string, template label.Template, labels []label.Label) {
+	var output bytes.Buffer
+	var err error
+	switch format {
+	case "png":
+		err = label.PNG(&output, template, labels[0])
+	case "pdf":
+		err = label.PDF(&output, template, labels)
+	case "zpl":
+		err = label.ZPL(&output, template, labels)
+	}
+
+	if err != nil {
+		if errors.Is(err, label.ErrLabelTooSmall) || errors.Is(err, label.ErrLabelTooLarge) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.%s"`, name, format))
+	c.Data(http.StatusOK, labelContentTypes[format], output.Bytes())
+}
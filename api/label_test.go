@@ -0,0 +1,711 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLabelTemplate(t *testing.T) {
+	actor := util.RandomName()
+	template := randomLabelTemplate()
+
+	body := gin.H{
+		"template_name": template.TemplateName,
+		"width_mm":      template.WidthMm,
+		"height_mm":     template.HeightMm,
+		"dpi":           template.Dpi,
+		"code_type":     template.CodeType,
+		"show_model":    template.ShowModel,
+		"show_category": template.ShowCategory,
+		"show_unit":     template.ShowUnit,
+	}
+	with := func(key string, value interface{}) gin.H {
+		changed := gin.H{}
+		for k, v := range body {
+			changed[k] = v
+		}
+		changed[key] = value
+		return changed
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateLabelTemplateTxParams{
+					CreateLabelTemplateParams: db.CreateLabelTemplateParams{
+						TemplateName: template.TemplateName,
+						WidthMm:      template.WidthMm,
+						HeightMm:     template.HeightMm,
+						Dpi:          template.Dpi,
+						CodeType:     template.CodeType,
+						ShowModel:    template.ShowModel,
+						ShowCategory: template.ShowCategory,
+						ShowUnit:     template.ShowUnit,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(template, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLabelTemplate(t, recorder.Body, template)
+			},
+		},
+		{
+			name: "UnsupportedDPI",
+			body: with("dpi", 250),
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "WiderThanSheet",
+			body: with("width_mm", 200),
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCodeType",
+			body: with("code_type", "datamatrix"),
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: body,
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(1).Return(db.LabelTemplate{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			body: body,
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: body,
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(1).Return(db.LabelTemplate{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/label-templates", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetLabelTemplate(t *testing.T) {
+	template := randomLabelTemplate()
+
+	testCases := []struct {
+		name          string
+		templateID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			templateID: template.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLabelTemplate(t, recorder.Body, template)
+			},
+		},
+		{
+			name:       "NotFound",
+			templateID: template.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(db.LabelTemplate{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			templateID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/label-templates/%d", tc.templateID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListLabelTemplate(t *testing.T) {
+	templates := []db.LabelTemplate{randomLabelTemplate(), randomLabelTemplate()}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	arg := db.ListLabelTemplatesParams{
+		Limit:  5,
+		Offset: 5,
+	}
+	store.EXPECT().ListLabelTemplates(gomock.Any(), gomock.Eq(arg)).Times(1).Return(templates, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/label-templates?page_id=2&page_size=5", nil)
+	require.NoError(t, err)
+
+	addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, token.Scope{}, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.LabelTemplate
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, templates, got)
+}
+
+func TestUpdateLabelTemplate(t *testing.T) {
+	actor := util.RandomName()
+	template := randomLabelTemplate()
+	body := gin.H{
+		"template_name": template.TemplateName,
+		"width_mm":      template.WidthMm,
+		"height_mm":     template.HeightMm,
+		"dpi":           template.Dpi,
+		"code_type":     template.CodeType,
+		"show_model":    template.ShowModel,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateLabelTemplateTxParams{
+					UpdateLabelTemplateParams: db.UpdateLabelTemplateParams{
+						ID:           template.ID,
+						TemplateName: template.TemplateName,
+						WidthMm:      template.WidthMm,
+						HeightMm:     template.HeightMm,
+						Dpi:          template.Dpi,
+						CodeType:     template.CodeType,
+						ShowModel:    template.ShowModel,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().UpdateLabelTemplateTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(template, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLabelTemplate(t, recorder.Body, template)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(1).Return(db.LabelTemplate{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateName",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateLabelTemplateTx(gomock.Any(), gomock.Any()).Times(1).Return(db.LabelTemplate{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/label-templates/%d", template.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, db.RoleWarehouseManager, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteLabelTemplate(t *testing.T) {
+	actor := util.RandomName()
+	template := randomLabelTemplate()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.DeleteTxParams{
+					ID:    template.ID,
+					Actor: actor,
+				}
+				store.EXPECT().DeleteLabelTemplateTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteLabelTemplateTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteLabelTemplateTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/label-templates/%d", template.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, db.RoleWarehouseManager, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetGoodLabel(t *testing.T) {
+	good := randomGood()
+	template := randomLabelTemplate()
+	row := randomGoodLabel(good.ID)
+
+	testCases := []struct {
+		name          string
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "DefaultPNG",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(row, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+				_, err := png.Decode(recorder.Body)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:  "TemplateZPL",
+			query: fmt.Sprintf("?format=zpl&template_id=%d", template.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(template, nil)
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(row, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/zpl", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "^XA"))
+				require.Contains(t, recorder.Body.String(), row.Barcode.String[:12])
+			},
+		},
+		{
+			name:  "PDF",
+			query: "?format=pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(row, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "%PDF"))
+			},
+		},
+		{
+			name: "SkuWithoutBarcode",
+			buildStubs: func(store *mockdb.MockStore) {
+				skuRow := row
+				skuRow.Barcode = sql.NullString{}
+				skuRow.Symbology = sql.NullString{}
+				skuRow.Sku = sql.NullString{String: util.RandomString(8), Valid: true}
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(skuRow, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NoCode",
+			buildStubs: func(store *mockdb.MockStore) {
+				bareRow := row
+				bareRow.Barcode = sql.NullString{}
+				bareRow.Symbology = sql.NullString{}
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(bareRow, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:  "TemplateTooSmall",
+			query: fmt.Sprintf("?template_id=%d", template.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				small := template
+				small.HeightMm = 10
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(small, nil)
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(row, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:  "TemplateNotFound",
+			query: fmt.Sprintf("?template_id=%d", template.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLabelTemplate(gomock.Any(), gomock.Eq(template.ID)).Times(1).Return(db.LabelTemplate{}, sql.ErrNoRows)
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "GoodNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(db.GetGoodLabelRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: "?format=svg",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().GetGoodLabel(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/goods/%d/label%s", good.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListCategoryLabel(t *testing.T) {
+	category := randomCategory()
+	rows := []db.ListCategoryLabelsRow{
+		db.ListCategoryLabelsRow(randomGoodLabel(util.RandomInt(1, 1000))),
+		db.ListCategoryLabelsRow(randomGoodLabel(util.RandomInt(1, 1000))),
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "DefaultPDF",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().ListCategoryLabels(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/pdf", recorder.Header().Get("Content-Type"))
+				require.True(t, strings.HasPrefix(recorder.Body.String(), "%PDF"))
+			},
+		},
+		{
+			name:  "ZPL",
+			query: "?format=zpl",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().ListCategoryLabels(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, len(rows), strings.Count(recorder.Body.String(), "^XA"))
+			},
+		},
+		{
+			name:  "PNGNotBatched",
+			query: "?format=png",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCategoryLabels(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "GoodWithoutCode",
+			buildStubs: func(store *mockdb.MockStore) {
+				bare := rows[1]
+				bare.Barcode = sql.NullString{}
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().ListCategoryLabels(gomock.Any(), gomock.Eq(category.ID)).Times(1).
+					Return([]db.ListCategoryLabelsRow{rows[0], bare}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "CategoryNotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(db.Category{}, sql.ErrNoRows)
+				store.EXPECT().ListCategoryLabels(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Eq(category.ID)).Times(1).Return(category, nil)
+				store.EXPECT().ListCategoryLabels(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/categories/%d/labels%s", category.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomLabelTemplate() db.LabelTemplate {
+	return db.LabelTemplate{
+		ID:           util.RandomInt(1, 1000),
+		TemplateName: util.RandomName(),
+		WidthMm:      60,
+		HeightMm:     40,
+		Dpi:          300,
+		CodeType:     "barcode",
+		ShowModel:    true,
+		ShowCategory: true,
+		ShowUnit:     true,
+	}
+}
+
+func randomGoodLabel(goodID int64) db.GetGoodLabelRow {
+	return db.GetGoodLabelRow{
+		GoodID:       goodID,
+		Model:        util.RandomName(),
+		CategoryName: util.RandomName(),
+		UnitName:     util.RandomName(),
+		Barcode:      sql.NullString{String: util.RandomEAN13(), Valid: true},
+		Symbology:    sql.NullString{String: util.BarcodeEAN13, Valid: true},
+	}
+}
+
+func requireBodyMatchLabelTemplate(t *testing.T, body *bytes.Buffer, template db.LabelTemplate) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotTemplate db.LabelTemplate
+	err = json.Unmarshal(data, &gotTemplate)
+	require.NoError(t, err)
+	require.Equal(t, template, gotTemplate)
+}
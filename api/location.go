@@ -0,0 +1,291 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// locationLevels orders the location types from the top of the hierarchy down to the bins
+var locationLevels = map[string]int{
+	db.LocationTypeZone:  1,
+	db.LocationTypeAisle: 2,
+	db.LocationTypeRack:  3,
+	db.LocationTypeBin:   4,
+}
+
+var (
+	errZoneWithParent    = errors.New("a zone sits directly under its warehouse and cannot have a parent")
+	errMissingParent     = errors.New("only zones can be created without a parent location")
+	errParentWarehouse   = errors.New("parent location belongs to another warehouse")
+	errParentLevelTooLow = errors.New("parent location must be one level above: warehouse > zone > aisle > rack > bin")
+)
+
+type createLocationRequest struct {
+	WarehouseID  int64  `json:"warehouse_id" binding:"required,min=1"`
+	ParentID     int64  `json:"parent_id" binding:"omitempty,min=1"`
+	LocationType string `json:"location_type" binding:"required,oneof=zone aisle rack bin"`
+	LocationCode string `json:"location_code" binding:"required"`
+}
+
+func (server *Server) createLocation(c *gin.Context) {
+	var req createLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.LocationType == db.LocationTypeZone && req.ParentID != 0 {
+		c.JSON(http.StatusBadRequest, errorResponse(errZoneWithParent))
+		return
+	}
+
+	if req.LocationType != db.LocationTypeZone {
+		if req.ParentID == 0 {
+			c.JSON(http.StatusBadRequest, errorResponse(errMissingParent))
+			return
+		}
+
+		parent, err := server.store.GetLocation(c, req.ParentID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				c.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			c.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if parent.WarehouseID != req.WarehouseID {
+			c.JSON(http.StatusBadRequest, errorResponse(errParentWarehouse))
+			return
+		}
+		if locationLevels[parent.LocationType]+1 != locationLevels[req.LocationType] {
+			c.JSON(http.StatusBadRequest, errorResponse(errParentLevelTooLow))
+			return
+		}
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateLocationTxParams{
+		CreateLocationParams: db.CreateLocationParams{
+			WarehouseID: req.WarehouseID,
+			ParentID: sql.NullInt64{
+				Int64: req.ParentID,
+				Valid: req.ParentID > 0,
+			},
+			LocationType: req.LocationType,
+			LocationCode: req.LocationCode,
+		},
+		Actor: authPayload.Username,
+	}
+
+	location, err := server.store.CreateLocationTx(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+type getLocationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getLocation(c *gin.Context) {
+	var req getLocationRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	location, err := server.store.GetLocation(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+type listLocationRequest struct {
+	WarehouseID int64 `form:"warehouse_id" binding:"required,min=1"`
+	PageID      int32 `form:"page_id" binding:"required,min=1"`
+	PageSize    int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listLocation(c *gin.Context) {
+	var req listLocationRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListLocationsParams{
+		WarehouseID: req.WarehouseID,
+		Limit:       req.PageSize,
+		Offset:      (req.PageID - 1) * req.PageSize,
+	}
+	locations, err := server.store.ListLocations(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, locations)
+}
+
+type updateLocationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateLocationRequestJson struct {
+	LocationCode string `json:"location_code" binding:"required"`
+}
+
+func (server *Server) updateLocation(c *gin.Context) {
+	var req updateLocationRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqUpdate updateLocationRequestJson
+	if err1 := c.ShouldBindJSON(&reqUpdate); err1 != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err1))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateLocationTxParams{
+		UpdateLocationParams: db.UpdateLocationParams{
+			ID:           req.ID,
+			LocationCode: reqUpdate.LocationCode,
+		},
+		Actor: authPayload.Username,
+	}
+
+	location, err2 := server.store.UpdateLocationTx(c, arg)
+
+	if err2 != nil {
+		if err2 == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err2))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err2))
+		return
+	}
+
+	c.JSON(http.StatusOK, location)
+}
+
+type deleteLocationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteLocation(c *gin.Context) {
+	var req deleteLocationRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteLocationTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		// the location still has child locations, bin stock or movements
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "location deleted successfuly",
+	})
+}
+
+type listLocationContentsRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listLocationContentsRequestQuery struct {
+	// required for scoped users
+	Category int64 `form:"category" binding:"omitempty,min=1"`
+}
+
+// listLocationContents lists the goods stored in the bins at and below a location,
+// scoped users have to ask for the goods of one of their categories
+func (server *Server) listLocationContents(c *gin.Context) {
+	var req listLocationContentsRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqQuery listLocationContentsRequestQuery
+	if err := c.ShouldBindQuery(&reqQuery); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, reqQuery.Category > 0) {
+		return
+	}
+
+	if reqQuery.Category > 0 && !server.authorizeCategory(c, reqQuery.Category) {
+		return
+	}
+
+	location, err := server.store.GetLocation(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ListLocationContentsParams{
+		ID: location.ID,
+		Category: sql.NullInt64{
+			Int64: reqQuery.Category,
+			Valid: reqQuery.Category > 0,
+		},
+	}
+	contents, err := server.store.ListLocationContents(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, contents)
+}
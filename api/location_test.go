@@ -0,0 +1,766 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLocation(t *testing.T) {
+	location := randomLocation(util.RandomInt(1, 1000), db.LocationTypeZone, nil)
+
+	testCases := []struct {
+		name          string
+		locationID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(T *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			locationID: location.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(location.ID)).Times(1).Return(location, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLocation(t, recorder.Body, location)
+			},
+		},
+		{
+			name:       "NotFound",
+			locationID: location.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(location.ID)).Times(1).Return(db.Location{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			locationID: location.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(location.ID)).Times(1).Return(db.Location{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			locationID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/locations/%d", tc.locationID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+
+	}
+
+}
+
+func TestCreateLocation(t *testing.T) {
+	actor := util.RandomName()
+	warehouseID := util.RandomInt(1, 1000)
+	zone := randomLocation(warehouseID, db.LocationTypeZone, nil)
+	aisle := randomLocation(warehouseID, db.LocationTypeAisle, &zone)
+	otherZone := randomLocation(warehouseID+1, db.LocationTypeZone, nil)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "ZoneOK",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"location_type": db.LocationTypeZone,
+				"location_code": zone.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateLocationTxParams{
+					CreateLocationParams: db.CreateLocationParams{
+						WarehouseID:  warehouseID,
+						LocationType: db.LocationTypeZone,
+						LocationCode: zone.LocationCode,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(zone, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLocation(t, recorder.Body, zone)
+			},
+		},
+		{
+			name: "AisleOK",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"parent_id":     zone.ID,
+				"location_type": db.LocationTypeAisle,
+				"location_code": aisle.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateLocationTxParams{
+					CreateLocationParams: db.CreateLocationParams{
+						WarehouseID:  warehouseID,
+						ParentID:     sql.NullInt64{Int64: zone.ID, Valid: true},
+						LocationType: db.LocationTypeAisle,
+						LocationCode: aisle.LocationCode,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(zone, nil)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(aisle, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLocation(t, recorder.Body, aisle)
+			},
+		},
+		{
+			name: "ZoneWithParent",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"parent_id":     zone.ID,
+				"location_type": db.LocationTypeZone,
+				"location_code": zone.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingParent",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"location_type": db.LocationTypeBin,
+				"location_code": aisle.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ParentNotFound",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"parent_id":     zone.ID,
+				"location_type": db.LocationTypeAisle,
+				"location_code": aisle.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(db.Location{}, sql.ErrNoRows)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ParentInOtherWarehouse",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"parent_id":     otherZone.ID,
+				"location_type": db.LocationTypeAisle,
+				"location_code": aisle.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(otherZone.ID)).Times(1).Return(otherZone, nil)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SkippedLevel",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"parent_id":     zone.ID,
+				"location_type": db.LocationTypeBin,
+				"location_code": aisle.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(zone, nil)
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLocationType",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"location_type": "shelf",
+				"location_code": zone.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"warehouse_id":  warehouseID,
+				"location_type": db.LocationTypeZone,
+				"location_code": zone.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLocationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Location{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/locations"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestListLocation(t *testing.T) {
+	warehouseID := util.RandomInt(1, 1000)
+
+	n := 6
+	locations := make([]db.Location, n)
+
+	for i := 0; i < n; i++ {
+		locations[i] = randomLocation(warehouseID, db.LocationTypeZone, nil)
+	}
+
+	type Query struct {
+		warehouseID int64
+		pageID      int
+		pageSize    int
+	}
+
+	testCases := []struct {
+		name          string
+		query         Query
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: Query{
+				warehouseID: warehouseID,
+				pageID:      1,
+				pageSize:    n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListLocationsParams{
+					WarehouseID: warehouseID,
+					Limit:       int32(n),
+					Offset:      0,
+				}
+				store.EXPECT().ListLocations(gomock.Any(), gomock.Eq(arg)).Times(1).Return(locations, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLocations(t, recorder.Body, locations)
+			},
+		},
+		{
+			name: "InternalError",
+			query: Query{
+				warehouseID: warehouseID,
+				pageID:      1,
+				pageSize:    n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLocations(gomock.Any(), gomock.Any()).Times(1).Return([]db.Location{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MissingWarehouseID",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLocations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			query: Query{
+				warehouseID: warehouseID,
+				pageID:      1,
+				pageSize:    10000,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLocations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/locations"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query parameters to request URL
+			q := request.URL.Query()
+			q.Add("warehouse_id", fmt.Sprintf("%d", tc.query.warehouseID))
+			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			request.URL.RawQuery = q.Encode()
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+
+}
+
+func TestUpdateLocation(t *testing.T) {
+	actor := util.RandomName()
+	location := randomLocation(util.RandomInt(1, 1000), db.LocationTypeZone, nil)
+	locationUpdate := location
+	locationUpdate.LocationCode = util.RandomString(6)
+
+	testCases := []struct {
+		name          string
+		locationID    int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			locationID: location.ID,
+			body: gin.H{
+				"location_code": locationUpdate.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateLocationTxParams{
+					UpdateLocationParams: db.UpdateLocationParams{
+						ID:           location.ID,
+						LocationCode: locationUpdate.LocationCode,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().UpdateLocationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(locationUpdate, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLocation(t, recorder.Body, locationUpdate)
+			},
+		},
+		{
+			name:       "NotFound",
+			locationID: location.ID,
+			body: gin.H{
+				"location_code": locationUpdate.LocationCode,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateLocationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Location{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidContext",
+			locationID: location.ID,
+			body: gin.H{
+				"location_code": "",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/locations/%d", tc.locationID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestDeleteLocation(t *testing.T) {
+	actor := util.RandomName()
+	location := randomLocation(util.RandomInt(1, 1000), db.LocationTypeZone, nil)
+
+	testCases := []struct {
+		name          string
+		locationID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(T *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			locationID: location.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteLocationTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: location.ID, Actor: actor})).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "StillInUse",
+			locationID: location.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteLocationTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: location.ID, Actor: actor})).Times(1).Return(&pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			locationID: location.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteLocationTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: location.ID, Actor: actor})).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			locationID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteLocationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/locations/%d", tc.locationID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+
+	}
+
+}
+
+func TestListLocationContents(t *testing.T) {
+	zone := randomLocation(util.RandomInt(1, 1000), db.LocationTypeZone, nil)
+
+	n := 3
+	contents := make([]db.ListLocationContentsRow, n)
+	for i := 0; i < n; i++ {
+		contents[i] = db.ListLocationContentsRow{
+			GoodID:       util.RandomInt(1, 1000),
+			LocationID:   util.RandomInt(1, 1000),
+			Amount:       util.RandomInt(1, 100),
+			LocationCode: util.RandomString(6),
+			Model:        util.RandomName(),
+		}
+	}
+
+	category := randomCategory()
+
+	testCases := []struct {
+		name          string
+		locationID    int64
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(T *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			locationID: zone.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(zone, nil)
+				store.EXPECT().ListLocationContents(gomock.Any(), gomock.Eq(db.ListLocationContentsParams{ID: zone.ID})).Times(1).Return(contents, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := ioutil.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var gotContents []db.ListLocationContentsRow
+				err = json.Unmarshal(data, &gotContents)
+				require.NoError(t, err)
+				require.Equal(t, contents, gotContents)
+			},
+		},
+		{
+			name:       "Category",
+			locationID: zone.ID,
+			query:      fmt.Sprintf("?category=%d", category.ID),
+			scope:      token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListLocationContentsParams{
+					ID:       zone.ID,
+					Category: sql.NullInt64{Int64: category.ID, Valid: true},
+				}
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(zone, nil)
+				store.EXPECT().ListLocationContents(gomock.Any(), gomock.Eq(arg)).Times(1).Return(contents, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "ScopedWithoutCategory",
+			locationID: zone.ID,
+			scope:      token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListLocationContents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "CategoryOutOfScope",
+			locationID: zone.ID,
+			query:      fmt.Sprintf("?category=%d", category.ID),
+			scope:      token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListLocationContents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			locationID: zone.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(db.Location{}, sql.ErrNoRows)
+				store.EXPECT().ListLocationContents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			locationID: zone.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Eq(zone.ID)).Times(1).Return(zone, nil)
+				store.EXPECT().ListLocationContents(gomock.Any(), gomock.Eq(db.ListLocationContentsParams{ID: zone.ID})).Times(1).Return([]db.ListLocationContentsRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			locationID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLocation(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/locations/%d/contents%s", tc.locationID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+
+			require.NoError(t, err)
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+
+	}
+
+}
+
+func randomLocation(warehouseID int64, locationType string, parent *db.Location) db.Location {
+	location := db.Location{
+		ID:           util.RandomInt(1, 1000),
+		WarehouseID:  warehouseID,
+		LocationType: locationType,
+		LocationCode: util.RandomString(6),
+	}
+	if parent != nil {
+		location.ParentID = sql.NullInt64{Int64: parent.ID, Valid: true}
+	}
+	return location
+}
+
+func requireBodyMatchLocation(t *testing.T, body *bytes.Buffer, location db.Location) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotLocation db.Location
+	err = json.Unmarshal(data, &gotLocation)
+	require.NoError(t, err)
+	require.Equal(t, location, gotLocation)
+}
+
+func requireBodyMatchLocations(t *testing.T, body *bytes.Buffer, locations []db.Location) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotLocations []db.Location
+	err = json.Unmarshal(data, &gotLocations)
+	require.NoError(t, err)
+	require.Equal(t, locations, gotLocations)
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+// dateLayout is the layout of the dates without a time of day, like the expiry dates of lots
+const dateLayout = "2006-01-02"
+
+var errInvalidLotDates = errors.New("a lot cannot expire before it is manufactured")
+
+type createLotRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type createLotRequestJson struct {
+	LotNumber      string `json:"lot_number" binding:"required"`
+	ManufacturedOn string `json:"manufactured_on" binding:"omitempty,datetime=2006-01-02"`
+	// lots without an expiry date are issued after the ones that expire
+	ExpiresOn string `json:"expires_on" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// parseDate parses an optional date that has already been validated by the binding
+func parseDate(value string) sql.NullTime {
+	date, err := time.Parse(dateLayout, value)
+	return sql.NullTime{
+		Time:  date,
+		Valid: value != "" && err == nil,
+	}
+}
+
+func (server *Server) createLot(c *gin.Context) {
+	var req createLotRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqCreate createLotRequestJson
+	if err := c.ShouldBindJSON(&reqCreate); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	manufacturedOn := parseDate(reqCreate.ManufacturedOn)
+	expiresOn := parseDate(reqCreate.ExpiresOn)
+	if manufacturedOn.Valid && expiresOn.Valid && expiresOn.Time.Before(manufacturedOn.Time) {
+		c.JSON(http.StatusBadRequest, errorResponse(errInvalidLotDates))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateLotTxParams{
+		CreateLotParams: db.CreateLotParams{
+			GoodID:         req.ID,
+			LotNumber:      reqCreate.LotNumber,
+			ManufacturedOn: manufacturedOn,
+			ExpiresOn:      expiresOn,
+		},
+		Actor: authPayload.Username,
+	}
+
+	lot, err := server.store.CreateLotTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, lot)
+}
+
+type listGoodLotRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listGoodLotRequestPage struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listGoodLot(c *gin.Context) {
+	var req listGoodLotRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqPage listGoodLotRequestPage
+	if err := c.ShouldBindQuery(&reqPage); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	arg := db.ListGoodLotsParams{
+		GoodID: req.ID,
+		Limit:  reqPage.PageSize,
+		Offset: (reqPage.PageID - 1) * reqPage.PageSize,
+	}
+	lots, err := server.store.ListGoodLots(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, lots)
+}
+
+type listExpiringLotRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	// lots expiring within this many days from today, expired lots included
+	Days        int   `form:"days" binding:"min=0,max=3650"`
+	WarehouseID int64 `form:"warehouse_id" binding:"omitempty,min=1"`
+	// required for scoped users
+	Category int64 `form:"category" binding:"omitempty,min=1"`
+}
+
+func (server *Server) listExpiringLot(c *gin.Context) {
+	var req listExpiringLotRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, req.Category > 0) {
+		return
+	}
+
+	if req.Category > 0 && !server.authorizeCategory(c, req.Category) {
+		return
+	}
+
+	today, _ := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	arg := db.ListExpiringLotsParams{
+		ExpiresOn: today.AddDate(0, 0, req.Days),
+		WarehouseID: sql.NullInt64{
+			Int64: req.WarehouseID,
+			Valid: req.WarehouseID > 0,
+		},
+		Category: sql.NullInt64{
+			Int64: req.Category,
+			Valid: req.Category > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	lots, err := server.store.ListExpiringLots(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, lots)
+}
@@ -0,0 +1,482 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateLot(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	lot := randomLot(good.ID)
+
+	body := gin.H{
+		"lot_number":      lot.LotNumber,
+		"manufactured_on": lot.ManufacturedOn.Time.Format(dateLayout),
+		"expires_on":      lot.ExpiresOn.Time.Format(dateLayout),
+	}
+	arg := db.CreateLotTxParams{
+		CreateLotParams: db.CreateLotParams{
+			GoodID:         good.ID,
+			LotNumber:      lot.LotNumber,
+			ManufacturedOn: lot.ManufacturedOn,
+			ExpiresOn:      lot.ExpiresOn,
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			body:   body,
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(lot, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchLot(t, recorder.Body, lot)
+			},
+		},
+		{
+			name:   "WithoutDates",
+			goodID: good.ID,
+			body:   gin.H{"lot_number": lot.LotNumber},
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateLotTxParams{
+					CreateLotParams: db.CreateLotParams{
+						GoodID:    good.ID,
+						LotNumber: lot.LotNumber,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Lot{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ExpiresBeforeManufactured",
+			goodID: good.ID,
+			body: gin.H{
+				"lot_number":      lot.LotNumber,
+				"manufactured_on": "2024-05-02",
+				"expires_on":      "2024-05-01",
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidDate",
+			goodID: good.ID,
+			body: gin.H{
+				"lot_number": lot.LotNumber,
+				"expires_on": "01/05/2024",
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "MissingLotNumber",
+			goodID: good.ID,
+			body:   gin.H{},
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DuplicateLotNumber",
+			goodID: good.ID,
+			body:   body,
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Lot{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			goodID: good.ID,
+			body:   body,
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Lot{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			goodID: 0,
+			body:   body,
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			body:   body,
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Lot{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "AuditorForbidden",
+			goodID: good.ID,
+			body:   body,
+			role:   db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateLotTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/lots", tc.goodID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListGoodLot(t *testing.T) {
+	good := randomGood()
+	n := 5
+	lots := make([]db.ListGoodLotsRow, n)
+	for i := range lots {
+		lot := randomLot(good.ID)
+		lots[i] = db.ListGoodLotsRow{
+			ID:             lot.ID,
+			GoodID:         lot.GoodID,
+			LotNumber:      lot.LotNumber,
+			ManufacturedOn: lot.ManufacturedOn,
+			ExpiresOn:      lot.ExpiresOn,
+			Amount:         util.RandomInt(0, 100),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			query:  fmt.Sprintf("page_id=2&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGoodLotsParams{
+					GoodID: good.ID,
+					Limit:  int32(n),
+					Offset: int32(n),
+				}
+				store.EXPECT().ListGoodLots(gomock.Any(), gomock.Eq(arg)).Times(1).Return(lots, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ListGoodLotsRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, lots, got)
+			},
+		},
+		{
+			name:   "OutOfScope",
+			goodID: good.ID,
+			query:  fmt.Sprintf("page_id=1&page_size=%d", n),
+			scope:  token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodLots(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidPageSize",
+			goodID: good.ID,
+			query:  "page_id=1&page_size=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodLots(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			query:  fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodLots(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/lots?%s", tc.goodID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListExpiringLot(t *testing.T) {
+	n := 5
+	lots := make([]db.ListExpiringLotsRow, n)
+	for i := range lots {
+		lot := randomLot(util.RandomInt(1, 1000))
+		lots[i] = db.ListExpiringLotsRow{
+			ID:             lot.ID,
+			GoodID:         lot.GoodID,
+			LotNumber:      lot.LotNumber,
+			ManufacturedOn: lot.ManufacturedOn,
+			ExpiresOn:      lot.ExpiresOn,
+			WarehouseID:    util.RandomInt(1, 1000),
+			Amount:         util.RandomInt(1, 100),
+		}
+	}
+	category := randomCategory()
+	warehouseID := util.RandomInt(1, 1000)
+	today, err := time.Parse(dateLayout, time.Now().Format(dateLayout))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d&days=30", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListExpiringLotsParams{
+					ExpiresOn: today.AddDate(0, 0, 30),
+					Limit:     int32(n),
+				}
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Eq(arg)).Times(1).Return(lots, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.ListExpiringLotsRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, n)
+			},
+		},
+		{
+			name:  "ExpiredOnly",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListExpiringLotsParams{
+					ExpiresOn: today,
+					Limit:     int32(n),
+				}
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.ListExpiringLotsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "WarehouseAndCategory",
+			query: fmt.Sprintf("page_id=2&page_size=%d&days=7&warehouse_id=%d&category=%d", n, warehouseID, category.ID),
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListExpiringLotsParams{
+					ExpiresOn:   today.AddDate(0, 0, 7),
+					WarehouseID: sql.NullInt64{Int64: warehouseID, Valid: true},
+					Category:    sql.NullInt64{Int64: category.ID, Valid: true},
+					Limit:       int32(n),
+					Offset:      int32(n),
+				}
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.ListExpiringLotsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "CategoryOutOfScope",
+			query: fmt.Sprintf("page_id=1&page_size=%d&category=%d", n, category.ID),
+			scope: token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedWithoutCategory",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDays",
+			query: fmt.Sprintf("page_id=1&page_size=%d&days=-1", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListExpiringLots(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/lots/expiring?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomLot(goodID int64) db.Lot {
+	manufacturedOn := time.Date(2024, time.Month(util.RandomInt(1, 12)), int(util.RandomInt(1, 28)), 0, 0, 0, 0, time.UTC)
+	return db.Lot{
+		ID:             util.RandomInt(1, 1000),
+		GoodID:         goodID,
+		LotNumber:      util.RandomString(8),
+		ManufacturedOn: sql.NullTime{Time: manufacturedOn, Valid: true},
+		ExpiresOn:      sql.NullTime{Time: manufacturedOn.AddDate(0, 0, int(util.RandomInt(1, 365))), Valid: true},
+	}
+}
+
+func requireBodyMatchLot(t *testing.T, body *bytes.Buffer, lot db.Lot) {
+	var gotLot db.Lot
+	require.NoError(t, json.Unmarshal(body.Bytes(), &gotLot))
+	require.Equal(t, lot.ID, gotLot.ID)
+	require.Equal(t, lot.GoodID, gotLot.GoodID)
+	require.Equal(t, lot.LotNumber, gotLot.LotNumber)
+	require.True(t, lot.ExpiresOn.Time.Equal(gotLot.ExpiresOn.Time))
+}
@@ -1,13 +1,33 @@
 package api
 
 import (
+	db "inventory_management/db/sqlc"
+	"inventory_management/util"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
 )
 
+// inventoryCurrency is the currency the stock of the test servers is valued in
+const inventoryCurrency = "USD"
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:   util.RandomString(32),
+		AccessTokenDuration: time.Minute,
+		InventoryCurrency:   inventoryCurrency,
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+
+	return server
+}
+
 func TestMain(m *testing.M) {
-	gin.SetMode(gin.TestMode)	
+	gin.SetMode(gin.TestMode)
 	os.Exit(m.Run())
 }
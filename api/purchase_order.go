@@ -0,0 +1,395 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// purchaseOrderLine adds the deviation of the delivery from the order to a line
+type purchaseOrderLine struct {
+	db.PurchaseOrderLine
+	// received beyond the ordered amount, in the unit of the line
+	OverDelivered int64 `json:"over_delivered"`
+	// still missing of the ordered amount, in the unit of the line
+	UnderDelivered int64 `json:"under_delivered"`
+}
+
+func newPurchaseOrderLine(line db.PurchaseOrderLine) purchaseOrderLine {
+	result := purchaseOrderLine{PurchaseOrderLine: line}
+	if line.Received > line.Ordered {
+		result.OverDelivered = line.Received - line.Ordered
+	} else {
+		result.UnderDelivered = line.Ordered - line.Received
+	}
+	return result
+}
+
+type purchaseOrderResponse struct {
+	db.PurchaseOrder
+	Lines []purchaseOrderLine `json:"lines"`
+}
+
+func newPurchaseOrderResponse(purchaseOrder db.PurchaseOrder, lines []db.PurchaseOrderLine) purchaseOrderResponse {
+	result := purchaseOrderResponse{
+		PurchaseOrder: purchaseOrder,
+		Lines:         make([]purchaseOrderLine, len(lines)),
+	}
+	for i, line := range lines {
+		result.Lines[i] = newPurchaseOrderLine(line)
+	}
+	return result
+}
+
+type purchaseOrderLineRequestJson struct {
+	GoodID int64 `json:"good_id" binding:"required,min=1"`
+	// unit the line is ordered in, defaults to the unit of the good
+	UnitID  int64 `json:"unit_id" binding:"omitempty,min=1"`
+	Ordered int64 `json:"ordered" binding:"required,gt=0"`
+	// price of one unit of the line in minor units of the currency of the supplier
+	UnitPrice int64 `json:"unit_price" binding:"min=0"`
+}
+
+func (line purchaseOrderLineRequestJson) params(purchaseOrderID int64) db.CreatePurchaseOrderLineParams {
+	return db.CreatePurchaseOrderLineParams{
+		PurchaseOrderID: purchaseOrderID,
+		GoodID:          line.GoodID,
+		UnitID:          line.UnitID,
+		Ordered:         line.Ordered,
+		UnitPrice:       line.UnitPrice,
+	}
+}
+
+type createPurchaseOrderRequest struct {
+	SupplierID  int64                          `json:"supplier_id" binding:"required,min=1"`
+	WarehouseID int64                          `json:"warehouse_id" binding:"required,min=1"`
+	Reference   string                         `json:"reference"`
+	Lines       []purchaseOrderLineRequestJson `json:"lines" binding:"required,min=1,dive"`
+}
+
+func (server *Server) createPurchaseOrder(c *gin.Context) {
+	var req createPurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lines := make([]db.CreatePurchaseOrderLineParams, len(req.Lines))
+	for i, line := range req.Lines {
+		if !server.authorizeGood(c, line.GoodID) {
+			return
+		}
+		lines[i] = line.params(0)
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: db.CreatePurchaseOrderParams{
+			SupplierID:  req.SupplierID,
+			WarehouseID: req.WarehouseID,
+			Reference:   req.Reference,
+		},
+		Lines: lines,
+		Actor: authPayload.Username,
+	}
+
+	result, err := server.store.CreatePurchaseOrderTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if isConversionError(err) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, newPurchaseOrderResponse(result.PurchaseOrder, result.Lines))
+}
+
+type purchaseOrderRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getPurchaseOrder(c *gin.Context) {
+	var req purchaseOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	purchaseOrder, err := server.store.GetPurchaseOrder(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.store.ListPurchaseOrderLines(c, req.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	goodIDs := make([]int64, len(lines))
+	for i, line := range lines {
+		goodIDs[i] = line.GoodID
+	}
+	if !server.authorizeGoods(c, goodIDs) {
+		return
+	}
+
+	c.JSON(http.StatusOK, newPurchaseOrderResponse(purchaseOrder, lines))
+}
+
+type listPurchaseOrderRequest struct {
+	Status     string `form:"status" binding:"omitempty,oneof=draft sent partially_received closed"`
+	SupplierID int64  `form:"supplier_id" binding:"omitempty,min=1"`
+	PageID     int32  `form:"page_id" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listPurchaseOrder(c *gin.Context) {
+	var req listPurchaseOrderRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListPurchaseOrdersParams{
+		Status: sql.NullString{
+			String: req.Status,
+			Valid:  req.Status != "",
+		},
+		SupplierID: sql.NullInt64{
+			Int64: req.SupplierID,
+			Valid: req.SupplierID > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	purchaseOrders, err := server.store.ListPurchaseOrders(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrders)
+}
+
+func (server *Server) addPurchaseOrderLine(c *gin.Context) {
+	var req purchaseOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqLine purchaseOrderLineRequestJson
+	if err := c.ShouldBindJSON(&reqLine); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, reqLine.GoodID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.AddPurchaseOrderLineTxParams{
+		CreatePurchaseOrderLineParams: reqLine.params(req.ID),
+		Actor:                         authPayload.Username,
+	}
+
+	line, err := server.store.AddPurchaseOrderLineTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPurchaseOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if isConversionError(err) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, newPurchaseOrderLine(line))
+}
+
+func (server *Server) sendPurchaseOrder(c *gin.Context) {
+	server.changePurchaseOrderStatus(c, server.store.SendPurchaseOrderTx)
+}
+
+func (server *Server) closePurchaseOrder(c *gin.Context) {
+	server.changePurchaseOrderStatus(c, server.store.ClosePurchaseOrderTx)
+}
+
+// changePurchaseOrderStatus runs one of the transactions moving a purchase order to its next status
+func (server *Server) changePurchaseOrderStatus(
+	c *gin.Context,
+	change func(ctx context.Context, arg db.PurchaseOrderStatusTxParams) (db.PurchaseOrder, error),
+) {
+	var req purchaseOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.PurchaseOrderStatusTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	purchaseOrder, err := change(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPurchaseOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, purchaseOrder)
+}
+
+type receivePurchaseOrderLineJson struct {
+	LineID int64 `json:"line_id" binding:"required,min=1"`
+	// in the unit of the line
+	Amount     int64 `json:"amount" binding:"required,gt=0"`
+	LocationID int64 `json:"location_id" binding:"omitempty,min=1"`
+	// one per unit of a serialized good
+	Serials []string `json:"serials" binding:"omitempty,dive,required"`
+	// cost of one unit of the line in the inventory currency, receipts without one are valued at the price of
+	// the line when the supplier is paid in the inventory currency and at the average cost of the good otherwise
+	UnitCost *int64 `json:"unit_cost" binding:"omitempty,min=0"`
+}
+
+type receivePurchaseOrderRequestJson struct {
+	Lines []receivePurchaseOrderLineJson `json:"lines" binding:"required,min=1,dive"`
+}
+
+type receivePurchaseOrderResponse struct {
+	purchaseOrderResponse
+	Movements []db.StockMovement `json:"movements"`
+}
+
+func (server *Server) receivePurchaseOrder(c *gin.Context) {
+	var req purchaseOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqReceive receivePurchaseOrderRequestJson
+	if err := c.ShouldBindJSON(&reqReceive); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	receipts := make([]db.PurchaseOrderReceipt, len(reqReceive.Lines))
+	for i, line := range reqReceive.Lines {
+		receipts[i] = db.PurchaseOrderReceipt{
+			LineID: line.LineID,
+			Amount: line.Amount,
+			LocationID: sql.NullInt64{
+				Int64: line.LocationID,
+				Valid: line.LocationID > 0,
+			},
+			Serials: line.Serials,
+		}
+		if line.UnitCost != nil {
+			receipts[i].UnitCost = sql.NullInt64{Int64: *line.UnitCost, Valid: true}
+		}
+	}
+
+	if !server.authorizePurchaseOrderReceipts(c, req.ID, receipts) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ReceivePurchaseOrderTxParams{
+		ID:       req.ID,
+		Receipts: receipts,
+		Currency: server.config.InventoryCurrency,
+		Actor:    authPayload.Username,
+	}
+
+	result, err := server.store.ReceivePurchaseOrderTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPurchaseOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidPurchaseOrderLine) || errors.Is(err, db.ErrInvalidLocation) ||
+			errors.Is(err, db.ErrSerialMismatch) || isConversionError(err) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, receivePurchaseOrderResponse{
+		purchaseOrderResponse: newPurchaseOrderResponse(result.PurchaseOrder, result.Lines),
+		Movements:             result.Movements,
+	})
+}
+
+// authorizePurchaseOrderReceipts checks the scope of the user against the goods of the received lines
+func (server *Server) authorizePurchaseOrderReceipts(c *gin.Context, purchaseOrderID int64, receipts []db.PurchaseOrderReceipt) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+
+	lines, err := server.store.ListPurchaseOrderLines(c, purchaseOrderID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	received := make(map[int64]bool, len(receipts))
+	for _, receipt := range receipts {
+		received[receipt.LineID] = true
+	}
+	for _, line := range lines {
+		if received[line.ID] && !server.authorizeGood(c, line.GoodID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,956 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	purchaseOrder := randomPurchaseOrder(db.PurchaseOrderStatusDraft)
+	line := randomPurchaseOrderLine(purchaseOrder.ID, good)
+
+	body := gin.H{
+		"supplier_id":  purchaseOrder.SupplierID,
+		"warehouse_id": purchaseOrder.WarehouseID,
+		"reference":    purchaseOrder.Reference,
+		"lines": []gin.H{
+			{
+				"good_id":    line.GoodID,
+				"unit_id":    line.UnitID,
+				"ordered":    line.Ordered,
+				"unit_price": line.UnitPrice,
+			},
+		},
+	}
+	arg := db.CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: db.CreatePurchaseOrderParams{
+			SupplierID:  purchaseOrder.SupplierID,
+			WarehouseID: purchaseOrder.WarehouseID,
+			Reference:   purchaseOrder.Reference,
+		},
+		Lines: []db.CreatePurchaseOrderLineParams{
+			{
+				GoodID:    line.GoodID,
+				UnitID:    line.UnitID,
+				Ordered:   line.Ordered,
+				UnitPrice: line.UnitPrice,
+			},
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.PurchaseOrderTxResult{
+					PurchaseOrder: purchaseOrder,
+					Lines:         []db.PurchaseOrderLine{line},
+				}
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				got := requireBodyPurchaseOrder(t, recorder.Body)
+				require.Equal(t, purchaseOrder, got.PurchaseOrder)
+				require.Len(t, got.Lines, 1)
+				require.Equal(t, line, got.Lines[0].PurchaseOrderLine)
+				require.Equal(t, line.Ordered, got.Lines[0].UnderDelivered)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "GoodOutOfScope",
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "IncompatibleUnit",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderTxResult{}, db.ErrIncompatibleUnits)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "NoLines",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"supplier_id":  purchaseOrder.SupplierID,
+				"warehouse_id": purchaseOrder.WarehouseID,
+				"lines":        []gin.H{},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLine",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"supplier_id":  purchaseOrder.SupplierID,
+				"warehouse_id": purchaseOrder.WarehouseID,
+				"lines": []gin.H{
+					{
+						"good_id": line.GoodID,
+						"ordered": 0,
+					},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/purchase-orders", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetPurchaseOrder(t *testing.T) {
+	purchaseOrder := randomPurchaseOrder(db.PurchaseOrderStatusPartiallyReceived)
+	over := randomPurchaseOrderLine(purchaseOrder.ID, randomGood())
+	over.Received = over.Ordered + 2
+	good := randomGood()
+	under := randomPurchaseOrderLine(purchaseOrder.ID, good)
+	under.Received = under.Ordered - 1
+	lines := []db.PurchaseOrderLine{over, under}
+
+	testCases := []struct {
+		name            string
+		purchaseOrderID int64
+		scope           token.Scope
+		buildStubs      func(store *mockdb.MockStore)
+		checkResponse   func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:            "OK",
+			purchaseOrderID: purchaseOrder.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(purchaseOrder, nil)
+				store.EXPECT().ListPurchaseOrderLines(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(lines, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				got := requireBodyPurchaseOrder(t, recorder.Body)
+				require.Equal(t, purchaseOrder, got.PurchaseOrder)
+				require.Len(t, got.Lines, 2)
+				require.Equal(t, int64(2), got.Lines[0].OverDelivered)
+				require.Zero(t, got.Lines[0].UnderDelivered)
+				require.Zero(t, got.Lines[1].OverDelivered)
+				require.Equal(t, int64(1), got.Lines[1].UnderDelivered)
+			},
+		},
+		{
+			name:            "OutOfScope",
+			purchaseOrderID: purchaseOrder.ID,
+			scope:           token.Scope{Categories: []int64{good.Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				overGood := randomGood()
+				overGood.ID = over.GoodID
+				overGood.Category = good.Category + 1
+
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(purchaseOrder, nil)
+				store.EXPECT().ListPurchaseOrderLines(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(lines, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(over.GoodID)).Times(1).Return(overGood, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:            "NotFound",
+			purchaseOrderID: purchaseOrder.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(db.PurchaseOrder{}, sql.ErrNoRows)
+				store.EXPECT().ListPurchaseOrderLines(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:            "InternalError",
+			purchaseOrderID: purchaseOrder.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(purchaseOrder, nil)
+				store.EXPECT().ListPurchaseOrderLines(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:            "InvalidID",
+			purchaseOrderID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPurchaseOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/purchase-orders/%d", tc.purchaseOrderID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListPurchaseOrder(t *testing.T) {
+	n := 5
+	purchaseOrders := make([]db.PurchaseOrder, n)
+	for i := 0; i < n; i++ {
+		purchaseOrders[i] = randomPurchaseOrder(db.PurchaseOrderStatusSent)
+	}
+	supplierID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("status=sent&supplier_id=%d&page_id=1&page_size=%d", supplierID, n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListPurchaseOrdersParams{
+					Status:     sql.NullString{String: db.PurchaseOrderStatusSent, Valid: true},
+					SupplierID: sql.NullInt64{Int64: supplierID, Valid: true},
+					Limit:      int32(n),
+					Offset:     0,
+				}
+				store.EXPECT().ListPurchaseOrders(gomock.Any(), gomock.Eq(arg)).Times(1).Return(purchaseOrders, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.PurchaseOrder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, purchaseOrders, got)
+			},
+		},
+		{
+			name:  "NoFilter",
+			query: fmt.Sprintf("page_id=2&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListPurchaseOrdersParams{
+					Limit:  int32(n),
+					Offset: int32(n),
+				}
+				store.EXPECT().ListPurchaseOrders(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.PurchaseOrder{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: "status=lost&page_id=1&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPurchaseOrders(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: "page_id=1&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPurchaseOrders(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/purchase-orders?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAddPurchaseOrderLine(t *testing.T) {
+	actor := util.RandomName()
+	purchaseOrder := randomPurchaseOrder(db.PurchaseOrderStatusDraft)
+	line := randomPurchaseOrderLine(purchaseOrder.ID, randomGood())
+
+	body := gin.H{
+		"good_id":    line.GoodID,
+		"ordered":    line.Ordered,
+		"unit_price": line.UnitPrice,
+	}
+	arg := db.AddPurchaseOrderLineTxParams{
+		CreatePurchaseOrderLineParams: db.CreatePurchaseOrderLineParams{
+			PurchaseOrderID: purchaseOrder.ID,
+			GoodID:          line.GoodID,
+			Ordered:         line.Ordered,
+			UnitPrice:       line.UnitPrice,
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddPurchaseOrderLineTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(line, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got purchaseOrderLine
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, line, got.PurchaseOrderLine)
+			},
+		},
+		{
+			name: "NotDraft",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddPurchaseOrderLineTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderLine{}, db.ErrPurchaseOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddPurchaseOrderLineTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderLine{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "FractionalAmount",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddPurchaseOrderLineTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrderLine{}, db.ErrFractionalAmount)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeUnitPrice",
+			body: gin.H{
+				"good_id":    line.GoodID,
+				"ordered":    line.Ordered,
+				"unit_price": -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AddPurchaseOrderLineTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/purchase-orders/%d/lines", purchaseOrder.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestChangePurchaseOrderStatus(t *testing.T) {
+	actor := util.RandomName()
+	purchaseOrder := randomPurchaseOrder(db.PurchaseOrderStatusSent)
+	arg := db.PurchaseOrderStatusTxParams{ID: purchaseOrder.ID, Actor: actor}
+
+	testCases := []struct {
+		name          string
+		action        string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "SendOK",
+			action: "send",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SendPurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(purchaseOrder, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.PurchaseOrder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, purchaseOrder, got)
+			},
+		},
+		{
+			name:   "SendNotDraft",
+			action: "send",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SendPurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrder{}, db.ErrPurchaseOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "SendClerkForbidden",
+			action: "send",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SendPurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "CloseOK",
+			action: "close",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClosePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(purchaseOrder, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "CloseNotFound",
+			action: "close",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClosePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrder{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "CloseInternalError",
+			action: "close",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ClosePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.PurchaseOrder{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/purchase-orders/%d/%s", purchaseOrder.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReceivePurchaseOrder(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	purchaseOrder := randomPurchaseOrder(db.PurchaseOrderStatusClosed)
+	line := randomPurchaseOrderLine(purchaseOrder.ID, good)
+	locationID := util.RandomInt(1, 1000)
+
+	body := gin.H{
+		"lines": []gin.H{
+			{
+				"line_id":     line.ID,
+				"amount":      line.Ordered,
+				"location_id": locationID,
+			},
+		},
+	}
+	arg := db.ReceivePurchaseOrderTxParams{
+		ID: purchaseOrder.ID,
+		Receipts: []db.PurchaseOrderReceipt{
+			{
+				LineID:     line.ID,
+				Amount:     line.Ordered,
+				LocationID: sql.NullInt64{Int64: locationID, Valid: true},
+			},
+		},
+		Currency: inventoryCurrency,
+		Actor:    actor,
+	}
+	received := line
+	received.Received = line.Ordered
+	result := db.ReceivePurchaseOrderTxResult{
+		PurchaseOrderTxResult: db.PurchaseOrderTxResult{
+			PurchaseOrder: purchaseOrder,
+			Lines:         []db.PurchaseOrderLine{received},
+		},
+		Movements: []db.StockMovement{randomStockMovement(good)},
+	}
+
+	serials := []string{util.RandomString(8), util.RandomString(8)}
+	serialBody := gin.H{
+		"lines": []gin.H{
+			{
+				"line_id": line.ID,
+				"amount":  len(serials),
+				"serials": serials,
+			},
+		},
+	}
+	serialArg := db.ReceivePurchaseOrderTxParams{
+		ID:       purchaseOrder.ID,
+		Receipts: []db.PurchaseOrderReceipt{{LineID: line.ID, Amount: int64(len(serials)), Serials: serials}},
+		Currency: inventoryCurrency,
+		Actor:    actor,
+	}
+
+	unitCost := util.RandomInt(1, 1000)
+	unitCostBody := gin.H{
+		"lines": []gin.H{
+			{
+				"line_id":   line.ID,
+				"amount":    line.Ordered,
+				"unit_cost": unitCost,
+			},
+		},
+	}
+	unitCostArg := db.ReceivePurchaseOrderTxParams{
+		ID:       purchaseOrder.ID,
+		Receipts: []db.PurchaseOrderReceipt{{LineID: line.ID, Amount: line.Ordered, UnitCost: sql.NullInt64{Int64: unitCost, Valid: true}}},
+		Currency: inventoryCurrency,
+		Actor:    actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got receivePurchaseOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.PurchaseOrderStatusClosed, got.Status)
+				require.Len(t, got.Lines, 1)
+				require.Zero(t, got.Lines[0].UnderDelivered)
+				require.Zero(t, got.Lines[0].OverDelivered)
+				require.Len(t, got.Movements, 1)
+			},
+		},
+		{
+			name:  "InScope",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPurchaseOrderLines(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return([]db.PurchaseOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListPurchaseOrderLines(gomock.Any(), gomock.Eq(purchaseOrder.ID)).Times(1).Return([]db.PurchaseOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AuditorForbidden",
+			role: db.RoleAuditor,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotSent",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, db.ErrPurchaseOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ForeignLine",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, db.ErrInvalidPurchaseOrderLine)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLocation",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, db.ErrInvalidLocation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnitCost",
+			role: db.RoleWarehouseManager,
+			body: unitCostBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(unitCostArg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeUnitCost",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"lines": []gin.H{
+					{
+						"line_id":   line.ID,
+						"amount":    line.Ordered,
+						"unit_cost": -1,
+					},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ValueOverflow",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, db.ErrAmountOverflow)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SerialMismatch",
+			role: db.RoleWarehouseManager,
+			body: serialBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(serialArg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ReceivePurchaseOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAmount",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"lines": []gin.H{
+					{
+						"line_id": line.ID,
+						"amount":  -1,
+					},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoLines",
+			role: db.RoleWarehouseManager,
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceivePurchaseOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/purchase-orders/%d/receive", purchaseOrder.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomPurchaseOrder(status string) db.PurchaseOrder {
+	return db.PurchaseOrder{
+		ID:          util.RandomInt(1, 1000),
+		SupplierID:  util.RandomInt(1, 1000),
+		WarehouseID: util.RandomInt(1, 1000),
+		Status:      status,
+		Reference:   util.RandomString(8),
+	}
+}
+
+func randomPurchaseOrderLine(purchaseOrderID int64, good db.Good) db.PurchaseOrderLine {
+	return db.PurchaseOrderLine{
+		ID:              util.RandomInt(1, 1000),
+		PurchaseOrderID: purchaseOrderID,
+		GoodID:          good.ID,
+		UnitID:          good.Unit,
+		Ordered:         util.RandomInt(2, 100),
+		UnitPrice:       util.RandomInt(0, 10000),
+	}
+}
+
+func requireBodyPurchaseOrder(t *testing.T, body *bytes.Buffer) purchaseOrderResponse {
+	var got purchaseOrderResponse
+	require.NoError(t, json.Unmarshal(body.Bytes(), &got))
+	return got
+}
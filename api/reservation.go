@@ -0,0 +1,224 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errExpiredReservation = errors.New("expires_at must be in the future")
+
+type reservationGoodRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type createReservationRequestJson struct {
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+	// unit the amount is given in, defaults to the unit of the good
+	AmountUnit int64     `json:"amount_unit" binding:"omitempty,min=1"`
+	Reference  string    `json:"reference"`
+	ExpiresAt  time.Time `json:"expires_at" binding:"required"`
+	// optional serial numbers of the units of a serialized good to hold
+	Serials []string `json:"serials" binding:"omitempty,dive,required"`
+}
+
+func (server *Server) createReservation(c *gin.Context) {
+	var req reservationGoodRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqReservation createReservationRequestJson
+	if err := c.ShouldBindJSON(&reqReservation); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !reqReservation.ExpiresAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, errorResponse(errExpiredReservation))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateReservationTxParams{
+		CreateReservationParams: db.CreateReservationParams{
+			GoodID:    req.ID,
+			Amount:    reqReservation.Amount,
+			Reference: reqReservation.Reference,
+			ExpiresAt: reqReservation.ExpiresAt,
+		},
+		AmountUnit: reqReservation.AmountUnit,
+		Serials:    reqReservation.Serials,
+		Actor:      authPayload.Username,
+	}
+
+	reservation, err := server.store.CreateReservationTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if isConversionError(err) || errors.Is(err, db.ErrSerialMismatch) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+type listReservationRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=active released expired"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listReservation(c *gin.Context) {
+	var req reservationGoodRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqList listReservationRequest
+	if err := c.ShouldBindQuery(&reqList); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	arg := db.ListReservationsParams{
+		GoodID: req.ID,
+		Status: sql.NullString{
+			String: reqList.Status,
+			Valid:  reqList.Status != "",
+		},
+		Limit:  reqList.PageSize,
+		Offset: (reqList.PageID - 1) * reqList.PageSize,
+	}
+	reservations, err := server.store.ListReservations(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
+
+type getReservationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getReservation(c *gin.Context) {
+	var req getReservationRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reservation, err := server.store.GetReservation(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, reservation.GoodID) {
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+type releaseReservationRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) releaseReservation(c *gin.Context) {
+	var req releaseReservationRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	reservation, err := server.store.GetReservation(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, reservation.GoodID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ReleaseReservationTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	reservation, err = server.store.ReleaseReservationTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrReservationClosed) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// expireReservations expires every overdue reservation and responds with the reservations it expired
+func (server *Server) expireReservations(c *gin.Context) {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ExpireReservationsTxParams{
+		Now:   time.Now(),
+		Actor: authPayload.Username,
+	}
+
+	reservations, err := server.store.ExpireReservationsTx(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
@@ -0,0 +1,457 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReservation(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	reservation := randomReservation(good)
+	unitID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":      reservation.Amount,
+				"amount_unit": unitID,
+				"reference":   reservation.Reference,
+				"expires_at":  reservation.ExpiresAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateReservationTxParams{
+					CreateReservationParams: db.CreateReservationParams{
+						GoodID:    good.ID,
+						Amount:    reservation.Amount,
+						Reference: reservation.Reference,
+						ExpiresAt: reservation.ExpiresAt,
+					},
+					AmountUnit: unitID,
+					Actor:      actor,
+				}
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(reservation, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReservation(t, recorder.Body, reservation)
+			},
+		},
+		{
+			name:   "SerialMismatch",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":     reservation.Amount,
+				"expires_at": reservation.ExpiresAt,
+				"serials":    []string{util.RandomString(8)},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Reservation{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InsufficientStock",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":     reservation.Amount,
+				"expires_at": reservation.ExpiresAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Reservation{}, db.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":     reservation.Amount,
+				"expires_at": reservation.ExpiresAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Reservation{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":     reservation.Amount,
+				"expires_at": reservation.ExpiresAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Reservation{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "ExpiresInThePast",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":     reservation.Amount,
+				"expires_at": time.Now().Add(-time.Minute),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidAmount",
+			goodID: good.ID,
+			body: gin.H{
+				"amount":     0,
+				"expires_at": reservation.ExpiresAt,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateReservationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/reservations", tc.goodID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListReservation(t *testing.T) {
+	good := randomGood()
+
+	n := 5
+	reservations := make([]db.Reservation, n)
+	for i := 0; i < n; i++ {
+		reservations[i] = randomReservation(good)
+	}
+
+	testCases := []struct {
+		name          string
+		query         map[string]string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: map[string]string{
+				"status":    db.ReservationStatusActive,
+				"page_id":   "1",
+				"page_size": fmt.Sprint(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListReservationsParams{
+					GoodID: good.ID,
+					Status: sql.NullString{String: db.ReservationStatusActive, Valid: true},
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().ListReservations(gomock.Any(), gomock.Eq(arg)).Times(1).Return(reservations, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReservations(t, recorder.Body, reservations)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			query: map[string]string{
+				"status":    "pending",
+				"page_id":   "1",
+				"page_size": fmt.Sprint(n),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListReservations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/reservations", good.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			q := request.URL.Query()
+			for key, value := range tc.query {
+				q.Add(key, value)
+			}
+			request.URL.RawQuery = q.Encode()
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReleaseReservation(t *testing.T) {
+	actor := util.RandomName()
+	reservation := randomReservation(randomGood())
+
+	released := reservation
+	released.Status = db.ReservationStatusReleased
+	released.ClosedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	testCases := []struct {
+		name          string
+		reservationID int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:          "OK",
+			reservationID: reservation.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReservation(gomock.Any(), gomock.Eq(reservation.ID)).Times(1).Return(reservation, nil)
+
+				arg := db.ReleaseReservationTxParams{
+					ID:    reservation.ID,
+					Actor: actor,
+				}
+				store.EXPECT().ReleaseReservationTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(released, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReservation(t, recorder.Body, released)
+			},
+		},
+		{
+			name:          "Closed",
+			reservationID: reservation.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReservation(gomock.Any(), gomock.Eq(reservation.ID)).Times(1).Return(released, nil)
+				store.EXPECT().ReleaseReservationTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Reservation{}, db.ErrReservationClosed)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:          "NotFound",
+			reservationID: reservation.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReservation(gomock.Any(), gomock.Eq(reservation.ID)).Times(1).Return(db.Reservation{}, sql.ErrNoRows)
+				store.EXPECT().ReleaseReservationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:          "InvalidID",
+			reservationID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetReservation(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ReleaseReservationTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/reservations/%d/release", tc.reservationID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExpireReservations(t *testing.T) {
+	actor := util.RandomName()
+	expired := []db.Reservation{randomReservation(randomGood())}
+	expired[0].Status = db.ReservationStatusExpired
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExpireReservationsTx(gomock.Any(), gomock.Any()).Times(1).
+					DoAndReturn(func(_ interface{}, arg db.ExpireReservationsTxParams) ([]db.Reservation, error) {
+						require.Equal(t, actor, arg.Actor)
+						require.WithinDuration(t, time.Now(), arg.Now, time.Second)
+						return expired, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchReservations(t, recorder.Body, expired)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExpireReservationsTx(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "Forbidden",
+			role: db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ExpireReservationsTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/reservations/expire", nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomReservation(good db.Good) db.Reservation {
+	return db.Reservation{
+		ID:        util.RandomInt(1, 1000),
+		GoodID:    good.ID,
+		Amount:    util.RandomInt(1, 5),
+		Status:    db.ReservationStatusActive,
+		Reference: util.RandomName(),
+		ExpiresAt: time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+	}
+}
+
+func requireBodyMatchReservation(t *testing.T, body *bytes.Buffer, reservation db.Reservation) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotReservation db.Reservation
+	err = json.Unmarshal(data, &gotReservation)
+	require.NoError(t, err)
+	require.Equal(t, reservation.ID, gotReservation.ID)
+	require.Equal(t, reservation.Status, gotReservation.Status)
+	require.WithinDuration(t, reservation.ExpiresAt, gotReservation.ExpiresAt, time.Second)
+	require.Equal(t, reservation.ClosedAt.Valid, gotReservation.ClosedAt.Valid)
+}
+
+func requireBodyMatchReservations(t *testing.T, body *bytes.Buffer, reservations []db.Reservation) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotReservations []db.Reservation
+	err = json.Unmarshal(data, &gotReservations)
+	require.NoError(t, err)
+	require.Len(t, gotReservations, len(reservations))
+	for i := range reservations {
+		require.Equal(t, reservations[i].ID, gotReservations[i].ID)
+		require.Equal(t, reservations[i].Status, gotReservations[i].Status)
+	}
+}
@@ -0,0 +1,342 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type salesOrderResponse struct {
+	db.SalesOrder
+	Lines []db.SalesOrderLine `json:"lines"`
+}
+
+type salesOrderLineRequestJson struct {
+	GoodID int64 `json:"good_id" binding:"required,min=1"`
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+	// unit the amount is given in, defaults to the unit of the good
+	AmountUnit int64 `json:"amount_unit" binding:"omitempty,min=1"`
+}
+
+type createSalesOrderRequest struct {
+	CustomerName string                      `json:"customer_name" binding:"required"`
+	WarehouseID  int64                       `json:"warehouse_id" binding:"required,min=1"`
+	Reference    string                      `json:"reference"`
+	Lines        []salesOrderLineRequestJson `json:"lines" binding:"required,min=1,dive"`
+}
+
+func (server *Server) createSalesOrder(c *gin.Context) {
+	var req createSalesOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lines := make([]db.SalesOrderLineParams, len(req.Lines))
+	for i, line := range req.Lines {
+		if !server.authorizeGood(c, line.GoodID) {
+			return
+		}
+		lines[i] = db.SalesOrderLineParams{
+			GoodID:     line.GoodID,
+			Amount:     line.Amount,
+			AmountUnit: line.AmountUnit,
+		}
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateSalesOrderTxParams{
+		CreateSalesOrderParams: db.CreateSalesOrderParams{
+			CustomerName: req.CustomerName,
+			WarehouseID:  req.WarehouseID,
+			Reference:    req.Reference,
+		},
+		Lines: lines,
+		Actor: authPayload.Username,
+	}
+
+	result, err := server.store.CreateSalesOrderTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if isConversionError(err) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, salesOrderResponse{SalesOrder: result.SalesOrder, Lines: result.Lines})
+}
+
+type salesOrderRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getSalesOrder(c *gin.Context) {
+	var req salesOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	salesOrder, err := server.store.GetSalesOrder(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.store.ListSalesOrderLines(c, req.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	goodIDs := make([]int64, len(lines))
+	for i, line := range lines {
+		goodIDs[i] = line.GoodID
+	}
+	if !server.authorizeGoods(c, goodIDs) {
+		return
+	}
+
+	c.JSON(http.StatusOK, salesOrderResponse{SalesOrder: salesOrder, Lines: lines})
+}
+
+type listSalesOrderRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=open allocated shipped cancelled"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+	// orders with a line of a good of the category, required for scoped users
+	Category int64 `form:"category" binding:"omitempty,min=1"`
+}
+
+func (server *Server) listSalesOrder(c *gin.Context) {
+	var req listSalesOrderRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, req.Category > 0) {
+		return
+	}
+
+	if req.Category > 0 && !server.authorizeCategory(c, req.Category) {
+		return
+	}
+
+	arg := db.ListSalesOrdersParams{
+		Status: sql.NullString{
+			String: req.Status,
+			Valid:  req.Status != "",
+		},
+		Category: sql.NullInt64{
+			Int64: req.Category,
+			Valid: req.Category > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	salesOrders, err := server.store.ListSalesOrders(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, salesOrders)
+}
+
+func (server *Server) allocateSalesOrder(c *gin.Context) {
+	server.changeSalesOrderStatus(c, server.store.AllocateSalesOrderTx)
+}
+
+func (server *Server) cancelSalesOrder(c *gin.Context) {
+	server.changeSalesOrderStatus(c, server.store.CancelSalesOrderTx)
+}
+
+// changeSalesOrderStatus runs one of the transactions allocating or releasing the stock of a sales order
+func (server *Server) changeSalesOrderStatus(
+	c *gin.Context,
+	change func(ctx context.Context, arg db.SalesOrderStatusTxParams) (db.SalesOrderTxResult, error),
+) {
+	var req salesOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.SalesOrderStatusTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	result, err := change(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSalesOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, salesOrderResponse{SalesOrder: result.SalesOrder, Lines: result.Lines})
+}
+
+func (server *Server) getSalesOrderPickList(c *gin.Context) {
+	var req salesOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeSalesOrderLines(c, req.ID) {
+		return
+	}
+
+	pickList, err := server.store.GetPickListTx(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSalesOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, pickList)
+}
+
+type salesOrderPickJson struct {
+	LineID int64 `json:"line_id" binding:"required,min=1"`
+	// in the unit of the good
+	Amount     int64 `json:"amount" binding:"required,gt=0"`
+	LocationID int64 `json:"location_id" binding:"omitempty,min=1"`
+	// one per unit of a serialized good
+	Serials []string `json:"serials" binding:"omitempty,dive,required"`
+}
+
+type shipSalesOrderRequestJson struct {
+	Picks []salesOrderPickJson `json:"picks" binding:"required,min=1,dive"`
+}
+
+type shipSalesOrderResponse struct {
+	salesOrderResponse
+	Movements []db.StockMovement `json:"movements"`
+}
+
+func (server *Server) shipSalesOrder(c *gin.Context) {
+	var req salesOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqShip shipSalesOrderRequestJson
+	if err := c.ShouldBindJSON(&reqShip); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	picks := make([]db.SalesOrderPick, len(reqShip.Picks))
+	for i, pick := range reqShip.Picks {
+		picks[i] = db.SalesOrderPick{
+			LineID: pick.LineID,
+			Amount: pick.Amount,
+			LocationID: sql.NullInt64{
+				Int64: pick.LocationID,
+				Valid: pick.LocationID > 0,
+			},
+			Serials: pick.Serials,
+		}
+	}
+
+	if !server.authorizeSalesOrderLines(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.ShipSalesOrderTxParams{
+		ID:    req.ID,
+		Picks: picks,
+		Actor: authPayload.Username,
+	}
+
+	result, err := server.store.ShipSalesOrderTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSalesOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidPick) || errors.Is(err, db.ErrInvalidLocation) || errors.Is(err, db.ErrSerialMismatch) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, shipSalesOrderResponse{
+		salesOrderResponse: salesOrderResponse{SalesOrder: result.SalesOrder, Lines: result.Lines},
+		Movements:          result.Movements,
+	})
+}
+
+// authorizeSalesOrderLines checks the scope of the user against the goods of all lines of a sales order
+func (server *Server) authorizeSalesOrderLines(c *gin.Context, salesOrderID int64) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+
+	lines, err := server.store.ListSalesOrderLines(c, salesOrderID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	for _, line := range lines {
+		if !server.authorizeGood(c, line.GoodID) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,884 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSalesOrder(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	salesOrder := randomSalesOrder(db.SalesOrderStatusOpen)
+	line := randomSalesOrderLine(salesOrder.ID, good)
+
+	body := gin.H{
+		"customer_name": salesOrder.CustomerName,
+		"warehouse_id":  salesOrder.WarehouseID,
+		"reference":     salesOrder.Reference,
+		"lines": []gin.H{
+			{
+				"good_id": line.GoodID,
+				"amount":  line.Amount,
+			},
+		},
+	}
+	arg := db.CreateSalesOrderTxParams{
+		CreateSalesOrderParams: db.CreateSalesOrderParams{
+			CustomerName: salesOrder.CustomerName,
+			WarehouseID:  salesOrder.WarehouseID,
+			Reference:    salesOrder.Reference,
+		},
+		Lines: []db.SalesOrderLineParams{
+			{
+				GoodID: line.GoodID,
+				Amount: line.Amount,
+			},
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.SalesOrderTxResult{
+					SalesOrder: salesOrder,
+					Lines:      []db.SalesOrderLine{line},
+				}
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				got := requireBodySalesOrder(t, recorder.Body)
+				require.Equal(t, salesOrder, got.SalesOrder)
+				require.Equal(t, []db.SalesOrderLine{line}, got.Lines)
+			},
+		},
+		{
+			name: "AuditorForbidden",
+			role: db.RoleAuditor,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "GoodOutOfScope",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SalesOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "IncompatibleUnit",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SalesOrderTxResult{}, db.ErrIncompatibleUnits)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SalesOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "NoCustomer",
+			role: db.RoleClerk,
+			body: gin.H{
+				"warehouse_id": salesOrder.WarehouseID,
+				"lines":        body["lines"],
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidLine",
+			role: db.RoleClerk,
+			body: gin.H{
+				"customer_name": salesOrder.CustomerName,
+				"warehouse_id":  salesOrder.WarehouseID,
+				"lines": []gin.H{
+					{
+						"good_id": line.GoodID,
+						"amount":  0,
+					},
+				},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/sales-orders", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetSalesOrder(t *testing.T) {
+	good := randomGood()
+	salesOrder := randomSalesOrder(db.SalesOrderStatusAllocated)
+	line := randomSalesOrderLine(salesOrder.ID, good)
+
+	testCases := []struct {
+		name          string
+		id            int64
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   salesOrder.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSalesOrder(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(salesOrder, nil)
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return([]db.SalesOrderLine{line}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				got := requireBodySalesOrder(t, recorder.Body)
+				require.Equal(t, salesOrder, got.SalesOrder)
+				require.Equal(t, []db.SalesOrderLine{line}, got.Lines)
+			},
+		},
+		{
+			name:  "InScope",
+			id:    salesOrder.ID,
+			scope: token.Scope{Categories: []int64{good.Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSalesOrder(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(salesOrder, nil)
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return([]db.SalesOrderLine{line, line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			id:    salesOrder.ID,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSalesOrder(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(salesOrder, nil)
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return([]db.SalesOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			id:   salesOrder.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSalesOrder(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(db.SalesOrder{}, sql.ErrNoRows)
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			id:   salesOrder.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSalesOrder(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(salesOrder, nil)
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidID",
+			id:   0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSalesOrder(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/sales-orders/%d", tc.id)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListSalesOrder(t *testing.T) {
+	n := 5
+	salesOrders := make([]db.SalesOrder, n)
+	for i := range salesOrders {
+		salesOrders[i] = randomSalesOrder(db.SalesOrderStatusOpen)
+	}
+	category := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=open", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListSalesOrdersParams{
+					Status: sql.NullString{String: db.SalesOrderStatusOpen, Valid: true},
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().ListSalesOrders(gomock.Any(), gomock.Eq(arg)).Times(1).Return(salesOrders, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.SalesOrder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, salesOrders, got)
+			},
+		},
+		{
+			name:  "Category",
+			query: fmt.Sprintf("page_id=1&page_size=%d&category=%d", n, category),
+			scope: token.Scope{Categories: []int64{category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListSalesOrdersParams{
+					Category: sql.NullInt64{Int64: category, Valid: true},
+					Limit:    int32(n),
+					Offset:   0,
+				}
+				store.EXPECT().ListSalesOrders(gomock.Any(), gomock.Eq(arg)).Times(1).Return(salesOrders, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "CategoryOutOfScope",
+			query: fmt.Sprintf("page_id=1&page_size=%d&category=%d", n, category),
+			scope: token.Scope{Categories: []int64{category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrders(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedWithoutCategory",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			scope: token.Scope{Categories: []int64{category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrders(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=lost", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrders(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrders(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/sales-orders?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestChangeSalesOrderStatus(t *testing.T) {
+	actor := util.RandomName()
+	salesOrder := randomSalesOrder(db.SalesOrderStatusAllocated)
+	line := randomSalesOrderLine(salesOrder.ID, randomGood())
+	line.Allocated = line.Amount
+	arg := db.SalesOrderStatusTxParams{ID: salesOrder.ID, Actor: actor}
+	result := db.SalesOrderTxResult{SalesOrder: salesOrder, Lines: []db.SalesOrderLine{line}}
+
+	testCases := []struct {
+		name          string
+		action        string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "AllocateOK",
+			action: "allocate",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AllocateSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				got := requireBodySalesOrder(t, recorder.Body)
+				require.Equal(t, salesOrder, got.SalesOrder)
+				require.Equal(t, line.Amount, got.Lines[0].Allocated)
+			},
+		},
+		{
+			name:   "AllocateNotOpen",
+			action: "allocate",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AllocateSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SalesOrderTxResult{}, db.ErrSalesOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "AllocateAuditorForbidden",
+			action: "allocate",
+			role:   db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AllocateSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "CancelOK",
+			action: "cancel",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "CancelNotFound",
+			action: "cancel",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SalesOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "CancelInternalError",
+			action: "cancel",
+			role:   db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.SalesOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/sales-orders/%d/%s", salesOrder.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetSalesOrderPickList(t *testing.T) {
+	good := randomGood()
+	salesOrder := randomSalesOrder(db.SalesOrderStatusAllocated)
+	line := randomSalesOrderLine(salesOrder.ID, good)
+	pickList := db.PickList{
+		SalesOrderID: salesOrder.ID,
+		WarehouseID:  salesOrder.WarehouseID,
+		Locations: []db.PickListLocation{
+			{
+				LocationID:   sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+				LocationCode: util.RandomString(6),
+				Lines: []db.PickListLine{
+					{LineID: line.ID, GoodID: line.GoodID, Amount: line.Amount},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPickListTx(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(pickList, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.PickList
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, pickList, got)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return([]db.SalesOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().GetPickListTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Shipped",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPickListTx(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(db.PickList{}, db.ErrSalesOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPickListTx(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(db.PickList{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetPickListTx(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return(db.PickList{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/sales-orders/%d/pick-list", salesOrder.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestShipSalesOrder(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	salesOrder := randomSalesOrder(db.SalesOrderStatusShipped)
+	line := randomSalesOrderLine(salesOrder.ID, good)
+	locationID := util.RandomInt(1, 1000)
+
+	body := gin.H{
+		"picks": []gin.H{
+			{
+				"line_id":     line.ID,
+				"amount":      line.Amount,
+				"location_id": locationID,
+			},
+		},
+	}
+	arg := db.ShipSalesOrderTxParams{
+		ID: salesOrder.ID,
+		Picks: []db.SalesOrderPick{
+			{
+				LineID:     line.ID,
+				Amount:     line.Amount,
+				LocationID: sql.NullInt64{Int64: locationID, Valid: true},
+			},
+		},
+		Actor: actor,
+	}
+	result := db.ShipSalesOrderTxResult{
+		SalesOrderTxResult: db.SalesOrderTxResult{
+			SalesOrder: salesOrder,
+			Lines:      []db.SalesOrderLine{line},
+		},
+		Movements: []db.StockMovement{randomStockMovement(good)},
+	}
+
+	serials := []string{util.RandomString(8), util.RandomString(8)}
+	serialBody := gin.H{
+		"picks": []gin.H{
+			{
+				"line_id": line.ID,
+				"amount":  len(serials),
+				"serials": serials,
+			},
+		},
+	}
+	serialArg := db.ShipSalesOrderTxParams{
+		ID:    salesOrder.ID,
+		Picks: []db.SalesOrderPick{{LineID: line.ID, Amount: int64(len(serials)), Serials: serials}},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got shipSalesOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.SalesOrderStatusShipped, got.Status)
+				require.Len(t, got.Lines, 1)
+				require.Len(t, got.Movements, 1)
+			},
+		},
+		{
+			name:  "InScope",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return([]db.SalesOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSalesOrderLines(gomock.Any(), gomock.Eq(salesOrder.ID)).Times(1).Return([]db.SalesOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "AuditorForbidden",
+			role: db.RoleAuditor,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotAllocated",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ShipSalesOrderTxResult{}, db.ErrSalesOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPick",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ShipSalesOrderTxResult{}, db.ErrInvalidPick)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SerialMismatch",
+			role: db.RoleClerk,
+			body: serialBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(serialArg)).Times(1).Return(db.ShipSalesOrderTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientStock",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ShipSalesOrderTxResult{}, db.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ShipSalesOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.ShipSalesOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "NoPicks",
+			role: db.RoleClerk,
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipSalesOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/sales-orders/%d/ship", salesOrder.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomSalesOrder(status string) db.SalesOrder {
+	return db.SalesOrder{
+		ID:           util.RandomInt(1, 1000),
+		CustomerName: util.RandomName(),
+		WarehouseID:  util.RandomInt(1, 1000),
+		Status:       status,
+		Reference:    util.RandomString(8),
+	}
+}
+
+func randomSalesOrderLine(salesOrderID int64, good db.Good) db.SalesOrderLine {
+	return db.SalesOrderLine{
+		ID:           util.RandomInt(1, 1000),
+		SalesOrderID: salesOrderID,
+		GoodID:       good.ID,
+		Amount:       util.RandomInt(1, 100),
+	}
+}
+
+func requireBodySalesOrder(t *testing.T, body *bytes.Buffer) salesOrderResponse {
+	var got salesOrderResponse
+	require.NoError(t, json.Unmarshal(body.Bytes(), &got))
+	return got
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"html"
+	db "inventory_management/db/sqlc"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errBlankSearch = errors.New("the search needs at least one word")
+
+type searchGoodRequest struct {
+	Query    string `form:"q" binding:"required,max=200"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+	// required for scoped users
+	Category int64 `form:"category" binding:"omitempty,min=1"`
+}
+
+// goodSearchResult is a good found by a search with how well it matches
+type goodSearchResult struct {
+	goodStock
+	CategoryName string  `json:"category_name"`
+	Rank         float64 `json:"rank"`
+	// the model and the description escaped for HTML, with the words matched as written between <mark> and </mark>,
+	// goods only found despite typos come without marks
+	ModelSnippet string `json:"model_snippet"`
+	DescSnippet  string `json:"desc_snippet"`
+}
+
+func newGoodSearchResult(row db.SearchGoodsRow) goodSearchResult {
+	good := db.Good{
+		ID:           row.ID,
+		Category:     row.Category,
+		Model:        row.Model,
+		Unit:         row.Unit,
+		Amount:       row.Amount,
+		GoodDesc:     row.GoodDesc,
+		CreatedAt:    row.CreatedAt,
+		DeletedAt:    row.DeletedAt,
+		Version:      row.Version,
+		Reserved:     row.Reserved,
+		InTransit:    row.InTransit,
+		ReorderPoint: row.ReorderPoint,
+		SafetyStock:  row.SafetyStock,
+		MaxLevel:     row.MaxLevel,
+		Serialized:   row.Serialized,
+		StockValue:   row.StockValue,
+		Sku:          row.Sku,
+	}
+	return goodSearchResult{
+		goodStock:    newGoodStock(good),
+		CategoryName: row.CategoryName,
+		Rank:         row.Rank,
+		ModelSnippet: escapeSnippet(row.ModelSnippet),
+		DescSnippet:  escapeSnippet(row.DescSnippet),
+	}
+}
+
+// escapeSnippet escapes a snippet for HTML and keeps the marks of the matched words
+func escapeSnippet(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, html.EscapeString("<mark>"), "<mark>")
+	return strings.ReplaceAll(escaped, html.EscapeString("</mark>"), "</mark>")
+}
+
+// searchGood finds the goods by the words of their model, description and category name,
+// tolerating typos, and returns them ranked with the best match first
+func (server *Server) searchGood(c *gin.Context) {
+	var req searchGoodRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		c.JSON(http.StatusBadRequest, errorResponse(errBlankSearch))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, req.Category > 0) {
+		return
+	}
+
+	if req.Category > 0 && !server.authorizeCategory(c, req.Category) {
+		return
+	}
+
+	arg := db.SearchGoodsParams{
+		Query: query,
+		Category: sql.NullInt64{
+			Int64: req.Category,
+			Valid: req.Category > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	rows, err := server.store.SearchGoods(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	results := make([]goodSearchResult, len(rows))
+	for i, row := range rows {
+		results[i] = newGoodSearchResult(row)
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,235 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchGood(t *testing.T) {
+	category := randomCategory()
+	rows := []db.SearchGoodsRow{
+		randomGoodSearchRow(category, 1.2),
+		randomGoodSearchRow(category, 0.7),
+	}
+	rows[1].DescSnippet = "fits <b>any</b> <mark>drill</mark>"
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"q":         {"  bosch drill 18v "},
+				"page_id":   {"2"},
+				"page_size": {"5"},
+			},
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SearchGoodsParams{
+					Query:  "bosch drill 18v",
+					Limit:  5,
+					Offset: 5,
+				}
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				results := requireBodyGoodSearchResults(t, recorder.Body)
+				require.Len(t, results, 2)
+				require.Equal(t, rows[0].ID, results[0].ID)
+				require.Equal(t, rows[0].Model, results[0].Model)
+				require.Equal(t, rows[0].Amount-rows[0].Reserved, results[0].Available)
+				require.Equal(t, category.CategoryName, results[0].CategoryName)
+				require.Equal(t, rows[0].Rank, results[0].Rank)
+				require.Equal(t, rows[0].ModelSnippet, results[0].ModelSnippet)
+				// the text is escaped, the marks are kept
+				require.Equal(t, "fits &lt;b&gt;any&lt;/b&gt; <mark>drill</mark>", results[1].DescSnippet)
+			},
+		},
+		{
+			name: "ScopedCategory",
+			query: url.Values{
+				"q":         {"drill"},
+				"page_id":   {"1"},
+				"page_size": {"5"},
+				"category":  {fmt.Sprint(category.ID)},
+			},
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SearchGoodsParams{
+					Query:    "drill",
+					Category: sql.NullInt64{Int64: category.ID, Valid: true},
+					Limit:    5,
+				}
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ScopedWithoutCategory",
+			query: url.Values{
+				"q":         {"drill"},
+				"page_id":   {"1"},
+				"page_size": {"5"},
+			},
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "OutOfScope",
+			query: url.Values{
+				"q":         {"drill"},
+				"page_id":   {"1"},
+				"page_size": {"5"},
+				"category":  {fmt.Sprint(category.ID)},
+			},
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "MissingQuery",
+			query: url.Values{
+				"page_id":   {"1"},
+				"page_size": {"5"},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "BlankQuery",
+			query: url.Values{
+				"q":         {"   "},
+				"page_id":   {"1"},
+				"page_size": {"5"},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			query: url.Values{
+				"q":         {"drill"},
+				"page_id":   {"1"},
+				"page_size": {"100"},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			query: url.Values{
+				"q":         {"drill"},
+				"page_id":   {"1"},
+				"page_size": {"5"},
+			},
+			role: db.RoleAdmin,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SearchGoods(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/search?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomGoodSearchRow(category db.Category, rank float64) db.SearchGoodsRow {
+	good := randomGood()
+	return db.SearchGoodsRow{
+		ID:           good.ID,
+		Category:     category.ID,
+		Model:        good.Model,
+		Unit:         good.Unit,
+		Amount:       good.Amount,
+		GoodDesc:     good.GoodDesc,
+		Version:      good.Version,
+		Reserved:     good.Reserved,
+		CategoryName: category.CategoryName,
+		Rank:         rank,
+		ModelSnippet: "<mark>" + good.Model + "</mark>",
+		DescSnippet:  good.GoodDesc,
+	}
+}
+
+func requireBodyGoodSearchResults(t *testing.T, body *bytes.Buffer) []goodSearchResult {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var results []goodSearchResult
+	err = json.Unmarshal(data, &results)
+	require.NoError(t, err)
+	return results
+}
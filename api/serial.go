@@ -0,0 +1,105 @@
+package api
+
+import (
+	"database/sql"
+	db "inventory_management/db/sqlc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getSerialRequest struct {
+	SerialNumber string `uri:"sn" binding:"required"`
+}
+
+// serialResponse is a serial with its lifecycle, the oldest event first
+type serialResponse struct {
+	db.Serial
+	Events []db.SerialEvent `json:"events"`
+}
+
+func (server *Server) getSerial(c *gin.Context) {
+	var req getSerialRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	serial, err := server.store.GetSerialByNumber(c, req.SerialNumber)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, serial.GoodID) {
+		return
+	}
+
+	events, err := server.store.ListSerialEvents(c, serial.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, serialResponse{
+		Serial: serial,
+		Events: events,
+	})
+}
+
+type listGoodSerialRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listGoodSerialRequestQuery struct {
+	Status      string `form:"status" binding:"omitempty,oneof=in_stock reserved shipped returned"`
+	WarehouseID int64  `form:"warehouse_id" binding:"omitempty,min=1"`
+	PageID      int32  `form:"page_id" binding:"required,min=1"`
+	PageSize    int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listGoodSerial(c *gin.Context) {
+	var req listGoodSerialRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqQuery listGoodSerialRequestQuery
+	if err := c.ShouldBindQuery(&reqQuery); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	arg := db.ListGoodSerialsParams{
+		GoodID: req.ID,
+		Status: sql.NullString{
+			String: reqQuery.Status,
+			Valid:  reqQuery.Status != "",
+		},
+		WarehouseID: sql.NullInt64{
+			Int64: reqQuery.WarehouseID,
+			Valid: reqQuery.WarehouseID > 0,
+		},
+		Limit:  reqQuery.PageSize,
+		Offset: (reqQuery.PageID - 1) * reqQuery.PageSize,
+	}
+	serials, err := server.store.ListGoodSerials(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, serials)
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSerial(t *testing.T) {
+	good := randomGood()
+	serial := randomSerial(good.ID, db.SerialStatusShipped)
+	events := []db.SerialEvent{
+		{
+			ID:              util.RandomInt(1, 1000),
+			SerialID:        serial.ID,
+			Status:          db.SerialStatusInStock,
+			WarehouseID:     sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+			StockMovementID: sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+		},
+		{
+			ID:              util.RandomInt(1001, 2000),
+			SerialID:        serial.ID,
+			Status:          db.SerialStatusShipped,
+			WarehouseID:     sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+			StockMovementID: sql.NullInt64{Int64: util.RandomInt(1001, 2000), Valid: true},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		serialNumber  string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:         "OK",
+			serialNumber: serial.SerialNumber,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSerialByNumber(gomock.Any(), gomock.Eq(serial.SerialNumber)).Times(1).Return(serial, nil)
+				store.EXPECT().ListSerialEvents(gomock.Any(), gomock.Eq(serial.ID)).Times(1).Return(events, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got serialResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, serial.ID, got.ID)
+				require.Equal(t, serial.Status, got.Status)
+				require.Equal(t, events, got.Events)
+			},
+		},
+		{
+			name:         "NotFound",
+			serialNumber: serial.SerialNumber,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSerialByNumber(gomock.Any(), gomock.Any()).Times(1).Return(db.Serial{}, sql.ErrNoRows)
+				store.EXPECT().ListSerialEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:         "OutOfScope",
+			serialNumber: serial.SerialNumber,
+			scope:        token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSerialByNumber(gomock.Any(), gomock.Eq(serial.SerialNumber)).Times(1).Return(serial, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListSerialEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:         "InternalError",
+			serialNumber: serial.SerialNumber,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSerialByNumber(gomock.Any(), gomock.Any()).Times(1).Return(serial, nil)
+				store.EXPECT().ListSerialEvents(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/serials/"+tc.serialNumber, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListGoodSerial(t *testing.T) {
+	good := randomGood()
+	warehouseID := util.RandomInt(1, 1000)
+	n := 5
+	serials := make([]db.Serial, n)
+	for i := range serials {
+		serials[i] = randomSerial(good.ID, db.SerialStatusInStock)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGoodSerialsParams{
+					GoodID: good.ID,
+					Limit:  int32(n),
+				}
+				store.EXPECT().ListGoodSerials(gomock.Any(), gomock.Eq(arg)).Times(1).Return(serials, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.Serial
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, n)
+			},
+		},
+		{
+			name:  "StatusAndWarehouse",
+			query: fmt.Sprintf("page_id=2&page_size=%d&status=in_stock&warehouse_id=%d", n, warehouseID),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListGoodSerialsParams{
+					GoodID:      good.ID,
+					Status:      sql.NullString{String: db.SerialStatusInStock, Valid: true},
+					WarehouseID: sql.NullInt64{Int64: warehouseID, Valid: true},
+					Limit:       int32(n),
+					Offset:      int32(n),
+				}
+				store.EXPECT().ListGoodSerials(gomock.Any(), gomock.Eq(arg)).Times(1).Return(serials, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=lost", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodSerials(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodSerials(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/serials?%s", good.ID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomSerial(goodID int64, status string) db.Serial {
+	serial := db.Serial{
+		ID:           util.RandomInt(1, 1000),
+		GoodID:       goodID,
+		SerialNumber: util.RandomString(10),
+		Status:       status,
+	}
+	if status != db.SerialStatusShipped {
+		serial.WarehouseID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+	}
+	return serial
+}
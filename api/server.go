@@ -1,38 +1,152 @@
 package api
 
 import (
+	"fmt"
 	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Server serve HTTP requests for inventory_management services
 type Server struct {
-	store  db.Store
-	router *gin.Engine
+	config     util.Config
+	store      db.Store
+	tokenMaker token.Maker
+	router     *gin.Engine
 }
 
-func NewServer(store db.Store) *Server {
-	server := &Server{store: store}
+// NewServer creates a new HTTP server and setup routing
+func NewServer(config util.Config, store db.Store) (*Server, error) {
+	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	server := &Server{
+		config:     config,
+		store:      store,
+		tokenMaker: tokenMaker,
+	}
+
+	server.setupRouter()
+	return server, nil
+}
+
+func (server *Server) setupRouter() {
 	router := gin.Default()
 
-	router.POST("/categories", server.createCategory)
-	router.GET("/categories/:id", server.getCategory)
-	router.GET("/categories", server.listCategory)
-	router.PUT("/categories/:id", server.updateCategory)
-	router.DELETE("/categories/:id", server.deleteCategory)
-	router.POST("/units", server.createUnit)
-	router.GET("/units", server.listUnit)
-	router.DELETE("/units/:id", server.deleteUnit)
-	router.PUT("/units/:id", server.updateUnit)
-	router.POST("/goods", server.createGood)
-	router.GET("/goods/:id", server.getGood)
-	router.GET("/goods", server.listGood)
-	router.PUT("/goods/:id", server.updateGood)
-	router.DELETE("/goods/:id", server.deleteGood)
+	router.POST("/users/login", server.loginUser)
+
+	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+
+	authRoutes.POST("/categories", authorize(permCategoriesCreate), server.createCategory)
+	authRoutes.GET("/categories/:id", authorize(permCategoriesRead), server.getCategory)
+	authRoutes.GET("/categories", authorize(permCategoriesRead), server.listCategory)
+	authRoutes.GET("/categories/export", authorize(permCategoriesRead), server.exportCategory)
+	authRoutes.PUT("/categories/:id", authorize(permCategoriesUpdate), server.updateCategory)
+	authRoutes.PUT("/categories/:id/costing-method", authorize(permCategoriesUpdate), server.setCategoryCostingMethod)
+	authRoutes.DELETE("/categories/:id", authorize(permCategoriesDelete), server.deleteCategory)
+	authRoutes.POST("/categories/:id/restore", authorize(permCategoriesDelete), server.restoreCategory)
+	authRoutes.GET("/categories/:id/labels", authorize(permGoodsRead), server.listCategoryLabel)
+	authRoutes.POST("/units", authorize(permUnitsCreate), server.createUnit)
+	authRoutes.GET("/units/:id", authorize(permUnitsRead), server.getUnit)
+	authRoutes.GET("/units", authorize(permUnitsRead), server.listUnit)
+	authRoutes.GET("/units/export", authorize(permUnitsRead), server.exportUnit)
+	authRoutes.DELETE("/units/:id", authorize(permUnitsDelete), server.deleteUnit)
+	authRoutes.POST("/units/:id/restore", authorize(permUnitsDelete), server.restoreUnit)
+	authRoutes.PUT("/units/:id", authorize(permUnitsUpdate), server.updateUnit)
+	authRoutes.POST("/goods", authorize(permGoodsCreate), server.createGood)
+	authRoutes.GET("/goods/:id", authorize(permGoodsRead), server.getGood)
+	authRoutes.GET("/goods", authorize(permGoodsRead), server.listGood)
+	authRoutes.GET("/goods/low-stock", authorize(permGoodsRead), server.listLowStockGood)
+	authRoutes.GET("/goods/lookup", authorize(permGoodsRead), server.lookupGood)
+	authRoutes.GET("/goods/export", authorize(permGoodsRead), server.exportGood)
+	authRoutes.GET("/search", authorize(permGoodsRead), server.searchGood)
+	authRoutes.POST("/goods/import", authorize(permGoodsCreate), server.importGoods)
+	authRoutes.PUT("/goods/:id", authorize(permGoodsUpdate), server.updateGood)
+	authRoutes.PUT("/goods/:id/stock-levels", authorize(permGoodsUpdate), server.setGoodStockLevels)
+	authRoutes.PUT("/goods/:id/sku", authorize(permGoodsUpdate), server.setGoodSku)
+	authRoutes.GET("/goods/:id/barcodes", authorize(permGoodsRead), server.listGoodBarcode)
+	authRoutes.POST("/goods/:id/barcodes", authorize(permGoodsUpdate), server.createGoodBarcode)
+	authRoutes.DELETE("/goods/:id/barcodes/:barcode", authorize(permGoodsUpdate), server.deleteGoodBarcode)
+	authRoutes.GET("/goods/:id/label", authorize(permGoodsRead), server.getGoodLabel)
+	authRoutes.DELETE("/goods/:id", authorize(permGoodsDelete), server.deleteGood)
+	authRoutes.POST("/goods/:id/restore", authorize(permGoodsDelete), server.restoreGood)
+	authRoutes.GET("/stock-alerts", authorize(permGoodsRead), server.listStockAlert)
+	authRoutes.POST("/goods/:id/receipts", authorize(permStockCreate), server.createReceipt)
+	authRoutes.POST("/goods/:id/issues", authorize(permStockCreate), server.createIssue)
+	authRoutes.GET("/goods/:id/movements", authorize(permStockRead), server.listStockMovement)
+	authRoutes.POST("/goods/:id/lots", authorize(permStockCreate), server.createLot)
+	authRoutes.GET("/goods/:id/lots", authorize(permStockRead), server.listGoodLot)
+	authRoutes.GET("/lots/expiring", authorize(permStockRead), server.listExpiringLot)
+	authRoutes.GET("/goods/:id/serials", authorize(permStockRead), server.listGoodSerial)
+	authRoutes.GET("/serials/:sn", authorize(permStockRead), server.getSerial)
+	authRoutes.GET("/reports/valuation", authorize(permReportsRead), server.getValuationReport)
+	authRoutes.GET("/goods/:id/cost-layers", authorize(permReportsRead), server.listCostLayer)
+	authRoutes.POST("/goods/:id/reservations", authorize(permReservationsCreate), server.createReservation)
+	authRoutes.GET("/goods/:id/reservations", authorize(permReservationsRead), server.listReservation)
+	authRoutes.GET("/reservations/:id", authorize(permReservationsRead), server.getReservation)
+	authRoutes.POST("/reservations/:id/release", authorize(permReservationsRelease), server.releaseReservation)
+	authRoutes.POST("/reservations/expire", authorize(permReservationsRelease), server.expireReservations)
+	authRoutes.GET("/goods/:id/suppliers", authorize(permSuppliersRead), server.listGoodSupplier)
+	authRoutes.POST("/goods/:id/suppliers", authorize(permSuppliersUpdate), server.createGoodSupplier)
+	authRoutes.PUT("/goods/:id/suppliers/:supplier_id", authorize(permSuppliersUpdate), server.updateGoodSupplier)
+	authRoutes.DELETE("/goods/:id/suppliers/:supplier_id", authorize(permSuppliersUpdate), server.deleteGoodSupplier)
+	authRoutes.POST("/purchase-orders", authorize(permPurchaseOrdersCreate), server.createPurchaseOrder)
+	authRoutes.GET("/purchase-orders/:id", authorize(permPurchaseOrdersRead), server.getPurchaseOrder)
+	authRoutes.GET("/purchase-orders", authorize(permPurchaseOrdersRead), server.listPurchaseOrder)
+	authRoutes.POST("/purchase-orders/:id/lines", authorize(permPurchaseOrdersCreate), server.addPurchaseOrderLine)
+	authRoutes.POST("/purchase-orders/:id/send", authorize(permPurchaseOrdersCreate), server.sendPurchaseOrder)
+	authRoutes.POST("/purchase-orders/:id/receive", authorize(permPurchaseOrdersReceive), server.receivePurchaseOrder)
+	authRoutes.POST("/purchase-orders/:id/close", authorize(permPurchaseOrdersCreate), server.closePurchaseOrder)
+	authRoutes.POST("/sales-orders", authorize(permSalesOrdersCreate), server.createSalesOrder)
+	authRoutes.GET("/sales-orders/:id", authorize(permSalesOrdersRead), server.getSalesOrder)
+	authRoutes.GET("/sales-orders", authorize(permSalesOrdersRead), server.listSalesOrder)
+	authRoutes.POST("/sales-orders/:id/allocate", authorize(permSalesOrdersCreate), server.allocateSalesOrder)
+	authRoutes.GET("/sales-orders/:id/pick-list", authorize(permSalesOrdersRead), server.getSalesOrderPickList)
+	authRoutes.POST("/sales-orders/:id/ship", authorize(permSalesOrdersShip), server.shipSalesOrder)
+	authRoutes.POST("/sales-orders/:id/cancel", authorize(permSalesOrdersCreate), server.cancelSalesOrder)
+	authRoutes.POST("/transfer-orders", authorize(permTransferOrdersCreate), server.createTransferOrder)
+	authRoutes.GET("/transfer-orders/:id", authorize(permTransferOrdersRead), server.getTransferOrder)
+	authRoutes.GET("/transfer-orders", authorize(permTransferOrdersRead), server.listTransferOrder)
+	authRoutes.POST("/transfer-orders/:id/ship", authorize(permTransferOrdersShip), server.shipTransferOrder)
+	authRoutes.POST("/transfer-orders/:id/receive", authorize(permTransferOrdersShip), server.receiveTransferOrder)
+	authRoutes.POST("/transfer-orders/:id/cancel", authorize(permTransferOrdersCreate), server.cancelTransferOrder)
+	authRoutes.POST("/count-sessions", authorize(permCountSessionsCreate), server.startCountSession)
+	authRoutes.GET("/count-sessions/:id", authorize(permCountSessionsRead), server.getCountSession)
+	authRoutes.GET("/count-sessions", authorize(permCountSessionsRead), server.listCountSession)
+	authRoutes.POST("/count-sessions/:id/counts", authorize(permCountSessionsCount), server.recordCounts)
+	authRoutes.GET("/count-sessions/:id/variances", authorize(permCountSessionsRead), server.getCountVariances)
+	authRoutes.POST("/count-sessions/:id/approve", authorize(permCountSessionsApprove), server.approveCountSession)
+	authRoutes.POST("/count-sessions/:id/cancel", authorize(permCountSessionsCreate), server.cancelCountSession)
+	authRoutes.POST("/suppliers", authorize(permSuppliersCreate), server.createSupplier)
+	authRoutes.GET("/suppliers/:id", authorize(permSuppliersRead), server.getSupplier)
+	authRoutes.GET("/suppliers", authorize(permSuppliersRead), server.listSupplier)
+	authRoutes.PUT("/suppliers/:id", authorize(permSuppliersUpdate), server.updateSupplier)
+	authRoutes.DELETE("/suppliers/:id", authorize(permSuppliersDelete), server.deleteSupplier)
+	authRoutes.POST("/label-templates", authorize(permLabelTemplatesCreate), server.createLabelTemplate)
+	authRoutes.GET("/label-templates/:id", authorize(permLabelTemplatesRead), server.getLabelTemplate)
+	authRoutes.GET("/label-templates", authorize(permLabelTemplatesRead), server.listLabelTemplate)
+	authRoutes.PUT("/label-templates/:id", authorize(permLabelTemplatesUpdate), server.updateLabelTemplate)
+	authRoutes.DELETE("/label-templates/:id", authorize(permLabelTemplatesDelete), server.deleteLabelTemplate)
+	authRoutes.POST("/warehouses", authorize(permWarehousesCreate), server.createWarehouse)
+	authRoutes.GET("/warehouses/:id", authorize(permWarehousesRead), server.getWarehouse)
+	authRoutes.GET("/warehouses", authorize(permWarehousesRead), server.listWarehouse)
+	authRoutes.PUT("/warehouses/:id", authorize(permWarehousesUpdate), server.updateWarehouse)
+	authRoutes.DELETE("/warehouses/:id", authorize(permWarehousesDelete), server.deleteWarehouse)
+	authRoutes.POST("/locations", authorize(permLocationsCreate), server.createLocation)
+	authRoutes.GET("/locations/:id", authorize(permLocationsRead), server.getLocation)
+	authRoutes.GET("/locations", authorize(permLocationsRead), server.listLocation)
+	authRoutes.PUT("/locations/:id", authorize(permLocationsUpdate), server.updateLocation)
+	authRoutes.DELETE("/locations/:id", authorize(permLocationsDelete), server.deleteLocation)
+	authRoutes.GET("/locations/:id/contents", authorize(permLocationsRead), server.listLocationContents)
+	authRoutes.POST("/users", authorize(permUsersUpdate), server.createUser)
+	authRoutes.PUT("/users/:username/access", authorize(permUsersUpdate), server.updateUserAccess)
+	authRoutes.GET("/audit", authorize(permAuditRead), server.listAuditLog)
 
 	server.router = router
-	return server
 }
 
 // Start run the HTTP server on a specific address
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"database/sql"
+	db "inventory_management/db/sqlc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type listLowStockGoodRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	// required for scoped users
+	Category int64 `form:"category" binding:"omitempty,min=1"`
+}
+
+// lowStockGood is a good below its reorder point with the amount that refills it up to its max level
+type lowStockGood struct {
+	goodStock
+	BelowSafetyStock bool  `json:"below_safety_stock"`
+	SuggestedOrder   int64 `json:"suggested_order"`
+}
+
+func newLowStockGoods(goods []db.Good) []lowStockGood {
+	rsp := make([]lowStockGood, len(goods))
+	for i, good := range goods {
+		stock := newGoodStock(good)
+		rsp[i] = lowStockGood{
+			goodStock:        stock,
+			BelowSafetyStock: stock.Available < good.SafetyStock,
+			SuggestedOrder:   good.MaxLevel - stock.Available,
+		}
+	}
+	return rsp
+}
+
+func (server *Server) listLowStockGood(c *gin.Context) {
+	var req listLowStockGoodRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, req.Category > 0) {
+		return
+	}
+
+	if req.Category > 0 && !server.authorizeCategory(c, req.Category) {
+		return
+	}
+
+	arg := db.ListLowStockGoodsParams{
+		Category: sql.NullInt64{
+			Int64: req.Category,
+			Valid: req.Category > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	goods, err := server.store.ListLowStockGoods(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, newLowStockGoods(goods))
+}
+
+type listStockAlertRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	// required for scoped users
+	GoodID int64 `form:"good_id" binding:"omitempty,min=1"`
+	// resolved alerts are hidden unless asked for
+	IncludeResolved bool `form:"include_resolved"`
+}
+
+func (server *Server) listStockAlert(c *gin.Context) {
+	var req listStockAlertRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, req.GoodID > 0) {
+		return
+	}
+
+	if req.GoodID > 0 && !server.authorizeGood(c, req.GoodID) {
+		return
+	}
+
+	arg := db.ListStockAlertsParams{
+		GoodID: sql.NullInt64{
+			Int64: req.GoodID,
+			Valid: req.GoodID > 0,
+		},
+		IncludeResolved: req.IncludeResolved,
+		Limit:           req.PageSize,
+		Offset:          (req.PageID - 1) * req.PageSize,
+	}
+	alerts, err := server.store.ListStockAlerts(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
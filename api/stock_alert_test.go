@@ -0,0 +1,278 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListLowStockGood(t *testing.T) {
+	n := 5
+	goods := make([]db.Good, n)
+	for i := range goods {
+		goods[i] = randomLowStockGood()
+	}
+	category := randomCategory()
+
+	testCases := []struct {
+		name          string
+		query         string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListLowStockGoodsParams{Limit: int32(n)}
+				store.EXPECT().ListLowStockGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return(goods, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []lowStockGood
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, n)
+				for i, good := range goods {
+					available := good.Amount - good.Reserved
+					require.Equal(t, good, got[i].Good)
+					require.Equal(t, available, got[i].Available)
+					require.Equal(t, available < good.SafetyStock, got[i].BelowSafetyStock)
+					require.Equal(t, good.MaxLevel-available, got[i].SuggestedOrder)
+					require.Positive(t, got[i].SuggestedOrder)
+				}
+			},
+		},
+		{
+			name:  "CategoryInScope",
+			query: fmt.Sprintf("page_id=2&page_size=%d&category=%d", n, category.ID),
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListLowStockGoodsParams{
+					Category: sql.NullInt64{Int64: category.ID, Valid: true},
+					Limit:    int32(n),
+					Offset:   int32(n),
+				}
+				store.EXPECT().ListLowStockGoods(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Good{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "CategoryOutOfScope",
+			query: fmt.Sprintf("page_id=1&page_size=%d&category=%d", n, category.ID),
+			scope: token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLowStockGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedWithoutCategory",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLowStockGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: "page_id=1&page_size=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLowStockGoods(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListLowStockGoods(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/goods/low-stock?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListStockAlert(t *testing.T) {
+	n := 5
+	good := randomLowStockGood()
+	alerts := make([]db.StockAlert, n)
+	for i := range alerts {
+		alerts[i] = db.StockAlert{
+			ID:           util.RandomInt(1, 1000),
+			GoodID:       good.ID,
+			Available:    good.Amount - good.Reserved,
+			ReorderPoint: good.ReorderPoint,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d&include_resolved=true", n),
+			role:  db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListStockAlertsParams{
+					IncludeResolved: true,
+					Limit:           int32(n),
+				}
+				store.EXPECT().ListStockAlerts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(alerts, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.StockAlert
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, alerts, got)
+			},
+		},
+		{
+			name:  "GoodInScope",
+			query: fmt.Sprintf("page_id=1&page_size=%d&good_id=%d", n, good.ID),
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				arg := db.ListStockAlertsParams{
+					GoodID: sql.NullInt64{Int64: good.ID, Valid: true},
+					Limit:  int32(n),
+				}
+				store.EXPECT().ListStockAlerts(gomock.Any(), gomock.Eq(arg)).Times(1).Return(alerts, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "GoodOutOfScope",
+			query: fmt.Sprintf("page_id=1&page_size=%d&good_id=%d", n, good.ID),
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListStockAlerts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedWithoutGood",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			role:  db.RoleClerk,
+			scope: token.Scope{Categories: []int64{good.Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListStockAlerts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedAdmin",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			role:  db.RoleAdmin,
+			scope: token.Scope{Categories: []int64{good.Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListStockAlerts(gomock.Any(), gomock.Any()).Times(1).Return(alerts, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			role:  db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListStockAlerts(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/stock-alerts?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+// randomLowStockGood returns a good whose available stock is below its reorder point
+func randomLowStockGood() db.Good {
+	good := randomGood()
+	good.ReorderPoint = good.Amount - good.Reserved + util.RandomInt(1, 5)
+	good.SafetyStock = util.RandomInt(0, good.ReorderPoint)
+	good.MaxLevel = good.ReorderPoint + util.RandomInt(0, 20)
+	return good
+}
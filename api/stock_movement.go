@@ -0,0 +1,146 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type stockMovementRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type stockMovementRequestJson struct {
+	WarehouseID int64 `json:"warehouse_id" binding:"required,min=1"`
+	LocationID  int64 `json:"location_id" binding:"omitempty,min=1"`
+	// issues without a lot take the lots first expired first out
+	LotID      int64 `json:"lot_id" binding:"omitempty,min=1"`
+	Amount     int64 `json:"amount" binding:"required,gt=0"`
+	AmountUnit int64 `json:"amount_unit" binding:"omitempty,min=1"`
+	// one serial number per unit of a serialized good
+	Serials []string `json:"serials" binding:"omitempty,dive,required"`
+	// cost of one unit as given of a receipt, receipts without one are valued at the average cost of the good
+	UnitCost *int64 `json:"unit_cost" binding:"omitempty,min=0"`
+}
+
+func (server *Server) createReceipt(c *gin.Context) {
+	server.createStockMovement(c, db.MovementTypeReceipt, 1)
+}
+
+func (server *Server) createIssue(c *gin.Context) {
+	server.createStockMovement(c, db.MovementTypeIssue, -1)
+}
+
+// createStockMovement posts a movement of the given type, sign is applied to the requested amount
+func (server *Server) createStockMovement(c *gin.Context, movementType string, sign int64) {
+	var req stockMovementRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqMovement stockMovementRequestJson
+	if err := c.ShouldBindJSON(&reqMovement); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	var value sql.NullInt64
+	if reqMovement.UnitCost != nil {
+		total, err := db.MultiplyUnitCost(*reqMovement.UnitCost, reqMovement.Amount)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		value = sql.NullInt64{
+			Int64: total,
+			Valid: true,
+		}
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.StockMovementTxParams{
+		GoodID:      req.ID,
+		WarehouseID: reqMovement.WarehouseID,
+		LocationID: sql.NullInt64{
+			Int64: reqMovement.LocationID,
+			Valid: reqMovement.LocationID > 0,
+		},
+		LotID: sql.NullInt64{
+			Int64: reqMovement.LotID,
+			Valid: reqMovement.LotID > 0,
+		},
+		MovementType: movementType,
+		Amount:       sign * reqMovement.Amount,
+		Value:        value,
+		AmountUnit:   reqMovement.AmountUnit,
+		Serials:      reqMovement.Serials,
+		Actor:        authPayload.Username,
+	}
+
+	result, err := server.store.StockMovementTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidLocation) || errors.Is(err, db.ErrInvalidLot) || errors.Is(err, db.ErrSerialMismatch) || isConversionError(err) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+type listStockMovementRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listStockMovement(c *gin.Context) {
+	var req stockMovementRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqPage listStockMovementRequest
+	if err := c.ShouldBindQuery(&reqPage); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	arg := db.ListStockMovementsParams{
+		GoodID: req.ID,
+		Limit:  reqPage.PageSize,
+		Offset: (reqPage.PageID - 1) * reqPage.PageSize,
+	}
+	movements, err := server.store.ListStockMovements(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, movements)
+}
@@ -0,0 +1,658 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/util"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"net/http/httptest"
+
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateReceipt(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	warehouseID := util.RandomInt(1, 1000)
+	binID := util.RandomInt(1, 1000)
+	lotID := util.RandomInt(1, 1000)
+	unitID := util.RandomInt(1, 1000)
+	amount := util.RandomInt(1, 10)
+
+	result := db.StockMovementTxResult{
+		Good: good,
+		Movement: db.StockMovement{
+			ID:           util.RandomInt(1, 1000),
+			GoodID:       good.ID,
+			WarehouseID:  warehouseID,
+			MovementType: db.MovementTypeReceipt,
+			Amount:       amount,
+		},
+	}
+	result.Good.Amount += amount
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeReceipt,
+					Amount:       amount,
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchStockMovementResult(t, recorder.Body, result)
+			},
+		},
+		{
+			name:   "IntoBin",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"location_id":  binID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					LocationID:   sql.NullInt64{Int64: binID, Valid: true},
+					MovementType: db.MovementTypeReceipt,
+					Amount:       amount,
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchStockMovementResult(t, recorder.Body, result)
+			},
+		},
+		{
+			name:   "WithUnitCost",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+				"unit_cost":    250,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeReceipt,
+					Amount:       amount,
+					Value:        sql.NullInt64{Int64: 250 * amount, Valid: true},
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "FreeOfCost",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+				"unit_cost":    0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeReceipt,
+					Amount:       amount,
+					Value:        sql.NullInt64{Valid: true},
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NegativeUnitCost",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+				"unit_cost":    -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ValueOverflow",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       2,
+				"unit_cost":    int64(math.MaxInt64/2 + 1),
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "IntoLot",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"lot_id":       lotID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					LotID:        sql.NullInt64{Int64: lotID, Valid: true},
+					MovementType: db.MovementTypeReceipt,
+					Amount:       amount,
+					Actor:        actor,
+				}
+				lotResult := result
+				lotResult.Lots = []db.LotMovement{{StockMovementID: result.Movement.ID, LotID: lotID, Amount: amount}}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(lotResult, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.StockMovementTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Lots, 1)
+				require.Equal(t, lotID, got.Lots[0].LotID)
+			},
+		},
+		{
+			name:   "InvalidLot",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"lot_id":       lotID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.StockMovementTxResult{}, db.ErrInvalidLot)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "AmountInOtherUnit",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+				"amount_unit":  unitID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeReceipt,
+					Amount:       amount,
+					AmountUnit:   unitID,
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchStockMovementResult(t, recorder.Body, result)
+			},
+		},
+		{
+			name:   "IncompatibleUnits",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+				"amount_unit":  unitID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.StockMovementTxResult{}, db.ErrIncompatibleUnits)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidLocation",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"location_id":  binID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.StockMovementTxResult{}, db.ErrInvalidLocation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.StockMovementTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.StockMovementTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "NegativeAmount",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       -amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			goodID: 0,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/receipts", tc.goodID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestCreateIssue(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	warehouseID := util.RandomInt(1, 1000)
+	amount := good.Amount - 1
+
+	result := db.StockMovementTxResult{
+		Good: good,
+		Movement: db.StockMovement{
+			ID:           util.RandomInt(1, 1000),
+			GoodID:       good.ID,
+			WarehouseID:  warehouseID,
+			MovementType: db.MovementTypeIssue,
+			Amount:       -amount,
+		},
+	}
+	result.Good.Amount -= amount
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       amount,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeIssue,
+					Amount:       -amount,
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchStockMovementResult(t, recorder.Body, result)
+			},
+		},
+		{
+			name:   "WithSerials",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       2,
+				"serials":      []string{"SN-1", "SN-2"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeIssue,
+					Amount:       -2,
+					Serials:      []string{"SN-1", "SN-2"},
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "SerialMismatch",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       2,
+				"serials":      []string{"SN-1"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(1).Return(db.StockMovementTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "EmptySerial",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       1,
+				"serials":      []string{""},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InsufficientStock",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       good.Amount + 1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					GoodID:       good.ID,
+					WarehouseID:  warehouseID,
+					MovementType: db.MovementTypeIssue,
+					Amount:       -(good.Amount + 1),
+					Actor:        actor,
+				}
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.StockMovementTxResult{}, db.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "ZeroAmount",
+			goodID: good.ID,
+			body: gin.H{
+				"warehouse_id": warehouseID,
+				"amount":       0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().StockMovementTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/issues", tc.goodID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestListStockMovements(t *testing.T) {
+	good := randomGood()
+
+	n := 5
+	movements := make([]db.StockMovement, n)
+	for i := 0; i < n; i++ {
+		movements[i] = randomStockMovement(good)
+	}
+
+	type Query struct {
+		pageID   int
+		pageSize int
+	}
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		query         Query
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListStockMovementsParams{
+					GoodID: good.ID,
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Eq(arg)).Times(1).Return(movements, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchStockMovements(t, recorder.Body, movements)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(1).Return([]db.StockMovement{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidPageSize",
+			goodID: good.ID,
+			query: Query{
+				pageID:   1,
+				pageSize: 10000,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListStockMovements(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/movements", tc.goodID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query parameters to request URL
+			q := request.URL.Query()
+			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			request.URL.RawQuery = q.Encode()
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+
+}
+
+func randomStockMovement(good db.Good) db.StockMovement {
+	return db.StockMovement{
+		ID:           util.RandomInt(1, 1000),
+		GoodID:       good.ID,
+		WarehouseID:  util.RandomInt(1, 1000),
+		MovementType: db.MovementTypeReceipt,
+		Amount:       util.RandomInt(1, 10),
+	}
+}
+
+func requireBodyMatchStockMovementResult(t *testing.T, body *bytes.Buffer, result db.StockMovementTxResult) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotResult db.StockMovementTxResult
+	err = json.Unmarshal(data, &gotResult)
+	require.NoError(t, err)
+	require.Equal(t, result, gotResult)
+}
+
+func requireBodyMatchStockMovements(t *testing.T, body *bytes.Buffer, movements []db.StockMovement) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotMovements []db.StockMovement
+	err = json.Unmarshal(data, &gotMovements)
+	require.NoError(t, err)
+	require.Equal(t, movements, gotMovements)
+}
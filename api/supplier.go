@@ -0,0 +1,378 @@
+package api
+
+import (
+	"database/sql"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type createSupplierRequest struct {
+	SupplierName string `json:"supplier_name" binding:"required"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email" binding:"omitempty,email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days" binding:"min=0"`
+	Currency     string `json:"currency" binding:"required,iso4217"`
+}
+
+func (server *Server) createSupplier(c *gin.Context) {
+	var req createSupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateSupplierTxParams{
+		CreateSupplierParams: db.CreateSupplierParams{
+			SupplierName: req.SupplierName,
+			ContactName:  req.ContactName,
+			Email:        req.Email,
+			Phone:        req.Phone,
+			Address:      req.Address,
+			LeadTimeDays: req.LeadTimeDays,
+			Currency:     req.Currency,
+		},
+		Actor: authPayload.Username,
+	}
+
+	supplier, err := server.store.CreateSupplierTx(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+type getSupplierRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getSupplier(c *gin.Context) {
+	var req getSupplierRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	supplier, err := server.store.GetSupplier(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+type listSupplierRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listSupplier(c *gin.Context) {
+	var req listSupplierRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListSuppliersParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	suppliers, err := server.store.ListSuppliers(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, suppliers)
+}
+
+type updateSupplierRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateSupplierRequestJson struct {
+	SupplierName string `json:"supplier_name" binding:"required"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email" binding:"omitempty,email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	LeadTimeDays int32  `json:"lead_time_days" binding:"min=0"`
+	Currency     string `json:"currency" binding:"required,iso4217"`
+}
+
+func (server *Server) updateSupplier(c *gin.Context) {
+	var req updateSupplierRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqUpdate updateSupplierRequestJson
+	if err := c.ShouldBindJSON(&reqUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateSupplierTxParams{
+		UpdateSupplierParams: db.UpdateSupplierParams{
+			ID:           req.ID,
+			SupplierName: reqUpdate.SupplierName,
+			ContactName:  reqUpdate.ContactName,
+			Email:        reqUpdate.Email,
+			Phone:        reqUpdate.Phone,
+			Address:      reqUpdate.Address,
+			LeadTimeDays: reqUpdate.LeadTimeDays,
+			Currency:     reqUpdate.Currency,
+		},
+		Actor: authPayload.Username,
+	}
+
+	supplier, err := server.store.UpdateSupplierTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+type deleteSupplierRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteSupplier(c *gin.Context) {
+	var req deleteSupplierRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteSupplierTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		// the supplier still sells goods
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "supplier deleted successfuly",
+	})
+}
+
+type goodSupplierGoodRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listGoodSupplier(c *gin.Context) {
+	var req goodSupplierGoodRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	goodSuppliers, err := server.store.ListGoodSuppliers(c, req.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, goodSuppliers)
+}
+
+type createGoodSupplierRequestJson struct {
+	SupplierID int64  `json:"supplier_id" binding:"required,min=1"`
+	PartNumber string `json:"part_number" binding:"required"`
+	// price of one unit of the good in minor units of the currency of the supplier
+	UnitPrice int64 `json:"unit_price" binding:"min=0"`
+	// in the unit of the good
+	MinOrderQty int64 `json:"min_order_qty" binding:"required,min=1"`
+}
+
+func (server *Server) createGoodSupplier(c *gin.Context) {
+	var req goodSupplierGoodRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqCreate createGoodSupplierRequestJson
+	if err := c.ShouldBindJSON(&reqCreate); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateGoodSupplierTxParams{
+		CreateGoodSupplierParams: db.CreateGoodSupplierParams{
+			GoodID:      req.ID,
+			SupplierID:  reqCreate.SupplierID,
+			PartNumber:  reqCreate.PartNumber,
+			UnitPrice:   reqCreate.UnitPrice,
+			MinOrderQty: reqCreate.MinOrderQty,
+		},
+		Actor: authPayload.Username,
+	}
+
+	goodSupplier, err := server.store.CreateGoodSupplierTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				c.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			case "unique_violation":
+				c.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, goodSupplier)
+}
+
+type goodSupplierRequest struct {
+	ID         int64 `uri:"id" binding:"required,min=1"`
+	SupplierID int64 `uri:"supplier_id" binding:"required,min=1"`
+}
+
+type updateGoodSupplierRequestJson struct {
+	PartNumber string `json:"part_number" binding:"required"`
+	// price of one unit of the good in minor units of the currency of the supplier
+	UnitPrice int64 `json:"unit_price" binding:"min=0"`
+	// in the unit of the good
+	MinOrderQty int64 `json:"min_order_qty" binding:"required,min=1"`
+}
+
+func (server *Server) updateGoodSupplier(c *gin.Context) {
+	var req goodSupplierRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqUpdate updateGoodSupplierRequestJson
+	if err := c.ShouldBindJSON(&reqUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateGoodSupplierTxParams{
+		UpdateGoodSupplierParams: db.UpdateGoodSupplierParams{
+			GoodID:      req.ID,
+			SupplierID:  req.SupplierID,
+			PartNumber:  reqUpdate.PartNumber,
+			UnitPrice:   reqUpdate.UnitPrice,
+			MinOrderQty: reqUpdate.MinOrderQty,
+		},
+		Actor: authPayload.Username,
+	}
+
+	goodSupplier, err := server.store.UpdateGoodSupplierTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, goodSupplier)
+}
+
+func (server *Server) deleteGoodSupplier(c *gin.Context) {
+	var req goodSupplierRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteGoodSupplierTxParams{
+		GoodID:     req.ID,
+		SupplierID: req.SupplierID,
+		Actor:      authPayload.Username,
+	}
+
+	err := server.store.DeleteGoodSupplierTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "supplier of the good deleted successfuly",
+	})
+}
@@ -0,0 +1,993 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSupplier(t *testing.T) {
+	supplier := randomSupplier()
+
+	testCases := []struct {
+		name          string
+		supplierID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(supplier, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchSupplier(t, recorder.Body, supplier)
+			},
+		},
+		{
+			name:       "NotFound",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(db.Supplier{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Eq(supplier.ID)).Times(1).Return(db.Supplier{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			supplierID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetSupplier(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/suppliers/%d", tc.supplierID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateSupplier(t *testing.T) {
+	actor := util.RandomName()
+	supplier := randomSupplier()
+
+	body := gin.H{
+		"supplier_name":  supplier.SupplierName,
+		"contact_name":   supplier.ContactName,
+		"email":          supplier.Email,
+		"phone":          supplier.Phone,
+		"address":        supplier.Address,
+		"lead_time_days": supplier.LeadTimeDays,
+		"currency":       supplier.Currency,
+	}
+	arg := db.CreateSupplierTxParams{
+		CreateSupplierParams: db.CreateSupplierParams{
+			SupplierName: supplier.SupplierName,
+			ContactName:  supplier.ContactName,
+			Email:        supplier.Email,
+			Phone:        supplier.Phone,
+			Address:      supplier.Address,
+			LeadTimeDays: supplier.LeadTimeDays,
+			Currency:     supplier.Currency,
+		},
+		Actor: actor,
+	}
+
+	withField := func(key string, value any) gin.H {
+		changed := gin.H{}
+		for k, v := range body {
+			changed[k] = v
+		}
+		changed[key] = value
+		return changed
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(supplier, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchSupplier(t, recorder.Body, supplier)
+			},
+		},
+		{
+			name: "InternalError",
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Supplier{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "MissingName",
+			body: withField("supplier_name", ""),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidCurrency",
+			body: withField("currency", "XYZ"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: withField("email", "not-an-email"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeLeadTime",
+			body: withField("lead_time_days", -1),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/suppliers"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListSupplier(t *testing.T) {
+	n := 5
+	suppliers := make([]db.Supplier, n)
+	for i := 0; i < n; i++ {
+		suppliers[i] = randomSupplier()
+	}
+
+	testCases := []struct {
+		name          string
+		pageID        int
+		pageSize      int
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			pageID:   2,
+			pageSize: n,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListSuppliersParams{
+					Limit:  int32(n),
+					Offset: int32(n),
+				}
+				store.EXPECT().ListSuppliers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(suppliers, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotSuppliers []db.Supplier
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotSuppliers))
+				require.Equal(t, suppliers, gotSuppliers)
+			},
+		},
+		{
+			name:     "InternalError",
+			pageID:   1,
+			pageSize: n,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSuppliers(gomock.Any(), gomock.Any()).Times(1).Return([]db.Supplier{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidPageSize",
+			pageID:   1,
+			pageSize: 10000,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListSuppliers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/suppliers?page_id=%d&page_size=%d", tc.pageID, tc.pageSize)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateSupplier(t *testing.T) {
+	actor := util.RandomName()
+	supplier := randomSupplier()
+	supplierUpdate := randomSupplier()
+	supplierUpdate.ID = supplier.ID
+
+	body := gin.H{
+		"supplier_name":  supplierUpdate.SupplierName,
+		"contact_name":   supplierUpdate.ContactName,
+		"email":          supplierUpdate.Email,
+		"phone":          supplierUpdate.Phone,
+		"address":        supplierUpdate.Address,
+		"lead_time_days": supplierUpdate.LeadTimeDays,
+		"currency":       supplierUpdate.Currency,
+	}
+	arg := db.UpdateSupplierTxParams{
+		UpdateSupplierParams: db.UpdateSupplierParams{
+			ID:           supplier.ID,
+			SupplierName: supplierUpdate.SupplierName,
+			ContactName:  supplierUpdate.ContactName,
+			Email:        supplierUpdate.Email,
+			Phone:        supplierUpdate.Phone,
+			Address:      supplierUpdate.Address,
+			LeadTimeDays: supplierUpdate.LeadTimeDays,
+			Currency:     supplierUpdate.Currency,
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		supplierID    int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			supplierID: supplier.ID,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(supplierUpdate, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchSupplier(t, recorder.Body, supplierUpdate)
+			},
+		},
+		{
+			name:       "NotFound",
+			supplierID: supplier.ID,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Supplier{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			supplierID: supplier.ID,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Supplier{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidContext",
+			supplierID: supplier.ID,
+			body: gin.H{
+				"supplier_name": "",
+				"currency":      "",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			supplierID: 0,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/suppliers/%d", tc.supplierID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteSupplier(t *testing.T) {
+	actor := util.RandomName()
+	supplier := randomSupplier()
+
+	testCases := []struct {
+		name          string
+		supplierID    int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteSupplierTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: supplier.ID, Actor: actor})).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteSupplierTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: supplier.ID, Actor: actor})).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "StillLinked",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteSupplierTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: supplier.ID, Actor: actor})).Times(1).Return(&pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "InternalError",
+			supplierID: supplier.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteSupplierTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: supplier.ID, Actor: actor})).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidID",
+			supplierID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/suppliers/%d", tc.supplierID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListGoodSupplier(t *testing.T) {
+	good := randomGood()
+	supplier := randomSupplier()
+	goodSupplier := randomGoodSupplier(good.ID, supplier.ID)
+	rows := []db.ListGoodSuppliersRow{
+		{
+			GoodID:       goodSupplier.GoodID,
+			SupplierID:   goodSupplier.SupplierID,
+			PartNumber:   goodSupplier.PartNumber,
+			UnitPrice:    goodSupplier.UnitPrice,
+			MinOrderQty:  goodSupplier.MinOrderQty,
+			SupplierName: supplier.SupplierName,
+			LeadTimeDays: supplier.LeadTimeDays,
+			Currency:     supplier.Currency,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addRoleAuthorization(t, request, tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, token.Scope{}, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodSuppliers(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var gotRows []db.ListGoodSuppliersRow
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &gotRows))
+				require.Equal(t, rows, gotRows)
+			},
+		},
+		{
+			name:   "OutOfScope",
+			goodID: good.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				scope := token.Scope{Categories: []int64{good.Category + 1}}
+				addRoleAuthorization(t, request, tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleClerk, scope, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ListGoodSuppliers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodSuppliers(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/goods/%d/suppliers", tc.goodID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCreateGoodSupplier(t *testing.T) {
+	actor := util.RandomName()
+	goodSupplier := randomGoodSupplier(util.RandomInt(1, 1000), util.RandomInt(1, 1000))
+
+	body := gin.H{
+		"supplier_id":   goodSupplier.SupplierID,
+		"part_number":   goodSupplier.PartNumber,
+		"unit_price":    goodSupplier.UnitPrice,
+		"min_order_qty": goodSupplier.MinOrderQty,
+	}
+	arg := db.CreateGoodSupplierTxParams{
+		CreateGoodSupplierParams: db.CreateGoodSupplierParams{
+			GoodID:      goodSupplier.GoodID,
+			SupplierID:  goodSupplier.SupplierID,
+			PartNumber:  goodSupplier.PartNumber,
+			UnitPrice:   goodSupplier.UnitPrice,
+			MinOrderQty: goodSupplier.MinOrderQty,
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(goodSupplier, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGoodSupplier(t, recorder.Body, goodSupplier)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "GoodNotFound",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.GoodSupplier{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "SupplierNotFound",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.GoodSupplier{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicatePartNumber",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.GoodSupplier{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.GoodSupplier{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidMinOrderQty",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"supplier_id":   goodSupplier.SupplierID,
+				"part_number":   goodSupplier.PartNumber,
+				"unit_price":    goodSupplier.UnitPrice,
+				"min_order_qty": 0,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeUnitPrice",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"supplier_id":   goodSupplier.SupplierID,
+				"part_number":   goodSupplier.PartNumber,
+				"unit_price":    -1,
+				"min_order_qty": goodSupplier.MinOrderQty,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGoodSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/suppliers", goodSupplier.GoodID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateGoodSupplier(t *testing.T) {
+	actor := util.RandomName()
+	goodSupplier := randomGoodSupplier(util.RandomInt(1, 1000), util.RandomInt(1, 1000))
+
+	body := gin.H{
+		"part_number":   goodSupplier.PartNumber,
+		"unit_price":    goodSupplier.UnitPrice,
+		"min_order_qty": goodSupplier.MinOrderQty,
+	}
+	arg := db.UpdateGoodSupplierTxParams{
+		UpdateGoodSupplierParams: db.UpdateGoodSupplierParams{
+			GoodID:      goodSupplier.GoodID,
+			SupplierID:  goodSupplier.SupplierID,
+			PartNumber:  goodSupplier.PartNumber,
+			UnitPrice:   goodSupplier.UnitPrice,
+			MinOrderQty: goodSupplier.MinOrderQty,
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		supplierID    int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "OK",
+			supplierID: goodSupplier.SupplierID,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(goodSupplier, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGoodSupplier(t, recorder.Body, goodSupplier)
+			},
+		},
+		{
+			name:       "NotFound",
+			supplierID: goodSupplier.SupplierID,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.GoodSupplier{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "DuplicatePartNumber",
+			supplierID: goodSupplier.SupplierID,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.GoodSupplier{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidSupplierID",
+			supplierID: 0,
+			body:       body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "MissingPartNumber",
+			supplierID: goodSupplier.SupplierID,
+			body: gin.H{
+				"unit_price":    goodSupplier.UnitPrice,
+				"min_order_qty": goodSupplier.MinOrderQty,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateGoodSupplierTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/goods/%d/suppliers/%d", goodSupplier.GoodID, tc.supplierID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteGoodSupplier(t *testing.T) {
+	actor := util.RandomName()
+	goodID := util.RandomInt(1, 1000)
+	supplierID := util.RandomInt(1, 1000)
+	arg := db.DeleteGoodSupplierTxParams{
+		GoodID:     goodID,
+		SupplierID: supplierID,
+		Actor:      actor,
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteGoodSupplierTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/goods/%d/suppliers/%d", goodID, supplierID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomSupplier() db.Supplier {
+	return db.Supplier{
+		ID:           util.RandomInt(1, 1000),
+		SupplierName: util.RandomName(),
+		ContactName:  util.RandomName(),
+		Email:        util.RandomEmail(),
+		Phone:        util.RandomString(10),
+		Address:      util.RandomName(),
+		LeadTimeDays: int32(util.RandomInt(0, 30)),
+		Currency:     util.RandomCurrency(),
+	}
+}
+
+func randomGoodSupplier(goodID, supplierID int64) db.GoodSupplier {
+	return db.GoodSupplier{
+		GoodID:      goodID,
+		SupplierID:  supplierID,
+		PartNumber:  util.RandomString(8),
+		UnitPrice:   util.RandomInt(0, 100000),
+		MinOrderQty: util.RandomInt(1, 100),
+	}
+}
+
+func requireBodyMatchSupplier(t *testing.T, body *bytes.Buffer, supplier db.Supplier) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotSupplier db.Supplier
+	err = json.Unmarshal(data, &gotSupplier)
+	require.NoError(t, err)
+	require.Equal(t, supplier, gotSupplier)
+}
+
+func requireBodyMatchGoodSupplier(t *testing.T, body *bytes.Buffer, goodSupplier db.GoodSupplier) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotGoodSupplier db.GoodSupplier
+	err = json.Unmarshal(data, &gotGoodSupplier)
+	require.NoError(t, err)
+	require.Equal(t, goodSupplier, gotGoodSupplier)
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type transferOrderResponse struct {
+	db.TransferOrder
+	Lines []db.TransferOrderLine `json:"lines"`
+}
+
+type transferOrderLineRequestJson struct {
+	GoodID int64 `json:"good_id" binding:"required,min=1"`
+	Amount int64 `json:"amount" binding:"required,gt=0"`
+	// unit the amount is given in, defaults to the unit of the good
+	AmountUnit     int64 `json:"amount_unit" binding:"omitempty,min=1"`
+	FromLocationID int64 `json:"from_location_id" binding:"omitempty,min=1"`
+	ToLocationID   int64 `json:"to_location_id" binding:"omitempty,min=1"`
+}
+
+type createTransferOrderRequest struct {
+	FromWarehouseID int64                          `json:"from_warehouse_id" binding:"required,min=1"`
+	ToWarehouseID   int64                          `json:"to_warehouse_id" binding:"required,min=1,nefield=FromWarehouseID"`
+	Reference       string                         `json:"reference"`
+	Lines           []transferOrderLineRequestJson `json:"lines" binding:"required,min=1,dive"`
+}
+
+func (server *Server) createTransferOrder(c *gin.Context) {
+	var req createTransferOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	lines := make([]db.TransferOrderLineParams, len(req.Lines))
+	for i, line := range req.Lines {
+		if !server.authorizeGood(c, line.GoodID) {
+			return
+		}
+		lines[i] = db.TransferOrderLineParams{
+			GoodID:     line.GoodID,
+			Amount:     line.Amount,
+			AmountUnit: line.AmountUnit,
+			FromLocationID: sql.NullInt64{
+				Int64: line.FromLocationID,
+				Valid: line.FromLocationID > 0,
+			},
+			ToLocationID: sql.NullInt64{
+				Int64: line.ToLocationID,
+				Valid: line.ToLocationID > 0,
+			},
+		}
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateTransferOrderTxParams{
+		CreateTransferOrderParams: db.CreateTransferOrderParams{
+			FromWarehouseID: req.FromWarehouseID,
+			ToWarehouseID:   req.ToWarehouseID,
+			Reference:       req.Reference,
+		},
+		Lines: lines,
+		Actor: authPayload.Username,
+	}
+
+	result, err := server.store.CreateTransferOrderTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrSameWarehouse) || errors.Is(err, db.ErrSerialMismatch) || isConversionError(err) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrderResponse{TransferOrder: result.TransferOrder, Lines: result.Lines})
+}
+
+type transferOrderRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getTransferOrder(c *gin.Context) {
+	var req transferOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	transferOrder, err := server.store.GetTransferOrder(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	lines, err := server.store.ListTransferOrderLines(c, req.ID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	goodIDs := make([]int64, len(lines))
+	for i, line := range lines {
+		goodIDs[i] = line.GoodID
+	}
+	if !server.authorizeGoods(c, goodIDs) {
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrderResponse{TransferOrder: transferOrder, Lines: lines})
+}
+
+type listTransferOrderRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft in_transit received cancelled"`
+	// orders leaving or arriving at the warehouse
+	WarehouseID int64 `form:"warehouse_id" binding:"omitempty,min=1"`
+	PageID      int32 `form:"page_id" binding:"required,min=1"`
+	PageSize    int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listTransferOrder(c *gin.Context) {
+	var req listTransferOrderRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTransferOrdersParams{
+		Status: sql.NullString{
+			String: req.Status,
+			Valid:  req.Status != "",
+		},
+		WarehouseID: sql.NullInt64{
+			Int64: req.WarehouseID,
+			Valid: req.WarehouseID > 0,
+		},
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	transferOrders, err := server.store.ListTransferOrders(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrders)
+}
+
+type transferOrderMovementResponse struct {
+	transferOrderResponse
+	Movements []db.StockMovement `json:"movements"`
+}
+
+func (server *Server) shipTransferOrder(c *gin.Context) {
+	server.moveTransferOrder(c, server.store.ShipTransferOrderTx)
+}
+
+func (server *Server) receiveTransferOrder(c *gin.Context) {
+	server.moveTransferOrder(c, server.store.ReceiveTransferOrderTx)
+}
+
+// moveTransferOrder runs one of the transactions moving the goods of a transfer order into or out of transit
+func (server *Server) moveTransferOrder(
+	c *gin.Context,
+	move func(ctx context.Context, arg db.TransferOrderStatusTxParams) (db.TransferOrderMovementTxResult, error),
+) {
+	var req transferOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeTransferOrderLines(c, req.ID) {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.TransferOrderStatusTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	result, err := move(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		// orders of serialized goods created before they were rejected cannot move
+		if errors.Is(err, db.ErrTransferOrderStatus) || errors.Is(err, db.ErrSerialMismatch) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidLocation) {
+			c.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) {
+			c.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrderMovementResponse{
+		transferOrderResponse: transferOrderResponse{TransferOrder: result.TransferOrder, Lines: result.Lines},
+		Movements:             result.Movements,
+	})
+}
+
+func (server *Server) cancelTransferOrder(c *gin.Context) {
+	var req transferOrderRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.TransferOrderStatusTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	transferOrder, err := server.store.CancelTransferOrderTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrTransferOrderStatus) {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, transferOrder)
+}
+
+// authorizeTransferOrderLines checks the scope of the user against the goods of all lines of a transfer order
+func (server *Server) authorizeTransferOrderLines(c *gin.Context, transferOrderID int64) bool {
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	if authPayload.Role == db.RoleAdmin || authPayload.Scope.IsEmpty() {
+		return true
+	}
+
+	lines, err := server.store.ListTransferOrderLines(c, transferOrderID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+
+	for _, line := range lines {
+		if !server.authorizeGood(c, line.GoodID) {
+			return false
+		}
+	}
+	return true
+}
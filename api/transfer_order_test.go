@@ -0,0 +1,651 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTransferOrder(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	transferOrder := randomTransferOrder(db.TransferOrderStatusDraft)
+	line := randomTransferOrderLine(transferOrder.ID, good)
+	line.ToLocationID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+
+	body := gin.H{
+		"from_warehouse_id": transferOrder.FromWarehouseID,
+		"to_warehouse_id":   transferOrder.ToWarehouseID,
+		"reference":         transferOrder.Reference,
+		"lines": []gin.H{
+			{
+				"good_id":        line.GoodID,
+				"amount":         line.Amount,
+				"to_location_id": line.ToLocationID.Int64,
+			},
+		},
+	}
+	arg := db.CreateTransferOrderTxParams{
+		CreateTransferOrderParams: db.CreateTransferOrderParams{
+			FromWarehouseID: transferOrder.FromWarehouseID,
+			ToWarehouseID:   transferOrder.ToWarehouseID,
+			Reference:       transferOrder.Reference,
+		},
+		Lines: []db.TransferOrderLineParams{
+			{
+				GoodID:       line.GoodID,
+				Amount:       line.Amount,
+				ToLocationID: line.ToLocationID,
+			},
+		},
+		Actor: actor,
+	}
+
+	testCases := []struct {
+		name          string
+		role          string
+		scope         token.Scope
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				result := db.TransferOrderTxResult{
+					TransferOrder: transferOrder,
+					Lines:         []db.TransferOrderLine{line},
+				}
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, transferOrder, got.TransferOrder)
+				require.Equal(t, []db.TransferOrderLine{line}, got.Lines)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			role: db.RoleClerk,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "GoodOutOfScope",
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			body:  body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "IncompatibleUnit",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderTxResult{}, db.ErrIncompatibleUnits)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Serialized",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: db.RoleWarehouseManager,
+			body: body,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "SameWarehouse",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"from_warehouse_id": transferOrder.FromWarehouseID,
+				"to_warehouse_id":   transferOrder.FromWarehouseID,
+				"lines":             body["lines"],
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoLines",
+			role: db.RoleWarehouseManager,
+			body: gin.H{
+				"from_warehouse_id": transferOrder.FromWarehouseID,
+				"to_warehouse_id":   transferOrder.ToWarehouseID,
+				"lines":             []gin.H{},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/transfer-orders", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetTransferOrder(t *testing.T) {
+	transferOrder := randomTransferOrder(db.TransferOrderStatusInTransit)
+	good := randomGood()
+	line := randomTransferOrderLine(transferOrder.ID, good)
+
+	testCases := []struct {
+		name          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferOrder(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return(transferOrder, nil)
+				store.EXPECT().ListTransferOrderLines(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return([]db.TransferOrderLine{line}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferOrderResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, transferOrder, got.TransferOrder)
+				require.Equal(t, []db.TransferOrderLine{line}, got.Lines)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			scope: token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferOrder(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return(transferOrder, nil)
+				store.EXPECT().ListTransferOrderLines(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return([]db.TransferOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferOrder(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return(db.TransferOrder{}, sql.ErrNoRows)
+				store.EXPECT().ListTransferOrderLines(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTransferOrder(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return(db.TransferOrder{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/transfer-orders/%d", transferOrder.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListTransferOrder(t *testing.T) {
+	n := 5
+	transferOrders := make([]db.TransferOrder, n)
+	for i := range transferOrders {
+		transferOrders[i] = randomTransferOrder(db.TransferOrderStatusInTransit)
+	}
+	warehouseID := util.RandomInt(1, 1000)
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=in_transit&warehouse_id=%d", n, warehouseID),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListTransferOrdersParams{
+					Status:      sql.NullString{String: db.TransferOrderStatusInTransit, Valid: true},
+					WarehouseID: sql.NullInt64{Int64: warehouseID, Valid: true},
+					Limit:       int32(n),
+					Offset:      0,
+				}
+				store.EXPECT().ListTransferOrders(gomock.Any(), gomock.Eq(arg)).Times(1).Return(transferOrders, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.TransferOrder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, transferOrders, got)
+			},
+		},
+		{
+			name:  "InvalidStatus",
+			query: fmt.Sprintf("page_id=1&page_size=%d&status=lost", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferOrders(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferOrders(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/transfer-orders?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestMoveTransferOrder(t *testing.T) {
+	actor := util.RandomName()
+	good := randomGood()
+	transferOrder := randomTransferOrder(db.TransferOrderStatusInTransit)
+	line := randomTransferOrderLine(transferOrder.ID, good)
+	arg := db.TransferOrderStatusTxParams{ID: transferOrder.ID, Actor: actor}
+	result := db.TransferOrderMovementTxResult{
+		TransferOrderTxResult: db.TransferOrderTxResult{
+			TransferOrder: transferOrder,
+			Lines:         []db.TransferOrderLine{line},
+		},
+		Movements: []db.StockMovement{randomStockMovement(good)},
+	}
+
+	testCases := []struct {
+		name          string
+		action        string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "ShipOK",
+			action: "ship",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got transferOrderMovementResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, transferOrder, got.TransferOrder)
+				require.Len(t, got.Lines, 1)
+				require.Len(t, got.Movements, 1)
+			},
+		},
+		{
+			name:   "ShipOutOfScope",
+			action: "ship",
+			role:   db.RoleClerk,
+			scope:  token.Scope{Categories: []int64{good.Category + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferOrderLines(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return([]db.TransferOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ShipTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ShipInsufficientStock",
+			action: "ship",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, db.ErrInsufficientStock)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+			},
+		},
+		{
+			name:   "ShipInvalidLocation",
+			action: "ship",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, db.ErrInvalidLocation)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ShipSerialized",
+			action: "ship",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ShipAuditorForbidden",
+			action: "ship",
+			role:   db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ShipTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ReceiveOK",
+			action: "receive",
+			role:   db.RoleClerk,
+			scope:  token.Scope{Categories: []int64{good.Category}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTransferOrderLines(gomock.Any(), gomock.Eq(transferOrder.ID)).Times(1).Return([]db.TransferOrderLine{line}, nil)
+				store.EXPECT().GetGoodIncludingDeleted(gomock.Any(), gomock.Eq(good.ID)).Times(1).Return(good, nil)
+				store.EXPECT().ReceiveTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ReceiveNotInTransit",
+			action: "receive",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceiveTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, db.ErrTransferOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ReceiveSerialized",
+			action: "receive",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceiveTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, db.ErrSerialMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "ReceiveNotFound",
+			action: "receive",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceiveTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ReceiveInternalError",
+			action: "receive",
+			role:   db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ReceiveTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrderMovementTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/transfer-orders/%d/%s", transferOrder.ID, tc.action)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCancelTransferOrder(t *testing.T) {
+	actor := util.RandomName()
+	transferOrder := randomTransferOrder(db.TransferOrderStatusCancelled)
+	arg := db.TransferOrderStatusTxParams{ID: transferOrder.ID, Actor: actor}
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(transferOrder, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.TransferOrder
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, transferOrder, got)
+			},
+		},
+		{
+			name: "ClerkForbidden",
+			role: db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelTransferOrderTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Shipped",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrder{}, db.ErrTransferOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			role: db.RoleWarehouseManager,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CancelTransferOrderTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.TransferOrder{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/transfer-orders/%d/cancel", transferOrder.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, tc.role, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomTransferOrder(status string) db.TransferOrder {
+	fromWarehouseID := util.RandomInt(1, 1000)
+	return db.TransferOrder{
+		ID:              util.RandomInt(1, 1000),
+		FromWarehouseID: fromWarehouseID,
+		ToWarehouseID:   fromWarehouseID + 1,
+		Status:          status,
+		Reference:       util.RandomString(8),
+	}
+}
+
+func randomTransferOrderLine(transferOrderID int64, good db.Good) db.TransferOrderLine {
+	return db.TransferOrderLine{
+		ID:              util.RandomInt(1, 1000),
+		TransferOrderID: transferOrderID,
+		GoodID:          good.ID,
+		Amount:          util.RandomInt(1, 100),
+	}
+}
@@ -2,15 +2,18 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	db "inventory_management/db/sqlc"
+	"inventory_management/token"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
 
 type createUnitRequest struct {
-	UnitName  string `json:"unit_name" binding:"required"`
-	UnitValue int64  `json:"unit_value" binding:"required"`
+	UnitName   string `json:"unit_name" binding:"required"`
+	UnitValue  int64  `json:"unit_value" binding:"required,min=1"`
+	UnitFamily string `json:"unit_family" binding:"required,oneof=count mass length volume"`
 }
 
 func (server *Server) createUnit(c *gin.Context) {
@@ -20,24 +23,73 @@ func (server *Server) createUnit(c *gin.Context) {
 		return
 	}
 
-	arg := db.CreateUnitParams{
-		UnitName:  req.UnitName,
-		UnitValue: req.UnitValue,
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateUnitTxParams{
+		CreateUnitParams: db.CreateUnitParams{
+			UnitName:   req.UnitName,
+			UnitValue:  req.UnitValue,
+			UnitFamily: req.UnitFamily,
+		},
+		Actor: authPayload.Username,
 	}
 
-	unit, err := server.store.CreateUnit(c, arg)
+	unit, err := server.store.CreateUnitTx(c, arg)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	setETag(c, unit.Version)
+	c.JSON(http.StatusOK, unit)
+}
+
+type getUnitRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getUnit(c *gin.Context) {
+	var req getUnitRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqDeleted includeDeletedRequest
+	if err := c.ShouldBindQuery(&reqDeleted); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !authorizeIncludeDeleted(c, reqDeleted.IncludeDeleted) {
+		return
+	}
+
+	var unit db.Unit
+	var err error
+	if reqDeleted.IncludeDeleted {
+		unit, err = server.store.GetUnitIncludingDeleted(c, req.ID)
+	} else {
+		unit, err = server.store.GetUnit(c, req.ID)
+	}
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, unit.Version)
 	c.JSON(http.StatusOK, unit)
 }
 
 type listUnitRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+	PageID         int32 `form:"page_id" binding:"required,min=1"`
+	PageSize       int32 `form:"page_size" binding:"required,min=5,max=10"`
+	IncludeDeleted bool  `form:"include_deleted"`
 }
 
 func (server *Server) listUnit(c *gin.Context) {
@@ -47,9 +99,14 @@ func (server *Server) listUnit(c *gin.Context) {
 		return
 	}
 
+	if !authorizeIncludeDeleted(c, req.IncludeDeleted) {
+		return
+	}
+
 	arg := db.ListUnitsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		IncludeDeleted: req.IncludeDeleted,
+		Limit:          req.PageSize,
+		Offset:         (req.PageID - 1) * req.PageSize,
 	}
 	units, err := server.store.ListUnits(c, arg)
 
@@ -66,8 +123,9 @@ type updateUnitRequest struct {
 }
 
 type updateUnitRequestJson struct {
-	UnitName  string `json:"unit_name" binding:"required"`
-	UnitValue int64 `json:"unit_value" binding:"required"`
+	UnitName   string `json:"unit_name" binding:"required"`
+	UnitValue  int64  `json:"unit_value" binding:"required,min=1"`
+	UnitFamily string `json:"unit_family" binding:"required,oneof=count mass length volume"`
 }
 
 func (server *Server) updateUnit(c *gin.Context) {
@@ -83,23 +141,43 @@ func (server *Server) updateUnit(c *gin.Context) {
 		return
 	}
 
-	arg := db.UpdateUnitParams{
-		ID:        req.ID,
-		UnitName:  reqUpdate.UnitName,
-		UnitValue: reqUpdate.UnitValue,
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateUnitTxParams{
+		UpdateUnitParams: db.UpdateUnitParams{
+			ID:         req.ID,
+			UnitName:   reqUpdate.UnitName,
+			UnitValue:  reqUpdate.UnitValue,
+			UnitFamily: reqUpdate.UnitFamily,
+			Version:    version,
+		},
+		Actor: authPayload.Username,
 	}
 
-	unit, err2 := server.store.UpdateUnit(c, arg)
+	unit, err2 := server.store.UpdateUnitTx(c, arg)
 
 	if err2 != nil {
 		if err2 == sql.ErrNoRows {
 			c.JSON(http.StatusNotFound, errorResponse(err2))
 			return
 		}
+		if errors.Is(err2, db.ErrVersionMismatch) {
+			c.JSON(http.StatusPreconditionFailed, errorResponse(err2))
+			return
+		}
+		if errors.Is(err2, db.ErrUnitInUse) {
+			c.JSON(http.StatusConflict, errorResponse(err2))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, errorResponse(err2))
 		return
 	}
 
+	setETag(c, unit.Version)
 	c.JSON(http.StatusOK, unit)
 }
 
@@ -114,7 +192,13 @@ func (server *Server) deleteUnit(c *gin.Context) {
 		return
 	}
 
-	err := server.store.DeleteUnit(c, req.ID)
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteUnitTx(c, arg)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -129,3 +213,42 @@ func (server *Server) deleteUnit(c *gin.Context) {
 		"message": "unit deleted successfuly",
 	})
 }
+
+type restoreUnitRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) restoreUnit(c *gin.Context) {
+	var req restoreUnitRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.RestoreTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	unit, err := server.store.RestoreUnitTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setETag(c, unit.Version)
+	c.JSON(http.StatusOK, unit)
+}
+
+// isConversionError reports whether an amount could not be converted into the unit of its good
+func isConversionError(err error) bool {
+	return errors.Is(err, db.ErrIncompatibleUnits) ||
+		errors.Is(err, db.ErrFractionalAmount) ||
+		errors.Is(err, db.ErrAmountOverflow)
+}
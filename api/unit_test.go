@@ -13,6 +13,7 @@ import (
 	"net/http/httptest"
 
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
@@ -20,6 +21,7 @@ import (
 )
 
 func TestCreateUnit(t *testing.T) {
+	actor := util.RandomName()
 	unit := randomUnit()
 
 	testCases := []struct {
@@ -31,15 +33,20 @@ func TestCreateUnit(t *testing.T) {
 		{
 			name: "OK",
 			body: gin.H{
-				"unit_name":  unit.UnitName,
-				"unit_value": unit.UnitValue,
+				"unit_name":   unit.UnitName,
+				"unit_value":  unit.UnitValue,
+				"unit_family": unit.UnitFamily,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.CreateUnitParams{
-					UnitName:  unit.UnitName,
-					UnitValue: unit.UnitValue,
+				arg := db.CreateUnitTxParams{
+					CreateUnitParams: db.CreateUnitParams{
+						UnitName:   unit.UnitName,
+						UnitValue:  unit.UnitValue,
+						UnitFamily: unit.UnitFamily,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().CreateUnit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(unit, nil)
+				store.EXPECT().CreateUnitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(unit, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
@@ -49,16 +56,31 @@ func TestCreateUnit(t *testing.T) {
 		{
 			name: "InternalError",
 			body: gin.H{
-				"unit_name":  unit.UnitName,
-				"unit_value": unit.UnitValue,
+				"unit_name":   unit.UnitName,
+				"unit_value":  unit.UnitValue,
+				"unit_family": unit.UnitFamily,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateUnit(gomock.Any(), gomock.Any()).Times(1).Return(db.Unit{}, sql.ErrConnDone)
+				store.EXPECT().CreateUnitTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Unit{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "InvalidUnitFamily",
+			body: gin.H{
+				"unit_name":   unit.UnitName,
+				"unit_value":  unit.UnitValue,
+				"unit_family": "time",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateUnitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidContext",
 			body: gin.H{
@@ -66,7 +88,7 @@ func TestCreateUnit(t *testing.T) {
 				"unit_value": "",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateUnit(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateUnitTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -84,7 +106,7 @@ func TestCreateUnit(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			// Marshal body data to JSON
@@ -95,6 +117,7 @@ func TestCreateUnit(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -103,6 +126,83 @@ func TestCreateUnit(t *testing.T) {
 
 }
 
+func TestGetUnit(t *testing.T) {
+	unit := randomUnit()
+
+	testCases := []struct {
+		name          string
+		unitID        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			unitID: unit.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUnit(gomock.Any(), gomock.Eq(unit.ID)).Times(1).Return(unit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUnitRequest(t, recorder.Body, unit)
+				require.Equal(t, fmt.Sprintf(`"%d"`, unit.Version), recorder.Header().Get("ETag"))
+			},
+		},
+		{
+			name:   "NotFound",
+			unitID: unit.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUnit(gomock.Any(), gomock.Eq(unit.ID)).Times(1).Return(db.Unit{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			unitID: unit.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUnit(gomock.Any(), gomock.Eq(unit.ID)).Times(1).Return(db.Unit{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			unitID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetUnit(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/units/%d", tc.unitID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func TestListUnit(t *testing.T) {
 
 	n := 5
@@ -193,7 +293,7 @@ func TestListUnit(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			url := "/units"
@@ -206,6 +306,7 @@ func TestListUnit(t *testing.T) {
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
 			request.URL.RawQuery = q.Encode()
 
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -214,103 +315,193 @@ func TestListUnit(t *testing.T) {
 }
 
 func TestUpdateUnit(t *testing.T) {
-
+	actor := util.RandomName()
 	unit := randomUnit()
 	unitUpdate := randomUnit()
 
+	ifMatch := fmt.Sprintf(`"%d"`, unit.Version)
+
 	testCases := []struct {
 		name          string
 		UnitID        int64
 		body          gin.H
+		ifMatch       string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:   "OK",
-			UnitID: unit.ID,
+			name:    "OK",
+			UnitID:  unit.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
-				"unit_name":  unitUpdate.UnitName,
-				"unit_value": unitUpdate.UnitValue,
+				"unit_name":   unitUpdate.UnitName,
+				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.UpdateUnitParams{
-					ID:        unit.ID,
-					UnitName:  unitUpdate.UnitName,
-					UnitValue: unitUpdate.UnitValue,
+				arg := db.UpdateUnitTxParams{
+					UpdateUnitParams: db.UpdateUnitParams{
+						ID:         unit.ID,
+						UnitName:   unitUpdate.UnitName,
+						UnitValue:  unitUpdate.UnitValue,
+						UnitFamily: unitUpdate.UnitFamily,
+						Version:    unit.Version,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().UpdateUnit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(unitUpdate, nil)
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(unitUpdate, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchUnitRequest(t, recorder.Body, unitUpdate)
+				require.Equal(t, fmt.Sprintf(`"%d"`, unitUpdate.Version), recorder.Header().Get("ETag"))
 			},
 		},
 		{
-			name:   "InternalError",
-			UnitID: unit.ID,
+			name:    "InternalError",
+			UnitID:  unit.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
-				"unit_name":  unitUpdate.UnitName,
-				"unit_value": unitUpdate.UnitValue,
+				"unit_name":   unitUpdate.UnitName,
+				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.UpdateUnitParams{
-					ID:        unit.ID,
-					UnitName:  unitUpdate.UnitName,
-					UnitValue: unitUpdate.UnitValue,
+				arg := db.UpdateUnitTxParams{
+					UpdateUnitParams: db.UpdateUnitParams{
+						ID:         unit.ID,
+						UnitName:   unitUpdate.UnitName,
+						UnitValue:  unitUpdate.UnitValue,
+						UnitFamily: unitUpdate.UnitFamily,
+						Version:    unit.Version,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().UpdateUnit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Unit{}, sql.ErrConnDone)
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Unit{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name:       "InvalidContext",
-			UnitID: unit.ID,
+			name:    "InvalidContext",
+			UnitID:  unit.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": "",
 				"section_name":  "",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:       "InvalidID",
-			UnitID: 0,
+			name:    "InvalidID",
+			UnitID:  0,
+			ifMatch: ifMatch,
 			body: gin.H{
 				"category_name": "",
 				"section_name":  "",
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().UpdateCategory(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().UpdateCategoryTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
-			name:       "NotFound",
-			UnitID: unit.ID,
+			name:    "NotFound",
+			UnitID:  unit.ID,
+			ifMatch: ifMatch,
 			body: gin.H{
-				"unit_name": unitUpdate.UnitName,
+				"unit_name":   unitUpdate.UnitName,
 				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				arg := db.UpdateUnitParams{
-					ID:          unit.ID,
-					UnitName:    unitUpdate.UnitName,
-					UnitValue: unitUpdate.UnitValue,
+				arg := db.UpdateUnitTxParams{
+					UpdateUnitParams: db.UpdateUnitParams{
+						ID:         unit.ID,
+						UnitName:   unitUpdate.UnitName,
+						UnitValue:  unitUpdate.UnitValue,
+						UnitFamily: unitUpdate.UnitFamily,
+						Version:    unit.Version,
+					},
+					Actor: actor,
 				}
-				store.EXPECT().UpdateUnit(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Unit{}, sql.ErrNoRows)
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Unit{}, sql.ErrNoRows)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
+		{
+			name:   "MissingIfMatch",
+			UnitID: unit.ID,
+			body: gin.H{
+				"unit_name":   unitUpdate.UnitName,
+				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionRequired, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidIfMatch",
+			UnitID: unit.ID,
+			body: gin.H{
+				"unit_name":   unitUpdate.UnitName,
+				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
+			},
+			ifMatch: "W/\"1\"",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "VersionMismatch",
+			UnitID: unit.ID,
+			body: gin.H{
+				"unit_name":   unitUpdate.UnitName,
+				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
+			},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Unit{}, db.ErrVersionMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusPreconditionFailed, recorder.Code)
+			},
+		},
+		{
+			name:   "UnitInUse",
+			UnitID: unit.ID,
+			body: gin.H{
+				"unit_name":   unitUpdate.UnitName,
+				"unit_value":  unitUpdate.UnitValue,
+				"unit_family": unitUpdate.UnitFamily,
+			},
+			ifMatch: ifMatch,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateUnitTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Unit{}, db.ErrUnitInUse)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -323,7 +514,7 @@ func TestUpdateUnit(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			// Marshal body data to JSON
@@ -334,6 +525,10 @@ func TestUpdateUnit(t *testing.T) {
 			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			if tc.ifMatch != "" {
+				request.Header.Set("If-Match", tc.ifMatch)
+			}
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(recorder)
 		})
@@ -343,49 +538,50 @@ func TestUpdateUnit(t *testing.T) {
 }
 
 func TestDeleteUnit(t *testing.T) {
+	actor := util.RandomName()
 	unit := randomUnit()
 
 	testCases := []struct {
 		name          string
-		unitID    int64
+		unitID        int64
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(T *testing.T, recorder *httptest.ResponseRecorder)
 	}{
 		{
-			name:       "OK",
+			name:   "OK",
 			unitID: unit.ID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteUnit(gomock.Any(), gomock.Eq(unit.ID)).Times(1).Return(nil)
+				store.EXPECT().DeleteUnitTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: unit.ID, Actor: actor})).Times(1).Return(nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
 		{
-			name:       "NotFound",
+			name:   "NotFound",
 			unitID: unit.ID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteUnit(gomock.Any(), gomock.Eq(unit.ID)).Times(1).Return(sql.ErrNoRows)
+				store.EXPECT().DeleteUnitTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: unit.ID, Actor: actor})).Times(1).Return(sql.ErrNoRows)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
 		{
-			name:       "InternalError",
+			name:   "InternalError",
 			unitID: unit.ID,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteUnit(gomock.Any(), gomock.Eq(unit.ID)).Times(1).Return(sql.ErrConnDone)
+				store.EXPECT().DeleteUnitTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: unit.ID, Actor: actor})).Times(1).Return(sql.ErrConnDone)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
 		{
-			name:       "InvalidID",
+			name:   "InvalidID",
 			unitID: 0,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().DeleteUnit(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteUnitTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -403,12 +599,13 @@ func TestDeleteUnit(t *testing.T) {
 			store := mockdb.NewMockStore(ctrl)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 			url := fmt.Sprintf("/units/%d", tc.unitID)
 			request, err := http.NewRequest(http.MethodDelete, url, nil)
 
 			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
 			server.router.ServeHTTP(recorder, request)
 			tc.checkResponse(t, recorder)
 		})
@@ -417,11 +614,83 @@ func TestDeleteUnit(t *testing.T) {
 
 }
 
+func TestRestoreUnit(t *testing.T) {
+	actor := util.RandomName()
+	unit := randomUnit()
+
+	testCases := []struct {
+		name          string
+		unitID        int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			unitID: unit.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RestoreTxParams{
+					ID:    unit.ID,
+					Actor: actor,
+				}
+				store.EXPECT().RestoreUnitTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(unit, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUnitRequest(t, recorder.Body, unit)
+			},
+		},
+		{
+			name:   "NotDeleted",
+			unitID: unit.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreUnitTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Unit{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			unitID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RestoreUnitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/units/%d/restore", tc.unitID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func randomUnit() db.Unit {
 	return db.Unit{
-		ID:        util.RandomInt(1, 1000),
-		UnitName:  util.RandomName(),
-		UnitValue: util.RandomInt(1, 8),
+		ID:         util.RandomInt(1, 1000),
+		UnitName:   util.RandomName(),
+		UnitValue:  util.RandomInt(1, 8),
+		UnitFamily: db.UnitFamilyCount,
+		Version:    util.RandomInt(1, 100),
 	}
 }
 
@@ -454,4 +723,5 @@ func requireBodyMatchUnitRequest(t *testing.T, body *bytes.Buffer, unit db.Unit)
 	require.NoError(t, err)
 	require.Equal(t, unit.UnitName, gotUnit.UnitName)
 	require.Equal(t, unit.UnitValue, gotUnit.UnitValue)
+	require.Equal(t, unit.UnitFamily, gotUnit.UnitFamily)
 }
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+var errInvalidCredentials = errors.New("incorrect username or password")
+
+type createUserRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Password string `json:"password" binding:"required,min=6"`
+	FullName string `json:"full_name" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+}
+
+// userResponse is a user without its hashed password
+type userResponse struct {
+	Username          string    `json:"username"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	Role              string    `json:"role"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func newUserResponse(user db.User) userResponse {
+	return userResponse{
+		Username:          user.Username,
+		FullName:          user.FullName,
+		Email:             user.Email,
+		Role:              user.Role,
+		PasswordChangedAt: user.PasswordChangedAt,
+		CreatedAt:         user.CreatedAt,
+	}
+}
+
+// createUser lets an admin add a user without permissions, the admin gives it a role with updateUserAccess.
+// The first admin is set up from the config when the server starts.
+func (server *Server) createUser(c *gin.Context) {
+	var req createUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	hashedPassword, err := util.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateUserTxParams{
+		CreateUserParams: db.CreateUserParams{
+			Username:       req.Username,
+			HashedPassword: hashedPassword,
+			FullName:       req.FullName,
+			Email:          req.Email,
+		},
+		Actor: authPayload.Username,
+	}
+
+	user, err := server.store.CreateUserTx(c, arg)
+
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			c.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, newUserResponse(user))
+}
+
+type loginUserRequest struct {
+	Username string `json:"username" binding:"required,alphanum"`
+	Password string `json:"password" binding:"required,min=6"`
+}
+
+type loginUserResponse struct {
+	AccessToken          string       `json:"access_token"`
+	AccessTokenExpiresAt time.Time    `json:"access_token_expires_at"`
+	User                 userResponse `json:"user"`
+}
+
+func (server *Server) loginUser(c *gin.Context) {
+	var req loginUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUser(c, req.Username)
+
+	if err != nil {
+		// unknown usernames fail like wrong passwords, so they do not tell which users exist
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = util.CheckPassword(req.Password, user.HashedPassword)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, errorResponse(errInvalidCredentials))
+		return
+	}
+
+	scopes, err := server.store.ListUserScopes(c, user.Username)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	accessToken, accessPayload, err := server.tokenMaker.CreateToken(
+		user.Username,
+		user.Role,
+		newTokenScope(scopes),
+		server.config.AccessTokenDuration,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := loginUserResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: accessPayload.ExpiredAt,
+		User:                 newUserResponse(user),
+	}
+	c.JSON(http.StatusOK, rsp)
+}
+
+// newTokenScope collects the scopes of a user into the scope of its access tokens
+func newTokenScope(scopes []db.UserScope) token.Scope {
+	var scope token.Scope
+	for _, userScope := range scopes {
+		if userScope.CategoryID.Valid {
+			scope.Categories = append(scope.Categories, userScope.CategoryID.Int64)
+		}
+		if userScope.SectionName.Valid {
+			scope.Sections = append(scope.Sections, userScope.SectionName.String)
+		}
+	}
+	return scope
+}
+
+type updateUserAccessRequest struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+type updateUserAccessRequestJson struct {
+	Role string `json:"role" binding:"required,oneof=admin warehouse_manager clerk auditor none"`
+	// categories and sections the user is restricted to, none gives access to every category
+	Categories []int64  `json:"categories" binding:"omitempty,dive,min=1"`
+	Sections   []string `json:"sections" binding:"omitempty,dive,required"`
+}
+
+type updateUserAccessResponse struct {
+	User   userResponse   `json:"user"`
+	Scopes []db.UserScope `json:"scopes"`
+}
+
+// updateUserAccess replaces the role and the scopes of a user, they apply from its next login
+func (server *Server) updateUserAccess(c *gin.Context) {
+	var req updateUserAccessRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqAccess updateUserAccessRequestJson
+	if err := c.ShouldBindJSON(&reqAccess); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateUserAccessTxParams{
+		Username:   req.Username,
+		Role:       reqAccess.Role,
+		Categories: reqAccess.Categories,
+		Sections:   reqAccess.Sections,
+		Actor:      authPayload.Username,
+	}
+
+	result, err := server.store.UpdateUserAccessTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := updateUserAccessResponse{
+		User:   newUserResponse(result.User),
+		Scopes: result.Scopes,
+	}
+	c.JSON(http.StatusOK, rsp)
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	db "inventory_management/db/sqlc"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidPeriod = errors.New("the period cannot end before it starts")
+
+type getValuationReportRequest struct {
+	// the period of the cost of goods issued, both days included
+	From     string `form:"from" binding:"required,datetime=2006-01-02"`
+	To       string `form:"to" binding:"required,datetime=2006-01-02"`
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=10"`
+	// required for scoped users
+	Category int64 `form:"category" binding:"omitempty,min=1"`
+}
+
+// valuationReport is the value of the stock by good and by category, in minor units of the inventory currency,
+// with the cost of the goods issued within the period. The goods are paged, the categories are not.
+type valuationReport struct {
+	From       string                         `json:"from"`
+	To         string                         `json:"to"`
+	Goods      []db.ListGoodValuationsRow     `json:"goods"`
+	Categories []db.ListCategoryValuationsRow `json:"categories"`
+}
+
+func (server *Server) getValuationReport(c *gin.Context) {
+	var req getValuationReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from := parseDate(req.From)
+	to := parseDate(req.To)
+	if to.Time.Before(from.Time) {
+		c.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+
+	if !authorizeUnfilteredList(c, req.Category > 0) {
+		return
+	}
+
+	if req.Category > 0 && !server.authorizeCategory(c, req.Category) {
+		return
+	}
+
+	category := sql.NullInt64{
+		Int64: req.Category,
+		Valid: req.Category > 0,
+	}
+	// the last day of the period is included
+	end := to.Time.AddDate(0, 0, 1)
+
+	goods, err := server.store.ListGoodValuations(c, db.ListGoodValuationsParams{
+		FromTime: from.Time,
+		ToTime:   end,
+		Category: category,
+		Limit:    req.PageSize,
+		Offset:   (req.PageID - 1) * req.PageSize,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	categories, err := server.store.ListCategoryValuations(c, db.ListCategoryValuationsParams{
+		FromTime: from.Time,
+		ToTime:   end,
+		Category: category,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, valuationReport{
+		From:       req.From,
+		To:         req.To,
+		Goods:      goods,
+		Categories: categories,
+	})
+}
+
+type listCostLayerRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type listCostLayerRequestPage struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listCostLayer(c *gin.Context) {
+	var req listCostLayerRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqPage listCostLayerRequestPage
+	if err := c.ShouldBindQuery(&reqPage); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !server.authorizeGood(c, req.ID) {
+		return
+	}
+
+	arg := db.ListCostLayersParams{
+		GoodID: req.ID,
+		Limit:  reqPage.PageSize,
+		Offset: (reqPage.PageID - 1) * reqPage.PageSize,
+	}
+	layers, err := server.store.ListCostLayers(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, layers)
+}
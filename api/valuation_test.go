@@ -0,0 +1,317 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"inventory_management/util"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetValuationReport(t *testing.T) {
+	category := randomCategory()
+	n := 5
+	goods := make([]db.ListGoodValuationsRow, n)
+	for i := range goods {
+		goods[i] = db.ListGoodValuationsRow{
+			GoodID:            util.RandomInt(1, 1000),
+			Model:             util.RandomName(),
+			Category:          category.ID,
+			CostingMethod:     category.CostingMethod,
+			Quantity:          util.RandomInt(1, 100),
+			Value:             util.RandomInt(0, 100000),
+			CostOfGoodsIssued: util.RandomInt(0, 100000),
+		}
+	}
+	categories := []db.ListCategoryValuationsRow{
+		{
+			Category:          category.ID,
+			CategoryName:      category.CategoryName,
+			CostingMethod:     category.CostingMethod,
+			Value:             util.RandomInt(0, 100000),
+			CostOfGoodsIssued: util.RandomInt(0, 100000),
+		},
+	}
+
+	from, _ := time.Parse(dateLayout, "2023-01-01")
+	end, _ := time.Parse(dateLayout, "2023-02-01")
+
+	testCases := []struct {
+		name          string
+		query         string
+		role          string
+		scope         token.Scope
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: fmt.Sprintf("from=2023-01-01&to=2023-01-31&page_id=1&page_size=%d", n),
+			role:  db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				goodsArg := db.ListGoodValuationsParams{
+					FromTime: from,
+					ToTime:   end,
+					Limit:    int32(n),
+				}
+				categoriesArg := db.ListCategoryValuationsParams{
+					FromTime: from,
+					ToTime:   end,
+				}
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Eq(goodsArg)).Times(1).Return(goods, nil)
+				store.EXPECT().ListCategoryValuations(gomock.Any(), gomock.Eq(categoriesArg)).Times(1).Return(categories, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got valuationReport
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, "2023-01-01", got.From)
+				require.Equal(t, "2023-01-31", got.To)
+				require.Equal(t, goods, got.Goods)
+				require.Equal(t, categories, got.Categories)
+			},
+		},
+		{
+			name:  "ScopedCategory",
+			query: fmt.Sprintf("from=2023-01-01&to=2023-01-31&page_id=2&page_size=%d&category=%d", n, category.ID),
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				goodsArg := db.ListGoodValuationsParams{
+					FromTime: from,
+					ToTime:   end,
+					Category: sql.NullInt64{Int64: category.ID, Valid: true},
+					Limit:    int32(n),
+					Offset:   int32(n),
+				}
+				categoriesArg := db.ListCategoryValuationsParams{
+					FromTime: from,
+					ToTime:   end,
+					Category: sql.NullInt64{Int64: category.ID, Valid: true},
+				}
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Eq(goodsArg)).Times(1).Return(goods, nil)
+				store.EXPECT().ListCategoryValuations(gomock.Any(), gomock.Eq(categoriesArg)).Times(1).Return(categories, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopedUnfiltered",
+			query: fmt.Sprintf("from=2023-01-01&to=2023-01-31&page_id=1&page_size=%d", n),
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{category.ID}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListCategoryValuations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "OutOfScope",
+			query: fmt.Sprintf("from=2023-01-01&to=2023-01-31&page_id=1&page_size=%d&category=%d", n, category.ID),
+			role:  db.RoleWarehouseManager,
+			scope: token.Scope{Categories: []int64{category.ID + 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListCategoryValuations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "ClerkForbidden",
+			query: fmt.Sprintf("from=2023-01-01&to=2023-01-31&page_id=1&page_size=%d", n),
+			role:  db.RoleClerk,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "PeriodEndsBeforeStart",
+			query: fmt.Sprintf("from=2023-01-31&to=2023-01-01&page_id=1&page_size=%d", n),
+			role:  db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidDate",
+			query: fmt.Sprintf("from=2023-01-01&to=31.01.2023&page_id=1&page_size=%d", n),
+			role:  db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingPeriod",
+			query: fmt.Sprintf("page_id=1&page_size=%d", n),
+			role:  db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: fmt.Sprintf("from=2023-01-01&to=2023-01-31&page_id=1&page_size=%d", n),
+			role:  db.RoleAuditor,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListGoodValuations(gomock.Any(), gomock.Any()).Times(1).Return(goods, nil)
+				store.EXPECT().ListCategoryValuations(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/reports/valuation?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), tc.role, tc.scope, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListCostLayer(t *testing.T) {
+	good := randomGood()
+	n := 5
+	layers := make([]db.CostLayer, n)
+	for i := range layers {
+		quantity := util.RandomInt(1, 100)
+		value := util.RandomInt(0, 100000)
+		layers[i] = db.CostLayer{
+			ID:              util.RandomInt(1, 1000),
+			GoodID:          good.ID,
+			StockMovementID: sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true},
+			Quantity:        quantity,
+			Remaining:       quantity,
+			Value:           value,
+			RemainingValue:  value,
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		goodID        int64
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			goodID: good.ID,
+			query:  fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListCostLayersParams{
+					GoodID: good.ID,
+					Limit:  int32(n),
+				}
+				store.EXPECT().ListCostLayers(gomock.Any(), gomock.Eq(arg)).Times(1).Return(layers, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.CostLayer
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, layers, got)
+			},
+		},
+		{
+			name:   "InvalidPageSize",
+			goodID: good.ID,
+			query:  "page_id=1&page_size=100",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCostLayers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			goodID: 0,
+			query:  fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCostLayers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			goodID: good.ID,
+			query:  fmt.Sprintf("page_id=1&page_size=%d", n),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListCostLayers(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/goods/%d/cost-layers?%s", tc.goodID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addRoleAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), db.RoleAuditor, token.Scope{}, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
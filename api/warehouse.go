@@ -0,0 +1,177 @@
+package api
+
+import (
+	"database/sql"
+	db "inventory_management/db/sqlc"
+	"inventory_management/token"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+type createWarehouseRequest struct {
+	WarehouseName string `json:"warehouse_name" binding:"required"`
+	Address       string `json:"address" binding:"required"`
+}
+
+func (server *Server) createWarehouse(c *gin.Context) {
+	var req createWarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.CreateWarehouseTxParams{
+		CreateWarehouseParams: db.CreateWarehouseParams{
+			WarehouseName: req.WarehouseName,
+			Address:       req.Address,
+		},
+		Actor: authPayload.Username,
+	}
+
+	warehouse, err := server.store.CreateWarehouseTx(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+type getWarehouseRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getWarehouse(c *gin.Context) {
+	var req getWarehouseRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	warehouse, err := server.store.GetWarehouse(c, req.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+type listWarehouseRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+func (server *Server) listWarehouse(c *gin.Context) {
+	var req listWarehouseRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListWarehousesParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	warehouses, err := server.store.ListWarehouses(c, arg)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouses)
+}
+
+type updateWarehouseRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type updateWarehouseRequestJson struct {
+	WarehouseName string `json:"warehouse_name" binding:"required"`
+	Address       string `json:"address" binding:"required"`
+}
+
+func (server *Server) updateWarehouse(c *gin.Context) {
+	var req updateWarehouseRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var reqUpdate updateWarehouseRequestJson
+	if err1 := c.ShouldBindJSON(&reqUpdate); err1 != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err1))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.UpdateWarehouseTxParams{
+		UpdateWarehouseParams: db.UpdateWarehouseParams{
+			ID:            req.ID,
+			WarehouseName: reqUpdate.WarehouseName,
+			Address:       reqUpdate.Address,
+		},
+		Actor: authPayload.Username,
+	}
+
+	warehouse, err2 := server.store.UpdateWarehouseTx(c, arg)
+
+	if err2 != nil {
+		if err2 == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err2))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err2))
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+type deleteWarehouseRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteWarehouse(c *gin.Context) {
+	var req deleteWarehouseRequest
+	if err := c.ShouldBindUri(&req); err != nil {
+		c.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := c.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := db.DeleteTxParams{
+		ID:    req.ID,
+		Actor: authPayload.Username,
+	}
+
+	err := server.store.DeleteWarehouseTx(c, arg)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		// the warehouse still has locations, balances, movements or orders
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			c.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "warehouse deleted successfuly",
+	})
+}
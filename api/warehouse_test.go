@@ -0,0 +1,576 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/util"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetWarehouse(t *testing.T) {
+	warehouse := randomWarehouse()
+
+	testCases := []struct {
+		name          string
+		warehouseID   int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(T *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Eq(warehouse.ID)).Times(1).Return(warehouse, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWarehouse(t, recorder.Body, warehouse)
+
+			},
+		},
+		{
+			name:        "NotFound",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Eq(warehouse.ID)).Times(1).Return(db.Warehouse{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Eq(warehouse.ID)).Times(1).Return(db.Warehouse{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidID",
+			warehouseID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetWarehouse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/warehouses/%d", tc.warehouseID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+
+	}
+
+}
+
+func TestCreateWarehouse(t *testing.T) {
+	actor := util.RandomName()
+	warehouse := randomWarehouse()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"warehouse_name": warehouse.WarehouseName,
+				"address":        warehouse.Address,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateWarehouseTxParams{
+					CreateWarehouseParams: db.CreateWarehouseParams{
+						WarehouseName: warehouse.WarehouseName,
+						Address:       warehouse.Address,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().CreateWarehouseTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(warehouse, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWarehouseRequest(t, recorder.Body, warehouse)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"warehouse_name": warehouse.WarehouseName,
+				"address":        warehouse.Address,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateWarehouseTxParams{
+					CreateWarehouseParams: db.CreateWarehouseParams{
+						WarehouseName: warehouse.WarehouseName,
+						Address:       warehouse.Address,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().CreateWarehouseTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Warehouse{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidContext",
+			body: gin.H{
+				"warehouse_name": "",
+				"address":        "",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateWarehouseTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := "/warehouses"
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestListWarehouse(t *testing.T) {
+
+	n := 6
+	warehouses := make([]db.Warehouse, n)
+
+	for i := 0; i < n; i++ {
+		warehouses[i] = randomWarehouse()
+	}
+
+	type Query struct {
+		pageID   int
+		pageSize int
+	}
+
+	testCases := []struct {
+		name          string
+		query         Query
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recoder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListWarehousesParams{
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().ListWarehouses(gomock.Any(), gomock.Eq(arg)).Times(1).Return(warehouses, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWarehouses(t, recorder.Body, warehouses)
+
+			},
+		},
+		{
+			name: "InternalError",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListWarehousesParams{
+					Limit:  int32(n),
+					Offset: 0,
+				}
+				store.EXPECT().ListWarehouses(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.Warehouse{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageID",
+			query: Query{
+				pageID:   -1,
+				pageSize: n,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWarehouses(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPageSize",
+			query: Query{
+				pageID:   1,
+				pageSize: 10000,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListWarehouses(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := "/warehouses"
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			// Add query parameters to request URL
+			q := request.URL.Query()
+			q.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
+			q.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			request.URL.RawQuery = q.Encode()
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, util.RandomName(), time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+
+}
+
+func TestUpdateWarehouse(t *testing.T) {
+	actor := util.RandomName()
+	warehouse := randomWarehouse()
+	warehouseUpdate := randomWarehouse()
+
+	testCases := []struct {
+		name          string
+		WarehouseID   int64
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			WarehouseID: warehouse.ID,
+			body: gin.H{
+				"warehouse_name": warehouseUpdate.WarehouseName,
+				"address":        warehouseUpdate.Address,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateWarehouseTxParams{
+					UpdateWarehouseParams: db.UpdateWarehouseParams{
+						ID:            warehouse.ID,
+						WarehouseName: warehouseUpdate.WarehouseName,
+						Address:       warehouseUpdate.Address,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().UpdateWarehouseTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(warehouseUpdate, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchWarehouseRequest(t, recorder.Body, warehouseUpdate)
+			},
+		},
+		{
+			name:        "InternalError",
+			WarehouseID: warehouse.ID,
+			body: gin.H{
+				"warehouse_name": warehouseUpdate.WarehouseName,
+				"address":        warehouseUpdate.Address,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateWarehouseTxParams{
+					UpdateWarehouseParams: db.UpdateWarehouseParams{
+						ID:            warehouse.ID,
+						WarehouseName: warehouseUpdate.WarehouseName,
+						Address:       warehouseUpdate.Address,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().UpdateWarehouseTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Warehouse{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidContext",
+			WarehouseID: warehouse.ID,
+			body: gin.H{
+				"warehouse_name": "",
+				"address":        "",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWarehouseTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidID",
+			WarehouseID: 0,
+			body: gin.H{
+				"warehouse_name": "",
+				"address":        "",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UpdateWarehouseTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			WarehouseID: warehouse.ID,
+			body: gin.H{
+				"warehouse_name": warehouseUpdate.WarehouseName,
+				"address":        warehouseUpdate.Address,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateWarehouseTxParams{
+					UpdateWarehouseParams: db.UpdateWarehouseParams{
+						ID:            warehouse.ID,
+						WarehouseName: warehouseUpdate.WarehouseName,
+						Address:       warehouseUpdate.Address,
+					},
+					Actor: actor,
+				}
+				store.EXPECT().UpdateWarehouseTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.Warehouse{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			// Marshal body data to JSON
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/warehouses/%d", tc.WarehouseID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+
+	}
+
+}
+
+func TestDeleteWarehouse(t *testing.T) {
+	actor := util.RandomName()
+	warehouse := randomWarehouse()
+
+	testCases := []struct {
+		name          string
+		warehouseID   int64
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(T *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWarehouseTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: warehouse.ID, Actor: actor})).Times(1).Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWarehouseTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: warehouse.ID, Actor: actor})).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "StillInUse",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWarehouseTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: warehouse.ID, Actor: actor})).Times(1).Return(&pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "InternalError",
+			warehouseID: warehouse.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWarehouseTx(gomock.Any(), gomock.Eq(db.DeleteTxParams{ID: warehouse.ID, Actor: actor})).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:        "InvalidID",
+			warehouseID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().DeleteWarehouseTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+			url := fmt.Sprintf("/warehouses/%d", tc.warehouseID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, actor, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+
+	}
+
+}
+
+func randomWarehouse() db.Warehouse {
+	return db.Warehouse{
+		ID:            util.RandomInt(1, 1000),
+		WarehouseName: util.RandomName(),
+		Address:       util.RandomName(),
+	}
+}
+
+func requireBodyMatchWarehouse(t *testing.T, body *bytes.Buffer, warehouse db.Warehouse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotWarehouse db.Warehouse
+	err = json.Unmarshal(data, &gotWarehouse)
+	require.NoError(t, err)
+	require.Equal(t, warehouse, gotWarehouse)
+}
+
+func requireBodyMatchWarehouses(t *testing.T, body *bytes.Buffer, warehouses []db.Warehouse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotWarehouses []db.Warehouse
+	err = json.Unmarshal(data, &gotWarehouses)
+	require.NoError(t, err)
+	require.Equal(t, warehouses, gotWarehouses)
+}
+
+func requireBodyMatchWarehouseRequest(t *testing.T, body *bytes.Buffer, warehouse db.Warehouse) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var gotWarehouse db.Warehouse
+	err = json.Unmarshal(data, &gotWarehouse)
+	require.NoError(t, err)
+	require.Equal(t, warehouse.WarehouseName, gotWarehouse.WarehouseName)
+	require.Equal(t, warehouse.Address, gotWarehouse.Address)
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS "goods" DROP CONSTRAINT IF EXISTS "goods_amount_check";
+DROP TABLE IF EXISTS stock_movements;
@@ -0,0 +1,21 @@
+CREATE TABLE "stock_movements" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "movement_type" varchar NOT NULL,
+  "amount" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "stock_movements" ("good_id");
+
+COMMENT ON COLUMN "stock_movements"."amount" IS 'positive for receipts, negative for issues';
+
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+-- negative amounts cannot be real stock, they are set to zero with an adjustment recorded in the ledger
+INSERT INTO "stock_movements" ("good_id", "movement_type", "amount")
+SELECT "id", 'adjustment', -"amount" FROM "goods" WHERE "amount" < 0;
+
+UPDATE "goods" SET "amount" = 0 WHERE "amount" < 0;
+
+ALTER TABLE "goods" ADD CONSTRAINT "goods_amount_check" CHECK ("amount" >= 0);
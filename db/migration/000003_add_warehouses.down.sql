@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS "stock_movements" DROP COLUMN IF EXISTS "warehouse_id";
+COMMENT ON COLUMN "goods"."amount" IS 'must be positive and bigger than zero';
+DROP TABLE IF EXISTS good_balances;
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,39 @@
+CREATE TABLE "warehouses" (
+  "id" bigserial PRIMARY KEY,
+  "warehouse_name" varchar NOT NULL,
+  "address" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "good_balances" (
+  "good_id" bigint NOT NULL,
+  "warehouse_id" bigint NOT NULL,
+  "amount" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("good_id", "warehouse_id")
+);
+
+CREATE INDEX ON "warehouses" ("warehouse_name");
+
+CREATE INDEX ON "good_balances" ("warehouse_id");
+
+COMMENT ON COLUMN "goods"."amount" IS 'total of the balances in all warehouses';
+
+ALTER TABLE "good_balances" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "good_balances" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "good_balances" ADD CONSTRAINT "good_balances_amount_check" CHECK ("amount" >= 0);
+
+-- existing stock is kept in a default warehouse
+INSERT INTO "warehouses" ("warehouse_name", "address") VALUES ('default', '');
+
+INSERT INTO "good_balances" ("good_id", "warehouse_id", "amount")
+SELECT "id", (SELECT min("id") FROM "warehouses"), "amount" FROM "goods";
+
+ALTER TABLE "stock_movements" ADD COLUMN "warehouse_id" bigint;
+
+UPDATE "stock_movements" SET "warehouse_id" = (SELECT min("id") FROM "warehouses");
+
+ALTER TABLE "stock_movements" ALTER COLUMN "warehouse_id" SET NOT NULL;
+
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
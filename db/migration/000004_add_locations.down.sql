@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS "stock_movements" DROP COLUMN IF EXISTS "location_id";
+DROP TABLE IF EXISTS bin_stocks;
+DROP TABLE IF EXISTS locations;
@@ -0,0 +1,41 @@
+CREATE TABLE "locations" (
+  "id" bigserial PRIMARY KEY,
+  "warehouse_id" bigint NOT NULL,
+  "parent_id" bigint,
+  "location_type" varchar NOT NULL,
+  "location_code" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "bin_stocks" (
+  "good_id" bigint NOT NULL,
+  "location_id" bigint NOT NULL,
+  "amount" bigint NOT NULL DEFAULT 0,
+  PRIMARY KEY ("good_id", "location_id")
+);
+
+CREATE INDEX ON "locations" ("parent_id");
+
+CREATE UNIQUE INDEX ON "locations" ("warehouse_id", "location_code");
+
+CREATE INDEX ON "bin_stocks" ("location_id");
+
+COMMENT ON COLUMN "locations"."location_type" IS 'zone, aisle, rack or bin';
+
+COMMENT ON COLUMN "locations"."parent_id" IS 'null for zones, which sit directly under the warehouse';
+
+ALTER TABLE "locations" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "locations" ADD FOREIGN KEY ("parent_id") REFERENCES "locations" ("id");
+
+ALTER TABLE "locations" ADD CONSTRAINT "locations_location_type_check" CHECK ("location_type" IN ('zone', 'aisle', 'rack', 'bin'));
+
+ALTER TABLE "bin_stocks" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "bin_stocks" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+
+ALTER TABLE "bin_stocks" ADD CONSTRAINT "bin_stocks_amount_check" CHECK ("amount" >= 0);
+
+ALTER TABLE "stock_movements" ADD COLUMN "location_id" bigint;
+
+ALTER TABLE "stock_movements" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
@@ -0,0 +1,5 @@
+COMMENT ON COLUMN "units"."unit_value" IS NULL;
+
+ALTER TABLE "units" DROP CONSTRAINT IF EXISTS "units_unit_value_check";
+
+ALTER TABLE "units" DROP COLUMN IF EXISTS "unit_family";
@@ -0,0 +1,11 @@
+ALTER TABLE "units" ADD COLUMN "unit_family" varchar NOT NULL DEFAULT 'count';
+
+ALTER TABLE "units" ADD CONSTRAINT "units_unit_family_check" CHECK ("unit_family" IN ('count', 'mass', 'length', 'volume'));
+
+UPDATE "units" SET "unit_value" = 1 WHERE "unit_value" < 1;
+
+ALTER TABLE "units" ADD CONSTRAINT "units_unit_value_check" CHECK ("unit_value" > 0);
+
+COMMENT ON COLUMN "units"."unit_value" IS 'how many of the smallest unit of the family one of this unit holds';
+
+COMMENT ON COLUMN "units"."unit_family" IS 'count, mass, length or volume, only units of the same family convert into each other';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "user_scopes";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "role";
@@ -0,0 +1,26 @@
+-- users created before roles existed may have registered themselves, they wait for an admin to give them a role
+ALTER TABLE "users" ADD COLUMN "role" varchar NOT NULL DEFAULT 'none';
+
+ALTER TABLE "users" ADD CONSTRAINT "users_role_check" CHECK ("role" IN ('admin', 'warehouse_manager', 'clerk', 'auditor', 'none'));
+
+CREATE TABLE "user_scopes" (
+  "id" bigserial PRIMARY KEY,
+  "username" varchar NOT NULL,
+  "category_id" bigint,
+  "section_name" varchar,
+  CONSTRAINT "user_scopes_target_check" CHECK (("category_id" IS NULL) <> ("section_name" IS NULL))
+);
+
+CREATE INDEX ON "user_scopes" ("username");
+
+COMMENT ON COLUMN "users"."role" IS 'admin, warehouse_manager, clerk, auditor or none, users with none have no permissions';
+
+COMMENT ON TABLE "user_scopes" IS 'a user without scopes may act on every category';
+
+COMMENT ON COLUMN "user_scopes"."category_id" IS 'set when the scope is a single category';
+
+COMMENT ON COLUMN "user_scopes"."section_name" IS 'set when the scope is every category of a section';
+
+ALTER TABLE "user_scopes" ADD FOREIGN KEY ("username") REFERENCES "users" ("username") ON DELETE CASCADE;
+
+ALTER TABLE "user_scopes" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id") ON DELETE CASCADE;
@@ -0,0 +1,37 @@
+CREATE TABLE "audit_log" (
+  "id" bigserial PRIMARY KEY,
+  "actor" varchar NOT NULL,
+  "action" varchar NOT NULL,
+  "entity_type" varchar NOT NULL,
+  "entity_id" varchar NOT NULL,
+  "before" jsonb NOT NULL DEFAULT 'null',
+  "after" jsonb NOT NULL DEFAULT 'null',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "audit_log_action_check" CHECK ("action" IN ('create', 'update', 'delete'))
+);
+
+CREATE INDEX ON "audit_log" ("entity_type", "entity_id");
+
+CREATE INDEX ON "audit_log" ("actor");
+
+CREATE INDEX ON "audit_log" ("created_at");
+
+COMMENT ON TABLE "audit_log" IS 'written in the transaction of every mutation, rows are never updated or deleted';
+
+COMMENT ON COLUMN "audit_log"."actor" IS 'username of the user who made the change, kept even when the user is gone';
+
+COMMENT ON COLUMN "audit_log"."action" IS 'create, update or delete';
+
+COMMENT ON COLUMN "audit_log"."before" IS 'snapshot of the entity before the change, null for a create';
+
+COMMENT ON COLUMN "audit_log"."after" IS 'snapshot of the entity after the change, null for a delete';
+
+CREATE FUNCTION audit_log_immutable() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "audit_log_immutable"
+BEFORE UPDATE OR DELETE ON "audit_log"
+FOR EACH ROW EXECUTE FUNCTION audit_log_immutable();
@@ -0,0 +1,13 @@
+ALTER TABLE "audit_log" DROP CONSTRAINT IF EXISTS "audit_log_action_check";
+
+ALTER TABLE "audit_log" ADD CONSTRAINT "audit_log_action_check" CHECK ("action" IN ('create', 'update', 'delete')) NOT VALID;
+
+COMMENT ON COLUMN "audit_log"."action" IS 'create, update or delete';
+
+COMMENT ON COLUMN "audit_log"."after" IS 'snapshot of the entity after the change, null for a delete';
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE "units" DROP COLUMN IF EXISTS "deleted_at";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "deleted_at";
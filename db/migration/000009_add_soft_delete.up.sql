@@ -0,0 +1,19 @@
+ALTER TABLE "categories" ADD COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE "units" ADD COLUMN "deleted_at" timestamptz;
+
+ALTER TABLE "goods" ADD COLUMN "deleted_at" timestamptz;
+
+COMMENT ON COLUMN "categories"."deleted_at" IS 'set when the category is deleted, deleted categories are hidden unless asked for';
+
+COMMENT ON COLUMN "units"."deleted_at" IS 'set when the unit is deleted, deleted units are hidden unless asked for';
+
+COMMENT ON COLUMN "goods"."deleted_at" IS 'set when the good is deleted, deleted goods are hidden unless asked for';
+
+ALTER TABLE "audit_log" DROP CONSTRAINT "audit_log_action_check";
+
+ALTER TABLE "audit_log" ADD CONSTRAINT "audit_log_action_check" CHECK ("action" IN ('create', 'update', 'delete', 'restore'));
+
+COMMENT ON COLUMN "audit_log"."action" IS 'create, update, delete or restore';
+
+COMMENT ON COLUMN "audit_log"."after" IS 'snapshot of the entity after the change, null for a hard delete';
@@ -0,0 +1,5 @@
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE "units" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "version";
@@ -0,0 +1,11 @@
+ALTER TABLE "categories" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "units" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "goods" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN "categories"."version" IS 'incremented by every change of the category, compared against If-Match on updates';
+
+COMMENT ON COLUMN "units"."version" IS 'incremented by every change of the unit, compared against If-Match on updates';
+
+COMMENT ON COLUMN "goods"."version" IS 'incremented by every change of the good, compared against If-Match on updates';
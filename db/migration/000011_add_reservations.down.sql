@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "reservations";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "reserved";
@@ -0,0 +1,32 @@
+CREATE TABLE "reservations" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'active',
+  "reference" varchar NOT NULL DEFAULT '',
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz,
+  CONSTRAINT "reservations_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "reservations_status_check" CHECK ("status" IN ('active', 'released', 'expired'))
+);
+
+CREATE INDEX ON "reservations" ("good_id", "status");
+
+CREATE INDEX ON "reservations" ("expires_at") WHERE "status" = 'active';
+
+COMMENT ON COLUMN "reservations"."amount" IS 'in the unit of the good';
+
+COMMENT ON COLUMN "reservations"."status" IS 'active, released or expired, only active reservations hold stock';
+
+COMMENT ON COLUMN "reservations"."reference" IS 'what the stock is held for, like the number of a sales order';
+
+COMMENT ON COLUMN "reservations"."closed_at" IS 'set when the reservation is released or expired';
+
+ALTER TABLE "reservations" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "goods" ADD COLUMN "reserved" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "goods" ADD CONSTRAINT "goods_reserved_check" CHECK ("reserved" >= 0);
+
+COMMENT ON COLUMN "goods"."reserved" IS 'total of the active reservations, available is amount minus reserved';
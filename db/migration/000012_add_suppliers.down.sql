@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "good_suppliers";
+
+DROP TABLE IF EXISTS "suppliers";
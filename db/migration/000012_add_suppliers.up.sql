@@ -0,0 +1,42 @@
+CREATE TABLE "suppliers" (
+  "id" bigserial PRIMARY KEY,
+  "supplier_name" varchar NOT NULL,
+  "contact_name" varchar NOT NULL DEFAULT '',
+  "email" varchar NOT NULL DEFAULT '',
+  "phone" varchar NOT NULL DEFAULT '',
+  "address" varchar NOT NULL DEFAULT '',
+  "lead_time_days" integer NOT NULL DEFAULT 0,
+  "currency" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "suppliers_lead_time_days_check" CHECK ("lead_time_days" >= 0)
+);
+
+CREATE TABLE "good_suppliers" (
+  "good_id" bigint NOT NULL,
+  "supplier_id" bigint NOT NULL,
+  "part_number" varchar NOT NULL,
+  "unit_price" bigint NOT NULL,
+  "min_order_qty" bigint NOT NULL DEFAULT 1,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("good_id", "supplier_id"),
+  CONSTRAINT "good_suppliers_unit_price_check" CHECK ("unit_price" >= 0),
+  CONSTRAINT "good_suppliers_min_order_qty_check" CHECK ("min_order_qty" > 0)
+);
+
+CREATE INDEX ON "suppliers" ("supplier_name");
+
+CREATE UNIQUE INDEX ON "good_suppliers" ("supplier_id", "part_number");
+
+COMMENT ON COLUMN "suppliers"."lead_time_days" IS 'days from sending an order to its delivery';
+
+COMMENT ON COLUMN "suppliers"."currency" IS 'ISO 4217 code the supplier invoices in';
+
+COMMENT ON COLUMN "good_suppliers"."part_number" IS 'number the supplier sells the good under';
+
+COMMENT ON COLUMN "good_suppliers"."unit_price" IS 'price of one unit of the good in minor units of the currency of the supplier';
+
+COMMENT ON COLUMN "good_suppliers"."min_order_qty" IS 'in the unit of the good';
+
+ALTER TABLE "good_suppliers" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "good_suppliers" ADD FOREIGN KEY ("supplier_id") REFERENCES "suppliers" ("id");
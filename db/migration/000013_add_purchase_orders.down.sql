@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "purchase_order_lines";
+
+DROP TABLE IF EXISTS "purchase_orders";
@@ -0,0 +1,55 @@
+CREATE TABLE "purchase_orders" (
+  "id" bigserial PRIMARY KEY,
+  "supplier_id" bigint NOT NULL,
+  "warehouse_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'draft',
+  "reference" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "sent_at" timestamptz,
+  "closed_at" timestamptz,
+  CONSTRAINT "purchase_orders_status_check" CHECK ("status" IN ('draft', 'sent', 'partially_received', 'closed'))
+);
+
+CREATE TABLE "purchase_order_lines" (
+  "id" bigserial PRIMARY KEY,
+  "purchase_order_id" bigint NOT NULL,
+  "good_id" bigint NOT NULL,
+  "unit_id" bigint NOT NULL,
+  "ordered" bigint NOT NULL,
+  "received" bigint NOT NULL DEFAULT 0,
+  "unit_price" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "purchase_order_lines_ordered_check" CHECK ("ordered" > 0),
+  CONSTRAINT "purchase_order_lines_received_check" CHECK ("received" >= 0),
+  CONSTRAINT "purchase_order_lines_unit_price_check" CHECK ("unit_price" >= 0)
+);
+
+CREATE INDEX ON "purchase_orders" ("supplier_id", "status");
+
+CREATE INDEX ON "purchase_orders" ("status");
+
+CREATE INDEX ON "purchase_order_lines" ("purchase_order_id");
+
+CREATE INDEX ON "purchase_order_lines" ("good_id");
+
+COMMENT ON COLUMN "purchase_orders"."warehouse_id" IS 'warehouse the goods are delivered to';
+
+COMMENT ON COLUMN "purchase_orders"."status" IS 'draft, sent, partially_received or closed';
+
+COMMENT ON COLUMN "purchase_order_lines"."unit_id" IS 'unit the line is ordered and received in';
+
+COMMENT ON COLUMN "purchase_order_lines"."ordered" IS 'in the unit of the line';
+
+COMMENT ON COLUMN "purchase_order_lines"."received" IS 'in the unit of the line, more than ordered when over-delivered';
+
+COMMENT ON COLUMN "purchase_order_lines"."unit_price" IS 'price of one unit of the line in minor units of the currency of the supplier';
+
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("supplier_id") REFERENCES "suppliers" ("id");
+
+ALTER TABLE "purchase_orders" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "purchase_order_lines" ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_orders" ("id");
+
+ALTER TABLE "purchase_order_lines" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "purchase_order_lines" ADD FOREIGN KEY ("unit_id") REFERENCES "units" ("id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "sales_order_lines";
+
+DROP TABLE IF EXISTS "sales_orders";
@@ -0,0 +1,41 @@
+CREATE TABLE "sales_orders" (
+  "id" bigserial PRIMARY KEY,
+  "customer_name" varchar NOT NULL,
+  "warehouse_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "reference" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "shipped_at" timestamptz,
+  CONSTRAINT "sales_orders_status_check" CHECK ("status" IN ('open', 'allocated', 'shipped', 'cancelled'))
+);
+
+CREATE TABLE "sales_order_lines" (
+  "id" bigserial PRIMARY KEY,
+  "sales_order_id" bigint NOT NULL,
+  "good_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "allocated" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "sales_order_lines_amount_check" CHECK ("amount" > 0),
+  CONSTRAINT "sales_order_lines_allocated_check" CHECK ("allocated" >= 0 AND "allocated" <= "amount")
+);
+
+CREATE INDEX ON "sales_orders" ("status");
+
+CREATE INDEX ON "sales_order_lines" ("sales_order_id");
+
+CREATE INDEX ON "sales_order_lines" ("good_id");
+
+COMMENT ON COLUMN "sales_orders"."warehouse_id" IS 'warehouse the order is shipped from';
+
+COMMENT ON COLUMN "sales_orders"."status" IS 'open, allocated, shipped or cancelled';
+
+COMMENT ON COLUMN "sales_order_lines"."amount" IS 'in the unit of the good';
+
+COMMENT ON COLUMN "sales_order_lines"."allocated" IS 'part of the amount held in the reserved total of the good until the order ships';
+
+ALTER TABLE "sales_orders" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "sales_order_lines" ADD FOREIGN KEY ("sales_order_id") REFERENCES "sales_orders" ("id");
+
+ALTER TABLE "sales_order_lines" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "transfer_order_lines";
+
+DROP TABLE IF EXISTS "transfer_orders";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "in_transit";
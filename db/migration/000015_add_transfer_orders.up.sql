@@ -0,0 +1,59 @@
+CREATE TABLE "transfer_orders" (
+  "id" bigserial PRIMARY KEY,
+  "from_warehouse_id" bigint NOT NULL,
+  "to_warehouse_id" bigint NOT NULL,
+  "status" varchar NOT NULL DEFAULT 'draft',
+  "reference" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "shipped_at" timestamptz,
+  "received_at" timestamptz,
+  CONSTRAINT "transfer_orders_status_check" CHECK ("status" IN ('draft', 'in_transit', 'received', 'cancelled')),
+  CONSTRAINT "transfer_orders_warehouses_check" CHECK ("from_warehouse_id" <> "to_warehouse_id")
+);
+
+CREATE TABLE "transfer_order_lines" (
+  "id" bigserial PRIMARY KEY,
+  "transfer_order_id" bigint NOT NULL,
+  "good_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  "from_location_id" bigint,
+  "to_location_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "transfer_order_lines_amount_check" CHECK ("amount" > 0)
+);
+
+CREATE INDEX ON "transfer_orders" ("status");
+
+CREATE INDEX ON "transfer_orders" ("from_warehouse_id");
+
+CREATE INDEX ON "transfer_orders" ("to_warehouse_id");
+
+CREATE INDEX ON "transfer_order_lines" ("transfer_order_id");
+
+CREATE INDEX ON "transfer_order_lines" ("good_id");
+
+COMMENT ON COLUMN "transfer_orders"."status" IS 'draft, in_transit, received or cancelled';
+
+COMMENT ON COLUMN "transfer_order_lines"."amount" IS 'in the unit of the good';
+
+COMMENT ON COLUMN "transfer_order_lines"."from_location_id" IS 'optional bin of the source warehouse the goods are taken from';
+
+COMMENT ON COLUMN "transfer_order_lines"."to_location_id" IS 'optional bin of the destination warehouse the goods are put into';
+
+ALTER TABLE "transfer_orders" ADD FOREIGN KEY ("from_warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "transfer_orders" ADD FOREIGN KEY ("to_warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "transfer_order_lines" ADD FOREIGN KEY ("transfer_order_id") REFERENCES "transfer_orders" ("id");
+
+ALTER TABLE "transfer_order_lines" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "transfer_order_lines" ADD FOREIGN KEY ("from_location_id") REFERENCES "locations" ("id");
+
+ALTER TABLE "transfer_order_lines" ADD FOREIGN KEY ("to_location_id") REFERENCES "locations" ("id");
+
+ALTER TABLE "goods" ADD COLUMN "in_transit" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "goods" ADD CONSTRAINT "goods_in_transit_check" CHECK ("in_transit" >= 0);
+
+COMMENT ON COLUMN "goods"."in_transit" IS 'shipped by transfer orders and not yet received, not part of amount';
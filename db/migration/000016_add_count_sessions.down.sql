@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "count_session_items";
+
+DROP TABLE IF EXISTS "count_sessions";
@@ -0,0 +1,54 @@
+CREATE TABLE "count_sessions" (
+  "id" bigserial PRIMARY KEY,
+  "warehouse_id" bigint NOT NULL,
+  "category_id" bigint,
+  "section_name" varchar,
+  "location_id" bigint,
+  "status" varchar NOT NULL DEFAULT 'open',
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "closed_at" timestamptz,
+  CONSTRAINT "count_sessions_status_check" CHECK ("status" IN ('open', 'approved', 'cancelled')),
+  CONSTRAINT "count_sessions_scope_check" CHECK (num_nonnulls("category_id", "section_name", "location_id") = 1)
+);
+
+CREATE TABLE "count_session_items" (
+  "id" bigserial PRIMARY KEY,
+  "count_session_id" bigint NOT NULL,
+  "good_id" bigint NOT NULL,
+  "location_id" bigint,
+  "snapshot" bigint NOT NULL,
+  "counted" bigint,
+  "counted_by" varchar NOT NULL DEFAULT '',
+  "counted_at" timestamptz,
+  CONSTRAINT "count_session_items_counted_check" CHECK ("counted" >= 0)
+);
+
+CREATE INDEX ON "count_sessions" ("warehouse_id", "status");
+
+CREATE UNIQUE INDEX ON "count_session_items" ("count_session_id", "good_id", "location_id") NULLS NOT DISTINCT;
+
+COMMENT ON COLUMN "count_sessions"."category_id" IS 'set when the session counts the goods of a category';
+
+COMMENT ON COLUMN "count_sessions"."section_name" IS 'set when the session counts the goods of a section';
+
+COMMENT ON COLUMN "count_sessions"."location_id" IS 'set when the session counts the bins below a location';
+
+COMMENT ON COLUMN "count_sessions"."status" IS 'open, approved or cancelled, counts are entered while the session is open';
+
+COMMENT ON COLUMN "count_session_items"."location_id" IS 'bin the good is counted in, null for the stock of the warehouse outside its bins';
+
+COMMENT ON COLUMN "count_session_items"."snapshot" IS 'stock when the session started, in the unit of the good';
+
+COMMENT ON COLUMN "count_session_items"."counted" IS 'null until the item is counted, in the unit of the good';
+
+ALTER TABLE "count_sessions" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "count_sessions" ADD FOREIGN KEY ("category_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "count_sessions" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
+
+ALTER TABLE "count_session_items" ADD FOREIGN KEY ("count_session_id") REFERENCES "count_sessions" ("id");
+
+ALTER TABLE "count_session_items" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "count_session_items" ADD FOREIGN KEY ("location_id") REFERENCES "locations" ("id");
@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS "goods_notify_low_stock" ON "goods";
+
+DROP FUNCTION IF EXISTS "notify_low_stock";
+
+DROP TABLE IF EXISTS "stock_alerts";
+
+ALTER TABLE "goods" DROP CONSTRAINT IF EXISTS "goods_stock_levels_check";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "reorder_point";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "safety_stock";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "max_level";
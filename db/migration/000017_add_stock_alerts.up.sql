@@ -0,0 +1,56 @@
+ALTER TABLE "goods" ADD COLUMN "reorder_point" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "goods" ADD COLUMN "safety_stock" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "goods" ADD COLUMN "max_level" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "goods" ADD CONSTRAINT "goods_stock_levels_check" CHECK (
+  0 <= "safety_stock" AND "safety_stock" <= "reorder_point" AND "reorder_point" <= "max_level"
+);
+
+COMMENT ON COLUMN "goods"."reorder_point" IS 'the good is low on stock once its available stock falls below it, zero disables the alerts';
+
+COMMENT ON COLUMN "goods"."safety_stock" IS 'stock kept against uncertainty, alerts below it are urgent';
+
+COMMENT ON COLUMN "goods"."max_level" IS 'available stock the suggested orders refill the good up to';
+
+CREATE TABLE "stock_alerts" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "available" bigint NOT NULL,
+  "reorder_point" bigint NOT NULL,
+  "below_safety_stock" boolean NOT NULL DEFAULT false,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "resolved_at" timestamptz
+);
+
+CREATE INDEX ON "stock_alerts" ("good_id");
+
+-- a good has at most one open alert
+CREATE UNIQUE INDEX ON "stock_alerts" ("good_id") WHERE "resolved_at" IS NULL;
+
+COMMENT ON COLUMN "stock_alerts"."available" IS 'available stock of the good when the alert was raised';
+
+COMMENT ON COLUMN "stock_alerts"."resolved_at" IS 'set once the available stock is back at the reorder point, or the good is deleted';
+
+ALTER TABLE "stock_alerts" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+-- tells the low stock checker of the server about every good that falls below or comes back to its reorder point,
+-- the notification is delivered once the transaction commits
+CREATE FUNCTION "notify_low_stock"() RETURNS trigger AS $$
+DECLARE
+  "was_low" boolean := false;
+  "is_low" boolean := NEW."deleted_at" IS NULL AND NEW."amount" - NEW."reserved" < NEW."reorder_point";
+BEGIN
+  IF TG_OP = 'UPDATE' THEN
+    "was_low" := OLD."deleted_at" IS NULL AND OLD."amount" - OLD."reserved" < OLD."reorder_point";
+  END IF;
+  IF "is_low" <> "was_low" THEN
+    PERFORM pg_notify('low_stock', NEW."id"::text);
+  END IF;
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "goods_notify_low_stock" AFTER INSERT OR UPDATE ON "goods"
+FOR EACH ROW EXECUTE FUNCTION "notify_low_stock"();
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "lot_movements";
+
+DROP TABLE IF EXISTS "lot_stocks";
+
+DROP TABLE IF EXISTS "lots";
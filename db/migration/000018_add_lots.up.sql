@@ -0,0 +1,48 @@
+CREATE TABLE "lots" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "lot_number" varchar NOT NULL,
+  "manufactured_on" date,
+  "expires_on" date,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "lots_dates_check" CHECK ("expires_on" >= "manufactured_on")
+);
+
+CREATE TABLE "lot_stocks" (
+  "lot_id" bigint NOT NULL,
+  "warehouse_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  PRIMARY KEY ("lot_id", "warehouse_id"),
+  CONSTRAINT "lot_stocks_amount_check" CHECK ("amount" >= 0)
+);
+
+CREATE TABLE "lot_movements" (
+  "stock_movement_id" bigint NOT NULL,
+  "lot_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  PRIMARY KEY ("stock_movement_id", "lot_id")
+);
+
+CREATE UNIQUE INDEX ON "lots" ("good_id", "lot_number");
+
+CREATE INDEX ON "lots" ("expires_on");
+
+CREATE INDEX ON "lot_stocks" ("warehouse_id");
+
+CREATE INDEX ON "lot_movements" ("lot_id");
+
+COMMENT ON COLUMN "lots"."expires_on" IS 'lots without an expiry date are issued after the ones that expire';
+
+COMMENT ON COLUMN "lot_stocks"."amount" IS 'part of the balance of the good in the warehouse, in the unit of the good';
+
+COMMENT ON COLUMN "lot_movements"."amount" IS 'part of the amount of the stock movement booked against the lot';
+
+ALTER TABLE "lots" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "lot_stocks" ADD FOREIGN KEY ("lot_id") REFERENCES "lots" ("id");
+
+ALTER TABLE "lot_stocks" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "lot_movements" ADD FOREIGN KEY ("stock_movement_id") REFERENCES "stock_movements" ("id");
+
+ALTER TABLE "lot_movements" ADD FOREIGN KEY ("lot_id") REFERENCES "lots" ("id");
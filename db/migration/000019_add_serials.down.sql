@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "serial_events";
+
+DROP TABLE IF EXISTS "serials";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "serialized";
@@ -0,0 +1,59 @@
+ALTER TABLE "goods" ADD COLUMN "serialized" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "serials" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "serial_number" varchar NOT NULL,
+  "status" varchar NOT NULL,
+  "warehouse_id" bigint,
+  "reservation_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "serials_status_check" CHECK ("status" IN ('in_stock', 'reserved', 'shipped', 'returned')),
+  CONSTRAINT "serials_warehouse_check" CHECK (("status" = 'shipped') = ("warehouse_id" IS NULL)),
+  CONSTRAINT "serials_reservation_check" CHECK (("status" = 'reserved') = ("reservation_id" IS NOT NULL))
+);
+
+CREATE TABLE "serial_events" (
+  "id" bigserial PRIMARY KEY,
+  "serial_id" bigint NOT NULL,
+  "status" varchar NOT NULL,
+  "warehouse_id" bigint,
+  "stock_movement_id" bigint,
+  "reservation_id" bigint,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE UNIQUE INDEX ON "serials" ("serial_number");
+
+CREATE INDEX ON "serials" ("good_id", "status");
+
+CREATE INDEX ON "serials" ("reservation_id");
+
+CREATE INDEX ON "serial_events" ("serial_id");
+
+COMMENT ON COLUMN "goods"."serialized" IS 'units of serialized goods are tracked one by one by their serial number';
+
+COMMENT ON COLUMN "serials"."status" IS 'in_stock, reserved, shipped or returned';
+
+COMMENT ON COLUMN "serials"."warehouse_id" IS 'warehouse holding the unit, null once it has left the stock';
+
+COMMENT ON COLUMN "serials"."reservation_id" IS 'reservation holding the unit while it is reserved';
+
+COMMENT ON COLUMN "serial_events"."status" IS 'status of the serial after the event';
+
+COMMENT ON COLUMN "serial_events"."stock_movement_id" IS 'movement that moved the unit, null for reservations';
+
+ALTER TABLE "serials" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "serials" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "serials" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id");
+
+ALTER TABLE "serial_events" ADD FOREIGN KEY ("serial_id") REFERENCES "serials" ("id");
+
+ALTER TABLE "serial_events" ADD FOREIGN KEY ("warehouse_id") REFERENCES "warehouses" ("id");
+
+ALTER TABLE "serial_events" ADD FOREIGN KEY ("stock_movement_id") REFERENCES "stock_movements" ("id");
+
+ALTER TABLE "serial_events" ADD FOREIGN KEY ("reservation_id") REFERENCES "reservations" ("id");
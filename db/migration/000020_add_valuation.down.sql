@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "cost_layers";
+
+ALTER TABLE "stock_movements" DROP COLUMN IF EXISTS "value";
+
+ALTER TABLE "goods" DROP CONSTRAINT IF EXISTS "goods_stock_value_check";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "stock_value";
+
+ALTER TABLE "categories" DROP CONSTRAINT IF EXISTS "categories_costing_method_check";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "costing_method";
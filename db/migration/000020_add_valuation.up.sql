@@ -0,0 +1,51 @@
+ALTER TABLE "categories" ADD COLUMN "costing_method" varchar NOT NULL DEFAULT 'fifo';
+
+ALTER TABLE "categories" ADD CONSTRAINT "categories_costing_method_check" CHECK ("costing_method" IN ('fifo', 'average'));
+
+ALTER TABLE "goods" ADD COLUMN "stock_value" bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE "goods" ADD CONSTRAINT "goods_stock_value_check" CHECK ("stock_value" >= 0);
+
+ALTER TABLE "stock_movements" ADD COLUMN "value" bigint NOT NULL DEFAULT 0;
+
+CREATE TABLE "cost_layers" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "stock_movement_id" bigint,
+  "quantity" bigint NOT NULL,
+  "remaining" bigint NOT NULL,
+  "value" bigint NOT NULL,
+  "remaining_value" bigint NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "cost_layers_remaining_check" CHECK (0 <= "remaining" AND "remaining" <= "quantity"),
+  CONSTRAINT "cost_layers_remaining_value_check" CHECK (0 <= "remaining_value" AND "remaining_value" <= "value")
+);
+
+CREATE INDEX ON "cost_layers" ("good_id", "remaining");
+
+CREATE INDEX ON "stock_movements" ("movement_type", "created_at");
+
+COMMENT ON COLUMN "categories"."costing_method" IS 'fifo or average, how the goods of the category are valued when they are issued';
+
+COMMENT ON COLUMN "goods"."stock_value" IS 'value of the amount and of the stock in transit in minor units of the inventory currency';
+
+COMMENT ON COLUMN "stock_movements"."value" IS 'value of the movement in minor units of the inventory currency, signed like the amount';
+
+COMMENT ON COLUMN "cost_layers"."stock_movement_id" IS 'movement that brought the layer in, null for the opening stock';
+
+COMMENT ON COLUMN "cost_layers"."quantity" IS 'in the unit of the good';
+
+COMMENT ON COLUMN "cost_layers"."remaining" IS 'part of the quantity not issued yet';
+
+COMMENT ON COLUMN "cost_layers"."value" IS 'value of the whole quantity in minor units of the inventory currency';
+
+COMMENT ON COLUMN "cost_layers"."remaining_value" IS 'value of the remaining part';
+
+ALTER TABLE "cost_layers" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
+
+ALTER TABLE "cost_layers" ADD FOREIGN KEY ("stock_movement_id") REFERENCES "stock_movements" ("id");
+
+-- the stock on hand before costing has no known cost, it opens a layer without value
+INSERT INTO "cost_layers" ("good_id", "quantity", "remaining", "value", "remaining_value")
+SELECT "id", "amount" + "in_transit", "amount" + "in_transit", 0, 0 FROM "goods"
+WHERE "amount" + "in_transit" > 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "good_barcodes";
+
+ALTER TABLE "goods" DROP COLUMN IF EXISTS "sku";
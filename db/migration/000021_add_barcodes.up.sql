@@ -0,0 +1,24 @@
+ALTER TABLE "goods" ADD COLUMN "sku" varchar;
+
+CREATE UNIQUE INDEX ON "goods" ("sku");
+
+CREATE TABLE "good_barcodes" (
+  "id" bigserial PRIMARY KEY,
+  "good_id" bigint NOT NULL,
+  "barcode" varchar NOT NULL,
+  "symbology" varchar NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "good_barcodes_symbology_check" CHECK ("symbology" IN ('ean13', 'upca', 'code128'))
+);
+
+CREATE UNIQUE INDEX ON "good_barcodes" ("barcode");
+
+CREATE INDEX ON "good_barcodes" ("good_id");
+
+COMMENT ON COLUMN "goods"."sku" IS 'stock keeping unit, unique among all goods';
+
+COMMENT ON COLUMN "good_barcodes"."barcode" IS 'unique among all goods, so a scan resolves to one good';
+
+COMMENT ON COLUMN "good_barcodes"."symbology" IS 'ean13, upca or code128';
+
+ALTER TABLE "good_barcodes" ADD FOREIGN KEY ("good_id") REFERENCES "goods" ("id");
@@ -0,0 +1,22 @@
+CREATE TABLE "label_templates" (
+  "id" bigserial PRIMARY KEY,
+  "template_name" varchar NOT NULL,
+  "width_mm" integer NOT NULL,
+  "height_mm" integer NOT NULL,
+  "dpi" integer NOT NULL DEFAULT 203,
+  "code_type" varchar NOT NULL DEFAULT 'barcode',
+  "show_model" boolean NOT NULL DEFAULT true,
+  "show_category" boolean NOT NULL DEFAULT true,
+  "show_unit" boolean NOT NULL DEFAULT true,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  CONSTRAINT "label_templates_width_mm_check" CHECK ("width_mm" > 0),
+  CONSTRAINT "label_templates_height_mm_check" CHECK ("height_mm" > 0),
+  CONSTRAINT "label_templates_dpi_check" CHECK ("dpi" > 0),
+  CONSTRAINT "label_templates_code_type_check" CHECK ("code_type" IN ('barcode', 'qr'))
+);
+
+CREATE UNIQUE INDEX ON "label_templates" ("template_name");
+
+COMMENT ON COLUMN "label_templates"."dpi" IS 'resolution of the PNG and ZPL output, 203 or 300 for most label printers';
+
+COMMENT ON COLUMN "label_templates"."code_type" IS 'barcode prints the first barcode of the good, qr a QR code of it';
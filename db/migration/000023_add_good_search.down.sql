@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "goods_good_desc_trgm_idx";
+
+DROP INDEX IF EXISTS "goods_model_trgm_idx";
+
+DROP INDEX IF EXISTS "goods_search_document_idx";
+
+DROP EXTENSION IF EXISTS pg_trgm;
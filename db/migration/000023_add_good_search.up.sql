@@ -0,0 +1,13 @@
+-- trigram similarity lets the search of goods match words with typos
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- the search looks up the words of goods in the document of their model and description,
+-- the expression must stay the same as in the search query for the index to be used
+CREATE INDEX "goods_search_document_idx" ON "goods" USING GIN (
+  (setweight(to_tsvector('english', "model"), 'A') || setweight(to_tsvector('english', "good_desc"), 'B'))
+);
+
+-- words with typos are looked up by their trigrams
+CREATE INDEX "goods_model_trgm_idx" ON "goods" USING GIN ("model" gin_trgm_ops);
+
+CREATE INDEX "goods_good_desc_trgm_idx" ON "goods" USING GIN ("good_desc" gin_trgm_ops);
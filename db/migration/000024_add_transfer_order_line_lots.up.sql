@@ -0,0 +1,15 @@
+CREATE TABLE "transfer_order_line_lots" (
+  "transfer_order_line_id" bigint NOT NULL,
+  "lot_id" bigint NOT NULL,
+  "amount" bigint NOT NULL,
+  PRIMARY KEY ("transfer_order_line_id", "lot_id"),
+  CONSTRAINT "transfer_order_line_lots_amount_check" CHECK ("amount" > 0)
+);
+
+CREATE INDEX ON "transfer_order_line_lots" ("lot_id");
+
+COMMENT ON COLUMN "transfer_order_line_lots"."amount" IS 'part of the amount of the line shipped out of the lot, booked back into it on receipt';
+
+ALTER TABLE "transfer_order_line_lots" ADD FOREIGN KEY ("transfer_order_line_id") REFERENCES "transfer_order_lines" ("id");
+
+ALTER TABLE "transfer_order_line_lots" ADD FOREIGN KEY ("lot_id") REFERENCES "lots" ("id");
@@ -6,8 +6,10 @@ package mockdb
 
 import (
 	context "context"
+	sql "database/sql"
 	db "inventory_management/db/sqlc"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,166 +37,2974 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddBinStock mocks base method.
+func (m *MockStore) AddBinStock(arg0 context.Context, arg1 db.AddBinStockParams) (db.BinStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBinStock", arg0, arg1)
+	ret0, _ := ret[0].(db.BinStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBinStock indicates an expected call of AddBinStock.
+func (mr *MockStoreMockRecorder) AddBinStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBinStock", reflect.TypeOf((*MockStore)(nil).AddBinStock), arg0, arg1)
+}
+
+// AddGoodAmount mocks base method.
+func (m *MockStore) AddGoodAmount(arg0 context.Context, arg1 db.AddGoodAmountParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGoodAmount", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGoodAmount indicates an expected call of AddGoodAmount.
+func (mr *MockStoreMockRecorder) AddGoodAmount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGoodAmount", reflect.TypeOf((*MockStore)(nil).AddGoodAmount), arg0, arg1)
+}
+
+// AddGoodBalance mocks base method.
+func (m *MockStore) AddGoodBalance(arg0 context.Context, arg1 db.AddGoodBalanceParams) (db.GoodBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGoodBalance", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGoodBalance indicates an expected call of AddGoodBalance.
+func (mr *MockStoreMockRecorder) AddGoodBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGoodBalance", reflect.TypeOf((*MockStore)(nil).AddGoodBalance), arg0, arg1)
+}
+
+// AddGoodInTransit mocks base method.
+func (m *MockStore) AddGoodInTransit(arg0 context.Context, arg1 db.AddGoodInTransitParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGoodInTransit", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGoodInTransit indicates an expected call of AddGoodInTransit.
+func (mr *MockStoreMockRecorder) AddGoodInTransit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGoodInTransit", reflect.TypeOf((*MockStore)(nil).AddGoodInTransit), arg0, arg1)
+}
+
+// AddGoodReserved mocks base method.
+func (m *MockStore) AddGoodReserved(arg0 context.Context, arg1 db.AddGoodReservedParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGoodReserved", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGoodReserved indicates an expected call of AddGoodReserved.
+func (mr *MockStoreMockRecorder) AddGoodReserved(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGoodReserved", reflect.TypeOf((*MockStore)(nil).AddGoodReserved), arg0, arg1)
+}
+
+// AddGoodStockValue mocks base method.
+func (m *MockStore) AddGoodStockValue(arg0 context.Context, arg1 db.AddGoodStockValueParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGoodStockValue", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGoodStockValue indicates an expected call of AddGoodStockValue.
+func (mr *MockStoreMockRecorder) AddGoodStockValue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGoodStockValue", reflect.TypeOf((*MockStore)(nil).AddGoodStockValue), arg0, arg1)
+}
+
+// AddLotStock mocks base method.
+func (m *MockStore) AddLotStock(arg0 context.Context, arg1 db.AddLotStockParams) (db.LotStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLotStock", arg0, arg1)
+	ret0, _ := ret[0].(db.LotStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLotStock indicates an expected call of AddLotStock.
+func (mr *MockStoreMockRecorder) AddLotStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLotStock", reflect.TypeOf((*MockStore)(nil).AddLotStock), arg0, arg1)
+}
+
+// AddPurchaseOrderLineReceived mocks base method.
+func (m *MockStore) AddPurchaseOrderLineReceived(arg0 context.Context, arg1 db.AddPurchaseOrderLineReceivedParams) (db.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPurchaseOrderLineReceived", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPurchaseOrderLineReceived indicates an expected call of AddPurchaseOrderLineReceived.
+func (mr *MockStoreMockRecorder) AddPurchaseOrderLineReceived(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPurchaseOrderLineReceived", reflect.TypeOf((*MockStore)(nil).AddPurchaseOrderLineReceived), arg0, arg1)
+}
+
+// AddPurchaseOrderLineTx mocks base method.
+func (m *MockStore) AddPurchaseOrderLineTx(arg0 context.Context, arg1 db.AddPurchaseOrderLineTxParams) (db.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPurchaseOrderLineTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPurchaseOrderLineTx indicates an expected call of AddPurchaseOrderLineTx.
+func (mr *MockStoreMockRecorder) AddPurchaseOrderLineTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPurchaseOrderLineTx", reflect.TypeOf((*MockStore)(nil).AddPurchaseOrderLineTx), arg0, arg1)
+}
+
+// AddSalesOrderLineAllocated mocks base method.
+func (m *MockStore) AddSalesOrderLineAllocated(arg0 context.Context, arg1 db.AddSalesOrderLineAllocatedParams) (db.SalesOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSalesOrderLineAllocated", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSalesOrderLineAllocated indicates an expected call of AddSalesOrderLineAllocated.
+func (mr *MockStoreMockRecorder) AddSalesOrderLineAllocated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSalesOrderLineAllocated", reflect.TypeOf((*MockStore)(nil).AddSalesOrderLineAllocated), arg0, arg1)
+}
+
+// AllocateSalesOrderTx mocks base method.
+func (m *MockStore) AllocateSalesOrderTx(arg0 context.Context, arg1 db.SalesOrderStatusTxParams) (db.SalesOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllocateSalesOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllocateSalesOrderTx indicates an expected call of AllocateSalesOrderTx.
+func (mr *MockStoreMockRecorder) AllocateSalesOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllocateSalesOrderTx", reflect.TypeOf((*MockStore)(nil).AllocateSalesOrderTx), arg0, arg1)
+}
+
+// ApproveCountSessionTx mocks base method.
+func (m *MockStore) ApproveCountSessionTx(arg0 context.Context, arg1 db.CountSessionStatusTxParams) (db.ApproveCountSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveCountSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveCountSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveCountSessionTx indicates an expected call of ApproveCountSessionTx.
+func (mr *MockStoreMockRecorder) ApproveCountSessionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveCountSessionTx", reflect.TypeOf((*MockStore)(nil).ApproveCountSessionTx), arg0, arg1)
+}
+
+// CancelCountSessionTx mocks base method.
+func (m *MockStore) CancelCountSessionTx(arg0 context.Context, arg1 db.CountSessionStatusTxParams) (db.CountSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCountSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCountSessionTx indicates an expected call of CancelCountSessionTx.
+func (mr *MockStoreMockRecorder) CancelCountSessionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCountSessionTx", reflect.TypeOf((*MockStore)(nil).CancelCountSessionTx), arg0, arg1)
+}
+
+// CancelSalesOrderTx mocks base method.
+func (m *MockStore) CancelSalesOrderTx(arg0 context.Context, arg1 db.SalesOrderStatusTxParams) (db.SalesOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSalesOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelSalesOrderTx indicates an expected call of CancelSalesOrderTx.
+func (mr *MockStoreMockRecorder) CancelSalesOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSalesOrderTx", reflect.TypeOf((*MockStore)(nil).CancelSalesOrderTx), arg0, arg1)
+}
+
+// CancelTransferOrderTx mocks base method.
+func (m *MockStore) CancelTransferOrderTx(arg0 context.Context, arg1 db.TransferOrderStatusTxParams) (db.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelTransferOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelTransferOrderTx indicates an expected call of CancelTransferOrderTx.
+func (mr *MockStoreMockRecorder) CancelTransferOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelTransferOrderTx", reflect.TypeOf((*MockStore)(nil).CancelTransferOrderTx), arg0, arg1)
+}
+
+// ClosePurchaseOrderTx mocks base method.
+func (m *MockStore) ClosePurchaseOrderTx(arg0 context.Context, arg1 db.PurchaseOrderStatusTxParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClosePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClosePurchaseOrderTx indicates an expected call of ClosePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) ClosePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClosePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).ClosePurchaseOrderTx), arg0, arg1)
+}
+
+// ConsumeCostLayer mocks base method.
+func (m *MockStore) ConsumeCostLayer(arg0 context.Context, arg1 db.ConsumeCostLayerParams) (db.CostLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeCostLayer", arg0, arg1)
+	ret0, _ := ret[0].(db.CostLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeCostLayer indicates an expected call of ConsumeCostLayer.
+func (mr *MockStoreMockRecorder) ConsumeCostLayer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeCostLayer", reflect.TypeOf((*MockStore)(nil).ConsumeCostLayer), arg0, arg1)
+}
+
+// CountOpenPurchaseOrderLines mocks base method.
+func (m *MockStore) CountOpenPurchaseOrderLines(arg0 context.Context, arg1 int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOpenPurchaseOrderLines", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOpenPurchaseOrderLines indicates an expected call of CountOpenPurchaseOrderLines.
+func (mr *MockStoreMockRecorder) CountOpenPurchaseOrderLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOpenPurchaseOrderLines", reflect.TypeOf((*MockStore)(nil).CountOpenPurchaseOrderLines), arg0, arg1)
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockStore) CreateAuditLog(arg0 context.Context, arg1 db.CreateAuditLogParams) (db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", arg0, arg1)
+	ret0, _ := ret[0].(db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockStoreMockRecorder) CreateAuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockStore)(nil).CreateAuditLog), arg0, arg1)
+}
+
 // CreateCategory mocks base method.
 func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateCategoryTx mocks base method.
+func (m *MockStore) CreateCategoryTx(arg0 context.Context, arg1 db.CreateCategoryTxParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategoryTx indicates an expected call of CreateCategoryTx.
+func (mr *MockStoreMockRecorder) CreateCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryTx", reflect.TypeOf((*MockStore)(nil).CreateCategoryTx), arg0, arg1)
+}
+
+// CreateCostLayer mocks base method.
+func (m *MockStore) CreateCostLayer(arg0 context.Context, arg1 db.CreateCostLayerParams) (db.CostLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCostLayer", arg0, arg1)
+	ret0, _ := ret[0].(db.CostLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCostLayer indicates an expected call of CreateCostLayer.
+func (mr *MockStoreMockRecorder) CreateCostLayer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCostLayer", reflect.TypeOf((*MockStore)(nil).CreateCostLayer), arg0, arg1)
+}
+
+// CreateCountSession mocks base method.
+func (m *MockStore) CreateCountSession(arg0 context.Context, arg1 db.CreateCountSessionParams) (db.CountSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCountSession", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCountSession indicates an expected call of CreateCountSession.
+func (mr *MockStoreMockRecorder) CreateCountSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCountSession", reflect.TypeOf((*MockStore)(nil).CreateCountSession), arg0, arg1)
+}
+
+// CreateCountSessionItem mocks base method.
+func (m *MockStore) CreateCountSessionItem(arg0 context.Context, arg1 db.CreateCountSessionItemParams) (db.CountSessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCountSessionItem", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCountSessionItem indicates an expected call of CreateCountSessionItem.
+func (mr *MockStoreMockRecorder) CreateCountSessionItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCountSessionItem", reflect.TypeOf((*MockStore)(nil).CreateCountSessionItem), arg0, arg1)
+}
+
+// CreateFirstAdmin mocks base method.
+func (m *MockStore) CreateFirstAdmin(arg0 context.Context, arg1 db.CreateFirstAdminParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirstAdmin", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirstAdmin indicates an expected call of CreateFirstAdmin.
+func (mr *MockStoreMockRecorder) CreateFirstAdmin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirstAdmin", reflect.TypeOf((*MockStore)(nil).CreateFirstAdmin), arg0, arg1)
+}
+
+// CreateFirstAdminTx mocks base method.
+func (m *MockStore) CreateFirstAdminTx(arg0 context.Context, arg1 db.CreateFirstAdminParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFirstAdminTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFirstAdminTx indicates an expected call of CreateFirstAdminTx.
+func (mr *MockStoreMockRecorder) CreateFirstAdminTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFirstAdminTx", reflect.TypeOf((*MockStore)(nil).CreateFirstAdminTx), arg0, arg1)
+}
+
+// CreateGood mocks base method.
+func (m *MockStore) CreateGood(arg0 context.Context, arg1 db.CreateGoodParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGood", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGood indicates an expected call of CreateGood.
+func (mr *MockStoreMockRecorder) CreateGood(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGood", reflect.TypeOf((*MockStore)(nil).CreateGood), arg0, arg1)
+}
+
+// CreateGoodBarcode mocks base method.
+func (m *MockStore) CreateGoodBarcode(arg0 context.Context, arg1 db.CreateGoodBarcodeParams) (db.GoodBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodBarcode", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodBarcode indicates an expected call of CreateGoodBarcode.
+func (mr *MockStoreMockRecorder) CreateGoodBarcode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodBarcode", reflect.TypeOf((*MockStore)(nil).CreateGoodBarcode), arg0, arg1)
+}
+
+// CreateGoodBarcodeTx mocks base method.
+func (m *MockStore) CreateGoodBarcodeTx(arg0 context.Context, arg1 db.CreateGoodBarcodeTxParams) (db.GoodBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodBarcodeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodBarcodeTx indicates an expected call of CreateGoodBarcodeTx.
+func (mr *MockStoreMockRecorder) CreateGoodBarcodeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodBarcodeTx", reflect.TypeOf((*MockStore)(nil).CreateGoodBarcodeTx), arg0, arg1)
+}
+
+// CreateGoodSupplier mocks base method.
+func (m *MockStore) CreateGoodSupplier(arg0 context.Context, arg1 db.CreateGoodSupplierParams) (db.GoodSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodSupplier indicates an expected call of CreateGoodSupplier.
+func (mr *MockStoreMockRecorder) CreateGoodSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodSupplier", reflect.TypeOf((*MockStore)(nil).CreateGoodSupplier), arg0, arg1)
+}
+
+// CreateGoodSupplierTx mocks base method.
+func (m *MockStore) CreateGoodSupplierTx(arg0 context.Context, arg1 db.CreateGoodSupplierTxParams) (db.GoodSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodSupplierTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodSupplierTx indicates an expected call of CreateGoodSupplierTx.
+func (mr *MockStoreMockRecorder) CreateGoodSupplierTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodSupplierTx", reflect.TypeOf((*MockStore)(nil).CreateGoodSupplierTx), arg0, arg1)
+}
+
+// CreateGoodTx mocks base method.
+func (m *MockStore) CreateGoodTx(arg0 context.Context, arg1 db.CreateGoodTxParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodTx indicates an expected call of CreateGoodTx.
+func (mr *MockStoreMockRecorder) CreateGoodTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodTx", reflect.TypeOf((*MockStore)(nil).CreateGoodTx), arg0, arg1)
+}
+
+// CreateLabelTemplate mocks base method.
+func (m *MockStore) CreateLabelTemplate(arg0 context.Context, arg1 db.CreateLabelTemplateParams) (db.LabelTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabelTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.LabelTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabelTemplate indicates an expected call of CreateLabelTemplate.
+func (mr *MockStoreMockRecorder) CreateLabelTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabelTemplate", reflect.TypeOf((*MockStore)(nil).CreateLabelTemplate), arg0, arg1)
+}
+
+// CreateLabelTemplateTx mocks base method.
+func (m *MockStore) CreateLabelTemplateTx(arg0 context.Context, arg1 db.CreateLabelTemplateTxParams) (db.LabelTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLabelTemplateTx", arg0, arg1)
+	ret0, _ := ret[0].(db.LabelTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLabelTemplateTx indicates an expected call of CreateLabelTemplateTx.
+func (mr *MockStoreMockRecorder) CreateLabelTemplateTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLabelTemplateTx", reflect.TypeOf((*MockStore)(nil).CreateLabelTemplateTx), arg0, arg1)
+}
+
+// CreateLocation mocks base method.
+func (m *MockStore) CreateLocation(arg0 context.Context, arg1 db.CreateLocationParams) (db.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLocation indicates an expected call of CreateLocation.
+func (mr *MockStoreMockRecorder) CreateLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocation", reflect.TypeOf((*MockStore)(nil).CreateLocation), arg0, arg1)
+}
+
+// CreateLocationTx mocks base method.
+func (m *MockStore) CreateLocationTx(arg0 context.Context, arg1 db.CreateLocationTxParams) (db.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLocationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLocationTx indicates an expected call of CreateLocationTx.
+func (mr *MockStoreMockRecorder) CreateLocationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocationTx", reflect.TypeOf((*MockStore)(nil).CreateLocationTx), arg0, arg1)
+}
+
+// CreateLot mocks base method.
+func (m *MockStore) CreateLot(arg0 context.Context, arg1 db.CreateLotParams) (db.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLot", arg0, arg1)
+	ret0, _ := ret[0].(db.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLot indicates an expected call of CreateLot.
+func (mr *MockStoreMockRecorder) CreateLot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLot", reflect.TypeOf((*MockStore)(nil).CreateLot), arg0, arg1)
+}
+
+// CreateLotMovement mocks base method.
+func (m *MockStore) CreateLotMovement(arg0 context.Context, arg1 db.CreateLotMovementParams) (db.LotMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLotMovement", arg0, arg1)
+	ret0, _ := ret[0].(db.LotMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLotMovement indicates an expected call of CreateLotMovement.
+func (mr *MockStoreMockRecorder) CreateLotMovement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLotMovement", reflect.TypeOf((*MockStore)(nil).CreateLotMovement), arg0, arg1)
+}
+
+// CreateLotTx mocks base method.
+func (m *MockStore) CreateLotTx(arg0 context.Context, arg1 db.CreateLotTxParams) (db.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLotTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLotTx indicates an expected call of CreateLotTx.
+func (mr *MockStoreMockRecorder) CreateLotTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLotTx", reflect.TypeOf((*MockStore)(nil).CreateLotTx), arg0, arg1)
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockStore) CreatePurchaseOrder(arg0 context.Context, arg1 db.CreatePurchaseOrderParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrder), arg0, arg1)
+}
+
+// CreatePurchaseOrderLine mocks base method.
+func (m *MockStore) CreatePurchaseOrderLine(arg0 context.Context, arg1 db.CreatePurchaseOrderLineParams) (db.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderLine indicates an expected call of CreatePurchaseOrderLine.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderLine", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderLine), arg0, arg1)
+}
+
+// CreatePurchaseOrderTx mocks base method.
+func (m *MockStore) CreatePurchaseOrderTx(arg0 context.Context, arg1 db.CreatePurchaseOrderTxParams) (db.PurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderTx indicates an expected call of CreatePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderTx), arg0, arg1)
+}
+
+// CreateReservation mocks base method.
+func (m *MockStore) CreateReservation(arg0 context.Context, arg1 db.CreateReservationParams) (db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservation", arg0, arg1)
+	ret0, _ := ret[0].(db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReservation indicates an expected call of CreateReservation.
+func (mr *MockStoreMockRecorder) CreateReservation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservation", reflect.TypeOf((*MockStore)(nil).CreateReservation), arg0, arg1)
+}
+
+// CreateReservationTx mocks base method.
+func (m *MockStore) CreateReservationTx(arg0 context.Context, arg1 db.CreateReservationTxParams) (db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReservationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReservationTx indicates an expected call of CreateReservationTx.
+func (mr *MockStoreMockRecorder) CreateReservationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReservationTx", reflect.TypeOf((*MockStore)(nil).CreateReservationTx), arg0, arg1)
+}
+
+// CreateSalesOrder mocks base method.
+func (m *MockStore) CreateSalesOrder(arg0 context.Context, arg1 db.CreateSalesOrderParams) (db.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSalesOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSalesOrder indicates an expected call of CreateSalesOrder.
+func (mr *MockStoreMockRecorder) CreateSalesOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSalesOrder", reflect.TypeOf((*MockStore)(nil).CreateSalesOrder), arg0, arg1)
+}
+
+// CreateSalesOrderLine mocks base method.
+func (m *MockStore) CreateSalesOrderLine(arg0 context.Context, arg1 db.CreateSalesOrderLineParams) (db.SalesOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSalesOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSalesOrderLine indicates an expected call of CreateSalesOrderLine.
+func (mr *MockStoreMockRecorder) CreateSalesOrderLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSalesOrderLine", reflect.TypeOf((*MockStore)(nil).CreateSalesOrderLine), arg0, arg1)
+}
+
+// CreateSalesOrderTx mocks base method.
+func (m *MockStore) CreateSalesOrderTx(arg0 context.Context, arg1 db.CreateSalesOrderTxParams) (db.SalesOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSalesOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSalesOrderTx indicates an expected call of CreateSalesOrderTx.
+func (mr *MockStoreMockRecorder) CreateSalesOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSalesOrderTx", reflect.TypeOf((*MockStore)(nil).CreateSalesOrderTx), arg0, arg1)
+}
+
+// CreateSerial mocks base method.
+func (m *MockStore) CreateSerial(arg0 context.Context, arg1 db.CreateSerialParams) (db.Serial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSerial", arg0, arg1)
+	ret0, _ := ret[0].(db.Serial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSerial indicates an expected call of CreateSerial.
+func (mr *MockStoreMockRecorder) CreateSerial(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSerial", reflect.TypeOf((*MockStore)(nil).CreateSerial), arg0, arg1)
+}
+
+// CreateSerialEvent mocks base method.
+func (m *MockStore) CreateSerialEvent(arg0 context.Context, arg1 db.CreateSerialEventParams) (db.SerialEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSerialEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.SerialEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSerialEvent indicates an expected call of CreateSerialEvent.
+func (mr *MockStoreMockRecorder) CreateSerialEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSerialEvent", reflect.TypeOf((*MockStore)(nil).CreateSerialEvent), arg0, arg1)
+}
+
+// CreateStockMovement mocks base method.
+func (m *MockStore) CreateStockMovement(arg0 context.Context, arg1 db.CreateStockMovementParams) (db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockMovement", arg0, arg1)
+	ret0, _ := ret[0].(db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockMovement indicates an expected call of CreateStockMovement.
+func (mr *MockStoreMockRecorder) CreateStockMovement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockMovement", reflect.TypeOf((*MockStore)(nil).CreateStockMovement), arg0, arg1)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockStore) CreateSupplier(arg0 context.Context, arg1 db.CreateSupplierParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockStoreMockRecorder) CreateSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockStore)(nil).CreateSupplier), arg0, arg1)
+}
+
+// CreateSupplierTx mocks base method.
+func (m *MockStore) CreateSupplierTx(arg0 context.Context, arg1 db.CreateSupplierTxParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplierTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplierTx indicates an expected call of CreateSupplierTx.
+func (mr *MockStoreMockRecorder) CreateSupplierTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplierTx", reflect.TypeOf((*MockStore)(nil).CreateSupplierTx), arg0, arg1)
+}
+
+// CreateTransferOrder mocks base method.
+func (m *MockStore) CreateTransferOrder(arg0 context.Context, arg1 db.CreateTransferOrderParams) (db.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferOrder indicates an expected call of CreateTransferOrder.
+func (mr *MockStoreMockRecorder) CreateTransferOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferOrder", reflect.TypeOf((*MockStore)(nil).CreateTransferOrder), arg0, arg1)
+}
+
+// CreateTransferOrderLine mocks base method.
+func (m *MockStore) CreateTransferOrderLine(arg0 context.Context, arg1 db.CreateTransferOrderLineParams) (db.TransferOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferOrderLine indicates an expected call of CreateTransferOrderLine.
+func (mr *MockStoreMockRecorder) CreateTransferOrderLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferOrderLine", reflect.TypeOf((*MockStore)(nil).CreateTransferOrderLine), arg0, arg1)
+}
+
+// CreateTransferOrderLineLot mocks base method.
+func (m *MockStore) CreateTransferOrderLineLot(arg0 context.Context, arg1 db.CreateTransferOrderLineLotParams) (db.TransferOrderLineLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferOrderLineLot", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrderLineLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferOrderLineLot indicates an expected call of CreateTransferOrderLineLot.
+func (mr *MockStoreMockRecorder) CreateTransferOrderLineLot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferOrderLineLot", reflect.TypeOf((*MockStore)(nil).CreateTransferOrderLineLot), arg0, arg1)
+}
+
+// CreateTransferOrderTx mocks base method.
+func (m *MockStore) CreateTransferOrderTx(arg0 context.Context, arg1 db.CreateTransferOrderTxParams) (db.TransferOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransferOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransferOrderTx indicates an expected call of CreateTransferOrderTx.
+func (mr *MockStoreMockRecorder) CreateTransferOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransferOrderTx", reflect.TypeOf((*MockStore)(nil).CreateTransferOrderTx), arg0, arg1)
+}
+
+// CreateUnit mocks base method.
+func (m *MockStore) CreateUnit(arg0 context.Context, arg1 db.CreateUnitParams) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUnit indicates an expected call of CreateUnit.
+func (mr *MockStoreMockRecorder) CreateUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUnit", reflect.TypeOf((*MockStore)(nil).CreateUnit), arg0, arg1)
+}
+
+// CreateUnitTx mocks base method.
+func (m *MockStore) CreateUnitTx(arg0 context.Context, arg1 db.CreateUnitTxParams) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUnitTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUnitTx indicates an expected call of CreateUnitTx.
+func (mr *MockStoreMockRecorder) CreateUnitTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUnitTx", reflect.TypeOf((*MockStore)(nil).CreateUnitTx), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateUserScope mocks base method.
+func (m *MockStore) CreateUserScope(arg0 context.Context, arg1 db.CreateUserScopeParams) (db.UserScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserScope", arg0, arg1)
+	ret0, _ := ret[0].(db.UserScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserScope indicates an expected call of CreateUserScope.
+func (mr *MockStoreMockRecorder) CreateUserScope(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserScope", reflect.TypeOf((*MockStore)(nil).CreateUserScope), arg0, arg1)
+}
+
+// CreateUserTx mocks base method.
+func (m *MockStore) CreateUserTx(arg0 context.Context, arg1 db.CreateUserTxParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserTx", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserTx indicates an expected call of CreateUserTx.
+func (mr *MockStoreMockRecorder) CreateUserTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserTx", reflect.TypeOf((*MockStore)(nil).CreateUserTx), arg0, arg1)
+}
+
+// CreateWarehouse mocks base method.
+func (m *MockStore) CreateWarehouse(arg0 context.Context, arg1 db.CreateWarehouseParams) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWarehouse", arg0, arg1)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWarehouse indicates an expected call of CreateWarehouse.
+func (mr *MockStoreMockRecorder) CreateWarehouse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWarehouse", reflect.TypeOf((*MockStore)(nil).CreateWarehouse), arg0, arg1)
+}
+
+// CreateWarehouseTx mocks base method.
+func (m *MockStore) CreateWarehouseTx(arg0 context.Context, arg1 db.CreateWarehouseTxParams) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWarehouseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWarehouseTx indicates an expected call of CreateWarehouseTx.
+func (mr *MockStoreMockRecorder) CreateWarehouseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWarehouseTx", reflect.TypeOf((*MockStore)(nil).CreateWarehouseTx), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteCategoryTx mocks base method.
+func (m *MockStore) DeleteCategoryTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategoryTx indicates an expected call of DeleteCategoryTx.
+func (mr *MockStoreMockRecorder) DeleteCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategoryTx", reflect.TypeOf((*MockStore)(nil).DeleteCategoryTx), arg0, arg1)
+}
+
+// DeleteGood mocks base method.
+func (m *MockStore) DeleteGood(arg0 context.Context, arg1 int64) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGood", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGood indicates an expected call of DeleteGood.
+func (mr *MockStoreMockRecorder) DeleteGood(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGood", reflect.TypeOf((*MockStore)(nil).DeleteGood), arg0, arg1)
+}
+
+// DeleteGoodBarcode mocks base method.
+func (m *MockStore) DeleteGoodBarcode(arg0 context.Context, arg1 db.DeleteGoodBarcodeParams) (db.GoodBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoodBarcode", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteGoodBarcode indicates an expected call of DeleteGoodBarcode.
+func (mr *MockStoreMockRecorder) DeleteGoodBarcode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoodBarcode", reflect.TypeOf((*MockStore)(nil).DeleteGoodBarcode), arg0, arg1)
+}
+
+// DeleteGoodBarcodeTx mocks base method.
+func (m *MockStore) DeleteGoodBarcodeTx(arg0 context.Context, arg1 db.DeleteGoodBarcodeTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoodBarcodeTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoodBarcodeTx indicates an expected call of DeleteGoodBarcodeTx.
+func (mr *MockStoreMockRecorder) DeleteGoodBarcodeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoodBarcodeTx", reflect.TypeOf((*MockStore)(nil).DeleteGoodBarcodeTx), arg0, arg1)
+}
+
+// DeleteGoodSupplier mocks base method.
+func (m *MockStore) DeleteGoodSupplier(arg0 context.Context, arg1 db.DeleteGoodSupplierParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoodSupplier", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoodSupplier indicates an expected call of DeleteGoodSupplier.
+func (mr *MockStoreMockRecorder) DeleteGoodSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoodSupplier", reflect.TypeOf((*MockStore)(nil).DeleteGoodSupplier), arg0, arg1)
+}
+
+// DeleteGoodSupplierTx mocks base method.
+func (m *MockStore) DeleteGoodSupplierTx(arg0 context.Context, arg1 db.DeleteGoodSupplierTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoodSupplierTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoodSupplierTx indicates an expected call of DeleteGoodSupplierTx.
+func (mr *MockStoreMockRecorder) DeleteGoodSupplierTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoodSupplierTx", reflect.TypeOf((*MockStore)(nil).DeleteGoodSupplierTx), arg0, arg1)
+}
+
+// DeleteGoodTx mocks base method.
+func (m *MockStore) DeleteGoodTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGoodTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGoodTx indicates an expected call of DeleteGoodTx.
+func (mr *MockStoreMockRecorder) DeleteGoodTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGoodTx", reflect.TypeOf((*MockStore)(nil).DeleteGoodTx), arg0, arg1)
+}
+
+// DeleteLabelTemplate mocks base method.
+func (m *MockStore) DeleteLabelTemplate(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabelTemplate", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabelTemplate indicates an expected call of DeleteLabelTemplate.
+func (mr *MockStoreMockRecorder) DeleteLabelTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabelTemplate", reflect.TypeOf((*MockStore)(nil).DeleteLabelTemplate), arg0, arg1)
+}
+
+// DeleteLabelTemplateTx mocks base method.
+func (m *MockStore) DeleteLabelTemplateTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLabelTemplateTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLabelTemplateTx indicates an expected call of DeleteLabelTemplateTx.
+func (mr *MockStoreMockRecorder) DeleteLabelTemplateTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLabelTemplateTx", reflect.TypeOf((*MockStore)(nil).DeleteLabelTemplateTx), arg0, arg1)
+}
+
+// DeleteLocation mocks base method.
+func (m *MockStore) DeleteLocation(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocation indicates an expected call of DeleteLocation.
+func (mr *MockStoreMockRecorder) DeleteLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocation", reflect.TypeOf((*MockStore)(nil).DeleteLocation), arg0, arg1)
+}
+
+// DeleteLocationTx mocks base method.
+func (m *MockStore) DeleteLocationTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocationTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocationTx indicates an expected call of DeleteLocationTx.
+func (mr *MockStoreMockRecorder) DeleteLocationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocationTx", reflect.TypeOf((*MockStore)(nil).DeleteLocationTx), arg0, arg1)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockStore) DeleteSupplier(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockStoreMockRecorder) DeleteSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockStore)(nil).DeleteSupplier), arg0, arg1)
+}
+
+// DeleteSupplierTx mocks base method.
+func (m *MockStore) DeleteSupplierTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplierTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSupplierTx indicates an expected call of DeleteSupplierTx.
+func (mr *MockStoreMockRecorder) DeleteSupplierTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplierTx", reflect.TypeOf((*MockStore)(nil).DeleteSupplierTx), arg0, arg1)
+}
+
+// DeleteUnit mocks base method.
+func (m *MockStore) DeleteUnit(arg0 context.Context, arg1 int64) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnit indicates an expected call of DeleteUnit.
+func (mr *MockStoreMockRecorder) DeleteUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnit", reflect.TypeOf((*MockStore)(nil).DeleteUnit), arg0, arg1)
+}
+
+// DeleteUnitTx mocks base method.
+func (m *MockStore) DeleteUnitTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnitTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUnitTx indicates an expected call of DeleteUnitTx.
+func (mr *MockStoreMockRecorder) DeleteUnitTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnitTx", reflect.TypeOf((*MockStore)(nil).DeleteUnitTx), arg0, arg1)
+}
+
+// DeleteUserScopes mocks base method.
+func (m *MockStore) DeleteUserScopes(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserScopes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserScopes indicates an expected call of DeleteUserScopes.
+func (mr *MockStoreMockRecorder) DeleteUserScopes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserScopes", reflect.TypeOf((*MockStore)(nil).DeleteUserScopes), arg0, arg1)
+}
+
+// DeleteWarehouse mocks base method.
+func (m *MockStore) DeleteWarehouse(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWarehouse", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWarehouse indicates an expected call of DeleteWarehouse.
+func (mr *MockStoreMockRecorder) DeleteWarehouse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarehouse", reflect.TypeOf((*MockStore)(nil).DeleteWarehouse), arg0, arg1)
+}
+
+// DeleteWarehouseTx mocks base method.
+func (m *MockStore) DeleteWarehouseTx(arg0 context.Context, arg1 db.DeleteTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWarehouseTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWarehouseTx indicates an expected call of DeleteWarehouseTx.
+func (mr *MockStoreMockRecorder) DeleteWarehouseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWarehouseTx", reflect.TypeOf((*MockStore)(nil).DeleteWarehouseTx), arg0, arg1)
+}
+
+// ExpireReservations mocks base method.
+func (m *MockStore) ExpireReservations(arg0 context.Context, arg1 db.ExpireReservationsParams) ([]db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservations", arg0, arg1)
+	ret0, _ := ret[0].([]db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservations indicates an expected call of ExpireReservations.
+func (mr *MockStoreMockRecorder) ExpireReservations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservations", reflect.TypeOf((*MockStore)(nil).ExpireReservations), arg0, arg1)
+}
+
+// ExpireReservationsTx mocks base method.
+func (m *MockStore) ExpireReservationsTx(arg0 context.Context, arg1 db.ExpireReservationsTxParams) ([]db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireReservationsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireReservationsTx indicates an expected call of ExpireReservationsTx.
+func (mr *MockStoreMockRecorder) ExpireReservationsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireReservationsTx", reflect.TypeOf((*MockStore)(nil).ExpireReservationsTx), arg0, arg1)
+}
+
+// ExportCategories mocks base method.
+func (m *MockStore) ExportCategories(arg0 context.Context, arg1 db.ExportCategoriesParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportCategories indicates an expected call of ExportCategories.
+func (mr *MockStoreMockRecorder) ExportCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportCategories", reflect.TypeOf((*MockStore)(nil).ExportCategories), arg0, arg1)
+}
+
+// ExportGoods mocks base method.
+func (m *MockStore) ExportGoods(arg0 context.Context, arg1 db.ExportGoodsParams) ([]db.ExportGoodsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportGoods", arg0, arg1)
+	ret0, _ := ret[0].([]db.ExportGoodsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportGoods indicates an expected call of ExportGoods.
+func (mr *MockStoreMockRecorder) ExportGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportGoods", reflect.TypeOf((*MockStore)(nil).ExportGoods), arg0, arg1)
+}
+
+// ExportUnits mocks base method.
+func (m *MockStore) ExportUnits(arg0 context.Context, arg1 db.ExportUnitsParams) ([]db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUnits", arg0, arg1)
+	ret0, _ := ret[0].([]db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportUnits indicates an expected call of ExportUnits.
+func (mr *MockStoreMockRecorder) ExportUnits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUnits", reflect.TypeOf((*MockStore)(nil).ExportUnits), arg0, arg1)
+}
+
+// GetBinStock mocks base method.
+func (m *MockStore) GetBinStock(arg0 context.Context, arg1 db.GetBinStockParams) (db.BinStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBinStock", arg0, arg1)
+	ret0, _ := ret[0].(db.BinStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBinStock indicates an expected call of GetBinStock.
+func (mr *MockStoreMockRecorder) GetBinStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBinStock", reflect.TypeOf((*MockStore)(nil).GetBinStock), arg0, arg1)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+}
+
+// GetCategoryIncludingDeleted mocks base method.
+func (m *MockStore) GetCategoryIncludingDeleted(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryIncludingDeleted", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryIncludingDeleted indicates an expected call of GetCategoryIncludingDeleted.
+func (mr *MockStoreMockRecorder) GetCategoryIncludingDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryIncludingDeleted", reflect.TypeOf((*MockStore)(nil).GetCategoryIncludingDeleted), arg0, arg1)
+}
+
+// GetCountSession mocks base method.
+func (m *MockStore) GetCountSession(arg0 context.Context, arg1 int64) (db.CountSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountSession", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountSession indicates an expected call of GetCountSession.
+func (mr *MockStoreMockRecorder) GetCountSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountSession", reflect.TypeOf((*MockStore)(nil).GetCountSession), arg0, arg1)
+}
+
+// GetCountSessionForUpdate mocks base method.
+func (m *MockStore) GetCountSessionForUpdate(arg0 context.Context, arg1 int64) (db.CountSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCountSessionForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCountSessionForUpdate indicates an expected call of GetCountSessionForUpdate.
+func (mr *MockStoreMockRecorder) GetCountSessionForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCountSessionForUpdate", reflect.TypeOf((*MockStore)(nil).GetCountSessionForUpdate), arg0, arg1)
+}
+
+// GetGood mocks base method.
+func (m *MockStore) GetGood(arg0 context.Context, arg1 int64) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGood", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGood indicates an expected call of GetGood.
+func (mr *MockStoreMockRecorder) GetGood(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGood", reflect.TypeOf((*MockStore)(nil).GetGood), arg0, arg1)
+}
+
+// GetGoodBalance mocks base method.
+func (m *MockStore) GetGoodBalance(arg0 context.Context, arg1 db.GetGoodBalanceParams) (db.GoodBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodBalance", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodBalance indicates an expected call of GetGoodBalance.
+func (mr *MockStoreMockRecorder) GetGoodBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodBalance", reflect.TypeOf((*MockStore)(nil).GetGoodBalance), arg0, arg1)
+}
+
+// GetGoodBarcode mocks base method.
+func (m *MockStore) GetGoodBarcode(arg0 context.Context, arg1 string) (db.GoodBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodBarcode", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodBarcode indicates an expected call of GetGoodBarcode.
+func (mr *MockStoreMockRecorder) GetGoodBarcode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodBarcode", reflect.TypeOf((*MockStore)(nil).GetGoodBarcode), arg0, arg1)
+}
+
+// GetGoodBySku mocks base method.
+func (m *MockStore) GetGoodBySku(arg0 context.Context, arg1 sql.NullString) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodBySku", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodBySku indicates an expected call of GetGoodBySku.
+func (mr *MockStoreMockRecorder) GetGoodBySku(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodBySku", reflect.TypeOf((*MockStore)(nil).GetGoodBySku), arg0, arg1)
+}
+
+// GetGoodForUpdate mocks base method.
+func (m *MockStore) GetGoodForUpdate(arg0 context.Context, arg1 int64) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodForUpdate indicates an expected call of GetGoodForUpdate.
+func (mr *MockStoreMockRecorder) GetGoodForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodForUpdate", reflect.TypeOf((*MockStore)(nil).GetGoodForUpdate), arg0, arg1)
+}
+
+// GetGoodIncludingDeleted mocks base method.
+func (m *MockStore) GetGoodIncludingDeleted(arg0 context.Context, arg1 int64) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodIncludingDeleted", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodIncludingDeleted indicates an expected call of GetGoodIncludingDeleted.
+func (mr *MockStoreMockRecorder) GetGoodIncludingDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodIncludingDeleted", reflect.TypeOf((*MockStore)(nil).GetGoodIncludingDeleted), arg0, arg1)
+}
+
+// GetGoodIncludingDeletedForUpdate mocks base method.
+func (m *MockStore) GetGoodIncludingDeletedForUpdate(arg0 context.Context, arg1 int64) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodIncludingDeletedForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodIncludingDeletedForUpdate indicates an expected call of GetGoodIncludingDeletedForUpdate.
+func (mr *MockStoreMockRecorder) GetGoodIncludingDeletedForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodIncludingDeletedForUpdate", reflect.TypeOf((*MockStore)(nil).GetGoodIncludingDeletedForUpdate), arg0, arg1)
+}
+
+// GetGoodLabel mocks base method.
+func (m *MockStore) GetGoodLabel(arg0 context.Context, arg1 int64) (db.GetGoodLabelRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodLabel", arg0, arg1)
+	ret0, _ := ret[0].(db.GetGoodLabelRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodLabel indicates an expected call of GetGoodLabel.
+func (mr *MockStoreMockRecorder) GetGoodLabel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodLabel", reflect.TypeOf((*MockStore)(nil).GetGoodLabel), arg0, arg1)
+}
+
+// GetGoodSupplier mocks base method.
+func (m *MockStore) GetGoodSupplier(arg0 context.Context, arg1 db.GetGoodSupplierParams) (db.GoodSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGoodSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGoodSupplier indicates an expected call of GetGoodSupplier.
+func (mr *MockStoreMockRecorder) GetGoodSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGoodSupplier", reflect.TypeOf((*MockStore)(nil).GetGoodSupplier), arg0, arg1)
+}
+
+// GetLabelTemplate mocks base method.
+func (m *MockStore) GetLabelTemplate(arg0 context.Context, arg1 int64) (db.LabelTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLabelTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.LabelTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLabelTemplate indicates an expected call of GetLabelTemplate.
+func (mr *MockStoreMockRecorder) GetLabelTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLabelTemplate", reflect.TypeOf((*MockStore)(nil).GetLabelTemplate), arg0, arg1)
+}
+
+// GetLocation mocks base method.
+func (m *MockStore) GetLocation(arg0 context.Context, arg1 int64) (db.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocation indicates an expected call of GetLocation.
+func (mr *MockStoreMockRecorder) GetLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocation", reflect.TypeOf((*MockStore)(nil).GetLocation), arg0, arg1)
+}
+
+// GetLot mocks base method.
+func (m *MockStore) GetLot(arg0 context.Context, arg1 int64) (db.Lot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLot", arg0, arg1)
+	ret0, _ := ret[0].(db.Lot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLot indicates an expected call of GetLot.
+func (mr *MockStoreMockRecorder) GetLot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLot", reflect.TypeOf((*MockStore)(nil).GetLot), arg0, arg1)
+}
+
+// GetLotStock mocks base method.
+func (m *MockStore) GetLotStock(arg0 context.Context, arg1 db.GetLotStockParams) (db.LotStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLotStock", arg0, arg1)
+	ret0, _ := ret[0].(db.LotStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLotStock indicates an expected call of GetLotStock.
+func (mr *MockStoreMockRecorder) GetLotStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLotStock", reflect.TypeOf((*MockStore)(nil).GetLotStock), arg0, arg1)
+}
+
+// GetPickListTx mocks base method.
+func (m *MockStore) GetPickListTx(arg0 context.Context, arg1 int64) (db.PickList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPickListTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PickList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPickListTx indicates an expected call of GetPickListTx.
+func (mr *MockStoreMockRecorder) GetPickListTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickListTx", reflect.TypeOf((*MockStore)(nil).GetPickListTx), arg0, arg1)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockStore) GetPurchaseOrder(arg0 context.Context, arg1 int64) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockStoreMockRecorder) GetPurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrder), arg0, arg1)
+}
+
+// GetPurchaseOrderForUpdate mocks base method.
+func (m *MockStore) GetPurchaseOrderForUpdate(arg0 context.Context, arg1 int64) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderForUpdate indicates an expected call of GetPurchaseOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetPurchaseOrderForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrderForUpdate), arg0, arg1)
+}
+
+// GetPurchaseOrderLine mocks base method.
+func (m *MockStore) GetPurchaseOrderLine(arg0 context.Context, arg1 int64) (db.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderLine", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderLine indicates an expected call of GetPurchaseOrderLine.
+func (mr *MockStoreMockRecorder) GetPurchaseOrderLine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderLine", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrderLine), arg0, arg1)
+}
+
+// GetReservation mocks base method.
+func (m *MockStore) GetReservation(arg0 context.Context, arg1 int64) (db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservation", arg0, arg1)
+	ret0, _ := ret[0].(db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservation indicates an expected call of GetReservation.
+func (mr *MockStoreMockRecorder) GetReservation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservation", reflect.TypeOf((*MockStore)(nil).GetReservation), arg0, arg1)
+}
+
+// GetSalesOrder mocks base method.
+func (m *MockStore) GetSalesOrder(arg0 context.Context, arg1 int64) (db.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesOrder indicates an expected call of GetSalesOrder.
+func (mr *MockStoreMockRecorder) GetSalesOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesOrder", reflect.TypeOf((*MockStore)(nil).GetSalesOrder), arg0, arg1)
+}
+
+// GetSalesOrderForUpdate mocks base method.
+func (m *MockStore) GetSalesOrderForUpdate(arg0 context.Context, arg1 int64) (db.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSalesOrderForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSalesOrderForUpdate indicates an expected call of GetSalesOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetSalesOrderForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSalesOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetSalesOrderForUpdate), arg0, arg1)
+}
+
+// GetSerialByNumber mocks base method.
+func (m *MockStore) GetSerialByNumber(arg0 context.Context, arg1 string) (db.Serial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSerialByNumber", arg0, arg1)
+	ret0, _ := ret[0].(db.Serial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSerialByNumber indicates an expected call of GetSerialByNumber.
+func (mr *MockStoreMockRecorder) GetSerialByNumber(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSerialByNumber", reflect.TypeOf((*MockStore)(nil).GetSerialByNumber), arg0, arg1)
+}
+
+// GetStockMovement mocks base method.
+func (m *MockStore) GetStockMovement(arg0 context.Context, arg1 int64) (db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockMovement", arg0, arg1)
+	ret0, _ := ret[0].(db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockMovement indicates an expected call of GetStockMovement.
+func (mr *MockStoreMockRecorder) GetStockMovement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockMovement", reflect.TypeOf((*MockStore)(nil).GetStockMovement), arg0, arg1)
+}
+
+// GetSupplier mocks base method.
+func (m *MockStore) GetSupplier(arg0 context.Context, arg1 int64) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockStoreMockRecorder) GetSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockStore)(nil).GetSupplier), arg0, arg1)
+}
+
+// GetTransferOrder mocks base method.
+func (m *MockStore) GetTransferOrder(arg0 context.Context, arg1 int64) (db.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferOrder indicates an expected call of GetTransferOrder.
+func (mr *MockStoreMockRecorder) GetTransferOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferOrder", reflect.TypeOf((*MockStore)(nil).GetTransferOrder), arg0, arg1)
+}
+
+// GetTransferOrderForUpdate mocks base method.
+func (m *MockStore) GetTransferOrderForUpdate(arg0 context.Context, arg1 int64) (db.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransferOrderForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransferOrderForUpdate indicates an expected call of GetTransferOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetTransferOrderForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransferOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetTransferOrderForUpdate), arg0, arg1)
+}
+
+// GetUnit mocks base method.
+func (m *MockStore) GetUnit(arg0 context.Context, arg1 int64) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnit indicates an expected call of GetUnit.
+func (mr *MockStoreMockRecorder) GetUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnit", reflect.TypeOf((*MockStore)(nil).GetUnit), arg0, arg1)
+}
+
+// GetUnitIncludingDeleted mocks base method.
+func (m *MockStore) GetUnitIncludingDeleted(arg0 context.Context, arg1 int64) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnitIncludingDeleted", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnitIncludingDeleted indicates an expected call of GetUnitIncludingDeleted.
+func (mr *MockStoreMockRecorder) GetUnitIncludingDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnitIncludingDeleted", reflect.TypeOf((*MockStore)(nil).GetUnitIncludingDeleted), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetWarehouse mocks base method.
+func (m *MockStore) GetWarehouse(arg0 context.Context, arg1 int64) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWarehouse", arg0, arg1)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWarehouse indicates an expected call of GetWarehouse.
+func (mr *MockStoreMockRecorder) GetWarehouse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWarehouse", reflect.TypeOf((*MockStore)(nil).GetWarehouse), arg0, arg1)
+}
+
+// ImportGoodsTx mocks base method.
+func (m *MockStore) ImportGoodsTx(arg0 context.Context, arg1 db.ImportGoodsTxParams) ([]db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportGoodsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportGoodsTx indicates an expected call of ImportGoodsTx.
+func (mr *MockStoreMockRecorder) ImportGoodsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportGoodsTx", reflect.TypeOf((*MockStore)(nil).ImportGoodsTx), arg0, arg1)
+}
+
+// ListAuditLogs mocks base method.
+func (m *MockStore) ListAuditLogs(arg0 context.Context, arg1 db.ListAuditLogsParams) ([]db.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditLogs", arg0, arg1)
+	ret0, _ := ret[0].([]db.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditLogs indicates an expected call of ListAuditLogs.
+func (mr *MockStoreMockRecorder) ListAuditLogs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditLogs", reflect.TypeOf((*MockStore)(nil).ListAuditLogs), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+}
+
+// ListCategoriesByName mocks base method.
+func (m *MockStore) ListCategoriesByName(arg0 context.Context, arg1 db.ListCategoriesByNameParams) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoriesByName", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoriesByName indicates an expected call of ListCategoriesByName.
+func (mr *MockStoreMockRecorder) ListCategoriesByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoriesByName", reflect.TypeOf((*MockStore)(nil).ListCategoriesByName), arg0, arg1)
+}
+
+// ListCategoryLabels mocks base method.
+func (m *MockStore) ListCategoryLabels(arg0 context.Context, arg1 int64) ([]db.ListCategoryLabelsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryLabels", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCategoryLabelsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryLabels indicates an expected call of ListCategoryLabels.
+func (mr *MockStoreMockRecorder) ListCategoryLabels(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryLabels", reflect.TypeOf((*MockStore)(nil).ListCategoryLabels), arg0, arg1)
+}
+
+// ListCategoryValuations mocks base method.
+func (m *MockStore) ListCategoryValuations(arg0 context.Context, arg1 db.ListCategoryValuationsParams) ([]db.ListCategoryValuationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategoryValuations", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCategoryValuationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategoryValuations indicates an expected call of ListCategoryValuations.
+func (mr *MockStoreMockRecorder) ListCategoryValuations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategoryValuations", reflect.TypeOf((*MockStore)(nil).ListCategoryValuations), arg0, arg1)
+}
+
+// ListCostLayers mocks base method.
+func (m *MockStore) ListCostLayers(arg0 context.Context, arg1 db.ListCostLayersParams) ([]db.CostLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCostLayers", arg0, arg1)
+	ret0, _ := ret[0].([]db.CostLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCostLayers indicates an expected call of ListCostLayers.
+func (mr *MockStoreMockRecorder) ListCostLayers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCostLayers", reflect.TypeOf((*MockStore)(nil).ListCostLayers), arg0, arg1)
+}
+
+// ListCountBinSnapshot mocks base method.
+func (m *MockStore) ListCountBinSnapshot(arg0 context.Context, arg1 db.ListCountBinSnapshotParams) ([]db.BinStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCountBinSnapshot", arg0, arg1)
+	ret0, _ := ret[0].([]db.BinStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCountBinSnapshot indicates an expected call of ListCountBinSnapshot.
+func (mr *MockStoreMockRecorder) ListCountBinSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountBinSnapshot", reflect.TypeOf((*MockStore)(nil).ListCountBinSnapshot), arg0, arg1)
+}
+
+// ListCountSessionItems mocks base method.
+func (m *MockStore) ListCountSessionItems(arg0 context.Context, arg1 int64) ([]db.CountSessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCountSessionItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.CountSessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCountSessionItems indicates an expected call of ListCountSessionItems.
+func (mr *MockStoreMockRecorder) ListCountSessionItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountSessionItems", reflect.TypeOf((*MockStore)(nil).ListCountSessionItems), arg0, arg1)
+}
+
+// ListCountSessions mocks base method.
+func (m *MockStore) ListCountSessions(arg0 context.Context, arg1 db.ListCountSessionsParams) ([]db.CountSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCountSessions", arg0, arg1)
+	ret0, _ := ret[0].([]db.CountSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCountSessions indicates an expected call of ListCountSessions.
+func (mr *MockStoreMockRecorder) ListCountSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountSessions", reflect.TypeOf((*MockStore)(nil).ListCountSessions), arg0, arg1)
+}
+
+// ListCountSnapshot mocks base method.
+func (m *MockStore) ListCountSnapshot(arg0 context.Context, arg1 db.ListCountSnapshotParams) ([]db.ListCountSnapshotRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCountSnapshot", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListCountSnapshotRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCountSnapshot indicates an expected call of ListCountSnapshot.
+func (mr *MockStoreMockRecorder) ListCountSnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCountSnapshot", reflect.TypeOf((*MockStore)(nil).ListCountSnapshot), arg0, arg1)
+}
+
+// ListExpiringLots mocks base method.
+func (m *MockStore) ListExpiringLots(arg0 context.Context, arg1 db.ListExpiringLotsParams) ([]db.ListExpiringLotsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiringLots", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExpiringLotsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringLots indicates an expected call of ListExpiringLots.
+func (mr *MockStoreMockRecorder) ListExpiringLots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringLots", reflect.TypeOf((*MockStore)(nil).ListExpiringLots), arg0, arg1)
+}
+
+// ListGoodBalances mocks base method.
+func (m *MockStore) ListGoodBalances(arg0 context.Context, arg1 int64) ([]db.ListGoodBalancesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodBalances", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGoodBalancesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodBalances indicates an expected call of ListGoodBalances.
+func (mr *MockStoreMockRecorder) ListGoodBalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodBalances", reflect.TypeOf((*MockStore)(nil).ListGoodBalances), arg0, arg1)
+}
+
+// ListGoodBarcodes mocks base method.
+func (m *MockStore) ListGoodBarcodes(arg0 context.Context, arg1 int64) ([]db.GoodBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodBarcodes", arg0, arg1)
+	ret0, _ := ret[0].([]db.GoodBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodBarcodes indicates an expected call of ListGoodBarcodes.
+func (mr *MockStoreMockRecorder) ListGoodBarcodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodBarcodes", reflect.TypeOf((*MockStore)(nil).ListGoodBarcodes), arg0, arg1)
+}
+
+// ListGoodLots mocks base method.
+func (m *MockStore) ListGoodLots(arg0 context.Context, arg1 db.ListGoodLotsParams) ([]db.ListGoodLotsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodLots", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGoodLotsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodLots indicates an expected call of ListGoodLots.
+func (mr *MockStoreMockRecorder) ListGoodLots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodLots", reflect.TypeOf((*MockStore)(nil).ListGoodLots), arg0, arg1)
+}
+
+// ListGoodSerials mocks base method.
+func (m *MockStore) ListGoodSerials(arg0 context.Context, arg1 db.ListGoodSerialsParams) ([]db.Serial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodSerials", arg0, arg1)
+	ret0, _ := ret[0].([]db.Serial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodSerials indicates an expected call of ListGoodSerials.
+func (mr *MockStoreMockRecorder) ListGoodSerials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodSerials", reflect.TypeOf((*MockStore)(nil).ListGoodSerials), arg0, arg1)
+}
+
+// ListGoodSuppliers mocks base method.
+func (m *MockStore) ListGoodSuppliers(arg0 context.Context, arg1 int64) ([]db.ListGoodSuppliersRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodSuppliers", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGoodSuppliersRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodSuppliers indicates an expected call of ListGoodSuppliers.
+func (mr *MockStoreMockRecorder) ListGoodSuppliers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodSuppliers", reflect.TypeOf((*MockStore)(nil).ListGoodSuppliers), arg0, arg1)
+}
+
+// ListGoodValuations mocks base method.
+func (m *MockStore) ListGoodValuations(arg0 context.Context, arg1 db.ListGoodValuationsParams) ([]db.ListGoodValuationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodValuations", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGoodValuationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodValuations indicates an expected call of ListGoodValuations.
+func (mr *MockStoreMockRecorder) ListGoodValuations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodValuations", reflect.TypeOf((*MockStore)(nil).ListGoodValuations), arg0, arg1)
+}
+
+// ListGoods mocks base method.
+func (m *MockStore) ListGoods(arg0 context.Context, arg1 db.ListGoodsParams) ([]db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoods", arg0, arg1)
+	ret0, _ := ret[0].([]db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoods indicates an expected call of ListGoods.
+func (mr *MockStoreMockRecorder) ListGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoods", reflect.TypeOf((*MockStore)(nil).ListGoods), arg0, arg1)
+}
+
+// ListIssuableLotStocks mocks base method.
+func (m *MockStore) ListIssuableLotStocks(arg0 context.Context, arg1 db.ListIssuableLotStocksParams) ([]db.ListIssuableLotStocksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssuableLotStocks", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListIssuableLotStocksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssuableLotStocks indicates an expected call of ListIssuableLotStocks.
+func (mr *MockStoreMockRecorder) ListIssuableLotStocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuableLotStocks", reflect.TypeOf((*MockStore)(nil).ListIssuableLotStocks), arg0, arg1)
+}
+
+// ListLabelTemplates mocks base method.
+func (m *MockStore) ListLabelTemplates(arg0 context.Context, arg1 db.ListLabelTemplatesParams) ([]db.LabelTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLabelTemplates", arg0, arg1)
+	ret0, _ := ret[0].([]db.LabelTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLabelTemplates indicates an expected call of ListLabelTemplates.
+func (mr *MockStoreMockRecorder) ListLabelTemplates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLabelTemplates", reflect.TypeOf((*MockStore)(nil).ListLabelTemplates), arg0, arg1)
+}
+
+// ListLocationContents mocks base method.
+func (m *MockStore) ListLocationContents(arg0 context.Context, arg1 db.ListLocationContentsParams) ([]db.ListLocationContentsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocationContents", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLocationContentsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocationContents indicates an expected call of ListLocationContents.
+func (mr *MockStoreMockRecorder) ListLocationContents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocationContents", reflect.TypeOf((*MockStore)(nil).ListLocationContents), arg0, arg1)
+}
+
+// ListLocations mocks base method.
+func (m *MockStore) ListLocations(arg0 context.Context, arg1 db.ListLocationsParams) ([]db.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocations", arg0, arg1)
+	ret0, _ := ret[0].([]db.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocations indicates an expected call of ListLocations.
+func (mr *MockStoreMockRecorder) ListLocations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocations", reflect.TypeOf((*MockStore)(nil).ListLocations), arg0, arg1)
+}
+
+// ListLotMovements mocks base method.
+func (m *MockStore) ListLotMovements(arg0 context.Context, arg1 int64) ([]db.LotMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLotMovements", arg0, arg1)
+	ret0, _ := ret[0].([]db.LotMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLotMovements indicates an expected call of ListLotMovements.
+func (mr *MockStoreMockRecorder) ListLotMovements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLotMovements", reflect.TypeOf((*MockStore)(nil).ListLotMovements), arg0, arg1)
+}
+
+// ListLowStockGoods mocks base method.
+func (m *MockStore) ListLowStockGoods(arg0 context.Context, arg1 db.ListLowStockGoodsParams) ([]db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLowStockGoods", arg0, arg1)
+	ret0, _ := ret[0].([]db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLowStockGoods indicates an expected call of ListLowStockGoods.
+func (mr *MockStoreMockRecorder) ListLowStockGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLowStockGoods", reflect.TypeOf((*MockStore)(nil).ListLowStockGoods), arg0, arg1)
+}
+
+// ListOpenCostLayers mocks base method.
+func (m *MockStore) ListOpenCostLayers(arg0 context.Context, arg1 int64) ([]db.CostLayer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenCostLayers", arg0, arg1)
+	ret0, _ := ret[0].([]db.CostLayer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenCostLayers indicates an expected call of ListOpenCostLayers.
+func (mr *MockStoreMockRecorder) ListOpenCostLayers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenCostLayers", reflect.TypeOf((*MockStore)(nil).ListOpenCostLayers), arg0, arg1)
+}
+
+// ListOverdueReservationGoods mocks base method.
+func (m *MockStore) ListOverdueReservationGoods(arg0 context.Context, arg1 time.Time) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueReservationGoods", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueReservationGoods indicates an expected call of ListOverdueReservationGoods.
+func (mr *MockStoreMockRecorder) ListOverdueReservationGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueReservationGoods", reflect.TypeOf((*MockStore)(nil).ListOverdueReservationGoods), arg0, arg1)
+}
+
+// ListPurchaseOrderLines mocks base method.
+func (m *MockStore) ListPurchaseOrderLines(arg0 context.Context, arg1 int64) ([]db.PurchaseOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrderLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.PurchaseOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrderLines indicates an expected call of ListPurchaseOrderLines.
+func (mr *MockStoreMockRecorder) ListPurchaseOrderLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrderLines", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrderLines), arg0, arg1)
+}
+
+// ListPurchaseOrders mocks base method.
+func (m *MockStore) ListPurchaseOrders(arg0 context.Context, arg1 db.ListPurchaseOrdersParams) ([]db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", arg0, arg1)
+	ret0, _ := ret[0].([]db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockStoreMockRecorder) ListPurchaseOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrders), arg0, arg1)
+}
+
+// ListReservations mocks base method.
+func (m *MockStore) ListReservations(arg0 context.Context, arg1 db.ListReservationsParams) ([]db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReservations", arg0, arg1)
+	ret0, _ := ret[0].([]db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReservations indicates an expected call of ListReservations.
+func (mr *MockStoreMockRecorder) ListReservations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReservations", reflect.TypeOf((*MockStore)(nil).ListReservations), arg0, arg1)
+}
+
+// ListSalesOrderLines mocks base method.
+func (m *MockStore) ListSalesOrderLines(arg0 context.Context, arg1 int64) ([]db.SalesOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSalesOrderLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.SalesOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSalesOrderLines indicates an expected call of ListSalesOrderLines.
+func (mr *MockStoreMockRecorder) ListSalesOrderLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSalesOrderLines", reflect.TypeOf((*MockStore)(nil).ListSalesOrderLines), arg0, arg1)
+}
+
+// ListSalesOrders mocks base method.
+func (m *MockStore) ListSalesOrders(arg0 context.Context, arg1 db.ListSalesOrdersParams) ([]db.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSalesOrders", arg0, arg1)
+	ret0, _ := ret[0].([]db.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSalesOrders indicates an expected call of ListSalesOrders.
+func (mr *MockStoreMockRecorder) ListSalesOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSalesOrders", reflect.TypeOf((*MockStore)(nil).ListSalesOrders), arg0, arg1)
+}
+
+// ListSerialEvents mocks base method.
+func (m *MockStore) ListSerialEvents(arg0 context.Context, arg1 int64) ([]db.SerialEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSerialEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.SerialEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSerialEvents indicates an expected call of ListSerialEvents.
+func (mr *MockStoreMockRecorder) ListSerialEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSerialEvents", reflect.TypeOf((*MockStore)(nil).ListSerialEvents), arg0, arg1)
+}
+
+// ListStockAlerts mocks base method.
+func (m *MockStore) ListStockAlerts(arg0 context.Context, arg1 db.ListStockAlertsParams) ([]db.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockAlerts", arg0, arg1)
+	ret0, _ := ret[0].([]db.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockAlerts indicates an expected call of ListStockAlerts.
+func (mr *MockStoreMockRecorder) ListStockAlerts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockAlerts", reflect.TypeOf((*MockStore)(nil).ListStockAlerts), arg0, arg1)
+}
+
+// ListStockMovements mocks base method.
+func (m *MockStore) ListStockMovements(arg0 context.Context, arg1 db.ListStockMovementsParams) ([]db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockMovements", arg0, arg1)
+	ret0, _ := ret[0].([]db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockStoreMockRecorder) ListStockMovements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockStore)(nil).ListStockMovements), arg0, arg1)
+}
+
+// ListSuppliers mocks base method.
+func (m *MockStore) ListSuppliers(arg0 context.Context, arg1 db.ListSuppliersParams) ([]db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppliers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockStoreMockRecorder) ListSuppliers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockStore)(nil).ListSuppliers), arg0, arg1)
+}
+
+// ListTransferOrderLineLots mocks base method.
+func (m *MockStore) ListTransferOrderLineLots(arg0 context.Context, arg1 int64) ([]db.TransferOrderLineLot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferOrderLineLots", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferOrderLineLot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferOrderLineLots indicates an expected call of ListTransferOrderLineLots.
+func (mr *MockStoreMockRecorder) ListTransferOrderLineLots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferOrderLineLots", reflect.TypeOf((*MockStore)(nil).ListTransferOrderLineLots), arg0, arg1)
+}
+
+// ListTransferOrderLines mocks base method.
+func (m *MockStore) ListTransferOrderLines(arg0 context.Context, arg1 int64) ([]db.TransferOrderLine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferOrderLines", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferOrderLine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferOrderLines indicates an expected call of ListTransferOrderLines.
+func (mr *MockStoreMockRecorder) ListTransferOrderLines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferOrderLines", reflect.TypeOf((*MockStore)(nil).ListTransferOrderLines), arg0, arg1)
+}
+
+// ListTransferOrders mocks base method.
+func (m *MockStore) ListTransferOrders(arg0 context.Context, arg1 db.ListTransferOrdersParams) ([]db.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransferOrders", arg0, arg1)
+	ret0, _ := ret[0].([]db.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransferOrders indicates an expected call of ListTransferOrders.
+func (mr *MockStoreMockRecorder) ListTransferOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransferOrders", reflect.TypeOf((*MockStore)(nil).ListTransferOrders), arg0, arg1)
+}
+
+// ListUnits mocks base method.
+func (m *MockStore) ListUnits(arg0 context.Context, arg1 db.ListUnitsParams) ([]db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnits", arg0, arg1)
+	ret0, _ := ret[0].([]db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnits indicates an expected call of ListUnits.
+func (mr *MockStoreMockRecorder) ListUnits(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnits", reflect.TypeOf((*MockStore)(nil).ListUnits), arg0, arg1)
+}
+
+// ListUnitsByName mocks base method.
+func (m *MockStore) ListUnitsByName(arg0 context.Context, arg1 string) ([]db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnitsByName", arg0, arg1)
+	ret0, _ := ret[0].([]db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnitsByName indicates an expected call of ListUnitsByName.
+func (mr *MockStoreMockRecorder) ListUnitsByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnitsByName", reflect.TypeOf((*MockStore)(nil).ListUnitsByName), arg0, arg1)
+}
+
+// ListUserScopes mocks base method.
+func (m *MockStore) ListUserScopes(arg0 context.Context, arg1 string) ([]db.UserScope, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserScopes", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserScope)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserScopes indicates an expected call of ListUserScopes.
+func (mr *MockStoreMockRecorder) ListUserScopes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserScopes", reflect.TypeOf((*MockStore)(nil).ListUserScopes), arg0, arg1)
+}
+
+// ListWarehouseBinStocks mocks base method.
+func (m *MockStore) ListWarehouseBinStocks(arg0 context.Context, arg1 db.ListWarehouseBinStocksParams) ([]db.ListWarehouseBinStocksRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWarehouseBinStocks", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListWarehouseBinStocksRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWarehouseBinStocks indicates an expected call of ListWarehouseBinStocks.
+func (mr *MockStoreMockRecorder) ListWarehouseBinStocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWarehouseBinStocks", reflect.TypeOf((*MockStore)(nil).ListWarehouseBinStocks), arg0, arg1)
+}
+
+// ListWarehouses mocks base method.
+func (m *MockStore) ListWarehouses(arg0 context.Context, arg1 db.ListWarehousesParams) ([]db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWarehouses", arg0, arg1)
+	ret0, _ := ret[0].([]db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWarehouses indicates an expected call of ListWarehouses.
+func (mr *MockStoreMockRecorder) ListWarehouses(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWarehouses", reflect.TypeOf((*MockStore)(nil).ListWarehouses), arg0, arg1)
+}
+
+// LockCategoryGoods mocks base method.
+func (m *MockStore) LockCategoryGoods(arg0 context.Context, arg1 int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockCategoryGoods", arg0, arg1)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockCategoryGoods indicates an expected call of LockCategoryGoods.
+func (mr *MockStoreMockRecorder) LockCategoryGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockCategoryGoods", reflect.TypeOf((*MockStore)(nil).LockCategoryGoods), arg0, arg1)
+}
+
+// RaiseStockAlerts mocks base method.
+func (m *MockStore) RaiseStockAlerts(arg0 context.Context, arg1 sql.NullInt64) ([]db.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RaiseStockAlerts", arg0, arg1)
+	ret0, _ := ret[0].([]db.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RaiseStockAlerts indicates an expected call of RaiseStockAlerts.
+func (mr *MockStoreMockRecorder) RaiseStockAlerts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RaiseStockAlerts", reflect.TypeOf((*MockStore)(nil).RaiseStockAlerts), arg0, arg1)
+}
+
+// ReceivePurchaseOrderTx mocks base method.
+func (m *MockStore) ReceivePurchaseOrderTx(arg0 context.Context, arg1 db.ReceivePurchaseOrderTxParams) (db.ReceivePurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceivePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReceivePurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceivePurchaseOrderTx indicates an expected call of ReceivePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) ReceivePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceivePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).ReceivePurchaseOrderTx), arg0, arg1)
+}
+
+// ReceiveTransferOrderTx mocks base method.
+func (m *MockStore) ReceiveTransferOrderTx(arg0 context.Context, arg1 db.TransferOrderStatusTxParams) (db.TransferOrderMovementTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveTransferOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrderMovementTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveTransferOrderTx indicates an expected call of ReceiveTransferOrderTx.
+func (mr *MockStoreMockRecorder) ReceiveTransferOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveTransferOrderTx", reflect.TypeOf((*MockStore)(nil).ReceiveTransferOrderTx), arg0, arg1)
+}
+
+// RecordCountsTx mocks base method.
+func (m *MockStore) RecordCountsTx(arg0 context.Context, arg1 db.RecordCountsTxParams) (db.CountSessionTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordCountsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSessionTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordCountsTx indicates an expected call of RecordCountsTx.
+func (mr *MockStoreMockRecorder) RecordCountsTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordCountsTx", reflect.TypeOf((*MockStore)(nil).RecordCountsTx), arg0, arg1)
+}
+
+// ReleaseReservation mocks base method.
+func (m *MockStore) ReleaseReservation(arg0 context.Context, arg1 int64) (db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservation", arg0, arg1)
+	ret0, _ := ret[0].(db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseReservation indicates an expected call of ReleaseReservation.
+func (mr *MockStoreMockRecorder) ReleaseReservation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservation", reflect.TypeOf((*MockStore)(nil).ReleaseReservation), arg0, arg1)
+}
+
+// ReleaseReservationSerials mocks base method.
+func (m *MockStore) ReleaseReservationSerials(arg0 context.Context, arg1 sql.NullInt64) ([]db.Serial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservationSerials", arg0, arg1)
+	ret0, _ := ret[0].([]db.Serial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseReservationSerials indicates an expected call of ReleaseReservationSerials.
+func (mr *MockStoreMockRecorder) ReleaseReservationSerials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservationSerials", reflect.TypeOf((*MockStore)(nil).ReleaseReservationSerials), arg0, arg1)
+}
+
+// ReleaseReservationTx mocks base method.
+func (m *MockStore) ReleaseReservationTx(arg0 context.Context, arg1 db.ReleaseReservationTxParams) (db.Reservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseReservationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Reservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseReservationTx indicates an expected call of ReleaseReservationTx.
+func (mr *MockStoreMockRecorder) ReleaseReservationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseReservationTx", reflect.TypeOf((*MockStore)(nil).ReleaseReservationTx), arg0, arg1)
+}
+
+// ResolveStockAlerts mocks base method.
+func (m *MockStore) ResolveStockAlerts(arg0 context.Context, arg1 sql.NullInt64) ([]db.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveStockAlerts", arg0, arg1)
+	ret0, _ := ret[0].([]db.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveStockAlerts indicates an expected call of ResolveStockAlerts.
+func (mr *MockStoreMockRecorder) ResolveStockAlerts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveStockAlerts", reflect.TypeOf((*MockStore)(nil).ResolveStockAlerts), arg0, arg1)
+}
+
+// RestoreCategory mocks base method.
+func (m *MockStore) RestoreCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategory indicates an expected call of RestoreCategory.
+func (mr *MockStoreMockRecorder) RestoreCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategory", reflect.TypeOf((*MockStore)(nil).RestoreCategory), arg0, arg1)
+}
+
+// RestoreCategoryTx mocks base method.
+func (m *MockStore) RestoreCategoryTx(arg0 context.Context, arg1 db.RestoreTxParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCategoryTx indicates an expected call of RestoreCategoryTx.
+func (mr *MockStoreMockRecorder) RestoreCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCategoryTx", reflect.TypeOf((*MockStore)(nil).RestoreCategoryTx), arg0, arg1)
+}
+
+// RestoreGood mocks base method.
+func (m *MockStore) RestoreGood(arg0 context.Context, arg1 int64) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreGood", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreGood indicates an expected call of RestoreGood.
+func (mr *MockStoreMockRecorder) RestoreGood(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreGood", reflect.TypeOf((*MockStore)(nil).RestoreGood), arg0, arg1)
+}
+
+// RestoreGoodTx mocks base method.
+func (m *MockStore) RestoreGoodTx(arg0 context.Context, arg1 db.RestoreTxParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreGoodTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreGoodTx indicates an expected call of RestoreGoodTx.
+func (mr *MockStoreMockRecorder) RestoreGoodTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreGoodTx", reflect.TypeOf((*MockStore)(nil).RestoreGoodTx), arg0, arg1)
+}
+
+// RestoreUnit mocks base method.
+func (m *MockStore) RestoreUnit(arg0 context.Context, arg1 int64) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUnit indicates an expected call of RestoreUnit.
+func (mr *MockStoreMockRecorder) RestoreUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUnit", reflect.TypeOf((*MockStore)(nil).RestoreUnit), arg0, arg1)
+}
+
+// RestoreUnitTx mocks base method.
+func (m *MockStore) RestoreUnitTx(arg0 context.Context, arg1 db.RestoreTxParams) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUnitTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreUnitTx indicates an expected call of RestoreUnitTx.
+func (mr *MockStoreMockRecorder) RestoreUnitTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUnitTx", reflect.TypeOf((*MockStore)(nil).RestoreUnitTx), arg0, arg1)
+}
+
+// RevalueCategoryGoods mocks base method.
+func (m *MockStore) RevalueCategoryGoods(arg0 context.Context, arg1 int64) ([]db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevalueCategoryGoods", arg0, arg1)
+	ret0, _ := ret[0].([]db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevalueCategoryGoods indicates an expected call of RevalueCategoryGoods.
+func (mr *MockStoreMockRecorder) RevalueCategoryGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevalueCategoryGoods", reflect.TypeOf((*MockStore)(nil).RevalueCategoryGoods), arg0, arg1)
+}
+
+// SearchGoods mocks base method.
+func (m *MockStore) SearchGoods(arg0 context.Context, arg1 db.SearchGoodsParams) ([]db.SearchGoodsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchGoods", arg0, arg1)
+	ret0, _ := ret[0].([]db.SearchGoodsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchGoods indicates an expected call of SearchGoods.
+func (mr *MockStoreMockRecorder) SearchGoods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchGoods", reflect.TypeOf((*MockStore)(nil).SearchGoods), arg0, arg1)
+}
+
+// SendPurchaseOrderTx mocks base method.
+func (m *MockStore) SendPurchaseOrderTx(arg0 context.Context, arg1 db.PurchaseOrderStatusTxParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendPurchaseOrderTx indicates an expected call of SendPurchaseOrderTx.
+func (mr *MockStoreMockRecorder) SendPurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).SendPurchaseOrderTx), arg0, arg1)
+}
+
+// SetCategoryCostingMethod mocks base method.
+func (m *MockStore) SetCategoryCostingMethod(arg0 context.Context, arg1 db.SetCategoryCostingMethodParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryCostingMethod", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategoryCostingMethod indicates an expected call of SetCategoryCostingMethod.
+func (mr *MockStoreMockRecorder) SetCategoryCostingMethod(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryCostingMethod", reflect.TypeOf((*MockStore)(nil).SetCategoryCostingMethod), arg0, arg1)
+}
+
+// SetCategoryCostingMethodTx mocks base method.
+func (m *MockStore) SetCategoryCostingMethodTx(arg0 context.Context, arg1 db.SetCategoryCostingMethodTxParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCategoryCostingMethodTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCategoryCostingMethodTx indicates an expected call of SetCategoryCostingMethodTx.
+func (mr *MockStoreMockRecorder) SetCategoryCostingMethodTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCategoryCostingMethodTx", reflect.TypeOf((*MockStore)(nil).SetCategoryCostingMethodTx), arg0, arg1)
+}
+
+// SetCountSessionItemCounted mocks base method.
+func (m *MockStore) SetCountSessionItemCounted(arg0 context.Context, arg1 db.SetCountSessionItemCountedParams) (db.CountSessionItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCountSessionItemCounted", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSessionItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCountSessionItemCounted indicates an expected call of SetCountSessionItemCounted.
+func (mr *MockStoreMockRecorder) SetCountSessionItemCounted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCountSessionItemCounted", reflect.TypeOf((*MockStore)(nil).SetCountSessionItemCounted), arg0, arg1)
+}
+
+// SetGoodSku mocks base method.
+func (m *MockStore) SetGoodSku(arg0 context.Context, arg1 db.SetGoodSkuParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGoodSku", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetGoodSku indicates an expected call of SetGoodSku.
+func (mr *MockStoreMockRecorder) SetGoodSku(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGoodSku", reflect.TypeOf((*MockStore)(nil).SetGoodSku), arg0, arg1)
+}
+
+// SetGoodSkuTx mocks base method.
+func (m *MockStore) SetGoodSkuTx(arg0 context.Context, arg1 db.SetGoodSkuTxParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGoodSkuTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+// SetGoodSkuTx indicates an expected call of SetGoodSkuTx.
+func (mr *MockStoreMockRecorder) SetGoodSkuTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGoodSkuTx", reflect.TypeOf((*MockStore)(nil).SetGoodSkuTx), arg0, arg1)
 }
 
-// CreateGood mocks base method.
-func (m *MockStore) CreateGood(arg0 context.Context, arg1 db.CreateGoodParams) (db.Good, error) {
+// SetGoodStockLevels mocks base method.
+func (m *MockStore) SetGoodStockLevels(arg0 context.Context, arg1 db.SetGoodStockLevelsParams) (db.Good, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGood", arg0, arg1)
+	ret := m.ctrl.Call(m, "SetGoodStockLevels", arg0, arg1)
 	ret0, _ := ret[0].(db.Good)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateGood indicates an expected call of CreateGood.
-func (mr *MockStoreMockRecorder) CreateGood(arg0, arg1 interface{}) *gomock.Call {
+// SetGoodStockLevels indicates an expected call of SetGoodStockLevels.
+func (mr *MockStoreMockRecorder) SetGoodStockLevels(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGood", reflect.TypeOf((*MockStore)(nil).CreateGood), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGoodStockLevels", reflect.TypeOf((*MockStore)(nil).SetGoodStockLevels), arg0, arg1)
 }
 
-// CreateUnit mocks base method.
-func (m *MockStore) CreateUnit(arg0 context.Context, arg1 db.CreateUnitParams) (db.Unit, error) {
+// SetGoodStockLevelsTx mocks base method.
+func (m *MockStore) SetGoodStockLevelsTx(arg0 context.Context, arg1 db.SetGoodStockLevelsTxParams) (db.Good, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUnit", arg0, arg1)
-	ret0, _ := ret[0].(db.Unit)
+	ret := m.ctrl.Call(m, "SetGoodStockLevelsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUnit indicates an expected call of CreateUnit.
-func (mr *MockStoreMockRecorder) CreateUnit(arg0, arg1 interface{}) *gomock.Call {
+// SetGoodStockLevelsTx indicates an expected call of SetGoodStockLevelsTx.
+func (mr *MockStoreMockRecorder) SetGoodStockLevelsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUnit", reflect.TypeOf((*MockStore)(nil).CreateUnit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGoodStockLevelsTx", reflect.TypeOf((*MockStore)(nil).SetGoodStockLevelsTx), arg0, arg1)
 }
 
-// DeleteCategory mocks base method.
-func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int64) error {
+// SetStockMovementValue mocks base method.
+func (m *MockStore) SetStockMovementValue(arg0 context.Context, arg1 db.SetStockMovementValueParams) (db.StockMovement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SetStockMovementValue", arg0, arg1)
+	ret0, _ := ret[0].(db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+// SetStockMovementValue indicates an expected call of SetStockMovementValue.
+func (mr *MockStoreMockRecorder) SetStockMovementValue(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStockMovementValue", reflect.TypeOf((*MockStore)(nil).SetStockMovementValue), arg0, arg1)
 }
 
-// DeleteGood mocks base method.
-func (m *MockStore) DeleteGood(arg0 context.Context, arg1 int64) error {
+// ShipSalesOrderTx mocks base method.
+func (m *MockStore) ShipSalesOrderTx(arg0 context.Context, arg1 db.ShipSalesOrderTxParams) (db.ShipSalesOrderTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGood", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ShipSalesOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ShipSalesOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteGood indicates an expected call of DeleteGood.
-func (mr *MockStoreMockRecorder) DeleteGood(arg0, arg1 interface{}) *gomock.Call {
+// ShipSalesOrderTx indicates an expected call of ShipSalesOrderTx.
+func (mr *MockStoreMockRecorder) ShipSalesOrderTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGood", reflect.TypeOf((*MockStore)(nil).DeleteGood), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipSalesOrderTx", reflect.TypeOf((*MockStore)(nil).ShipSalesOrderTx), arg0, arg1)
 }
 
-// DeleteUnit mocks base method.
-func (m *MockStore) DeleteUnit(arg0 context.Context, arg1 int64) error {
+// ShipTransferOrderTx mocks base method.
+func (m *MockStore) ShipTransferOrderTx(arg0 context.Context, arg1 db.TransferOrderStatusTxParams) (db.TransferOrderMovementTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUnit", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ShipTransferOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrderMovementTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteUnit indicates an expected call of DeleteUnit.
-func (mr *MockStoreMockRecorder) DeleteUnit(arg0, arg1 interface{}) *gomock.Call {
+// ShipTransferOrderTx indicates an expected call of ShipTransferOrderTx.
+func (mr *MockStoreMockRecorder) ShipTransferOrderTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnit", reflect.TypeOf((*MockStore)(nil).DeleteUnit), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipTransferOrderTx", reflect.TypeOf((*MockStore)(nil).ShipTransferOrderTx), arg0, arg1)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 context.Context, arg1 int64) (db.Category, error) {
+// StartCountSessionTx mocks base method.
+func (m *MockStore) StartCountSessionTx(arg0 context.Context, arg1 db.StartCountSessionTxParams) (db.CountSessionTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "StartCountSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSessionTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 interface{}) *gomock.Call {
+// StartCountSessionTx indicates an expected call of StartCountSessionTx.
+func (mr *MockStoreMockRecorder) StartCountSessionTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCountSessionTx", reflect.TypeOf((*MockStore)(nil).StartCountSessionTx), arg0, arg1)
 }
 
-// GetGood mocks base method.
-func (m *MockStore) GetGood(arg0 context.Context, arg1 int64) (db.Good, error) {
+// StockMovementTx mocks base method.
+func (m *MockStore) StockMovementTx(arg0 context.Context, arg1 db.StockMovementTxParams) (db.StockMovementTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGood", arg0, arg1)
-	ret0, _ := ret[0].(db.Good)
+	ret := m.ctrl.Call(m, "StockMovementTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StockMovementTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGood indicates an expected call of GetGood.
-func (mr *MockStoreMockRecorder) GetGood(arg0, arg1 interface{}) *gomock.Call {
+// StockMovementTx indicates an expected call of StockMovementTx.
+func (mr *MockStoreMockRecorder) StockMovementTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGood", reflect.TypeOf((*MockStore)(nil).GetGood), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StockMovementTx", reflect.TypeOf((*MockStore)(nil).StockMovementTx), arg0, arg1)
 }
 
-// ListCategories mocks base method.
-func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+// SumBinStocks mocks base method.
+func (m *MockStore) SumBinStocks(arg0 context.Context, arg1 db.SumBinStocksParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "SumBinStocks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 interface{}) *gomock.Call {
+// SumBinStocks indicates an expected call of SumBinStocks.
+func (mr *MockStoreMockRecorder) SumBinStocks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumBinStocks", reflect.TypeOf((*MockStore)(nil).SumBinStocks), arg0, arg1)
 }
 
-// ListGoods mocks base method.
-func (m *MockStore) ListGoods(arg0 context.Context, arg1 db.ListGoodsParams) ([]db.Good, error) {
+// SumLotStocks mocks base method.
+func (m *MockStore) SumLotStocks(arg0 context.Context, arg1 db.SumLotStocksParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListGoods", arg0, arg1)
-	ret0, _ := ret[0].([]db.Good)
+	ret := m.ctrl.Call(m, "SumLotStocks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListGoods indicates an expected call of ListGoods.
-func (mr *MockStoreMockRecorder) ListGoods(arg0, arg1 interface{}) *gomock.Call {
+// SumLotStocks indicates an expected call of SumLotStocks.
+func (mr *MockStoreMockRecorder) SumLotStocks(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoods", reflect.TypeOf((*MockStore)(nil).ListGoods), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLotStocks", reflect.TypeOf((*MockStore)(nil).SumLotStocks), arg0, arg1)
 }
 
-// ListUnits mocks base method.
-func (m *MockStore) ListUnits(arg0 context.Context, arg1 db.ListUnitsParams) ([]db.Unit, error) {
+// SumWarehouseAllocated mocks base method.
+func (m *MockStore) SumWarehouseAllocated(arg0 context.Context, arg1 db.SumWarehouseAllocatedParams) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUnits", arg0, arg1)
-	ret0, _ := ret[0].([]db.Unit)
+	ret := m.ctrl.Call(m, "SumWarehouseAllocated", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUnits indicates an expected call of ListUnits.
-func (mr *MockStoreMockRecorder) ListUnits(arg0, arg1 interface{}) *gomock.Call {
+// SumWarehouseAllocated indicates an expected call of SumWarehouseAllocated.
+func (mr *MockStoreMockRecorder) SumWarehouseAllocated(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnits", reflect.TypeOf((*MockStore)(nil).ListUnits), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumWarehouseAllocated", reflect.TypeOf((*MockStore)(nil).SumWarehouseAllocated), arg0, arg1)
+}
+
+// UnitInUse mocks base method.
+func (m *MockStore) UnitInUse(arg0 context.Context, arg1 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnitInUse", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnitInUse indicates an expected call of UnitInUse.
+func (mr *MockStoreMockRecorder) UnitInUse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnitInUse", reflect.TypeOf((*MockStore)(nil).UnitInUse), arg0, arg1)
 }
 
 // UpdateCategory mocks base method.
@@ -212,6 +3022,36 @@ func (mr *MockStoreMockRecorder) UpdateCategory(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0, arg1)
 }
 
+// UpdateCategoryTx mocks base method.
+func (m *MockStore) UpdateCategoryTx(arg0 context.Context, arg1 db.UpdateCategoryTxParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategoryTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategoryTx indicates an expected call of UpdateCategoryTx.
+func (mr *MockStoreMockRecorder) UpdateCategoryTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategoryTx", reflect.TypeOf((*MockStore)(nil).UpdateCategoryTx), arg0, arg1)
+}
+
+// UpdateCountSessionStatus mocks base method.
+func (m *MockStore) UpdateCountSessionStatus(arg0 context.Context, arg1 db.UpdateCountSessionStatusParams) (db.CountSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCountSessionStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.CountSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCountSessionStatus indicates an expected call of UpdateCountSessionStatus.
+func (mr *MockStoreMockRecorder) UpdateCountSessionStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCountSessionStatus", reflect.TypeOf((*MockStore)(nil).UpdateCountSessionStatus), arg0, arg1)
+}
+
 // UpdateGood mocks base method.
 func (m *MockStore) UpdateGood(arg0 context.Context, arg1 db.UpdateGoodParams) (db.Good, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +3067,201 @@ func (mr *MockStoreMockRecorder) UpdateGood(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGood", reflect.TypeOf((*MockStore)(nil).UpdateGood), arg0, arg1)
 }
 
+// UpdateGoodSupplier mocks base method.
+func (m *MockStore) UpdateGoodSupplier(arg0 context.Context, arg1 db.UpdateGoodSupplierParams) (db.GoodSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoodSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoodSupplier indicates an expected call of UpdateGoodSupplier.
+func (mr *MockStoreMockRecorder) UpdateGoodSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoodSupplier", reflect.TypeOf((*MockStore)(nil).UpdateGoodSupplier), arg0, arg1)
+}
+
+// UpdateGoodSupplierTx mocks base method.
+func (m *MockStore) UpdateGoodSupplierTx(arg0 context.Context, arg1 db.UpdateGoodSupplierTxParams) (db.GoodSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoodSupplierTx", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoodSupplierTx indicates an expected call of UpdateGoodSupplierTx.
+func (mr *MockStoreMockRecorder) UpdateGoodSupplierTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoodSupplierTx", reflect.TypeOf((*MockStore)(nil).UpdateGoodSupplierTx), arg0, arg1)
+}
+
+// UpdateGoodTx mocks base method.
+func (m *MockStore) UpdateGoodTx(arg0 context.Context, arg1 db.UpdateGoodTxParams) (db.Good, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGoodTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Good)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGoodTx indicates an expected call of UpdateGoodTx.
+func (mr *MockStoreMockRecorder) UpdateGoodTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGoodTx", reflect.TypeOf((*MockStore)(nil).UpdateGoodTx), arg0, arg1)
+}
+
+// UpdateLabelTemplate mocks base method.
+func (m *MockStore) UpdateLabelTemplate(arg0 context.Context, arg1 db.UpdateLabelTemplateParams) (db.LabelTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabelTemplate", arg0, arg1)
+	ret0, _ := ret[0].(db.LabelTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLabelTemplate indicates an expected call of UpdateLabelTemplate.
+func (mr *MockStoreMockRecorder) UpdateLabelTemplate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabelTemplate", reflect.TypeOf((*MockStore)(nil).UpdateLabelTemplate), arg0, arg1)
+}
+
+// UpdateLabelTemplateTx mocks base method.
+func (m *MockStore) UpdateLabelTemplateTx(arg0 context.Context, arg1 db.UpdateLabelTemplateTxParams) (db.LabelTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLabelTemplateTx", arg0, arg1)
+	ret0, _ := ret[0].(db.LabelTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLabelTemplateTx indicates an expected call of UpdateLabelTemplateTx.
+func (mr *MockStoreMockRecorder) UpdateLabelTemplateTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLabelTemplateTx", reflect.TypeOf((*MockStore)(nil).UpdateLabelTemplateTx), arg0, arg1)
+}
+
+// UpdateLocation mocks base method.
+func (m *MockStore) UpdateLocation(arg0 context.Context, arg1 db.UpdateLocationParams) (db.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLocation indicates an expected call of UpdateLocation.
+func (mr *MockStoreMockRecorder) UpdateLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocation", reflect.TypeOf((*MockStore)(nil).UpdateLocation), arg0, arg1)
+}
+
+// UpdateLocationTx mocks base method.
+func (m *MockStore) UpdateLocationTx(arg0 context.Context, arg1 db.UpdateLocationTxParams) (db.Location, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLocationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Location)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLocationTx indicates an expected call of UpdateLocationTx.
+func (mr *MockStoreMockRecorder) UpdateLocationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLocationTx", reflect.TypeOf((*MockStore)(nil).UpdateLocationTx), arg0, arg1)
+}
+
+// UpdatePurchaseOrderStatus mocks base method.
+func (m *MockStore) UpdatePurchaseOrderStatus(arg0 context.Context, arg1 db.UpdatePurchaseOrderStatusParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrderStatus indicates an expected call of UpdatePurchaseOrderStatus.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrderStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrderStatus), arg0, arg1)
+}
+
+// UpdateSalesOrderStatus mocks base method.
+func (m *MockStore) UpdateSalesOrderStatus(arg0 context.Context, arg1 db.UpdateSalesOrderStatusParams) (db.SalesOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSalesOrderStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.SalesOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSalesOrderStatus indicates an expected call of UpdateSalesOrderStatus.
+func (mr *MockStoreMockRecorder) UpdateSalesOrderStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSalesOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdateSalesOrderStatus), arg0, arg1)
+}
+
+// UpdateSerial mocks base method.
+func (m *MockStore) UpdateSerial(arg0 context.Context, arg1 db.UpdateSerialParams) (db.Serial, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSerial", arg0, arg1)
+	ret0, _ := ret[0].(db.Serial)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSerial indicates an expected call of UpdateSerial.
+func (mr *MockStoreMockRecorder) UpdateSerial(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSerial", reflect.TypeOf((*MockStore)(nil).UpdateSerial), arg0, arg1)
+}
+
+// UpdateSupplier mocks base method.
+func (m *MockStore) UpdateSupplier(arg0 context.Context, arg1 db.UpdateSupplierParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSupplier indicates an expected call of UpdateSupplier.
+func (mr *MockStoreMockRecorder) UpdateSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockStore)(nil).UpdateSupplier), arg0, arg1)
+}
+
+// UpdateSupplierTx mocks base method.
+func (m *MockStore) UpdateSupplierTx(arg0 context.Context, arg1 db.UpdateSupplierTxParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSupplierTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSupplierTx indicates an expected call of UpdateSupplierTx.
+func (mr *MockStoreMockRecorder) UpdateSupplierTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplierTx", reflect.TypeOf((*MockStore)(nil).UpdateSupplierTx), arg0, arg1)
+}
+
+// UpdateTransferOrderStatus mocks base method.
+func (m *MockStore) UpdateTransferOrderStatus(arg0 context.Context, arg1 db.UpdateTransferOrderStatusParams) (db.TransferOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransferOrderStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.TransferOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransferOrderStatus indicates an expected call of UpdateTransferOrderStatus.
+func (mr *MockStoreMockRecorder) UpdateTransferOrderStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransferOrderStatus", reflect.TypeOf((*MockStore)(nil).UpdateTransferOrderStatus), arg0, arg1)
+}
+
 // UpdateUnit mocks base method.
 func (m *MockStore) UpdateUnit(arg0 context.Context, arg1 db.UpdateUnitParams) (db.Unit, error) {
 	m.ctrl.T.Helper()
@@ -241,3 +3276,78 @@ func (mr *MockStoreMockRecorder) UpdateUnit(arg0, arg1 interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUnit", reflect.TypeOf((*MockStore)(nil).UpdateUnit), arg0, arg1)
 }
+
+// UpdateUnitTx mocks base method.
+func (m *MockStore) UpdateUnitTx(arg0 context.Context, arg1 db.UpdateUnitTxParams) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUnitTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUnitTx indicates an expected call of UpdateUnitTx.
+func (mr *MockStoreMockRecorder) UpdateUnitTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUnitTx", reflect.TypeOf((*MockStore)(nil).UpdateUnitTx), arg0, arg1)
+}
+
+// UpdateUserAccessTx mocks base method.
+func (m *MockStore) UpdateUserAccessTx(arg0 context.Context, arg1 db.UpdateUserAccessTxParams) (db.UpdateUserAccessTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserAccessTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdateUserAccessTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserAccessTx indicates an expected call of UpdateUserAccessTx.
+func (mr *MockStoreMockRecorder) UpdateUserAccessTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserAccessTx", reflect.TypeOf((*MockStore)(nil).UpdateUserAccessTx), arg0, arg1)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(arg0 context.Context, arg1 db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), arg0, arg1)
+}
+
+// UpdateWarehouse mocks base method.
+func (m *MockStore) UpdateWarehouse(arg0 context.Context, arg1 db.UpdateWarehouseParams) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWarehouse", arg0, arg1)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWarehouse indicates an expected call of UpdateWarehouse.
+func (mr *MockStoreMockRecorder) UpdateWarehouse(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWarehouse", reflect.TypeOf((*MockStore)(nil).UpdateWarehouse), arg0, arg1)
+}
+
+// UpdateWarehouseTx mocks base method.
+func (m *MockStore) UpdateWarehouseTx(arg0 context.Context, arg1 db.UpdateWarehouseTxParams) (db.Warehouse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWarehouseTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Warehouse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWarehouseTx indicates an expected call of UpdateWarehouseTx.
+func (mr *MockStoreMockRecorder) UpdateWarehouseTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWarehouseTx", reflect.TypeOf((*MockStore)(nil).UpdateWarehouseTx), arg0, arg1)
+}
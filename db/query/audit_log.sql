@@ -0,0 +1,24 @@
+-- name: CreateAuditLog :one
+INSERT INTO audit_log (
+  actor,
+  action,
+  entity_type,
+  entity_id,
+  before,
+  after
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListAuditLogs :many
+-- every filter is optional, the time range includes from_time and excludes to_time
+SELECT * FROM audit_log
+WHERE
+    (sqlc.narg(entity_type)::varchar IS NULL OR entity_type = sqlc.narg(entity_type)) AND
+    (sqlc.narg(entity_id)::varchar IS NULL OR entity_id = sqlc.narg(entity_id)) AND
+    (sqlc.narg(actor)::varchar IS NULL OR actor = sqlc.narg(actor)) AND
+    (sqlc.narg(from_time)::timestamptz IS NULL OR created_at >= sqlc.narg(from_time)) AND
+    (sqlc.narg(to_time)::timestamptz IS NULL OR created_at < sqlc.narg(to_time))
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
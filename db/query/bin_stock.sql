@@ -0,0 +1,26 @@
+-- name: GetBinStock :one
+SELECT * FROM bin_stocks
+WHERE good_id = $1 AND location_id = $2 LIMIT 1;
+
+-- name: SumBinStocks :one
+SELECT COALESCE(SUM(bin_stocks.amount), 0)::bigint AS total FROM bin_stocks
+JOIN locations ON locations.id = bin_stocks.location_id
+WHERE bin_stocks.good_id = $1 AND locations.warehouse_id = $2;
+
+-- name: AddBinStock :one
+INSERT INTO bin_stocks (
+  good_id,
+  location_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (good_id, location_id) DO UPDATE
+  set amount = bin_stocks.amount + EXCLUDED.amount
+RETURNING *;
+
+-- name: ListWarehouseBinStocks :many
+-- bins of the warehouse holding the good, in the order of their code
+SELECT bin_stocks.*, locations.location_code FROM bin_stocks
+JOIN locations ON locations.id = bin_stocks.location_id
+WHERE bin_stocks.good_id = $1 AND locations.warehouse_id = $2 AND bin_stocks.amount > 0
+ORDER BY locations.location_code, bin_stocks.location_id;
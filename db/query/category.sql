@@ -8,21 +8,63 @@ INSERT INTO categories (
 
 -- name: GetCategory :one
 SELECT * FROM categories
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: GetCategoryIncludingDeleted :one
+SELECT * FROM categories
 WHERE id = $1 LIMIT 1;
 
 -- name: ListCategories :many
 SELECT * FROM categories
+WHERE sqlc.arg(include_deleted)::bool OR deleted_at IS NULL
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateCategory :one
 UPDATE categories
   set category_name = $2,
-      section_name = $3
-WHERE id = $1
+      section_name = $3,
+      version = version + 1
+WHERE id = $1 AND version = $4 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DeleteCategory :one
+-- rows are only marked as deleted, so they can be restored
+UPDATE categories
+  set deleted_at = now(),
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RestoreCategory :one
+UPDATE categories
+  set deleted_at = NULL,
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
 RETURNING *;
 
--- name: DeleteCategory :exec
-DELETE FROM categories
-WHERE id = $1;
\ No newline at end of file
+-- name: SetCategoryCostingMethod :one
+UPDATE categories
+  set costing_method = sqlc.arg(costing_method),
+      version = version + 1
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) AND deleted_at IS NULL
+RETURNING *;
+
+-- name: ListCategoriesByName :many
+-- categories are not unique by name, the section tells apart the ones of different sections
+SELECT * FROM categories
+WHERE
+    category_name = sqlc.arg(category_name) AND
+    (sqlc.narg(section_name)::varchar IS NULL OR section_name = sqlc.narg(section_name)) AND
+    deleted_at IS NULL
+ORDER BY id;
+
+-- name: ExportCategories :many
+-- the categories a batch at a time after the category after_id
+SELECT * FROM categories
+WHERE
+    id > sqlc.arg(after_id) AND
+    (sqlc.arg(include_deleted)::bool OR deleted_at IS NULL)
+ORDER BY id
+LIMIT sqlc.arg('limit');
\ No newline at end of file
@@ -0,0 +1,31 @@
+-- name: CreateCostLayer :one
+INSERT INTO cost_layers (
+  good_id,
+  stock_movement_id,
+  quantity,
+  remaining,
+  value,
+  remaining_value
+) VALUES (
+  sqlc.arg(good_id), sqlc.arg(stock_movement_id), sqlc.arg(quantity), sqlc.arg(quantity), sqlc.arg(value), sqlc.arg(value)
+) RETURNING *;
+
+-- name: ListOpenCostLayers :many
+-- layers of the good with stock left in the order they are issued, the oldest first
+SELECT * FROM cost_layers
+WHERE good_id = $1 AND remaining > 0
+ORDER BY id;
+
+-- name: ConsumeCostLayer :one
+UPDATE cost_layers
+  set remaining = remaining - sqlc.arg(quantity),
+      remaining_value = remaining_value - sqlc.arg(value)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListCostLayers :many
+SELECT * FROM cost_layers
+WHERE good_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
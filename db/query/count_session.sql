@@ -0,0 +1,35 @@
+-- name: CreateCountSession :one
+INSERT INTO count_sessions (
+  warehouse_id,
+  category_id,
+  section_name,
+  location_id
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetCountSession :one
+SELECT * FROM count_sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCountSessionForUpdate :one
+SELECT * FROM count_sessions
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListCountSessions :many
+SELECT * FROM count_sessions
+WHERE
+    (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)) AND
+    (sqlc.narg(warehouse_id)::bigint IS NULL OR warehouse_id = sqlc.narg(warehouse_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateCountSessionStatus :one
+-- sets the status and stamps the time the session was closed
+UPDATE count_sessions
+  set status = sqlc.arg(status),
+      closed_at = CASE WHEN sqlc.arg(status) = 'open' THEN NULL ELSE now() END
+WHERE id = sqlc.arg(id)
+RETURNING *;
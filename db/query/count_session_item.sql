@@ -0,0 +1,53 @@
+-- name: CreateCountSessionItem :one
+INSERT INTO count_session_items (
+  count_session_id,
+  good_id,
+  location_id,
+  snapshot
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListCountSessionItems :many
+SELECT * FROM count_session_items
+WHERE count_session_id = $1
+ORDER BY id;
+
+-- name: SetCountSessionItemCounted :one
+UPDATE count_session_items
+  set counted = sqlc.arg(counted),
+      counted_by = sqlc.arg(counted_by),
+      counted_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListCountSnapshot :many
+-- stock in the warehouse outside its bins of the goods of a category or a section, zero for goods it does not hold
+SELECT goods.id AS good_id, (COALESCE(good_balances.amount, 0) - COALESCE(binned.total, 0))::bigint AS amount FROM goods
+JOIN categories ON categories.id = goods.category
+LEFT JOIN good_balances ON good_balances.good_id = goods.id AND good_balances.warehouse_id = sqlc.arg(warehouse_id)
+LEFT JOIN (
+    SELECT bin_stocks.good_id, SUM(bin_stocks.amount) AS total FROM bin_stocks
+    JOIN locations ON locations.id = bin_stocks.location_id
+    WHERE locations.warehouse_id = sqlc.arg(warehouse_id)
+    GROUP BY bin_stocks.good_id
+) binned ON binned.good_id = goods.id
+WHERE
+    goods.deleted_at IS NULL AND
+    (sqlc.narg(category_id)::bigint IS NULL OR goods.category = sqlc.narg(category_id)) AND
+    (sqlc.narg(section_name)::varchar IS NULL OR categories.section_name = sqlc.narg(section_name))
+ORDER BY goods.id;
+
+-- name: ListCountBinSnapshot :many
+-- stock in the bins of the warehouse of the goods of a category or a section
+SELECT bin_stocks.good_id, bin_stocks.location_id, bin_stocks.amount FROM bin_stocks
+JOIN locations ON locations.id = bin_stocks.location_id
+JOIN goods ON goods.id = bin_stocks.good_id
+JOIN categories ON categories.id = goods.category
+WHERE
+    locations.warehouse_id = sqlc.arg(warehouse_id) AND
+    bin_stocks.amount > 0 AND
+    goods.deleted_at IS NULL AND
+    (sqlc.narg(category_id)::bigint IS NULL OR goods.category = sqlc.narg(category_id)) AND
+    (sqlc.narg(section_name)::varchar IS NULL OR categories.section_name = sqlc.narg(section_name))
+ORDER BY bin_stocks.good_id, bin_stocks.location_id;
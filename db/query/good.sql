@@ -4,31 +4,215 @@ INSERT INTO goods (
   model,
   unit,
   amount,
-  good_desc
+  good_desc,
+  reorder_point,
+  safety_stock,
+  max_level,
+  serialized,
+  sku
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetGood :one
 SELECT * FROM goods
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: GetGoodIncludingDeleted :one
+SELECT * FROM goods
 WHERE id = $1 LIMIT 1;
 
--- name: ListGoods :many
+-- name: GetGoodForUpdate :one
 SELECT * FROM goods
-WHERE 
-    category = $1 OR
-    model = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: GetGoodIncludingDeletedForUpdate :one
+SELECT * FROM goods
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListGoods :many
+-- every filter is optional and the given ones all apply. The goods are sorted by up to three keys
+-- and then by id, a key is one of id, model, amount and created_at and sorts descending with a leading -.
+SELECT goods.* FROM goods
+JOIN categories ON categories.id = goods.category
+WHERE
+    (sqlc.narg(category)::bigint IS NULL OR goods.category = sqlc.narg(category)) AND
+    (sqlc.narg(section)::varchar IS NULL OR categories.section_name = sqlc.narg(section)) AND
+    (sqlc.narg(unit)::bigint IS NULL OR goods.unit = sqlc.narg(unit)) AND
+    (sqlc.narg(model_prefix)::varchar IS NULL OR starts_with(goods.model, sqlc.narg(model_prefix))) AND
+    (sqlc.narg(min_amount)::bigint IS NULL OR goods.amount >= sqlc.narg(min_amount)) AND
+    (sqlc.narg(max_amount)::bigint IS NULL OR goods.amount <= sqlc.narg(max_amount)) AND
+    (sqlc.narg(created_from)::timestamptz IS NULL OR goods.created_at >= sqlc.narg(created_from)) AND
+    (sqlc.narg(created_to)::timestamptz IS NULL OR goods.created_at < sqlc.narg(created_to)) AND
+    (sqlc.narg(warehouse_id)::bigint IS NULL OR goods.id IN (
+        SELECT good_id FROM good_balances
+        WHERE warehouse_id = sqlc.narg(warehouse_id)
+    )) AND
+    (sqlc.arg(include_deleted)::bool OR goods.deleted_at IS NULL)
+ORDER BY
+    CASE WHEN sqlc.arg(sort1)::text = 'id' THEN goods.id END,
+    CASE WHEN sqlc.arg(sort1)::text = '-id' THEN goods.id END DESC,
+    CASE WHEN sqlc.arg(sort1)::text = 'model' THEN goods.model END,
+    CASE WHEN sqlc.arg(sort1)::text = '-model' THEN goods.model END DESC,
+    CASE WHEN sqlc.arg(sort1)::text = 'amount' THEN goods.amount END,
+    CASE WHEN sqlc.arg(sort1)::text = '-amount' THEN goods.amount END DESC,
+    CASE WHEN sqlc.arg(sort1)::text = 'created_at' THEN goods.created_at END,
+    CASE WHEN sqlc.arg(sort1)::text = '-created_at' THEN goods.created_at END DESC,
+    CASE WHEN sqlc.arg(sort2)::text = 'id' THEN goods.id END,
+    CASE WHEN sqlc.arg(sort2)::text = '-id' THEN goods.id END DESC,
+    CASE WHEN sqlc.arg(sort2)::text = 'model' THEN goods.model END,
+    CASE WHEN sqlc.arg(sort2)::text = '-model' THEN goods.model END DESC,
+    CASE WHEN sqlc.arg(sort2)::text = 'amount' THEN goods.amount END,
+    CASE WHEN sqlc.arg(sort2)::text = '-amount' THEN goods.amount END DESC,
+    CASE WHEN sqlc.arg(sort2)::text = 'created_at' THEN goods.created_at END,
+    CASE WHEN sqlc.arg(sort2)::text = '-created_at' THEN goods.created_at END DESC,
+    CASE WHEN sqlc.arg(sort3)::text = 'id' THEN goods.id END,
+    CASE WHEN sqlc.arg(sort3)::text = '-id' THEN goods.id END DESC,
+    CASE WHEN sqlc.arg(sort3)::text = 'model' THEN goods.model END,
+    CASE WHEN sqlc.arg(sort3)::text = '-model' THEN goods.model END DESC,
+    CASE WHEN sqlc.arg(sort3)::text = 'amount' THEN goods.amount END,
+    CASE WHEN sqlc.arg(sort3)::text = '-amount' THEN goods.amount END DESC,
+    CASE WHEN sqlc.arg(sort3)::text = 'created_at' THEN goods.created_at END,
+    CASE WHEN sqlc.arg(sort3)::text = '-created_at' THEN goods.created_at END DESC,
+    goods.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateGood :one
 UPDATE goods
   set unit = $2,
-      amount = $3
+      amount = $3,
+      version = version + 1
 WHERE id = $1
 RETURNING *;
 
--- name: DeleteGood :exec
-DELETE FROM goods
-WHERE id = $1;
\ No newline at end of file
+-- name: AddGoodAmount :one
+UPDATE goods
+  set amount = amount + sqlc.arg(amount),
+      version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AddGoodReserved :one
+-- reservations do not change the version, they are not edited through the good
+UPDATE goods
+  set reserved = reserved + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AddGoodInTransit :one
+UPDATE goods
+  set in_transit = in_transit + sqlc.arg(amount),
+      version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetGoodStockLevels :one
+UPDATE goods
+  set reorder_point = sqlc.arg(reorder_point),
+      safety_stock = sqlc.arg(safety_stock),
+      max_level = sqlc.arg(max_level),
+      version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListLowStockGoods :many
+-- goods whose available stock is below their reorder point, the lowest first
+SELECT * FROM goods
+WHERE
+    deleted_at IS NULL AND
+    amount - reserved < reorder_point AND
+    (sqlc.narg(category)::bigint IS NULL OR category = sqlc.narg(category))
+ORDER BY amount - reserved - reorder_point, id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: DeleteGood :one
+-- rows are only marked as deleted, so they can be restored
+UPDATE goods
+  set deleted_at = now(),
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RestoreGood :one
+UPDATE goods
+  set deleted_at = NULL,
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: AddGoodStockValue :one
+-- the value follows the movements, it does not change the version
+UPDATE goods
+  set stock_value = stock_value + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: LockCategoryGoods :many
+-- locks the goods of the category in the order of their id
+SELECT id FROM goods
+WHERE category = $1
+ORDER BY id
+FOR NO KEY UPDATE;
+
+-- name: RevalueCategoryGoods :many
+-- sets the value of the goods of the category to the value left in their cost layers
+UPDATE goods
+  set stock_value = COALESCE((
+    SELECT SUM(cost_layers.remaining_value) FROM cost_layers
+    WHERE cost_layers.good_id = goods.id
+  ), 0)
+WHERE category = $1
+RETURNING *;
+
+-- name: GetGoodBySku :one
+SELECT * FROM goods
+WHERE sku = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: SetGoodSku :one
+UPDATE goods
+  set sku = sqlc.arg(sku),
+      version = version + 1
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ExportGoods :many
+-- the goods with the names of their category and unit, a batch at a time after the good after_id.
+-- The filters are the ones of ListGoods.
+SELECT
+    goods.id,
+    goods.model,
+    goods.good_desc,
+    goods.sku,
+    categories.category_name,
+    categories.section_name,
+    units.unit_name,
+    goods.amount,
+    goods.reserved,
+    goods.in_transit,
+    goods.stock_value,
+    goods.created_at,
+    goods.deleted_at
+FROM goods
+JOIN categories ON categories.id = goods.category
+JOIN units ON units.id = goods.unit
+WHERE
+    goods.id > sqlc.arg(after_id) AND
+    (sqlc.narg(category)::bigint IS NULL OR goods.category = sqlc.narg(category)) AND
+    (sqlc.narg(section)::varchar IS NULL OR categories.section_name = sqlc.narg(section)) AND
+    (sqlc.narg(unit)::bigint IS NULL OR goods.unit = sqlc.narg(unit)) AND
+    (sqlc.narg(model_prefix)::varchar IS NULL OR starts_with(goods.model, sqlc.narg(model_prefix))) AND
+    (sqlc.narg(min_amount)::bigint IS NULL OR goods.amount >= sqlc.narg(min_amount)) AND
+    (sqlc.narg(max_amount)::bigint IS NULL OR goods.amount <= sqlc.narg(max_amount)) AND
+    (sqlc.narg(created_from)::timestamptz IS NULL OR goods.created_at >= sqlc.narg(created_from)) AND
+    (sqlc.narg(created_to)::timestamptz IS NULL OR goods.created_at < sqlc.narg(created_to)) AND
+    (sqlc.narg(warehouse_id)::bigint IS NULL OR goods.id IN (
+        SELECT good_id FROM good_balances
+        WHERE warehouse_id = sqlc.narg(warehouse_id)
+    )) AND
+    (sqlc.arg(include_deleted)::bool OR goods.deleted_at IS NULL)
+ORDER BY goods.id
+LIMIT sqlc.arg('limit');
\ No newline at end of file
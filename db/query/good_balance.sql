@@ -0,0 +1,20 @@
+-- name: GetGoodBalance :one
+SELECT * FROM good_balances
+WHERE good_id = $1 AND warehouse_id = $2 LIMIT 1;
+
+-- name: ListGoodBalances :many
+SELECT good_balances.*, warehouses.warehouse_name FROM good_balances
+JOIN warehouses ON warehouses.id = good_balances.warehouse_id
+WHERE good_balances.good_id = $1
+ORDER BY good_balances.warehouse_id;
+
+-- name: AddGoodBalance :one
+INSERT INTO good_balances (
+  good_id,
+  warehouse_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (good_id, warehouse_id) DO UPDATE
+  set amount = good_balances.amount + EXCLUDED.amount
+RETURNING *;
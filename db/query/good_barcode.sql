@@ -0,0 +1,22 @@
+-- name: CreateGoodBarcode :one
+INSERT INTO good_barcodes (
+  good_id,
+  barcode,
+  symbology
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetGoodBarcode :one
+SELECT * FROM good_barcodes
+WHERE barcode = $1 LIMIT 1;
+
+-- name: ListGoodBarcodes :many
+SELECT * FROM good_barcodes
+WHERE good_id = $1
+ORDER BY id;
+
+-- name: DeleteGoodBarcode :one
+DELETE FROM good_barcodes
+WHERE good_id = $1 AND barcode = $2
+RETURNING *;
@@ -0,0 +1,42 @@
+-- name: GetGoodLabel :one
+-- what goes on the label of a good, with the first of its barcodes
+SELECT
+    goods.id AS good_id,
+    goods.model,
+    goods.sku,
+    categories.category_name,
+    units.unit_name,
+    first_barcode.barcode,
+    first_barcode.symbology
+FROM goods
+JOIN categories ON categories.id = goods.category
+JOIN units ON units.id = goods.unit
+LEFT JOIN LATERAL (
+  SELECT good_barcodes.barcode, good_barcodes.symbology FROM good_barcodes
+  WHERE good_barcodes.good_id = goods.id
+  ORDER BY good_barcodes.id
+  LIMIT 1
+) AS first_barcode ON true
+WHERE goods.id = $1 AND goods.deleted_at IS NULL LIMIT 1;
+
+-- name: ListCategoryLabels :many
+-- what goes on the labels of all goods of a category, with the first of their barcodes
+SELECT
+    goods.id AS good_id,
+    goods.model,
+    goods.sku,
+    categories.category_name,
+    units.unit_name,
+    first_barcode.barcode,
+    first_barcode.symbology
+FROM goods
+JOIN categories ON categories.id = goods.category
+JOIN units ON units.id = goods.unit
+LEFT JOIN LATERAL (
+  SELECT good_barcodes.barcode, good_barcodes.symbology FROM good_barcodes
+  WHERE good_barcodes.good_id = goods.id
+  ORDER BY good_barcodes.id
+  LIMIT 1
+) AS first_barcode ON true
+WHERE goods.category = $1 AND goods.deleted_at IS NULL
+ORDER BY goods.id;
@@ -0,0 +1,64 @@
+-- name: SearchGoods :many
+-- goods with all words of the query in their model, description or category name, or with words resembling
+-- all of them despite typos, the best match first. The snippets mark the words matched as written with <mark>,
+-- words only matched despite a typo are not marked.
+-- The candidates are looked up in the search document and trigram indexes of the goods, goods in categories whose
+-- name shares or resembles a word of the query are checked too, as the category can hold the other words.
+WITH candidates AS (
+    SELECT goods.id FROM goods
+    WHERE
+        (setweight(to_tsvector('english', goods.model), 'A') || setweight(to_tsvector('english', goods.good_desc), 'B')) @@
+        websearch_to_tsquery('english', sqlc.arg(query))
+    UNION
+    SELECT goods.id FROM regexp_split_to_table(sqlc.arg(query), '\s+') AS word
+    JOIN goods ON word <% goods.model OR word <% goods.good_desc
+    WHERE word <> ''
+    UNION
+    SELECT goods.id FROM categories
+    JOIN goods ON goods.category = categories.id
+    WHERE
+        tsvector_to_array(to_tsvector('english', categories.category_name)) &&
+        tsvector_to_array(to_tsvector('english', sqlc.arg(query))) OR
+        EXISTS (
+            SELECT 1 FROM regexp_split_to_table(sqlc.arg(query), '\s+') AS word
+            WHERE word <> '' AND word <% categories.category_name
+        )
+)
+SELECT
+    goods.*,
+    categories.category_name,
+    (ts_rank(search.document, search.terms) + greatest(
+        word_similarity(search.query_text, goods.model),
+        word_similarity(search.query_text, goods.good_desc)
+    ))::float8 AS rank,
+    ts_headline('english', goods.model, search.terms, 'HighlightAll=true, StartSel=<mark>, StopSel=</mark>') AS model_snippet,
+    ts_headline('english', goods.good_desc, search.terms, 'MinWords=10, MaxWords=30, StartSel=<mark>, StopSel=</mark>') AS desc_snippet
+FROM candidates
+JOIN goods ON goods.id = candidates.id
+JOIN categories ON categories.id = goods.category
+CROSS JOIN LATERAL (
+    SELECT
+        setweight(to_tsvector('english', goods.model), 'A') ||
+        setweight(to_tsvector('english', goods.good_desc), 'B') ||
+        setweight(to_tsvector('english', categories.category_name), 'C') AS document,
+        sqlc.arg(query)::text AS query_text,
+        websearch_to_tsquery('english', sqlc.arg(query)) AS terms
+) AS search
+WHERE
+    goods.deleted_at IS NULL AND
+    (sqlc.narg(category)::bigint IS NULL OR goods.category = sqlc.narg(category)) AND
+    (
+        search.document @@ search.terms OR
+        -- every word resembles a word of the model, the description or the category name
+        NOT EXISTS (
+            SELECT 1 FROM regexp_split_to_table(search.query_text, '\s+') AS word
+            WHERE word <> '' AND NOT (
+                word <% goods.model OR
+                word <% goods.good_desc OR
+                word <% categories.category_name
+            )
+        )
+    )
+ORDER BY rank DESC, goods.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,32 @@
+-- name: CreateGoodSupplier :one
+INSERT INTO good_suppliers (
+  good_id,
+  supplier_id,
+  part_number,
+  unit_price,
+  min_order_qty
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetGoodSupplier :one
+SELECT * FROM good_suppliers
+WHERE good_id = $1 AND supplier_id = $2 LIMIT 1;
+
+-- name: ListGoodSuppliers :many
+SELECT good_suppliers.*, suppliers.supplier_name, suppliers.lead_time_days, suppliers.currency FROM good_suppliers
+JOIN suppliers ON suppliers.id = good_suppliers.supplier_id
+WHERE good_suppliers.good_id = $1
+ORDER BY good_suppliers.supplier_id;
+
+-- name: UpdateGoodSupplier :one
+UPDATE good_suppliers
+  set part_number = $3,
+      unit_price = $4,
+      min_order_qty = $5
+WHERE good_id = $1 AND supplier_id = $2
+RETURNING *;
+
+-- name: DeleteGoodSupplier :exec
+DELETE FROM good_suppliers
+WHERE good_id = $1 AND supplier_id = $2;
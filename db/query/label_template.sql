@@ -0,0 +1,40 @@
+-- name: CreateLabelTemplate :one
+INSERT INTO label_templates (
+  template_name,
+  width_mm,
+  height_mm,
+  dpi,
+  code_type,
+  show_model,
+  show_category,
+  show_unit
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: GetLabelTemplate :one
+SELECT * FROM label_templates
+WHERE id = $1 LIMIT 1;
+
+-- name: ListLabelTemplates :many
+SELECT * FROM label_templates
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateLabelTemplate :one
+UPDATE label_templates
+  set template_name = $2,
+      width_mm = $3,
+      height_mm = $4,
+      dpi = $5,
+      code_type = $6,
+      show_model = $7,
+      show_category = $8,
+      show_unit = $9
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLabelTemplate :exec
+DELETE FROM label_templates
+WHERE id = $1;
@@ -0,0 +1,48 @@
+-- name: CreateLocation :one
+INSERT INTO locations (
+  warehouse_id,
+  parent_id,
+  location_type,
+  location_code
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetLocation :one
+SELECT * FROM locations
+WHERE id = $1 LIMIT 1;
+
+-- name: ListLocations :many
+SELECT * FROM locations
+WHERE warehouse_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: UpdateLocation :one
+UPDATE locations
+  set location_code = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteLocation :exec
+DELETE FROM locations
+WHERE id = $1;
+
+-- name: ListLocationContents :many
+WITH RECURSIVE subtree AS (
+  SELECT locations.id FROM locations
+  WHERE locations.id = sqlc.arg(id)
+  UNION ALL
+  SELECT child.id FROM locations child
+  JOIN subtree ON child.parent_id = subtree.id
+)
+SELECT bin_stocks.good_id, bin_stocks.location_id, bin_stocks.amount, locations.location_code, goods.model
+FROM bin_stocks
+JOIN subtree ON subtree.id = bin_stocks.location_id
+JOIN locations ON locations.id = bin_stocks.location_id
+JOIN goods ON goods.id = bin_stocks.good_id
+WHERE
+    bin_stocks.amount > 0 AND
+    (sqlc.narg(category)::bigint IS NULL OR goods.category = sqlc.narg(category))
+ORDER BY bin_stocks.location_id, bin_stocks.good_id;
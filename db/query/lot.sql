@@ -0,0 +1,37 @@
+-- name: CreateLot :one
+INSERT INTO lots (
+  good_id,
+  lot_number,
+  manufactured_on,
+  expires_on
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetLot :one
+SELECT * FROM lots
+WHERE id = $1 LIMIT 1;
+
+-- name: ListGoodLots :many
+-- lots of the good with the stock they hold in all warehouses, the first to expire first
+SELECT lots.*, COALESCE(SUM(lot_stocks.amount), 0)::bigint AS amount FROM lots
+LEFT JOIN lot_stocks ON lot_stocks.lot_id = lots.id
+WHERE lots.good_id = $1
+GROUP BY lots.id
+ORDER BY lots.expires_on NULLS LAST, lots.id
+LIMIT $2
+OFFSET $3;
+
+-- name: ListExpiringLots :many
+-- lots holding stock that expire on the given day or before, expired lots included
+SELECT lots.*, lot_stocks.warehouse_id, lot_stocks.amount FROM lots
+JOIN lot_stocks ON lot_stocks.lot_id = lots.id
+JOIN goods ON goods.id = lots.good_id
+WHERE
+    lot_stocks.amount > 0 AND
+    lots.expires_on <= sqlc.arg(expires_on)::date AND
+    (sqlc.narg(warehouse_id)::bigint IS NULL OR lot_stocks.warehouse_id = sqlc.narg(warehouse_id)) AND
+    (sqlc.narg(category)::bigint IS NULL OR goods.category = sqlc.narg(category))
+ORDER BY lots.expires_on, lots.id, lot_stocks.warehouse_id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,44 @@
+-- name: GetLotStock :one
+SELECT * FROM lot_stocks
+WHERE lot_id = $1 AND warehouse_id = $2 LIMIT 1;
+
+-- name: AddLotStock :one
+INSERT INTO lot_stocks (
+  lot_id,
+  warehouse_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) ON CONFLICT (lot_id, warehouse_id) DO UPDATE
+  set amount = lot_stocks.amount + EXCLUDED.amount
+RETURNING *;
+
+-- name: SumLotStocks :one
+SELECT COALESCE(SUM(lot_stocks.amount), 0)::bigint AS total FROM lot_stocks
+JOIN lots ON lots.id = lot_stocks.lot_id
+WHERE lots.good_id = $1 AND lot_stocks.warehouse_id = $2;
+
+-- name: ListIssuableLotStocks :many
+-- lots of the good holding stock in the warehouse in the order they are issued, the first to expire first
+SELECT lot_stocks.*, lots.expires_on FROM lot_stocks
+JOIN lots ON lots.id = lot_stocks.lot_id
+WHERE
+    lots.good_id = sqlc.arg(good_id) AND
+    lot_stocks.warehouse_id = sqlc.arg(warehouse_id) AND
+    lot_stocks.amount > 0 AND
+    (sqlc.arg(include_expired)::bool OR lots.expires_on IS NULL OR lots.expires_on >= CURRENT_DATE)
+ORDER BY lots.expires_on NULLS LAST, lots.id;
+
+-- name: CreateLotMovement :one
+INSERT INTO lot_movements (
+  stock_movement_id,
+  lot_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListLotMovements :many
+SELECT * FROM lot_movements
+WHERE stock_movement_id = $1
+ORDER BY lot_id;
@@ -0,0 +1,35 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+  supplier_id,
+  warehouse_id,
+  reference
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT * FROM purchase_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT * FROM purchase_orders
+WHERE
+    (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)) AND
+    (sqlc.narg(supplier_id)::bigint IS NULL OR supplier_id = sqlc.narg(supplier_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdatePurchaseOrderStatus :one
+-- sets the status and stamps the time the order was sent or closed
+UPDATE purchase_orders
+  set status = sqlc.arg(status),
+      sent_at = CASE WHEN sqlc.arg(status) = 'sent' THEN now() ELSE sent_at END,
+      closed_at = CASE WHEN sqlc.arg(status) = 'closed' THEN now() ELSE closed_at END
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,30 @@
+-- name: CreatePurchaseOrderLine :one
+INSERT INTO purchase_order_lines (
+  purchase_order_id,
+  good_id,
+  unit_id,
+  ordered,
+  unit_price
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetPurchaseOrderLine :one
+SELECT * FROM purchase_order_lines
+WHERE id = $1 LIMIT 1;
+
+-- name: ListPurchaseOrderLines :many
+SELECT * FROM purchase_order_lines
+WHERE purchase_order_id = $1
+ORDER BY id;
+
+-- name: AddPurchaseOrderLineReceived :one
+UPDATE purchase_order_lines
+  set received = received + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CountOpenPurchaseOrderLines :one
+-- lines of the purchase order that have not been fully received yet
+SELECT count(*)::bigint AS open_lines FROM purchase_order_lines
+WHERE purchase_order_id = $1 AND received < ordered;
@@ -0,0 +1,43 @@
+-- name: CreateReservation :one
+INSERT INTO reservations (
+  good_id,
+  amount,
+  reference,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetReservation :one
+SELECT * FROM reservations
+WHERE id = $1 LIMIT 1;
+
+-- name: ListReservations :many
+SELECT * FROM reservations
+WHERE
+    good_id = sqlc.arg(good_id) AND
+    (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ReleaseReservation :one
+UPDATE reservations
+  set status = 'released',
+      closed_at = now()
+WHERE id = $1 AND status = 'active'
+RETURNING *;
+
+-- name: ListOverdueReservationGoods :many
+-- goods holding active reservations that expired at the given time
+SELECT good_id FROM reservations
+WHERE status = 'active' AND expires_at <= sqlc.arg(now)
+GROUP BY good_id
+ORDER BY good_id;
+
+-- name: ExpireReservations :many
+UPDATE reservations
+  set status = 'expired',
+      closed_at = now()
+WHERE good_id = sqlc.arg(good_id) AND status = 'active' AND expires_at <= sqlc.arg(now)
+RETURNING *;
@@ -0,0 +1,38 @@
+-- name: CreateSalesOrder :one
+INSERT INTO sales_orders (
+  customer_name,
+  warehouse_id,
+  reference
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetSalesOrder :one
+SELECT * FROM sales_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetSalesOrderForUpdate :one
+SELECT * FROM sales_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListSalesOrders :many
+SELECT * FROM sales_orders
+WHERE
+    (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)) AND
+    (sqlc.narg(category)::bigint IS NULL OR EXISTS (
+        SELECT 1 FROM sales_order_lines
+        JOIN goods ON goods.id = sales_order_lines.good_id
+        WHERE sales_order_lines.sales_order_id = sales_orders.id AND goods.category = sqlc.narg(category)
+    ))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateSalesOrderStatus :one
+-- sets the status and stamps the time the order was shipped
+UPDATE sales_orders
+  set status = sqlc.arg(status),
+      shipped_at = CASE WHEN sqlc.arg(status) = 'shipped' THEN now() ELSE shipped_at END
+WHERE id = sqlc.arg(id)
+RETURNING *;
@@ -0,0 +1,28 @@
+-- name: CreateSalesOrderLine :one
+INSERT INTO sales_order_lines (
+  sales_order_id,
+  good_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListSalesOrderLines :many
+SELECT * FROM sales_order_lines
+WHERE sales_order_id = $1
+ORDER BY id;
+
+-- name: AddSalesOrderLineAllocated :one
+UPDATE sales_order_lines
+  set allocated = allocated + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SumWarehouseAllocated :one
+-- stock of the good held by the sales orders of the warehouse that have not been shipped or cancelled
+SELECT COALESCE(SUM(sales_order_lines.allocated), 0)::bigint AS total FROM sales_order_lines
+JOIN sales_orders ON sales_orders.id = sales_order_lines.sales_order_id
+WHERE
+    sales_order_lines.good_id = $1 AND
+    sales_orders.warehouse_id = $2 AND
+    sales_orders.status IN ('open', 'allocated');
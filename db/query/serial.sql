@@ -0,0 +1,41 @@
+-- name: CreateSerial :one
+INSERT INTO serials (
+  good_id,
+  serial_number,
+  status,
+  warehouse_id
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetSerialByNumber :one
+SELECT * FROM serials
+WHERE serial_number = $1 LIMIT 1;
+
+-- name: UpdateSerial :one
+UPDATE serials
+  set status = $2,
+  warehouse_id = $3,
+  reservation_id = $4,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ReleaseReservationSerials :many
+-- gives the units held by the reservation back to the stock
+UPDATE serials
+  set status = 'in_stock',
+  reservation_id = NULL,
+  updated_at = now()
+WHERE reservation_id = $1
+RETURNING *;
+
+-- name: ListGoodSerials :many
+SELECT * FROM serials
+WHERE
+    good_id = sqlc.arg(good_id) AND
+    (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)) AND
+    (sqlc.narg(warehouse_id)::bigint IS NULL OR warehouse_id = sqlc.narg(warehouse_id))
+ORDER BY serial_number
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
@@ -0,0 +1,16 @@
+-- name: CreateSerialEvent :one
+INSERT INTO serial_events (
+  serial_id,
+  status,
+  warehouse_id,
+  stock_movement_id,
+  reservation_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListSerialEvents :many
+-- lifecycle of the serial, the oldest event first
+SELECT * FROM serial_events
+WHERE serial_id = $1
+ORDER BY id;
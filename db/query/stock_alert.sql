@@ -0,0 +1,37 @@
+-- name: RaiseStockAlerts :many
+-- opens an alert for the goods below their reorder point that have none open, only for the given good when one is given
+INSERT INTO stock_alerts (
+  good_id,
+  available,
+  reorder_point,
+  below_safety_stock
+)
+SELECT id, amount - reserved, reorder_point, amount - reserved < safety_stock FROM goods
+WHERE
+    deleted_at IS NULL AND
+    amount - reserved < reorder_point AND
+    (sqlc.narg(good_id)::bigint IS NULL OR id = sqlc.narg(good_id))
+ORDER BY id
+ON CONFLICT (good_id) WHERE resolved_at IS NULL DO NOTHING
+RETURNING *;
+
+-- name: ResolveStockAlerts :many
+-- closes the open alerts of the goods back at their reorder point or deleted, only for the given good when one is given
+UPDATE stock_alerts
+  set resolved_at = now()
+FROM goods
+WHERE
+    goods.id = stock_alerts.good_id AND
+    stock_alerts.resolved_at IS NULL AND
+    (goods.deleted_at IS NOT NULL OR goods.amount - goods.reserved >= goods.reorder_point) AND
+    (sqlc.narg(good_id)::bigint IS NULL OR goods.id = sqlc.narg(good_id))
+RETURNING stock_alerts.*;
+
+-- name: ListStockAlerts :many
+SELECT * FROM stock_alerts
+WHERE
+    (sqlc.narg(good_id)::bigint IS NULL OR good_id = sqlc.narg(good_id)) AND
+    (sqlc.arg(include_resolved)::bool OR resolved_at IS NULL)
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
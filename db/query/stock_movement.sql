@@ -0,0 +1,27 @@
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (
+  good_id,
+  warehouse_id,
+  location_id,
+  movement_type,
+  amount
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetStockMovement :one
+SELECT * FROM stock_movements
+WHERE id = $1 LIMIT 1;
+
+-- name: ListStockMovements :many
+SELECT * FROM stock_movements
+WHERE good_id = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: SetStockMovementValue :one
+UPDATE stock_movements
+  set value = sqlc.arg(value)
+WHERE id = sqlc.arg(id)
+RETURNING *;
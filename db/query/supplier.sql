@@ -0,0 +1,38 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (
+  supplier_name,
+  contact_name,
+  email,
+  phone,
+  address,
+  lead_time_days,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateSupplier :one
+UPDATE suppliers
+  set supplier_name = $2,
+      contact_name = $3,
+      email = $4,
+      phone = $5,
+      address = $6,
+      lead_time_days = $7,
+      currency = $8
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSupplier :exec
+DELETE FROM suppliers
+WHERE id = $1;
@@ -0,0 +1,35 @@
+-- name: CreateTransferOrder :one
+INSERT INTO transfer_orders (
+  from_warehouse_id,
+  to_warehouse_id,
+  reference
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetTransferOrder :one
+SELECT * FROM transfer_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferOrderForUpdate :one
+SELECT * FROM transfer_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListTransferOrders :many
+SELECT * FROM transfer_orders
+WHERE
+    (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)) AND
+    (sqlc.narg(warehouse_id)::bigint IS NULL OR from_warehouse_id = sqlc.narg(warehouse_id) OR to_warehouse_id = sqlc.narg(warehouse_id))
+ORDER BY id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: UpdateTransferOrderStatus :one
+-- sets the status and stamps the time the order was shipped or received
+UPDATE transfer_orders
+  set status = sqlc.arg(status),
+      shipped_at = CASE WHEN sqlc.arg(status) = 'in_transit' THEN now() ELSE shipped_at END,
+      received_at = CASE WHEN sqlc.arg(status) = 'received' THEN now() ELSE received_at END
+WHERE id = sqlc.arg(id)
+RETURNING *;
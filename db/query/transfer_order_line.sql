@@ -0,0 +1,30 @@
+-- name: CreateTransferOrderLine :one
+INSERT INTO transfer_order_lines (
+  transfer_order_id,
+  good_id,
+  amount,
+  from_location_id,
+  to_location_id
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListTransferOrderLines :many
+SELECT * FROM transfer_order_lines
+WHERE transfer_order_id = $1
+ORDER BY id;
+
+-- name: CreateTransferOrderLineLot :one
+INSERT INTO transfer_order_line_lots (
+  transfer_order_line_id,
+  lot_id,
+  amount
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListTransferOrderLineLots :many
+-- lots the line was shipped out of
+SELECT * FROM transfer_order_line_lots
+WHERE transfer_order_line_id = $1
+ORDER BY lot_id;
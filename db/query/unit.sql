@@ -1,24 +1,68 @@
 -- name: CreateUnit :one
 INSERT INTO units (
   unit_name,
-  unit_value
+  unit_value,
+  unit_family
 ) VALUES (
-  $1, $2
+  $1, $2, $3
 ) RETURNING *;
 
+-- name: GetUnit :one
+SELECT * FROM units
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
+
+-- name: GetUnitIncludingDeleted :one
+SELECT * FROM units
+WHERE id = $1 LIMIT 1;
+
 -- name: ListUnits :many
 SELECT * FROM units
+WHERE sqlc.arg(include_deleted)::bool OR deleted_at IS NULL
 ORDER BY id
-LIMIT $1
-OFFSET $2;
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: UpdateUnit :one
 UPDATE units
   set unit_name = $2,
-      unit_value = $3
-WHERE id = $1
+      unit_value = $3,
+      unit_family = $4,
+      version = version + 1
+WHERE id = $1 AND version = $5 AND deleted_at IS NULL
 RETURNING *;
 
--- name: DeleteUnit :exec
-DELETE FROM units
-WHERE id = $1;
\ No newline at end of file
+-- name: DeleteUnit :one
+-- rows are only marked as deleted, so they can be restored
+UPDATE units
+  set deleted_at = now(),
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: RestoreUnit :one
+UPDATE units
+  set deleted_at = NULL,
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: UnitInUse :one
+-- whether amounts are kept in the unit, by goods or by purchase order lines, deleted goods included
+SELECT (
+    EXISTS (SELECT 1 FROM goods WHERE goods.unit = sqlc.arg(id)) OR
+    EXISTS (SELECT 1 FROM purchase_order_lines WHERE purchase_order_lines.unit_id = sqlc.arg(id))
+)::bool AS in_use;
+
+-- name: ListUnitsByName :many
+SELECT * FROM units
+WHERE unit_name = $1 AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: ExportUnits :many
+-- the units a batch at a time after the unit after_id
+SELECT * FROM units
+WHERE
+    id > sqlc.arg(after_id) AND
+    (sqlc.arg(include_deleted)::bool OR deleted_at IS NULL)
+ORDER BY id
+LIMIT sqlc.arg('limit');
\ No newline at end of file
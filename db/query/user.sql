@@ -0,0 +1,33 @@
+-- name: CreateUser :one
+-- new users start without permissions until an admin gives them a role
+INSERT INTO users (
+  username,
+  hashed_password,
+  full_name,
+  email
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: CreateFirstAdmin :one
+-- sets up the admin of a new database, nothing is created once there is an admin
+INSERT INTO users (
+  username,
+  hashed_password,
+  full_name,
+  email,
+  role
+) SELECT
+  $1, $2, $3, $4, 'admin'
+WHERE NOT EXISTS (SELECT 1 FROM users WHERE role = 'admin')
+RETURNING *;
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE username = $1 LIMIT 1;
+
+-- name: UpdateUserRole :one
+UPDATE users
+  set role = $2
+WHERE username = $1
+RETURNING *;
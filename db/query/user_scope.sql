@@ -0,0 +1,17 @@
+-- name: CreateUserScope :one
+INSERT INTO user_scopes (
+  username,
+  category_id,
+  section_name
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListUserScopes :many
+SELECT * FROM user_scopes
+WHERE username = $1
+ORDER BY id;
+
+-- name: DeleteUserScopes :exec
+DELETE FROM user_scopes
+WHERE username = $1;
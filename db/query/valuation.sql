@@ -0,0 +1,50 @@
+-- name: ListGoodValuations :many
+-- value of the stock of every good and the cost of the goods it issued in the period
+SELECT
+    goods.id AS good_id,
+    goods.model,
+    goods.category,
+    categories.costing_method,
+    (goods.amount + goods.in_transit)::bigint AS quantity,
+    goods.stock_value AS value,
+    COALESCE((
+      SELECT -SUM(stock_movements.value) FROM stock_movements
+      WHERE
+          stock_movements.good_id = goods.id AND
+          stock_movements.movement_type = 'issue' AND
+          stock_movements.created_at >= sqlc.arg(from_time) AND
+          stock_movements.created_at < sqlc.arg(to_time)
+    ), 0)::bigint AS cost_of_goods_issued
+FROM goods
+JOIN categories ON categories.id = goods.category
+WHERE
+    goods.deleted_at IS NULL AND
+    (sqlc.narg(category)::bigint IS NULL OR goods.category = sqlc.narg(category))
+ORDER BY goods.id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: ListCategoryValuations :many
+-- value of the stock of every category and the cost of the goods it issued in the period
+SELECT
+    categories.id AS category,
+    categories.category_name,
+    categories.costing_method,
+    COALESCE(SUM(goods.stock_value), 0)::bigint AS value,
+    COALESCE((
+      SELECT -SUM(stock_movements.value) FROM stock_movements
+      JOIN goods AS issued ON issued.id = stock_movements.good_id
+      WHERE
+          issued.category = categories.id AND
+          issued.deleted_at IS NULL AND
+          stock_movements.movement_type = 'issue' AND
+          stock_movements.created_at >= sqlc.arg(from_time) AND
+          stock_movements.created_at < sqlc.arg(to_time)
+    ), 0)::bigint AS cost_of_goods_issued
+FROM categories
+LEFT JOIN goods ON goods.category = categories.id AND goods.deleted_at IS NULL
+WHERE
+    categories.deleted_at IS NULL AND
+    (sqlc.narg(category)::bigint IS NULL OR categories.id = sqlc.narg(category))
+GROUP BY categories.id
+ORDER BY categories.id;
@@ -0,0 +1,28 @@
+-- name: CreateWarehouse :one
+INSERT INTO warehouses (
+  warehouse_name,
+  address
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetWarehouse :one
+SELECT * FROM warehouses
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWarehouses :many
+SELECT * FROM warehouses
+ORDER BY id
+LIMIT $1
+OFFSET $2;
+
+-- name: UpdateWarehouse :one
+UPDATE warehouses
+  set warehouse_name = $2,
+      address = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWarehouse :exec
+DELETE FROM warehouses
+WHERE id = $1;
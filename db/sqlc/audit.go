@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// Types of entity recorded in the audit log
+const (
+	EntityCategory          = "category"
+	EntityUnit              = "unit"
+	EntityGood              = "good"
+	EntityStockMovement     = "stock_movement"
+	EntityWarehouse         = "warehouse"
+	EntityLocation          = "location"
+	EntityUser              = "user"
+	EntityReservation       = "reservation"
+	EntitySupplier          = "supplier"
+	EntityGoodSupplier      = "good_supplier"
+	EntityPurchaseOrder     = "purchase_order"
+	EntityPurchaseOrderLine = "purchase_order_line"
+	EntitySalesOrder        = "sales_order"
+	EntitySalesOrderLine    = "sales_order_line"
+	EntityTransferOrder     = "transfer_order"
+	EntityTransferOrderLine = "transfer_order_line"
+	EntityCountSession      = "count_session"
+	EntityCountSessionItem  = "count_session_item"
+	EntityLot               = "lot"
+	EntityGoodBarcode       = "good_barcode"
+	EntityLabelTemplate     = "label_template"
+)
+
+// DeleteTxParams contains the input parameters of the delete transactions
+type DeleteTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// RestoreTxParams contains the input parameters of the restore transactions
+type RestoreTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// recordAudit appends an entry to the audit log with JSON snapshots of the entity.
+// before is nil for a create and after is nil for a hard delete.
+// It must be called with the queries of the transaction making the change.
+func recordAudit(ctx context.Context, q *Queries, actor, action, entityType string, entityID interface{}, before, after interface{}) error {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateAuditLog(ctx, CreateAuditLogParams{
+		Actor:      actor,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   fmt.Sprint(entityID),
+		Before:     beforeJSON,
+		After:      afterJSON,
+	})
+	return err
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAuditLog(t *testing.T) AuditLog {
+	arg := CreateAuditLogParams{
+		Actor:      util.RandomName(),
+		Action:     AuditActionUpdate,
+		EntityType: EntityCategory,
+		EntityID:   fmt.Sprint(util.RandomInt(1, 1000)),
+		Before:     json.RawMessage(`{"section_name": "a"}`),
+		After:      json.RawMessage(`{"section_name": "b"}`),
+	}
+
+	log, err := testQueries.CreateAuditLog(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, log.ID)
+	require.Equal(t, arg.Actor, log.Actor)
+	require.Equal(t, arg.Action, log.Action)
+	require.Equal(t, arg.EntityType, log.EntityType)
+	require.Equal(t, arg.EntityID, log.EntityID)
+	require.JSONEq(t, string(arg.Before), string(log.Before))
+	require.JSONEq(t, string(arg.After), string(log.After))
+	require.NotZero(t, log.CreatedAt)
+
+	return log
+}
+
+// lastAuditLog returns the newest entry of the audit log about an entity
+func lastAuditLog(t *testing.T, entityType string, entityID interface{}) AuditLog {
+	logs, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		EntityType: sql.NullString{String: entityType, Valid: true},
+		EntityID:   sql.NullString{String: fmt.Sprint(entityID), Valid: true},
+		Limit:      1,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	return logs[0]
+}
+
+func TestCreateAuditLog(t *testing.T) {
+	createRandomAuditLog(t)
+}
+
+func TestListAuditLogs(t *testing.T) {
+	log1 := createRandomAuditLog(t)
+	log2 := createRandomAuditLog(t)
+
+	arg := ListAuditLogsParams{
+		Actor:  sql.NullString{String: log1.Actor, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	}
+	logs, err := testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	require.Equal(t, log1.ID, logs[0].ID)
+
+	// the newest entry comes first
+	arg = ListAuditLogsParams{
+		FromTime: sql.NullTime{Time: log1.CreatedAt, Valid: true},
+		ToTime:   sql.NullTime{Time: time.Now().Add(time.Minute), Valid: true},
+		Limit:    5,
+		Offset:   0,
+	}
+	logs, err = testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, logs)
+	require.Equal(t, log2.ID, logs[0].ID)
+
+	arg = ListAuditLogsParams{
+		Actor:  sql.NullString{String: log1.Actor, Valid: true},
+		ToTime: sql.NullTime{Time: log1.CreatedAt, Valid: true},
+		Limit:  5,
+		Offset: 0,
+	}
+	logs, err = testQueries.ListAuditLogs(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, logs)
+}
+
+func TestAuditLogImmutable(t *testing.T) {
+	log := createRandomAuditLog(t)
+
+	_, err := testDB.Exec("UPDATE audit_log SET actor = 'someone' WHERE id = $1", log.ID)
+	require.Error(t, err)
+
+	_, err = testDB.Exec("DELETE FROM audit_log WHERE id = $1", log.ID)
+	require.Error(t, err)
+}
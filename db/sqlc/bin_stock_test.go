@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func addRandomBinStock(t *testing.T, good Good, bin Location) BinStock {
+	arg := AddBinStockParams{
+		GoodID:     good.ID,
+		LocationID: bin.ID,
+		Amount:     util.RandomInt(1, 10),
+	}
+
+	binStock, err := testQueries.AddBinStock(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.GoodID, binStock.GoodID)
+	require.Equal(t, arg.LocationID, binStock.LocationID)
+
+	return binStock
+}
+
+func TestAddBinStock(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	bin := createRandomBin(t, createRandomWarehouse(t))[3]
+
+	binStock1 := addRandomBinStock(t, good, bin)
+	binStock2 := addRandomBinStock(t, good, bin)
+
+	// the second call adds to the existing row
+	require.Greater(t, binStock2.Amount, binStock1.Amount)
+
+	binStock3, err := testQueries.GetBinStock(context.Background(), GetBinStockParams{
+		GoodID:     good.ID,
+		LocationID: bin.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, binStock2, binStock3)
+}
+
+func TestGetBinStockNotFound(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	bin := createRandomBin(t, createRandomWarehouse(t))[3]
+
+	_, err := testQueries.GetBinStock(context.Background(), GetBinStockParams{
+		GoodID:     good.ID,
+		LocationID: bin.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestSumBinStocks(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse1 := createRandomWarehouse(t)
+	warehouse2 := createRandomWarehouse(t)
+
+	binStock1 := addRandomBinStock(t, good, createRandomBin(t, warehouse1)[3])
+	binStock2 := addRandomBinStock(t, good, createRandomBin(t, warehouse1)[3])
+	addRandomBinStock(t, good, createRandomBin(t, warehouse2)[3])
+
+	total, err := testQueries.SumBinStocks(context.Background(), SumBinStocksParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse1.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, binStock1.Amount+binStock2.Amount, total)
+}
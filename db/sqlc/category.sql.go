@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createCategory = `-- name: CreateCategory :one
@@ -15,7 +16,7 @@ INSERT INTO categories (
   section_name
 ) VALUES (
   $1, $2
-) RETURNING id, category_name, section_name
+) RETURNING id, category_name, section_name, deleted_at, version, costing_method
 `
 
 type CreateCategoryParams struct {
@@ -26,46 +27,140 @@ type CreateCategoryParams struct {
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
 	row := q.db.QueryRowContext(ctx, createCategory, arg.CategoryName, arg.SectionName)
 	var i Category
-	err := row.Scan(&i.ID, &i.CategoryName, &i.SectionName)
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
 	return i, err
 }
 
-const deleteCategory = `-- name: DeleteCategory :exec
-DELETE FROM categories
-WHERE id = $1
+const deleteCategory = `-- name: DeleteCategory :one
+UPDATE categories
+  set deleted_at = now(),
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, category_name, section_name, deleted_at, version, costing_method
+`
+
+// rows are only marked as deleted, so they can be restored
+func (q *Queries) DeleteCategory(ctx context.Context, id int64) (Category, error) {
+	row := q.db.QueryRowContext(ctx, deleteCategory, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
+	return i, err
+}
+
+const exportCategories = `-- name: ExportCategories :many
+SELECT id, category_name, section_name, deleted_at, version, costing_method FROM categories
+WHERE
+    id > $1 AND
+    ($2::bool OR deleted_at IS NULL)
+ORDER BY id
+LIMIT $3
 `
 
-func (q *Queries) DeleteCategory(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteCategory, id)
-	return err
+type ExportCategoriesParams struct {
+	AfterID        int64 `json:"after_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+	Limit          int32 `json:"limit"`
+}
+
+// the categories a batch at a time after the category after_id
+func (q *Queries) ExportCategories(ctx context.Context, arg ExportCategoriesParams) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, exportCategories, arg.AfterID, arg.IncludeDeleted, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryName,
+			&i.SectionName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CostingMethod,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, category_name, section_name FROM categories
-WHERE id = $1 LIMIT 1
+SELECT id, category_name, section_name, deleted_at, version, costing_method FROM categories
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetCategory(ctx context.Context, id int64) (Category, error) {
 	row := q.db.QueryRowContext(ctx, getCategory, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.CategoryName, &i.SectionName)
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
+	return i, err
+}
+
+const getCategoryIncludingDeleted = `-- name: GetCategoryIncludingDeleted :one
+SELECT id, category_name, section_name, deleted_at, version, costing_method FROM categories
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetCategoryIncludingDeleted(ctx context.Context, id int64) (Category, error) {
+	row := q.db.QueryRowContext(ctx, getCategoryIncludingDeleted, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
 	return i, err
 }
 
 const listCategories = `-- name: ListCategories :many
-SELECT id, category_name, section_name FROM categories
+SELECT id, category_name, section_name, deleted_at, version, costing_method FROM categories
+WHERE $1::bool OR deleted_at IS NULL
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
+OFFSET $3
 `
 
 type ListCategoriesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	IncludeDeleted bool  `json:"include_deleted"`
+	Limit          int32 `json:"limit"`
+	Offset         int32 `json:"offset"`
 }
 
 func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error) {
-	rows, err := q.db.QueryContext(ctx, listCategories, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listCategories, arg.IncludeDeleted, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +168,14 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.CategoryName, &i.SectionName); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryName,
+			&i.SectionName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CostingMethod,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -87,23 +189,132 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	return items, nil
 }
 
+const listCategoriesByName = `-- name: ListCategoriesByName :many
+SELECT id, category_name, section_name, deleted_at, version, costing_method FROM categories
+WHERE
+    category_name = $1 AND
+    ($2::varchar IS NULL OR section_name = $2) AND
+    deleted_at IS NULL
+ORDER BY id
+`
+
+type ListCategoriesByNameParams struct {
+	CategoryName string         `json:"category_name"`
+	SectionName  sql.NullString `json:"section_name"`
+}
+
+// categories are not unique by name, the section tells apart the ones of different sections
+func (q *Queries) ListCategoriesByName(ctx context.Context, arg ListCategoriesByNameParams) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, listCategoriesByName, arg.CategoryName, arg.SectionName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.CategoryName,
+			&i.SectionName,
+			&i.DeletedAt,
+			&i.Version,
+			&i.CostingMethod,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreCategory = `-- name: RestoreCategory :one
+UPDATE categories
+  set deleted_at = NULL,
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, category_name, section_name, deleted_at, version, costing_method
+`
+
+func (q *Queries) RestoreCategory(ctx context.Context, id int64) (Category, error) {
+	row := q.db.QueryRowContext(ctx, restoreCategory, id)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
+	return i, err
+}
+
+const setCategoryCostingMethod = `-- name: SetCategoryCostingMethod :one
+UPDATE categories
+  set costing_method = $1,
+      version = version + 1
+WHERE id = $2 AND version = $3 AND deleted_at IS NULL
+RETURNING id, category_name, section_name, deleted_at, version, costing_method
+`
+
+type SetCategoryCostingMethodParams struct {
+	CostingMethod string `json:"costing_method"`
+	ID            int64  `json:"id"`
+	Version       int64  `json:"version"`
+}
+
+func (q *Queries) SetCategoryCostingMethod(ctx context.Context, arg SetCategoryCostingMethodParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, setCategoryCostingMethod, arg.CostingMethod, arg.ID, arg.Version)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
+	return i, err
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories
   set category_name = $2,
-      section_name = $3
-WHERE id = $1
-RETURNING id, category_name, section_name
+      section_name = $3,
+      version = version + 1
+WHERE id = $1 AND version = $4 AND deleted_at IS NULL
+RETURNING id, category_name, section_name, deleted_at, version, costing_method
 `
 
 type UpdateCategoryParams struct {
 	ID           int64  `json:"id"`
 	CategoryName string `json:"category_name"`
 	SectionName  string `json:"section_name"`
+	Version      int64  `json:"version"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
-	row := q.db.QueryRowContext(ctx, updateCategory, arg.ID, arg.CategoryName, arg.SectionName)
+	row := q.db.QueryRowContext(ctx, updateCategory,
+		arg.ID,
+		arg.CategoryName,
+		arg.SectionName,
+		arg.Version,
+	)
 	var i Category
-	err := row.Scan(&i.ID, &i.CategoryName, &i.SectionName)
+	err := row.Scan(
+		&i.ID,
+		&i.CategoryName,
+		&i.SectionName,
+		&i.DeletedAt,
+		&i.Version,
+		&i.CostingMethod,
+	)
 	return i, err
 }
@@ -49,6 +49,7 @@ func TestUpdateCategory(t *testing.T) {
 		ID:           category1.ID,
 		CategoryName: util.RandomName(),
 		SectionName:  util.RandomName(),
+		Version:      category1.Version,
 	}
 
 	category2, err := testQueries.UpdateCategory(context.Background(), arg)
@@ -57,20 +58,53 @@ func TestUpdateCategory(t *testing.T) {
 
 	require.NotEqual(t, category1.CategoryName, category2.CategoryName)
 	require.NotEqual(t, category1.SectionName, category2.SectionName)
+	require.Equal(t, category1.Version+1, category2.Version)
+
+	// the version has moved on, so the same update does not match anymore
+	_, err = testQueries.UpdateCategory(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
 }
 
 func TestDeleteCategory(t *testing.T) {
 	category1 := createRandomCategory(t)
 
-	err := testQueries.DeleteCategory(context.Background(), category1.ID)
+	deleted, err := testQueries.DeleteCategory(context.Background(), category1.ID)
 
 	require.NoError(t, err)
+	require.Equal(t, category1.ID, deleted.ID)
+	require.True(t, deleted.DeletedAt.Valid)
 
 	category2, err1 := testQueries.GetCategory(context.Background(), category1.ID)
 
 	require.Error(t, err1)
 	require.EqualError(t, err1, sql.ErrNoRows.Error())
 	require.Empty(t, category2)
+
+	// the row is kept and can still be read on purpose
+	category3, err := testQueries.GetCategoryIncludingDeleted(context.Background(), category1.ID)
+	require.NoError(t, err)
+	require.Equal(t, deleted, category3)
+
+	_, err = testQueries.DeleteCategory(context.Background(), category1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestRestoreCategory(t *testing.T) {
+	category1 := createRandomCategory(t)
+
+	_, err := testQueries.RestoreCategory(context.Background(), category1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.DeleteCategory(context.Background(), category1.ID)
+	require.NoError(t, err)
+
+	category2, err := testQueries.RestoreCategory(context.Background(), category1.ID)
+	require.NoError(t, err)
+	require.Equal(t, category1, category2)
+
+	category3, err := testQueries.GetCategory(context.Background(), category1.ID)
+	require.NoError(t, err)
+	require.Equal(t, category1, category3)
 }
 
 func TestListCategories(t *testing.T) {
@@ -88,5 +122,93 @@ func TestListCategories(t *testing.T) {
 
 	for _, category := range categories {
 		require.NotEmpty(t, category)
+		require.False(t, category.DeletedAt.Valid)
+	}
+}
+
+func TestListCategoriesIncludeDeleted(t *testing.T) {
+	category := createRandomCategory(t)
+	_, err := testQueries.DeleteCategory(context.Background(), category.ID)
+	require.NoError(t, err)
+
+	contains := func(includeDeleted bool) bool {
+		// new categories come last, the deleted one is on the last page
+		arg := ListCategoriesParams{
+			IncludeDeleted: includeDeleted,
+			Limit:          1000000,
+		}
+		categories, err := testQueries.ListCategories(context.Background(), arg)
+		require.NoError(t, err)
+		for _, c := range categories {
+			if c.ID == category.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	require.False(t, contains(false))
+	require.True(t, contains(true))
+}
+
+func TestListCategoriesByName(t *testing.T) {
+	category1 := createRandomCategory(t)
+	// the same name in another section
+	category2, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		CategoryName: category1.CategoryName,
+		SectionName:  util.RandomName(),
+	})
+	require.NoError(t, err)
+
+	categories, err := testQueries.ListCategoriesByName(context.Background(), ListCategoriesByNameParams{
+		CategoryName: category1.CategoryName,
+	})
+	require.NoError(t, err)
+	require.Len(t, categories, 2)
+	require.Equal(t, category1.ID, categories[0].ID)
+	require.Equal(t, category2.ID, categories[1].ID)
+
+	categories, err = testQueries.ListCategoriesByName(context.Background(), ListCategoriesByNameParams{
+		CategoryName: category1.CategoryName,
+		SectionName:  sql.NullString{String: category2.SectionName, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Equal(t, category2.ID, categories[0].ID)
+
+	_, err = testQueries.DeleteCategory(context.Background(), category2.ID)
+	require.NoError(t, err)
+
+	categories, err = testQueries.ListCategoriesByName(context.Background(), ListCategoriesByNameParams{
+		CategoryName: category1.CategoryName,
+	})
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Equal(t, category1.ID, categories[0].ID)
+}
+
+func TestExportCategories(t *testing.T) {
+	category1 := createRandomCategory(t)
+	category2 := createRandomCategory(t)
+
+	_, err := testQueries.DeleteCategory(context.Background(), category2.ID)
+	require.NoError(t, err)
+
+	arg := ExportCategoriesParams{
+		AfterID: category1.ID - 1,
+		Limit:   5,
 	}
+	categories, err := testQueries.ExportCategories(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, categories)
+	require.Equal(t, category1, categories[0])
+	for _, category := range categories {
+		require.NotEqual(t, category2.ID, category.ID)
+	}
+
+	arg.IncludeDeleted = true
+	categories, err = testQueries.ExportCategories(context.Background(), arg)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(categories), 2)
+	require.Equal(t, category2.ID, categories[1].ID)
 }
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCostLayer(t *testing.T, good Good, quantity, value int64) CostLayer {
+	arg := CreateCostLayerParams{
+		GoodID:   good.ID,
+		Quantity: quantity,
+		Value:    value,
+	}
+
+	layer, err := testQueries.CreateCostLayer(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, layer)
+
+	require.Equal(t, arg.GoodID, layer.GoodID)
+	require.False(t, layer.StockMovementID.Valid)
+	require.Equal(t, quantity, layer.Quantity)
+	require.Equal(t, quantity, layer.Remaining)
+	require.Equal(t, value, layer.Value)
+	require.Equal(t, value, layer.RemainingValue)
+	require.NotZero(t, layer.ID)
+	require.NotZero(t, layer.CreatedAt)
+
+	return layer
+}
+
+func TestCreateCostLayer(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	createRandomCostLayer(t, good, 5, 500)
+
+	// a layer cannot hold less than nothing
+	_, err := testQueries.CreateCostLayer(context.Background(), CreateCostLayerParams{
+		GoodID:   good.ID,
+		Quantity: -1,
+	})
+	require.Error(t, err)
+}
+
+func TestConsumeCostLayer(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	layer := createRandomCostLayer(t, good, 5, 500)
+
+	consumed, err := testQueries.ConsumeCostLayer(context.Background(), ConsumeCostLayerParams{
+		ID:       layer.ID,
+		Quantity: 2,
+		Value:    200,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), consumed.Remaining)
+	require.Equal(t, int64(300), consumed.RemainingValue)
+	require.Equal(t, layer.Quantity, consumed.Quantity)
+	require.Equal(t, layer.Value, consumed.Value)
+
+	// a layer cannot give more than it has left
+	_, err = testQueries.ConsumeCostLayer(context.Background(), ConsumeCostLayerParams{
+		ID:       layer.ID,
+		Quantity: 4,
+		Value:    300,
+	})
+	require.Error(t, err)
+}
+
+func TestListOpenCostLayers(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	older := createRandomCostLayer(t, good, 2, 200)
+	empty := createRandomCostLayer(t, good, 1, 100)
+	newer := createRandomCostLayer(t, good, 3, 600)
+
+	_, err := testQueries.ConsumeCostLayer(context.Background(), ConsumeCostLayerParams{
+		ID:       empty.ID,
+		Quantity: 1,
+		Value:    100,
+	})
+	require.NoError(t, err)
+
+	layers, err := testQueries.ListOpenCostLayers(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Len(t, layers, 2)
+	require.Equal(t, older.ID, layers[0].ID)
+	require.Equal(t, newer.ID, layers[1].ID)
+
+	layers, err = testQueries.ListCostLayers(context.Background(), ListCostLayersParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, layers, 3)
+	require.Equal(t, empty.ID, layers[1].ID)
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCountSession(t *testing.T, warehouse Warehouse, category Category) CountSession {
+	arg := CreateCountSessionParams{
+		WarehouseID: warehouse.ID,
+		CategoryID:  sql.NullInt64{Int64: category.ID, Valid: true},
+	}
+
+	countSession, err := testQueries.CreateCountSession(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.WarehouseID, countSession.WarehouseID)
+	require.Equal(t, arg.CategoryID, countSession.CategoryID)
+	require.False(t, countSession.SectionName.Valid)
+	require.False(t, countSession.LocationID.Valid)
+	require.Equal(t, CountSessionStatusOpen, countSession.Status)
+	require.NotZero(t, countSession.ID)
+	require.NotZero(t, countSession.CreatedAt)
+	require.False(t, countSession.ClosedAt.Valid)
+
+	return countSession
+}
+
+func TestCreateCountSession(t *testing.T) {
+	createRandomCountSession(t, createRandomWarehouse(t), createRandomCategory(t))
+}
+
+func TestCreateCountSessionWithoutScope(t *testing.T) {
+	_, err := testQueries.CreateCountSession(context.Background(), CreateCountSessionParams{
+		WarehouseID: createRandomWarehouse(t).ID,
+	})
+	require.Error(t, err)
+}
+
+func TestListCountSessions(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+	category := createRandomCategory(t)
+	first := createRandomCountSession(t, warehouse, category)
+	second := createRandomCountSession(t, warehouse, category)
+	createRandomCountSession(t, createRandomWarehouse(t), category)
+
+	_, err := testQueries.UpdateCountSessionStatus(context.Background(), UpdateCountSessionStatusParams{
+		ID:     second.ID,
+		Status: CountSessionStatusCancelled,
+	})
+	require.NoError(t, err)
+
+	countSessions, err := testQueries.ListCountSessions(context.Background(), ListCountSessionsParams{
+		WarehouseID: sql.NullInt64{Int64: warehouse.ID, Valid: true},
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	require.Len(t, countSessions, 2)
+
+	open, err := testQueries.ListCountSessions(context.Background(), ListCountSessionsParams{
+		Status:      sql.NullString{String: CountSessionStatusOpen, Valid: true},
+		WarehouseID: sql.NullInt64{Int64: warehouse.ID, Valid: true},
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	require.Equal(t, first.ID, open[0].ID)
+}
+
+func TestUpdateCountSessionStatus(t *testing.T) {
+	countSession := createRandomCountSession(t, createRandomWarehouse(t), createRandomCategory(t))
+
+	closed, err := testQueries.UpdateCountSessionStatus(context.Background(), UpdateCountSessionStatusParams{
+		ID:     countSession.ID,
+		Status: CountSessionStatusApproved,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CountSessionStatusApproved, closed.Status)
+	require.True(t, closed.ClosedAt.Valid)
+}
+
+func TestCountSessionItems(t *testing.T) {
+	category := createRandomCategory(t)
+	good := createRandomGood(t, category, createRandomUnit(t))
+	countSession := createRandomCountSession(t, createRandomWarehouse(t), category)
+
+	item, err := testQueries.CreateCountSessionItem(context.Background(), CreateCountSessionItemParams{
+		CountSessionID: countSession.ID,
+		GoodID:         good.ID,
+		Snapshot:       4,
+	})
+	require.NoError(t, err)
+	require.False(t, item.Counted.Valid)
+
+	// a good is counted once per location of a session
+	_, err = testQueries.CreateCountSessionItem(context.Background(), CreateCountSessionItemParams{
+		CountSessionID: countSession.ID,
+		GoodID:         good.ID,
+		Snapshot:       4,
+	})
+	require.Error(t, err)
+
+	counted, err := testQueries.SetCountSessionItemCounted(context.Background(), SetCountSessionItemCountedParams{
+		ID:        item.ID,
+		Counted:   3,
+		CountedBy: "counter",
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: 3, Valid: true}, counted.Counted)
+	require.Equal(t, "counter", counted.CountedBy)
+	require.True(t, counted.CountedAt.Valid)
+
+	items, err := testQueries.ListCountSessionItems(context.Background(), countSession.ID)
+	require.NoError(t, err)
+	require.Equal(t, []CountSessionItem{counted}, items)
+}
+
+func TestListCountSnapshot(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	stocked := createRandomGood(t, category, unit)
+	empty := createRandomGood(t, category, unit)
+	createRandomGood(t, createRandomCategory(t), unit)
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      stocked.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      5,
+	})
+	require.NoError(t, err)
+
+	snapshot, err := testQueries.ListCountSnapshot(context.Background(), ListCountSnapshotParams{
+		WarehouseID: warehouse.ID,
+		CategoryID:  sql.NullInt64{Int64: category.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ListCountSnapshotRow{
+		{GoodID: stocked.ID, Amount: 5},
+		{GoodID: empty.ID, Amount: 0},
+	}, snapshot)
+}
@@ -7,26 +7,190 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const addGoodAmount = `-- name: AddGoodAmount :one
+UPDATE goods
+  set amount = amount + $1,
+      version = version + 1
+WHERE id = $2
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+type AddGoodAmountParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddGoodAmount(ctx context.Context, arg AddGoodAmountParams) (Good, error) {
+	row := q.db.QueryRowContext(ctx, addGoodAmount, arg.Amount, arg.ID)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const addGoodInTransit = `-- name: AddGoodInTransit :one
+UPDATE goods
+  set in_transit = in_transit + $1,
+      version = version + 1
+WHERE id = $2
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+type AddGoodInTransitParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+func (q *Queries) AddGoodInTransit(ctx context.Context, arg AddGoodInTransitParams) (Good, error) {
+	row := q.db.QueryRowContext(ctx, addGoodInTransit, arg.Amount, arg.ID)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const addGoodReserved = `-- name: AddGoodReserved :one
+UPDATE goods
+  set reserved = reserved + $1
+WHERE id = $2
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+type AddGoodReservedParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+// reservations do not change the version, they are not edited through the good
+func (q *Queries) AddGoodReserved(ctx context.Context, arg AddGoodReservedParams) (Good, error) {
+	row := q.db.QueryRowContext(ctx, addGoodReserved, arg.Amount, arg.ID)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const addGoodStockValue = `-- name: AddGoodStockValue :one
+UPDATE goods
+  set stock_value = stock_value + $1
+WHERE id = $2
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+type AddGoodStockValueParams struct {
+	Amount int64 `json:"amount"`
+	ID     int64 `json:"id"`
+}
+
+// the value follows the movements, it does not change the version
+func (q *Queries) AddGoodStockValue(ctx context.Context, arg AddGoodStockValueParams) (Good, error) {
+	row := q.db.QueryRowContext(ctx, addGoodStockValue, arg.Amount, arg.ID)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
 const createGood = `-- name: CreateGood :one
 INSERT INTO goods (
   category,
   model,
   unit,
   amount,
-  good_desc
+  good_desc,
+  reorder_point,
+  safety_stock,
+  max_level,
+  serialized,
+  sku
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING id, category, model, unit, amount, good_desc, created_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
 `
 
 type CreateGoodParams struct {
-	Category int64  `json:"category"`
-	Model    string `json:"model"`
-	Unit     int64  `json:"unit"`
-	Amount   int64  `json:"amount"`
-	GoodDesc string `json:"good_desc"`
+	Category     int64          `json:"category"`
+	Model        string         `json:"model"`
+	Unit         int64          `json:"unit"`
+	Amount       int64          `json:"amount"`
+	GoodDesc     string         `json:"good_desc"`
+	ReorderPoint int64          `json:"reorder_point"`
+	SafetyStock  int64          `json:"safety_stock"`
+	MaxLevel     int64          `json:"max_level"`
+	Serialized   bool           `json:"serialized"`
+	Sku          sql.NullString `json:"sku"`
 }
 
 func (q *Queries) CreateGood(ctx context.Context, arg CreateGoodParams) (Good, error) {
@@ -36,6 +200,11 @@ func (q *Queries) CreateGood(ctx context.Context, arg CreateGoodParams) (Good, e
 		arg.Unit,
 		arg.Amount,
 		arg.GoodDesc,
+		arg.ReorderPoint,
+		arg.SafetyStock,
+		arg.MaxLevel,
+		arg.Serialized,
+		arg.Sku,
 	)
 	var i Good
 	err := row.Scan(
@@ -46,23 +215,177 @@ func (q *Queries) CreateGood(ctx context.Context, arg CreateGoodParams) (Good, e
 		&i.Amount,
 		&i.GoodDesc,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
 	)
 	return i, err
 }
 
-const deleteGood = `-- name: DeleteGood :exec
-DELETE FROM goods
-WHERE id = $1
+const deleteGood = `-- name: DeleteGood :one
+UPDATE goods
+  set deleted_at = now(),
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+// rows are only marked as deleted, so they can be restored
+func (q *Queries) DeleteGood(ctx context.Context, id int64) (Good, error) {
+	row := q.db.QueryRowContext(ctx, deleteGood, id)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const exportGoods = `-- name: ExportGoods :many
+SELECT
+    goods.id,
+    goods.model,
+    goods.good_desc,
+    goods.sku,
+    categories.category_name,
+    categories.section_name,
+    units.unit_name,
+    goods.amount,
+    goods.reserved,
+    goods.in_transit,
+    goods.stock_value,
+    goods.created_at,
+    goods.deleted_at
+FROM goods
+JOIN categories ON categories.id = goods.category
+JOIN units ON units.id = goods.unit
+WHERE
+    goods.id > $1 AND
+    ($2::bigint IS NULL OR goods.category = $2) AND
+    ($3::varchar IS NULL OR categories.section_name = $3) AND
+    ($4::bigint IS NULL OR goods.unit = $4) AND
+    ($5::varchar IS NULL OR starts_with(goods.model, $5)) AND
+    ($6::bigint IS NULL OR goods.amount >= $6) AND
+    ($7::bigint IS NULL OR goods.amount <= $7) AND
+    ($8::timestamptz IS NULL OR goods.created_at >= $8) AND
+    ($9::timestamptz IS NULL OR goods.created_at < $9) AND
+    ($10::bigint IS NULL OR goods.id IN (
+        SELECT good_id FROM good_balances
+        WHERE warehouse_id = $10
+    )) AND
+    ($11::bool OR goods.deleted_at IS NULL)
+ORDER BY goods.id
+LIMIT $12
 `
 
-func (q *Queries) DeleteGood(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteGood, id)
-	return err
+type ExportGoodsParams struct {
+	AfterID        int64          `json:"after_id"`
+	Category       sql.NullInt64  `json:"category"`
+	Section        sql.NullString `json:"section"`
+	Unit           sql.NullInt64  `json:"unit"`
+	ModelPrefix    sql.NullString `json:"model_prefix"`
+	MinAmount      sql.NullInt64  `json:"min_amount"`
+	MaxAmount      sql.NullInt64  `json:"max_amount"`
+	CreatedFrom    sql.NullTime   `json:"created_from"`
+	CreatedTo      sql.NullTime   `json:"created_to"`
+	WarehouseID    sql.NullInt64  `json:"warehouse_id"`
+	IncludeDeleted bool           `json:"include_deleted"`
+	Limit          int32          `json:"limit"`
+}
+
+type ExportGoodsRow struct {
+	ID           int64          `json:"id"`
+	Model        string         `json:"model"`
+	GoodDesc     string         `json:"good_desc"`
+	Sku          sql.NullString `json:"sku"`
+	CategoryName string         `json:"category_name"`
+	SectionName  string         `json:"section_name"`
+	UnitName     string         `json:"unit_name"`
+	Amount       int64          `json:"amount"`
+	Reserved     int64          `json:"reserved"`
+	InTransit    int64          `json:"in_transit"`
+	StockValue   int64          `json:"stock_value"`
+	CreatedAt    time.Time      `json:"created_at"`
+	DeletedAt    sql.NullTime   `json:"deleted_at"`
+}
+
+// the goods with the names of their category and unit, a batch at a time after the good after_id.
+// The filters are the ones of ListGoods.
+func (q *Queries) ExportGoods(ctx context.Context, arg ExportGoodsParams) ([]ExportGoodsRow, error) {
+	rows, err := q.db.QueryContext(ctx, exportGoods,
+		arg.AfterID,
+		arg.Category,
+		arg.Section,
+		arg.Unit,
+		arg.ModelPrefix,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.WarehouseID,
+		arg.IncludeDeleted,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExportGoodsRow{}
+	for rows.Next() {
+		var i ExportGoodsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Model,
+			&i.GoodDesc,
+			&i.Sku,
+			&i.CategoryName,
+			&i.SectionName,
+			&i.UnitName,
+			&i.Amount,
+			&i.Reserved,
+			&i.InTransit,
+			&i.StockValue,
+			&i.CreatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getGood = `-- name: GetGood :one
-SELECT id, category, model, unit, amount, good_desc, created_at FROM goods
-WHERE id = $1 LIMIT 1
+SELECT id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku FROM goods
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetGood(ctx context.Context, id int64) (Good, error) {
@@ -76,31 +399,224 @@ func (q *Queries) GetGood(ctx context.Context, id int64) (Good, error) {
 		&i.Amount,
 		&i.GoodDesc,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const getGoodBySku = `-- name: GetGoodBySku :one
+SELECT id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku FROM goods
+WHERE sku = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetGoodBySku(ctx context.Context, sku sql.NullString) (Good, error) {
+	row := q.db.QueryRowContext(ctx, getGoodBySku, sku)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const getGoodForUpdate = `-- name: GetGoodForUpdate :one
+SELECT id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku FROM goods
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetGoodForUpdate(ctx context.Context, id int64) (Good, error) {
+	row := q.db.QueryRowContext(ctx, getGoodForUpdate, id)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const getGoodIncludingDeleted = `-- name: GetGoodIncludingDeleted :one
+SELECT id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku FROM goods
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetGoodIncludingDeleted(ctx context.Context, id int64) (Good, error) {
+	row := q.db.QueryRowContext(ctx, getGoodIncludingDeleted, id)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const getGoodIncludingDeletedForUpdate = `-- name: GetGoodIncludingDeletedForUpdate :one
+SELECT id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku FROM goods
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetGoodIncludingDeletedForUpdate(ctx context.Context, id int64) (Good, error) {
+	row := q.db.QueryRowContext(ctx, getGoodIncludingDeletedForUpdate, id)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
 	)
 	return i, err
 }
 
 const listGoods = `-- name: ListGoods :many
-SELECT id, category, model, unit, amount, good_desc, created_at FROM goods
-WHERE 
-    category = $1 OR
-    model = $2
-ORDER BY id
-LIMIT $3
-OFFSET $4
+SELECT goods.id, goods.category, goods.model, goods.unit, goods.amount, goods.good_desc, goods.created_at, goods.deleted_at, goods.version, goods.reserved, goods.in_transit, goods.reorder_point, goods.safety_stock, goods.max_level, goods.serialized, goods.stock_value, goods.sku FROM goods
+JOIN categories ON categories.id = goods.category
+WHERE
+    ($1::bigint IS NULL OR goods.category = $1) AND
+    ($2::varchar IS NULL OR categories.section_name = $2) AND
+    ($3::bigint IS NULL OR goods.unit = $3) AND
+    ($4::varchar IS NULL OR starts_with(goods.model, $4)) AND
+    ($5::bigint IS NULL OR goods.amount >= $5) AND
+    ($6::bigint IS NULL OR goods.amount <= $6) AND
+    ($7::timestamptz IS NULL OR goods.created_at >= $7) AND
+    ($8::timestamptz IS NULL OR goods.created_at < $8) AND
+    ($9::bigint IS NULL OR goods.id IN (
+        SELECT good_id FROM good_balances
+        WHERE warehouse_id = $9
+    )) AND
+    ($10::bool OR goods.deleted_at IS NULL)
+ORDER BY
+    CASE WHEN $11::text = 'id' THEN goods.id END,
+    CASE WHEN $11::text = '-id' THEN goods.id END DESC,
+    CASE WHEN $11::text = 'model' THEN goods.model END,
+    CASE WHEN $11::text = '-model' THEN goods.model END DESC,
+    CASE WHEN $11::text = 'amount' THEN goods.amount END,
+    CASE WHEN $11::text = '-amount' THEN goods.amount END DESC,
+    CASE WHEN $11::text = 'created_at' THEN goods.created_at END,
+    CASE WHEN $11::text = '-created_at' THEN goods.created_at END DESC,
+    CASE WHEN $12::text = 'id' THEN goods.id END,
+    CASE WHEN $12::text = '-id' THEN goods.id END DESC,
+    CASE WHEN $12::text = 'model' THEN goods.model END,
+    CASE WHEN $12::text = '-model' THEN goods.model END DESC,
+    CASE WHEN $12::text = 'amount' THEN goods.amount END,
+    CASE WHEN $12::text = '-amount' THEN goods.amount END DESC,
+    CASE WHEN $12::text = 'created_at' THEN goods.created_at END,
+    CASE WHEN $12::text = '-created_at' THEN goods.created_at END DESC,
+    CASE WHEN $13::text = 'id' THEN goods.id END,
+    CASE WHEN $13::text = '-id' THEN goods.id END DESC,
+    CASE WHEN $13::text = 'model' THEN goods.model END,
+    CASE WHEN $13::text = '-model' THEN goods.model END DESC,
+    CASE WHEN $13::text = 'amount' THEN goods.amount END,
+    CASE WHEN $13::text = '-amount' THEN goods.amount END DESC,
+    CASE WHEN $13::text = 'created_at' THEN goods.created_at END,
+    CASE WHEN $13::text = '-created_at' THEN goods.created_at END DESC,
+    goods.id
+LIMIT $14
+OFFSET $15
 `
 
 type ListGoodsParams struct {
-	Category int64  `json:"category"`
-	Model    string `json:"model"`
-	Limit    int32  `json:"limit"`
-	Offset   int32  `json:"offset"`
+	Category       sql.NullInt64  `json:"category"`
+	Section        sql.NullString `json:"section"`
+	Unit           sql.NullInt64  `json:"unit"`
+	ModelPrefix    sql.NullString `json:"model_prefix"`
+	MinAmount      sql.NullInt64  `json:"min_amount"`
+	MaxAmount      sql.NullInt64  `json:"max_amount"`
+	CreatedFrom    sql.NullTime   `json:"created_from"`
+	CreatedTo      sql.NullTime   `json:"created_to"`
+	WarehouseID    sql.NullInt64  `json:"warehouse_id"`
+	IncludeDeleted bool           `json:"include_deleted"`
+	Sort1          string         `json:"sort1"`
+	Sort2          string         `json:"sort2"`
+	Sort3          string         `json:"sort3"`
+	Limit          int32          `json:"limit"`
+	Offset         int32          `json:"offset"`
 }
 
+// every filter is optional and the given ones all apply. The goods are sorted by up to three keys
+// and then by id, a key is one of id, model, amount and created_at and sorts descending with a leading -.
 func (q *Queries) ListGoods(ctx context.Context, arg ListGoodsParams) ([]Good, error) {
 	rows, err := q.db.QueryContext(ctx, listGoods,
 		arg.Category,
-		arg.Model,
+		arg.Section,
+		arg.Unit,
+		arg.ModelPrefix,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.WarehouseID,
+		arg.IncludeDeleted,
+		arg.Sort1,
+		arg.Sort2,
+		arg.Sort3,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -119,6 +635,191 @@ func (q *Queries) ListGoods(ctx context.Context, arg ListGoodsParams) ([]Good, e
 			&i.Amount,
 			&i.GoodDesc,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Reserved,
+			&i.InTransit,
+			&i.ReorderPoint,
+			&i.SafetyStock,
+			&i.MaxLevel,
+			&i.Serialized,
+			&i.StockValue,
+			&i.Sku,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLowStockGoods = `-- name: ListLowStockGoods :many
+SELECT id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku FROM goods
+WHERE
+    deleted_at IS NULL AND
+    amount - reserved < reorder_point AND
+    ($1::bigint IS NULL OR category = $1)
+ORDER BY amount - reserved - reorder_point, id
+LIMIT $2
+OFFSET $3
+`
+
+type ListLowStockGoodsParams struct {
+	Category sql.NullInt64 `json:"category"`
+	Limit    int32         `json:"limit"`
+	Offset   int32         `json:"offset"`
+}
+
+// goods whose available stock is below their reorder point, the lowest first
+func (q *Queries) ListLowStockGoods(ctx context.Context, arg ListLowStockGoodsParams) ([]Good, error) {
+	rows, err := q.db.QueryContext(ctx, listLowStockGoods, arg.Category, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Good{}
+	for rows.Next() {
+		var i Good
+		if err := rows.Scan(
+			&i.ID,
+			&i.Category,
+			&i.Model,
+			&i.Unit,
+			&i.Amount,
+			&i.GoodDesc,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Reserved,
+			&i.InTransit,
+			&i.ReorderPoint,
+			&i.SafetyStock,
+			&i.MaxLevel,
+			&i.Serialized,
+			&i.StockValue,
+			&i.Sku,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCategoryGoods = `-- name: LockCategoryGoods :many
+SELECT id FROM goods
+WHERE category = $1
+ORDER BY id
+FOR NO KEY UPDATE
+`
+
+// locks the goods of the category in the order of their id
+func (q *Queries) LockCategoryGoods(ctx context.Context, category int64) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, lockCategoryGoods, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreGood = `-- name: RestoreGood :one
+UPDATE goods
+  set deleted_at = NULL,
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+func (q *Queries) RestoreGood(ctx context.Context, id int64) (Good, error) {
+	row := q.db.QueryRowContext(ctx, restoreGood, id)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const revalueCategoryGoods = `-- name: RevalueCategoryGoods :many
+UPDATE goods
+  set stock_value = COALESCE((
+    SELECT SUM(cost_layers.remaining_value) FROM cost_layers
+    WHERE cost_layers.good_id = goods.id
+  ), 0)
+WHERE category = $1
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+// sets the value of the goods of the category to the value left in their cost layers
+func (q *Queries) RevalueCategoryGoods(ctx context.Context, category int64) ([]Good, error) {
+	rows, err := q.db.QueryContext(ctx, revalueCategoryGoods, category)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Good{}
+	for rows.Next() {
+		var i Good
+		if err := rows.Scan(
+			&i.ID,
+			&i.Category,
+			&i.Model,
+			&i.Unit,
+			&i.Amount,
+			&i.GoodDesc,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.Version,
+			&i.Reserved,
+			&i.InTransit,
+			&i.ReorderPoint,
+			&i.SafetyStock,
+			&i.MaxLevel,
+			&i.Serialized,
+			&i.StockValue,
+			&i.Sku,
 		); err != nil {
 			return nil, err
 		}
@@ -133,12 +834,98 @@ func (q *Queries) ListGoods(ctx context.Context, arg ListGoodsParams) ([]Good, e
 	return items, nil
 }
 
+const setGoodSku = `-- name: SetGoodSku :one
+UPDATE goods
+  set sku = $1,
+      version = version + 1
+WHERE id = $2
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+type SetGoodSkuParams struct {
+	Sku sql.NullString `json:"sku"`
+	ID  int64          `json:"id"`
+}
+
+func (q *Queries) SetGoodSku(ctx context.Context, arg SetGoodSkuParams) (Good, error) {
+	row := q.db.QueryRowContext(ctx, setGoodSku, arg.Sku, arg.ID)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
+const setGoodStockLevels = `-- name: SetGoodStockLevels :one
+UPDATE goods
+  set reorder_point = $1,
+      safety_stock = $2,
+      max_level = $3,
+      version = version + 1
+WHERE id = $4
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
+`
+
+type SetGoodStockLevelsParams struct {
+	ReorderPoint int64 `json:"reorder_point"`
+	SafetyStock  int64 `json:"safety_stock"`
+	MaxLevel     int64 `json:"max_level"`
+	ID           int64 `json:"id"`
+}
+
+func (q *Queries) SetGoodStockLevels(ctx context.Context, arg SetGoodStockLevelsParams) (Good, error) {
+	row := q.db.QueryRowContext(ctx, setGoodStockLevels,
+		arg.ReorderPoint,
+		arg.SafetyStock,
+		arg.MaxLevel,
+		arg.ID,
+	)
+	var i Good
+	err := row.Scan(
+		&i.ID,
+		&i.Category,
+		&i.Model,
+		&i.Unit,
+		&i.Amount,
+		&i.GoodDesc,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
+	)
+	return i, err
+}
+
 const updateGood = `-- name: UpdateGood :one
 UPDATE goods
   set unit = $2,
-      amount = $3
+      amount = $3,
+      version = version + 1
 WHERE id = $1
-RETURNING id, category, model, unit, amount, good_desc, created_at
+RETURNING id, category, model, unit, amount, good_desc, created_at, deleted_at, version, reserved, in_transit, reorder_point, safety_stock, max_level, serialized, stock_value, sku
 `
 
 type UpdateGoodParams struct {
@@ -158,6 +945,16 @@ func (q *Queries) UpdateGood(ctx context.Context, arg UpdateGoodParams) (Good, e
 		&i.Amount,
 		&i.GoodDesc,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.Version,
+		&i.Reserved,
+		&i.InTransit,
+		&i.ReorderPoint,
+		&i.SafetyStock,
+		&i.MaxLevel,
+		&i.Serialized,
+		&i.StockValue,
+		&i.Sku,
 	)
 	return i, err
 }
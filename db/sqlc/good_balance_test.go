@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func addRandomGoodBalance(t *testing.T, good Good, warehouse Warehouse) GoodBalance {
+	arg := AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      util.RandomInt(1, 10),
+	}
+
+	balance, err := testQueries.AddGoodBalance(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.GoodID, balance.GoodID)
+	require.Equal(t, arg.WarehouseID, balance.WarehouseID)
+
+	return balance
+}
+
+func TestAddGoodBalance(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+
+	balance1 := addRandomGoodBalance(t, good, warehouse)
+	balance2 := addRandomGoodBalance(t, good, warehouse)
+
+	// the second call adds to the existing row
+	require.Greater(t, balance2.Amount, balance1.Amount)
+
+	balance3, err := testQueries.GetGoodBalance(context.Background(), GetGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, balance2, balance3)
+}
+
+func TestGetGoodBalanceNotFound(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+
+	_, err := testQueries.GetGoodBalance(context.Background(), GetGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestListGoodBalances(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	n := 3
+	warehouses := make([]Warehouse, n)
+	for i := 0; i < n; i++ {
+		warehouses[i] = createRandomWarehouse(t)
+		addRandomGoodBalance(t, good, warehouses[i])
+	}
+
+	balances, err := testQueries.ListGoodBalances(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Len(t, balances, n)
+
+	for i, balance := range balances {
+		require.Equal(t, good.ID, balance.GoodID)
+		require.Equal(t, warehouses[i].ID, balance.WarehouseID)
+		require.Equal(t, warehouses[i].WarehouseName, balance.WarehouseName)
+	}
+}
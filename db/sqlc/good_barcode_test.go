@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomGoodBarcode(t *testing.T, good Good) GoodBarcode {
+	arg := CreateGoodBarcodeParams{
+		GoodID:    good.ID,
+		Barcode:   util.RandomEAN13(),
+		Symbology: util.BarcodeEAN13,
+	}
+
+	barcode, err := testQueries.CreateGoodBarcode(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, barcode)
+
+	require.Equal(t, arg.GoodID, barcode.GoodID)
+	require.Equal(t, arg.Barcode, barcode.Barcode)
+	require.Equal(t, arg.Symbology, barcode.Symbology)
+	require.NotZero(t, barcode.ID)
+	require.NotZero(t, barcode.CreatedAt)
+
+	return barcode
+}
+
+func TestCreateGoodBarcode(t *testing.T) {
+	good1 := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	good2 := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	barcode := createRandomGoodBarcode(t, good1)
+
+	// a barcode belongs to one good only
+	_, err := testQueries.CreateGoodBarcode(context.Background(), CreateGoodBarcodeParams{
+		GoodID:    good2.ID,
+		Barcode:   barcode.Barcode,
+		Symbology: barcode.Symbology,
+	})
+	require.Error(t, err)
+
+	_, err = testQueries.CreateGoodBarcode(context.Background(), CreateGoodBarcodeParams{
+		GoodID:    good2.ID,
+		Barcode:   util.RandomEAN13(),
+		Symbology: "qr",
+	})
+	require.Error(t, err)
+}
+
+func TestGetGoodBarcode(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	barcode1 := createRandomGoodBarcode(t, good)
+
+	barcode2, err := testQueries.GetGoodBarcode(context.Background(), barcode1.Barcode)
+	require.NoError(t, err)
+	require.Equal(t, barcode1.ID, barcode2.ID)
+	require.Equal(t, good.ID, barcode2.GoodID)
+}
+
+func TestListGoodBarcodes(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	barcode1 := createRandomGoodBarcode(t, good)
+	barcode2 := createRandomGoodBarcode(t, good)
+	createRandomGoodBarcode(t, createRandomGood(t, createRandomCategory(t), createRandomUnit(t)))
+
+	barcodes, err := testQueries.ListGoodBarcodes(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Len(t, barcodes, 2)
+	require.Equal(t, barcode1.ID, barcodes[0].ID)
+	require.Equal(t, barcode2.ID, barcodes[1].ID)
+}
+
+func TestDeleteGoodBarcode(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	barcode := createRandomGoodBarcode(t, good)
+
+	arg := DeleteGoodBarcodeParams{
+		GoodID:  good.ID,
+		Barcode: barcode.Barcode,
+	}
+	deleted, err := testQueries.DeleteGoodBarcode(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, barcode.ID, deleted.ID)
+
+	_, err = testQueries.DeleteGoodBarcode(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.GetGoodBarcode(context.Background(), barcode.Barcode)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestGetGoodBySku(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	sku := sql.NullString{String: util.RandomString(10), Valid: true}
+
+	updated, err := testQueries.SetGoodSku(context.Background(), SetGoodSkuParams{
+		ID:  good.ID,
+		Sku: sku,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sku, updated.Sku)
+	require.Equal(t, good.Version+1, updated.Version)
+
+	found, err := testQueries.GetGoodBySku(context.Background(), sku)
+	require.NoError(t, err)
+	require.Equal(t, good.ID, found.ID)
+
+	// skus are unique among all goods
+	_, err = testQueries.SetGoodSku(context.Background(), SetGoodSkuParams{
+		ID:  createRandomGood(t, createRandomCategory(t), createRandomUnit(t)).ID,
+		Sku: sku,
+	})
+	require.Error(t, err)
+}
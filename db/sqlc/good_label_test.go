@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGoodLabel(t *testing.T) {
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	good := createRandomGood(t, category, unit)
+
+	label, err := testQueries.GetGoodLabel(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.ID, label.GoodID)
+	require.Equal(t, good.Model, label.Model)
+	require.Equal(t, category.CategoryName, label.CategoryName)
+	require.Equal(t, unit.UnitName, label.UnitName)
+	require.False(t, label.Barcode.Valid)
+	require.False(t, label.Symbology.Valid)
+
+	// the first barcode goes on the label
+	barcode := createRandomGoodBarcode(t, good)
+	createRandomGoodBarcode(t, good)
+
+	label, err = testQueries.GetGoodLabel(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, barcode.Barcode, label.Barcode.String)
+	require.Equal(t, barcode.Symbology, label.Symbology.String)
+
+	_, err = testQueries.DeleteGood(context.Background(), good.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetGoodLabel(context.Background(), good.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListCategoryLabels(t *testing.T) {
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	good1 := createRandomGood(t, category, unit)
+	good2 := createRandomGood(t, category, unit)
+	deleted := createRandomGood(t, category, unit)
+	createRandomGood(t, createRandomCategory(t), unit)
+	createRandomGoodBarcode(t, good1)
+
+	_, err := testQueries.DeleteGood(context.Background(), deleted.ID)
+	require.NoError(t, err)
+
+	labels, err := testQueries.ListCategoryLabels(context.Background(), category.ID)
+	require.NoError(t, err)
+	require.Len(t, labels, 2)
+	require.Equal(t, good1.ID, labels[0].GoodID)
+	require.True(t, labels[0].Barcode.Valid)
+	require.Equal(t, good2.ID, labels[1].GoodID)
+	require.False(t, labels[1].Barcode.Valid)
+}
@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createSearchGood(t *testing.T, category Category, model, desc string) Good {
+	good, err := testQueries.CreateGood(context.Background(), CreateGoodParams{
+		Category: category.ID,
+		Model:    model,
+		Unit:     createRandomUnit(t).ID,
+		Amount:   util.RandomInt(1, 10),
+		GoodDesc: desc,
+	})
+	require.NoError(t, err)
+	return good
+}
+
+func TestSearchGoods(t *testing.T) {
+	category, err := testQueries.CreateCategory(context.Background(), CreateCategoryParams{
+		CategoryName: "Power Drills",
+		SectionName:  util.RandomName(),
+	})
+	require.NoError(t, err)
+
+	drill := createSearchGood(t, category, "Bosch GSR 18V-55", "cordless screwdriver with two batteries")
+	saw := createSearchGood(t, category, "Makita HS7601", "circular saw for wood")
+	deleted := createSearchGood(t, category, "Bosch GSR 18V-21", "older cordless screwdriver")
+	_, err = testQueries.DeleteGood(context.Background(), deleted.ID)
+	require.NoError(t, err)
+
+	arg := SearchGoodsParams{
+		// drill is only in the name of the category
+		Query:    "bosch drill 18v",
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
+		Limit:    10,
+	}
+	results, err := testQueries.SearchGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, drill.ID, results[0].ID)
+	require.Equal(t, category.CategoryName, results[0].CategoryName)
+	require.Positive(t, results[0].Rank)
+	require.Contains(t, results[0].ModelSnippet, "<mark>Bosch</mark>")
+
+	// words with typos still find the good
+	arg.Query = "makitta circuler"
+	results, err = testQueries.SearchGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, saw.ID, results[0].ID)
+	require.NotContains(t, results[0].ModelSnippet, "<mark>")
+
+	// the snippet of the description marks the matched word
+	arg.Query = "cordless"
+	results, err = testQueries.SearchGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Contains(t, results[0].DescSnippet, "<mark>cordless</mark>")
+
+	arg.Query = "hammer"
+	results, err = testQueries.SearchGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
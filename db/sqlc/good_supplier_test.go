@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomGoodSupplier(t *testing.T, good Good, supplier Supplier) GoodSupplier {
+	arg := CreateGoodSupplierParams{
+		GoodID:      good.ID,
+		SupplierID:  supplier.ID,
+		PartNumber:  util.RandomString(10),
+		UnitPrice:   util.RandomInt(0, 100000),
+		MinOrderQty: util.RandomInt(1, 100),
+	}
+
+	goodSupplier, err := testQueries.CreateGoodSupplier(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.GoodID, goodSupplier.GoodID)
+	require.Equal(t, arg.SupplierID, goodSupplier.SupplierID)
+	require.Equal(t, arg.PartNumber, goodSupplier.PartNumber)
+	require.Equal(t, arg.UnitPrice, goodSupplier.UnitPrice)
+	require.Equal(t, arg.MinOrderQty, goodSupplier.MinOrderQty)
+	require.NotZero(t, goodSupplier.CreatedAt)
+
+	return goodSupplier
+}
+
+func TestCreateGoodSupplier(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	createRandomGoodSupplier(t, good, createRandomSupplier(t))
+}
+
+func TestCreateGoodSupplierDuplicatePartNumber(t *testing.T) {
+	supplier := createRandomSupplier(t)
+	good1 := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	good2 := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	goodSupplier := createRandomGoodSupplier(t, good1, supplier)
+
+	// a part number names one good of the supplier
+	_, err := testQueries.CreateGoodSupplier(context.Background(), CreateGoodSupplierParams{
+		GoodID:      good2.ID,
+		SupplierID:  supplier.ID,
+		PartNumber:  goodSupplier.PartNumber,
+		MinOrderQty: 1,
+	})
+	require.Error(t, err)
+}
+
+func TestListGoodSuppliers(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	supplier1 := createRandomSupplier(t)
+	supplier2 := createRandomSupplier(t)
+	createRandomGoodSupplier(t, good, supplier1)
+	createRandomGoodSupplier(t, good, supplier2)
+
+	rows, err := testQueries.ListGoodSuppliers(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	require.Equal(t, supplier1.ID, rows[0].SupplierID)
+	require.Equal(t, supplier1.SupplierName, rows[0].SupplierName)
+	require.Equal(t, supplier1.LeadTimeDays, rows[0].LeadTimeDays)
+	require.Equal(t, supplier1.Currency, rows[0].Currency)
+	require.Equal(t, supplier2.ID, rows[1].SupplierID)
+}
+
+func TestUpdateGoodSupplier(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	goodSupplier1 := createRandomGoodSupplier(t, good, createRandomSupplier(t))
+
+	arg := UpdateGoodSupplierParams{
+		GoodID:      goodSupplier1.GoodID,
+		SupplierID:  goodSupplier1.SupplierID,
+		PartNumber:  util.RandomString(10),
+		UnitPrice:   goodSupplier1.UnitPrice + 1,
+		MinOrderQty: goodSupplier1.MinOrderQty + 1,
+	}
+	goodSupplier2, err := testQueries.UpdateGoodSupplier(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Equal(t, arg.PartNumber, goodSupplier2.PartNumber)
+	require.Equal(t, arg.UnitPrice, goodSupplier2.UnitPrice)
+	require.Equal(t, arg.MinOrderQty, goodSupplier2.MinOrderQty)
+}
+
+func TestDeleteGoodSupplier(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	goodSupplier := createRandomGoodSupplier(t, good, createRandomSupplier(t))
+
+	err := testQueries.DeleteGoodSupplier(context.Background(), DeleteGoodSupplierParams{
+		GoodID:     goodSupplier.GoodID,
+		SupplierID: goodSupplier.SupplierID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetGoodSupplier(context.Background(), GetGoodSupplierParams{
+		GoodID:     goodSupplier.GoodID,
+		SupplierID: goodSupplier.SupplierID,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestDeleteSupplierWithGoods(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	supplier := createRandomSupplier(t)
+	createRandomGoodSupplier(t, good, supplier)
+
+	err := testQueries.DeleteSupplier(context.Background(), supplier.ID)
+	require.Error(t, err)
+}
@@ -68,7 +68,7 @@ func TestListGoods(t *testing.T) {
 	}
 
 	arg := ListGoodsParams{
-		Category: category.ID,
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
 		Limit:    5,
 		Offset:   5,
 	}
@@ -79,8 +79,74 @@ func TestListGoods(t *testing.T) {
 
 	for _, good := range goods {
 		require.NotEmpty(t, good)
-		require.True(t, good.Category == category.ID || good.Unit == unit.ID)
+		require.Equal(t, category.ID, good.Category)
+	}
+}
+
+func TestListGoodsFilters(t *testing.T) {
+	category := createRandomCategory(t)
+	unit1 := createRandomUnit(t)
+	unit2 := createRandomUnit(t)
+
+	var goods []Good
+	for i, model := range []string{"DR-100", "DR-200", "SAW-1", "DR-300"} {
+		unit := unit1
+		if i == 3 {
+			unit = unit2
+		}
+		good, err := testQueries.CreateGood(context.Background(), CreateGoodParams{
+			Category: category.ID,
+			Model:    model,
+			Unit:     unit.ID,
+			Amount:   int64(10 * (i + 1)),
+			GoodDesc: util.RandomName(),
+		})
+		require.NoError(t, err)
+		goods = append(goods, good)
+	}
+
+	// the filters combine, the other unit and the other model are left out
+	arg := ListGoodsParams{
+		Category:    sql.NullInt64{Int64: category.ID, Valid: true},
+		Section:     sql.NullString{String: category.SectionName, Valid: true},
+		Unit:        sql.NullInt64{Int64: unit1.ID, Valid: true},
+		ModelPrefix: sql.NullString{String: "DR-", Valid: true},
+		MinAmount:   sql.NullInt64{Int64: 10, Valid: true},
+		MaxAmount:   sql.NullInt64{Int64: 40, Valid: true},
+		CreatedFrom: sql.NullTime{Time: goods[0].CreatedAt.Add(-time.Minute), Valid: true},
+		CreatedTo:   sql.NullTime{Time: goods[3].CreatedAt.Add(time.Minute), Valid: true},
+		Limit:       10,
 	}
+	listed, err := testQueries.ListGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	require.Equal(t, goods[0].ID, listed[0].ID)
+	require.Equal(t, goods[1].ID, listed[1].ID)
+
+	arg.Sort1 = "-amount"
+	listed, err = testQueries.ListGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	require.Equal(t, goods[1].ID, listed[0].ID)
+	require.Equal(t, goods[0].ID, listed[1].ID)
+
+	// an empty key sorts nothing
+	arg = ListGoodsParams{
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
+		Sort2:    "-model",
+		Limit:    10,
+	}
+	listed, err = testQueries.ListGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, listed, 4)
+	require.Equal(t, "SAW-1", listed[0].Model)
+	require.Equal(t, "DR-100", listed[3].Model)
+
+	arg.MinAmount = sql.NullInt64{Int64: 35, Valid: true}
+	listed, err = testQueries.ListGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, goods[3].ID, listed[0].ID)
 }
 
 func TestUpdateGood(t *testing.T) {
@@ -102,6 +168,7 @@ func TestUpdateGood(t *testing.T) {
 	require.Equal(t, good1.ID, good2.ID)
 	require.NotEqual(t, good1.Unit, good2.Unit)
 	require.NotEqual(t, good1.Amount, good2.Amount)
+	require.Equal(t, good1.Version+1, good2.Version)
 }
 
 func TestDeleteGood(t *testing.T) {
@@ -109,9 +176,10 @@ func TestDeleteGood(t *testing.T) {
 	unit := createRandomUnit(t)
 	good1 := createRandomGood(t, category, unit)
 
-	err1:= testQueries.DeleteGood(context.Background(), good1.ID)
+	deleted, err1 := testQueries.DeleteGood(context.Background(), good1.ID)
 
 	require.NoError(t, err1)
+	require.True(t, deleted.DeletedAt.Valid)
 
 	good2, err2 := testQueries.GetGood(context.Background(), good1.ID)
 
@@ -119,4 +187,72 @@ func TestDeleteGood(t *testing.T) {
 	require.EqualError(t, err2, sql.ErrNoRows.Error())
 
 	require.Empty(t, good2)
-}
\ No newline at end of file
+
+	_, err2 = testQueries.GetGoodForUpdate(context.Background(), good1.ID)
+	require.EqualError(t, err2, sql.ErrNoRows.Error())
+
+	good3, err3 := testQueries.RestoreGood(context.Background(), good1.ID)
+	require.NoError(t, err3)
+	require.False(t, good3.DeletedAt.Valid)
+	require.Equal(t, good1.ID, good3.ID)
+}
+
+func TestSetGoodStockLevels(t *testing.T) {
+	good1 := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	arg := SetGoodStockLevelsParams{
+		ID:           good1.ID,
+		SafetyStock:  1,
+		ReorderPoint: 3,
+		MaxLevel:     10,
+	}
+	good2, err := testQueries.SetGoodStockLevels(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.SafetyStock, good2.SafetyStock)
+	require.Equal(t, arg.ReorderPoint, good2.ReorderPoint)
+	require.Equal(t, arg.MaxLevel, good2.MaxLevel)
+	require.Equal(t, good1.Version+1, good2.Version)
+
+	// the reorder point may not exceed the max level
+	arg.MaxLevel = 2
+	_, err = testQueries.SetGoodStockLevels(context.Background(), arg)
+	require.Error(t, err)
+}
+func TestExportGoods(t *testing.T) {
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+
+	var goods []Good
+	for i := 0; i < 5; i++ {
+		goods = append(goods, createRandomGood(t, category, unit))
+	}
+
+	_, err := testQueries.DeleteGood(context.Background(), goods[4].ID)
+	require.NoError(t, err)
+
+	arg := ExportGoodsParams{
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
+		Limit:    3,
+	}
+	batch1, err := testQueries.ExportGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, batch1, 3)
+	require.Equal(t, goods[0].ID, batch1[0].ID)
+	require.Equal(t, goods[0].Model, batch1[0].Model)
+	require.Equal(t, category.CategoryName, batch1[0].CategoryName)
+	require.Equal(t, category.SectionName, batch1[0].SectionName)
+	require.Equal(t, unit.UnitName, batch1[0].UnitName)
+
+	// the next batch starts after the last good of the batch, the deleted good is left out
+	arg.AfterID = batch1[2].ID
+	batch2, err := testQueries.ExportGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, batch2, 1)
+	require.Equal(t, goods[3].ID, batch2[0].ID)
+
+	arg.IncludeDeleted = true
+	batch2, err = testQueries.ExportGoods(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, batch2, 2)
+	require.True(t, batch2[1].DeletedAt.Valid)
+}
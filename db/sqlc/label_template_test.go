@@ -0,0 +1,115 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomLabelTemplate(t *testing.T) LabelTemplate {
+	arg := CreateLabelTemplateParams{
+		TemplateName: util.RandomName() + util.RandomString(6),
+		WidthMm:      int32(util.RandomInt(30, 100)),
+		HeightMm:     int32(util.RandomInt(20, 60)),
+		Dpi:          203,
+		CodeType:     "barcode",
+		ShowModel:    true,
+		ShowCategory: true,
+		ShowUnit:     false,
+	}
+
+	template, err := testQueries.CreateLabelTemplate(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, template)
+
+	require.Equal(t, arg.TemplateName, template.TemplateName)
+	require.Equal(t, arg.WidthMm, template.WidthMm)
+	require.Equal(t, arg.HeightMm, template.HeightMm)
+	require.Equal(t, arg.Dpi, template.Dpi)
+	require.Equal(t, arg.CodeType, template.CodeType)
+	require.Equal(t, arg.ShowModel, template.ShowModel)
+	require.Equal(t, arg.ShowCategory, template.ShowCategory)
+	require.Equal(t, arg.ShowUnit, template.ShowUnit)
+	require.NotZero(t, template.ID)
+	require.NotZero(t, template.CreatedAt)
+
+	return template
+}
+
+func TestCreateLabelTemplate(t *testing.T) {
+	template := createRandomLabelTemplate(t)
+
+	// names are unique
+	_, err := testQueries.CreateLabelTemplate(context.Background(), CreateLabelTemplateParams{
+		TemplateName: template.TemplateName,
+		WidthMm:      50,
+		HeightMm:     30,
+		Dpi:          203,
+		CodeType:     "qr",
+	})
+	require.Error(t, err)
+
+	_, err = testQueries.CreateLabelTemplate(context.Background(), CreateLabelTemplateParams{
+		TemplateName: util.RandomName() + util.RandomString(6),
+		WidthMm:      50,
+		HeightMm:     30,
+		Dpi:          203,
+		CodeType:     "datamatrix",
+	})
+	require.Error(t, err)
+}
+
+func TestGetLabelTemplate(t *testing.T) {
+	template1 := createRandomLabelTemplate(t)
+	template2, err := testQueries.GetLabelTemplate(context.Background(), template1.ID)
+
+	require.NoError(t, err)
+	require.Equal(t, template1, template2)
+}
+
+func TestListLabelTemplates(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		createRandomLabelTemplate(t)
+	}
+
+	templates, err := testQueries.ListLabelTemplates(context.Background(), ListLabelTemplatesParams{
+		Limit:  5,
+		Offset: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, templates, 5)
+}
+
+func TestUpdateLabelTemplate(t *testing.T) {
+	template1 := createRandomLabelTemplate(t)
+
+	arg := UpdateLabelTemplateParams{
+		ID:           template1.ID,
+		TemplateName: template1.TemplateName,
+		WidthMm:      template1.WidthMm + 10,
+		HeightMm:     template1.HeightMm,
+		Dpi:          300,
+		CodeType:     "qr",
+		ShowUnit:     true,
+	}
+	template2, err := testQueries.UpdateLabelTemplate(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Equal(t, arg.WidthMm, template2.WidthMm)
+	require.Equal(t, arg.Dpi, template2.Dpi)
+	require.Equal(t, arg.CodeType, template2.CodeType)
+	require.False(t, template2.ShowModel)
+	require.True(t, template2.ShowUnit)
+}
+
+func TestDeleteLabelTemplate(t *testing.T) {
+	template1 := createRandomLabelTemplate(t)
+	err := testQueries.DeleteLabelTemplate(context.Background(), template1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetLabelTemplate(context.Background(), template1.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
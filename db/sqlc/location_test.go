@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomLocation(t *testing.T, warehouse Warehouse, locationType string, parent *Location) Location {
+	arg := CreateLocationParams{
+		WarehouseID:  warehouse.ID,
+		LocationType: locationType,
+		LocationCode: util.RandomString(8),
+	}
+	if parent != nil {
+		arg.ParentID = sql.NullInt64{Int64: parent.ID, Valid: true}
+	}
+
+	location, err := testQueries.CreateLocation(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, location)
+
+	require.Equal(t, arg.WarehouseID, location.WarehouseID)
+	require.Equal(t, arg.ParentID, location.ParentID)
+	require.Equal(t, arg.LocationType, location.LocationType)
+	require.Equal(t, arg.LocationCode, location.LocationCode)
+	require.NotZero(t, location.ID)
+	require.NotZero(t, location.CreatedAt)
+
+	return location
+}
+
+// createRandomBin creates a zone > aisle > rack > bin chain and returns all four levels
+func createRandomBin(t *testing.T, warehouse Warehouse) []Location {
+	zone := createRandomLocation(t, warehouse, LocationTypeZone, nil)
+	aisle := createRandomLocation(t, warehouse, LocationTypeAisle, &zone)
+	rack := createRandomLocation(t, warehouse, LocationTypeRack, &aisle)
+	bin := createRandomLocation(t, warehouse, LocationTypeBin, &rack)
+
+	return []Location{zone, aisle, rack, bin}
+}
+
+func TestCreateLocation(t *testing.T) {
+	createRandomBin(t, createRandomWarehouse(t))
+}
+
+func TestCreateLocationInvalidType(t *testing.T) {
+	arg := CreateLocationParams{
+		WarehouseID:  createRandomWarehouse(t).ID,
+		LocationType: "shelf",
+		LocationCode: util.RandomString(8),
+	}
+
+	_, err := testQueries.CreateLocation(context.Background(), arg)
+	require.Error(t, err)
+}
+
+func TestGetLocation(t *testing.T) {
+	location1 := createRandomLocation(t, createRandomWarehouse(t), LocationTypeZone, nil)
+	location2, err := testQueries.GetLocation(context.Background(), location1.ID)
+
+	require.NoError(t, err)
+	require.Equal(t, location1, location2)
+}
+
+func TestListLocations(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+	for i := 0; i < 10; i++ {
+		createRandomLocation(t, warehouse, LocationTypeZone, nil)
+	}
+
+	arg := ListLocationsParams{
+		WarehouseID: warehouse.ID,
+		Limit:       5,
+		Offset:      5,
+	}
+
+	locations, err := testQueries.ListLocations(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, locations, 5)
+
+	for _, location := range locations {
+		require.Equal(t, warehouse.ID, location.WarehouseID)
+	}
+}
+
+func TestUpdateLocation(t *testing.T) {
+	location1 := createRandomLocation(t, createRandomWarehouse(t), LocationTypeZone, nil)
+
+	arg := UpdateLocationParams{
+		ID:           location1.ID,
+		LocationCode: util.RandomString(8),
+	}
+
+	location2, err := testQueries.UpdateLocation(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, location1.ID, location2.ID)
+	require.Equal(t, arg.LocationCode, location2.LocationCode)
+	require.Equal(t, location1.LocationType, location2.LocationType)
+}
+
+func TestDeleteLocation(t *testing.T) {
+	location1 := createRandomLocation(t, createRandomWarehouse(t), LocationTypeZone, nil)
+
+	err := testQueries.DeleteLocation(context.Background(), location1.ID)
+	require.NoError(t, err)
+
+	location2, err := testQueries.GetLocation(context.Background(), location1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, location2)
+}
+
+func TestListLocationContents(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	levels := createRandomBin(t, warehouse)
+	rack := levels[2]
+	bin1 := levels[3]
+	bin2 := createRandomLocation(t, warehouse, LocationTypeBin, &rack)
+
+	addRandomBinStock(t, good, bin1)
+	addRandomBinStock(t, good, bin2)
+
+	// every level above the bins sees the goods stored below it
+	for _, location := range levels[:3] {
+		contents, err := testQueries.ListLocationContents(context.Background(), ListLocationContentsParams{ID: location.ID})
+		require.NoError(t, err)
+		require.Len(t, contents, 2)
+
+		for _, row := range contents {
+			require.Equal(t, good.ID, row.GoodID)
+			require.Equal(t, good.Model, row.Model)
+		}
+	}
+
+	contents, err := testQueries.ListLocationContents(context.Background(), ListLocationContentsParams{ID: bin1.ID})
+	require.NoError(t, err)
+	require.Len(t, contents, 1)
+	require.Equal(t, bin1.LocationCode, contents[0].LocationCode)
+
+	// goods of other categories are left out
+	other := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	addRandomBinStock(t, other, bin1)
+
+	contents, err = testQueries.ListLocationContents(context.Background(), ListLocationContentsParams{
+		ID:       rack.ID,
+		Category: sql.NullInt64{Int64: good.Category, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, contents, 2)
+	for _, row := range contents {
+		require.Equal(t, good.ID, row.GoodID)
+	}
+}
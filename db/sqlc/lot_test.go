@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createRandomLot creates a lot of the good expiring the given number of days from today
+func createRandomLot(t *testing.T, good Good, expiresIn int) Lot {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	arg := CreateLotParams{
+		GoodID:         good.ID,
+		LotNumber:      util.RandomString(8),
+		ManufacturedOn: sql.NullTime{Time: today.AddDate(0, 0, expiresIn-365), Valid: true},
+		ExpiresOn:      sql.NullTime{Time: today.AddDate(0, 0, expiresIn), Valid: true},
+	}
+
+	lot, err := testQueries.CreateLot(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, lot)
+
+	require.Equal(t, arg.GoodID, lot.GoodID)
+	require.Equal(t, arg.LotNumber, lot.LotNumber)
+	require.True(t, lot.ExpiresOn.Valid)
+	require.NotZero(t, lot.ID)
+	require.NotZero(t, lot.CreatedAt)
+
+	return lot
+}
+
+func TestCreateLot(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	lot := createRandomLot(t, good, 30)
+
+	// lot numbers are unique per good
+	_, err := testQueries.CreateLot(context.Background(), CreateLotParams{
+		GoodID:    good.ID,
+		LotNumber: lot.LotNumber,
+	})
+	require.Error(t, err)
+
+	// a lot cannot expire before it is manufactured
+	_, err = testQueries.CreateLot(context.Background(), CreateLotParams{
+		GoodID:         good.ID,
+		LotNumber:      util.RandomString(8),
+		ManufacturedOn: lot.ExpiresOn,
+		ExpiresOn:      lot.ManufacturedOn,
+	})
+	require.Error(t, err)
+}
+
+func TestGetLot(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	lot1 := createRandomLot(t, good, 30)
+
+	lot2, err := testQueries.GetLot(context.Background(), lot1.ID)
+	require.NoError(t, err)
+	require.Equal(t, lot1.ID, lot2.ID)
+	require.Equal(t, lot1.LotNumber, lot2.LotNumber)
+	require.WithinDuration(t, lot1.ExpiresOn.Time, lot2.ExpiresOn.Time, time.Second)
+}
+
+func TestAddLotStock(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	lot := createRandomLot(t, good, 30)
+
+	arg := AddLotStockParams{
+		LotID:       lot.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      5,
+	}
+	_, err := testQueries.AddLotStock(context.Background(), arg)
+	require.NoError(t, err)
+
+	lotStock, err := testQueries.AddLotStock(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), lotStock.Amount)
+
+	// a lot cannot hold less than nothing
+	arg.Amount = -11
+	_, err = testQueries.AddLotStock(context.Background(), arg)
+	require.Error(t, err)
+
+	total, err := testQueries.SumLotStocks(context.Background(), SumLotStocksParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), total)
+}
+
+func TestListIssuableLotStocks(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	expired := createRandomLot(t, good, -1)
+	later := createRandomLot(t, good, 60)
+	sooner := createRandomLot(t, good, 10)
+	empty := createRandomLot(t, good, 5)
+
+	for _, lot := range []Lot{expired, later, sooner} {
+		_, err := testQueries.AddLotStock(context.Background(), AddLotStockParams{
+			LotID:       lot.ID,
+			WarehouseID: warehouse.ID,
+			Amount:      3,
+		})
+		require.NoError(t, err)
+	}
+	_, err := testQueries.AddLotStock(context.Background(), AddLotStockParams{
+		LotID:       empty.ID,
+		WarehouseID: warehouse.ID,
+	})
+	require.NoError(t, err)
+
+	arg := ListIssuableLotStocksParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+	}
+	lotStocks, err := testQueries.ListIssuableLotStocks(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lotStocks, 2)
+	require.Equal(t, sooner.ID, lotStocks[0].LotID)
+	require.Equal(t, later.ID, lotStocks[1].LotID)
+
+	arg.IncludeExpired = true
+	lotStocks, err = testQueries.ListIssuableLotStocks(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lotStocks, 3)
+	require.Equal(t, expired.ID, lotStocks[0].LotID)
+}
+
+func TestListGoodLots(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	later := createRandomLot(t, good, 60)
+	sooner := createRandomLot(t, good, 10)
+
+	for i := 0; i < 2; i++ {
+		_, err := testQueries.AddLotStock(context.Background(), AddLotStockParams{
+			LotID:       sooner.ID,
+			WarehouseID: createRandomWarehouse(t).ID,
+			Amount:      4,
+		})
+		require.NoError(t, err)
+	}
+
+	lots, err := testQueries.ListGoodLots(context.Background(), ListGoodLotsParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	require.Equal(t, sooner.ID, lots[0].ID)
+	require.Equal(t, int64(8), lots[0].Amount)
+	require.Equal(t, later.ID, lots[1].ID)
+	require.Zero(t, lots[1].Amount)
+}
+
+func TestListExpiringLots(t *testing.T) {
+	category := createRandomCategory(t)
+	good := createRandomGood(t, category, createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	expired := createRandomLot(t, good, -3)
+	expiring := createRandomLot(t, good, 5)
+	later := createRandomLot(t, good, 60)
+
+	for _, lot := range []Lot{expired, expiring, later} {
+		_, err := testQueries.AddLotStock(context.Background(), AddLotStockParams{
+			LotID:       lot.ID,
+			WarehouseID: warehouse.ID,
+			Amount:      2,
+		})
+		require.NoError(t, err)
+	}
+
+	arg := ListExpiringLotsParams{
+		ExpiresOn:   time.Now().UTC().AddDate(0, 0, 7),
+		WarehouseID: sql.NullInt64{Int64: warehouse.ID, Valid: true},
+		Category:    sql.NullInt64{Int64: category.ID, Valid: true},
+		Limit:       5,
+		Offset:      0,
+	}
+	lots, err := testQueries.ListExpiringLots(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, lots, 2)
+	require.Equal(t, expired.ID, lots[0].ID)
+	require.Equal(t, expiring.ID, lots[1].ID)
+	for _, lot := range lots {
+		require.Equal(t, warehouse.ID, lot.WarehouseID)
+		require.Equal(t, int64(2), lot.Amount)
+	}
+
+	arg.WarehouseID = sql.NullInt64{Int64: createRandomWarehouse(t).ID, Valid: true}
+	lots, err = testQueries.ListExpiringLots(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, lots)
+}
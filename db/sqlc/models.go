@@ -5,13 +5,88 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+// written in the transaction of every mutation, rows are never updated or deleted
+type AuditLog struct {
+	ID int64 `json:"id"`
+	// username of the user who made the change, kept even when the user is gone
+	Actor string `json:"actor"`
+	// create, update, delete or restore
+	Action     string `json:"action"`
+	EntityType string `json:"entity_type"`
+	EntityID   string `json:"entity_id"`
+	// snapshot of the entity before the change, null for a create
+	Before json.RawMessage `json:"before"`
+	// snapshot of the entity after the change, null for a hard delete
+	After     json.RawMessage `json:"after"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type BinStock struct {
+	GoodID     int64 `json:"good_id"`
+	LocationID int64 `json:"location_id"`
+	Amount     int64 `json:"amount"`
+}
+
 type Category struct {
 	ID           int64  `json:"id"`
 	CategoryName string `json:"category_name"`
 	SectionName  string `json:"section_name"`
+	// set when the category is deleted, deleted categories are hidden unless asked for
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	// incremented by every change of the category, compared against If-Match on updates
+	Version int64 `json:"version"`
+	// fifo or average, how the goods of the category are valued when they are issued
+	CostingMethod string `json:"costing_method"`
+}
+
+type CostLayer struct {
+	ID     int64 `json:"id"`
+	GoodID int64 `json:"good_id"`
+	// movement that brought the layer in, null for the opening stock
+	StockMovementID sql.NullInt64 `json:"stock_movement_id"`
+	// in the unit of the good
+	Quantity int64 `json:"quantity"`
+	// part of the quantity not issued yet
+	Remaining int64 `json:"remaining"`
+	// value of the whole quantity in minor units of the inventory currency
+	Value int64 `json:"value"`
+	// value of the remaining part
+	RemainingValue int64     `json:"remaining_value"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type CountSession struct {
+	ID          int64 `json:"id"`
+	WarehouseID int64 `json:"warehouse_id"`
+	// set when the session counts the goods of a category
+	CategoryID sql.NullInt64 `json:"category_id"`
+	// set when the session counts the goods of a section
+	SectionName sql.NullString `json:"section_name"`
+	// set when the session counts the bins below a location
+	LocationID sql.NullInt64 `json:"location_id"`
+	// open, approved or cancelled, counts are entered while the session is open
+	Status    string       `json:"status"`
+	CreatedAt time.Time    `json:"created_at"`
+	ClosedAt  sql.NullTime `json:"closed_at"`
+}
+
+type CountSessionItem struct {
+	ID             int64 `json:"id"`
+	CountSessionID int64 `json:"count_session_id"`
+	GoodID         int64 `json:"good_id"`
+	// bin the good is counted in, null for the stock of the warehouse outside its bins
+	LocationID sql.NullInt64 `json:"location_id"`
+	// stock when the session started, in the unit of the good
+	Snapshot int64 `json:"snapshot"`
+	// null until the item is counted, in the unit of the good
+	Counted   sql.NullInt64 `json:"counted"`
+	CountedBy string        `json:"counted_by"`
+	CountedAt sql.NullTime  `json:"counted_at"`
 }
 
 type Good struct {
@@ -19,14 +94,310 @@ type Good struct {
 	Category int64  `json:"category"`
 	Model    string `json:"model"`
 	Unit     int64  `json:"unit"`
-	// must be positive and bigger than zero
+	// total of the balances in all warehouses
 	Amount    int64     `json:"amount"`
 	GoodDesc  string    `json:"good_desc"`
 	CreatedAt time.Time `json:"created_at"`
+	// set when the good is deleted, deleted goods are hidden unless asked for
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	// incremented by every change of the good, compared against If-Match on updates
+	Version int64 `json:"version"`
+	// total of the active reservations, available is amount minus reserved
+	Reserved int64 `json:"reserved"`
+	// shipped by transfer orders and not yet received, not part of amount
+	InTransit int64 `json:"in_transit"`
+	// the good is low on stock once its available stock falls below it, zero disables the alerts
+	ReorderPoint int64 `json:"reorder_point"`
+	// stock kept against uncertainty, alerts below it are urgent
+	SafetyStock int64 `json:"safety_stock"`
+	// available stock the suggested orders refill the good up to
+	MaxLevel int64 `json:"max_level"`
+	// units of serialized goods are tracked one by one by their serial number
+	Serialized bool `json:"serialized"`
+	// value of the amount and of the stock in transit in minor units of the inventory currency
+	StockValue int64 `json:"stock_value"`
+	// stock keeping unit, unique among all goods
+	Sku sql.NullString `json:"sku"`
+}
+
+type GoodBalance struct {
+	GoodID      int64 `json:"good_id"`
+	WarehouseID int64 `json:"warehouse_id"`
+	Amount      int64 `json:"amount"`
+}
+
+type GoodBarcode struct {
+	ID     int64 `json:"id"`
+	GoodID int64 `json:"good_id"`
+	// unique among all goods, so a scan resolves to one good
+	Barcode string `json:"barcode"`
+	// ean13, upca or code128
+	Symbology string    `json:"symbology"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type GoodSupplier struct {
+	GoodID     int64 `json:"good_id"`
+	SupplierID int64 `json:"supplier_id"`
+	// number the supplier sells the good under
+	PartNumber string `json:"part_number"`
+	// price of one unit of the good in minor units of the currency of the supplier
+	UnitPrice int64 `json:"unit_price"`
+	// in the unit of the good
+	MinOrderQty int64     `json:"min_order_qty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type LabelTemplate struct {
+	ID           int64  `json:"id"`
+	TemplateName string `json:"template_name"`
+	WidthMm      int32  `json:"width_mm"`
+	HeightMm     int32  `json:"height_mm"`
+	// resolution of the PNG and ZPL output, 203 or 300 for most label printers
+	Dpi int32 `json:"dpi"`
+	// barcode prints the first barcode of the good, qr a QR code of it
+	CodeType     string    `json:"code_type"`
+	ShowModel    bool      `json:"show_model"`
+	ShowCategory bool      `json:"show_category"`
+	ShowUnit     bool      `json:"show_unit"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Location struct {
+	ID          int64 `json:"id"`
+	WarehouseID int64 `json:"warehouse_id"`
+	// null for zones, which sit directly under the warehouse
+	ParentID sql.NullInt64 `json:"parent_id"`
+	// zone, aisle, rack or bin
+	LocationType string    `json:"location_type"`
+	LocationCode string    `json:"location_code"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Lot struct {
+	ID             int64        `json:"id"`
+	GoodID         int64        `json:"good_id"`
+	LotNumber      string       `json:"lot_number"`
+	ManufacturedOn sql.NullTime `json:"manufactured_on"`
+	// lots without an expiry date are issued after the ones that expire
+	ExpiresOn sql.NullTime `json:"expires_on"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type LotMovement struct {
+	StockMovementID int64 `json:"stock_movement_id"`
+	LotID           int64 `json:"lot_id"`
+	// part of the amount of the stock movement booked against the lot
+	Amount int64 `json:"amount"`
+}
+
+type LotStock struct {
+	LotID       int64 `json:"lot_id"`
+	WarehouseID int64 `json:"warehouse_id"`
+	// part of the balance of the good in the warehouse, in the unit of the good
+	Amount int64 `json:"amount"`
+}
+
+type PurchaseOrder struct {
+	ID         int64 `json:"id"`
+	SupplierID int64 `json:"supplier_id"`
+	// warehouse the goods are delivered to
+	WarehouseID int64 `json:"warehouse_id"`
+	// draft, sent, partially_received or closed
+	Status    string       `json:"status"`
+	Reference string       `json:"reference"`
+	CreatedAt time.Time    `json:"created_at"`
+	SentAt    sql.NullTime `json:"sent_at"`
+	ClosedAt  sql.NullTime `json:"closed_at"`
+}
+
+type PurchaseOrderLine struct {
+	ID              int64 `json:"id"`
+	PurchaseOrderID int64 `json:"purchase_order_id"`
+	GoodID          int64 `json:"good_id"`
+	// unit the line is ordered and received in
+	UnitID int64 `json:"unit_id"`
+	// in the unit of the line
+	Ordered int64 `json:"ordered"`
+	// in the unit of the line, more than ordered when over-delivered
+	Received int64 `json:"received"`
+	// price of one unit of the line in minor units of the currency of the supplier
+	UnitPrice int64     `json:"unit_price"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Reservation struct {
+	ID     int64 `json:"id"`
+	GoodID int64 `json:"good_id"`
+	// in the unit of the good
+	Amount int64 `json:"amount"`
+	// active, released or expired, only active reservations hold stock
+	Status string `json:"status"`
+	// what the stock is held for, like the number of a sales order
+	Reference string    `json:"reference"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	// set when the reservation is released or expired
+	ClosedAt sql.NullTime `json:"closed_at"`
+}
+
+type SalesOrder struct {
+	ID           int64  `json:"id"`
+	CustomerName string `json:"customer_name"`
+	// warehouse the order is shipped from
+	WarehouseID int64 `json:"warehouse_id"`
+	// open, allocated, shipped or cancelled
+	Status    string       `json:"status"`
+	Reference string       `json:"reference"`
+	CreatedAt time.Time    `json:"created_at"`
+	ShippedAt sql.NullTime `json:"shipped_at"`
+}
+
+type SalesOrderLine struct {
+	ID           int64 `json:"id"`
+	SalesOrderID int64 `json:"sales_order_id"`
+	GoodID       int64 `json:"good_id"`
+	// in the unit of the good
+	Amount int64 `json:"amount"`
+	// part of the amount held in the reserved total of the good until the order ships
+	Allocated int64     `json:"allocated"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Serial struct {
+	ID           int64  `json:"id"`
+	GoodID       int64  `json:"good_id"`
+	SerialNumber string `json:"serial_number"`
+	// in_stock, reserved, shipped or returned
+	Status string `json:"status"`
+	// warehouse holding the unit, null once it has left the stock
+	WarehouseID sql.NullInt64 `json:"warehouse_id"`
+	// reservation holding the unit while it is reserved
+	ReservationID sql.NullInt64 `json:"reservation_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+type SerialEvent struct {
+	ID       int64 `json:"id"`
+	SerialID int64 `json:"serial_id"`
+	// status of the serial after the event
+	Status      string        `json:"status"`
+	WarehouseID sql.NullInt64 `json:"warehouse_id"`
+	// movement that moved the unit, null for reservations
+	StockMovementID sql.NullInt64 `json:"stock_movement_id"`
+	ReservationID   sql.NullInt64 `json:"reservation_id"`
+	CreatedAt       time.Time     `json:"created_at"`
+}
+
+type StockAlert struct {
+	ID     int64 `json:"id"`
+	GoodID int64 `json:"good_id"`
+	// available stock of the good when the alert was raised
+	Available        int64     `json:"available"`
+	ReorderPoint     int64     `json:"reorder_point"`
+	BelowSafetyStock bool      `json:"below_safety_stock"`
+	CreatedAt        time.Time `json:"created_at"`
+	// set once the available stock is back at the reorder point, or the good is deleted
+	ResolvedAt sql.NullTime `json:"resolved_at"`
+}
+
+type StockMovement struct {
+	ID           int64  `json:"id"`
+	GoodID       int64  `json:"good_id"`
+	MovementType string `json:"movement_type"`
+	// positive for receipts, negative for issues
+	Amount      int64         `json:"amount"`
+	CreatedAt   time.Time     `json:"created_at"`
+	WarehouseID int64         `json:"warehouse_id"`
+	LocationID  sql.NullInt64 `json:"location_id"`
+	// value of the movement in minor units of the inventory currency, signed like the amount
+	Value int64 `json:"value"`
+}
+
+type Supplier struct {
+	ID           int64  `json:"id"`
+	SupplierName string `json:"supplier_name"`
+	ContactName  string `json:"contact_name"`
+	Email        string `json:"email"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+	// days from sending an order to its delivery
+	LeadTimeDays int32 `json:"lead_time_days"`
+	// ISO 4217 code the supplier invoices in
+	Currency  string    `json:"currency"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TransferOrder struct {
+	ID              int64 `json:"id"`
+	FromWarehouseID int64 `json:"from_warehouse_id"`
+	ToWarehouseID   int64 `json:"to_warehouse_id"`
+	// draft, in_transit, received or cancelled
+	Status     string       `json:"status"`
+	Reference  string       `json:"reference"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ShippedAt  sql.NullTime `json:"shipped_at"`
+	ReceivedAt sql.NullTime `json:"received_at"`
+}
+
+type TransferOrderLine struct {
+	ID              int64 `json:"id"`
+	TransferOrderID int64 `json:"transfer_order_id"`
+	GoodID          int64 `json:"good_id"`
+	// in the unit of the good
+	Amount int64 `json:"amount"`
+	// optional bin of the source warehouse the goods are taken from
+	FromLocationID sql.NullInt64 `json:"from_location_id"`
+	// optional bin of the destination warehouse the goods are put into
+	ToLocationID sql.NullInt64 `json:"to_location_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type TransferOrderLineLot struct {
+	TransferOrderLineID int64 `json:"transfer_order_line_id"`
+	LotID               int64 `json:"lot_id"`
+	// part of the amount of the line shipped out of the lot, booked back into it on receipt
+	Amount int64 `json:"amount"`
 }
 
 type Unit struct {
-	ID        int64  `json:"id"`
-	UnitName  string `json:"unit_name"`
-	UnitValue int64  `json:"unit_value"`
+	ID       int64  `json:"id"`
+	UnitName string `json:"unit_name"`
+	// how many of the smallest unit of the family one of this unit holds
+	UnitValue int64 `json:"unit_value"`
+	// count, mass, length or volume, only units of the same family convert into each other
+	UnitFamily string `json:"unit_family"`
+	// set when the unit is deleted, deleted units are hidden unless asked for
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	// incremented by every change of the unit, compared against If-Match on updates
+	Version int64 `json:"version"`
+}
+
+type User struct {
+	Username          string    `json:"username"`
+	HashedPassword    string    `json:"hashed_password"`
+	FullName          string    `json:"full_name"`
+	Email             string    `json:"email"`
+	PasswordChangedAt time.Time `json:"password_changed_at"`
+	CreatedAt         time.Time `json:"created_at"`
+	// admin, warehouse_manager, clerk or auditor
+	Role string `json:"role"`
+}
+
+// a user without scopes may act on every category
+type UserScope struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	// set when the scope is a single category
+	CategoryID sql.NullInt64 `json:"category_id"`
+	// set when the scope is every category of a section
+	SectionName sql.NullString `json:"section_name"`
+}
+
+type Warehouse struct {
+	ID            int64     `json:"id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Address       string    `json:"address"`
+	CreatedAt     time.Time `json:"created_at"`
 }
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomPurchaseOrder(t *testing.T, supplier Supplier, warehouse Warehouse) PurchaseOrder {
+	arg := CreatePurchaseOrderParams{
+		SupplierID:  supplier.ID,
+		WarehouseID: warehouse.ID,
+		Reference:   util.RandomString(8),
+	}
+
+	purchaseOrder, err := testQueries.CreatePurchaseOrder(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.SupplierID, purchaseOrder.SupplierID)
+	require.Equal(t, arg.WarehouseID, purchaseOrder.WarehouseID)
+	require.Equal(t, arg.Reference, purchaseOrder.Reference)
+	require.Equal(t, PurchaseOrderStatusDraft, purchaseOrder.Status)
+	require.NotZero(t, purchaseOrder.ID)
+	require.NotZero(t, purchaseOrder.CreatedAt)
+	require.False(t, purchaseOrder.SentAt.Valid)
+	require.False(t, purchaseOrder.ClosedAt.Valid)
+
+	return purchaseOrder
+}
+
+func createRandomPurchaseOrderLine(t *testing.T, purchaseOrder PurchaseOrder, good Good) PurchaseOrderLine {
+	arg := CreatePurchaseOrderLineParams{
+		PurchaseOrderID: purchaseOrder.ID,
+		GoodID:          good.ID,
+		UnitID:          good.Unit,
+		Ordered:         util.RandomInt(2, 100),
+		UnitPrice:       util.RandomInt(0, 10000),
+	}
+
+	line, err := testQueries.CreatePurchaseOrderLine(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.PurchaseOrderID, line.PurchaseOrderID)
+	require.Equal(t, arg.GoodID, line.GoodID)
+	require.Equal(t, arg.UnitID, line.UnitID)
+	require.Equal(t, arg.Ordered, line.Ordered)
+	require.Equal(t, arg.UnitPrice, line.UnitPrice)
+	require.Zero(t, line.Received)
+
+	return line
+}
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	createRandomPurchaseOrder(t, createRandomSupplier(t), createRandomWarehouse(t))
+}
+
+func TestListPurchaseOrders(t *testing.T) {
+	supplier := createRandomSupplier(t)
+	warehouse := createRandomWarehouse(t)
+	draft := createRandomPurchaseOrder(t, supplier, warehouse)
+	sent := createRandomPurchaseOrder(t, supplier, warehouse)
+	createRandomPurchaseOrder(t, createRandomSupplier(t), warehouse)
+
+	_, err := testQueries.UpdatePurchaseOrderStatus(context.Background(), UpdatePurchaseOrderStatusParams{
+		ID:     sent.ID,
+		Status: PurchaseOrderStatusSent,
+	})
+	require.NoError(t, err)
+
+	purchaseOrders, err := testQueries.ListPurchaseOrders(context.Background(), ListPurchaseOrdersParams{
+		SupplierID: sql.NullInt64{Int64: supplier.ID, Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, purchaseOrders, 2)
+
+	purchaseOrders, err = testQueries.ListPurchaseOrders(context.Background(), ListPurchaseOrdersParams{
+		Status:     sql.NullString{String: PurchaseOrderStatusDraft, Valid: true},
+		SupplierID: sql.NullInt64{Int64: supplier.ID, Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, purchaseOrders, 1)
+	require.Equal(t, draft.ID, purchaseOrders[0].ID)
+}
+
+func TestUpdatePurchaseOrderStatus(t *testing.T) {
+	purchaseOrder := createRandomPurchaseOrder(t, createRandomSupplier(t), createRandomWarehouse(t))
+
+	sent, err := testQueries.UpdatePurchaseOrderStatus(context.Background(), UpdatePurchaseOrderStatusParams{
+		ID:     purchaseOrder.ID,
+		Status: PurchaseOrderStatusSent,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusSent, sent.Status)
+	require.True(t, sent.SentAt.Valid)
+	require.False(t, sent.ClosedAt.Valid)
+
+	closed, err := testQueries.UpdatePurchaseOrderStatus(context.Background(), UpdatePurchaseOrderStatusParams{
+		ID:     purchaseOrder.ID,
+		Status: PurchaseOrderStatusClosed,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusClosed, closed.Status)
+	require.Equal(t, sent.SentAt, closed.SentAt)
+	require.True(t, closed.ClosedAt.Valid)
+
+	_, err = testQueries.UpdatePurchaseOrderStatus(context.Background(), UpdatePurchaseOrderStatusParams{
+		ID:     purchaseOrder.ID,
+		Status: "lost",
+	})
+	require.Error(t, err)
+}
+
+func TestCountOpenPurchaseOrderLines(t *testing.T) {
+	purchaseOrder := createRandomPurchaseOrder(t, createRandomSupplier(t), createRandomWarehouse(t))
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	line1 := createRandomPurchaseOrderLine(t, purchaseOrder, createRandomGood(t, category, unit))
+	line2 := createRandomPurchaseOrderLine(t, purchaseOrder, createRandomGood(t, category, unit))
+
+	openLines, err := testQueries.CountOpenPurchaseOrderLines(context.Background(), purchaseOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), openLines)
+
+	// an over-delivered line is fulfilled as well
+	received, err := testQueries.AddPurchaseOrderLineReceived(context.Background(), AddPurchaseOrderLineReceivedParams{
+		ID:     line1.ID,
+		Amount: line1.Ordered + 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, line1.Ordered+1, received.Received)
+
+	_, err = testQueries.AddPurchaseOrderLineReceived(context.Background(), AddPurchaseOrderLineReceivedParams{
+		ID:     line2.ID,
+		Amount: line2.Ordered - 1,
+	})
+	require.NoError(t, err)
+
+	openLines, err = testQueries.CountOpenPurchaseOrderLines(context.Background(), purchaseOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), openLines)
+
+	lines, err := testQueries.ListPurchaseOrderLines(context.Background(), purchaseOrder.ID)
+	require.NoError(t, err)
+	require.Len(t, lines, 2)
+	require.Equal(t, line1.ID, lines[0].ID)
+	require.Equal(t, line2.ID, lines[1].ID)
+}
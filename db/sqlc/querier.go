@@ -6,23 +6,221 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AddBinStock(ctx context.Context, arg AddBinStockParams) (BinStock, error)
+	AddGoodAmount(ctx context.Context, arg AddGoodAmountParams) (Good, error)
+	AddGoodBalance(ctx context.Context, arg AddGoodBalanceParams) (GoodBalance, error)
+	AddGoodInTransit(ctx context.Context, arg AddGoodInTransitParams) (Good, error)
+	// reservations do not change the version, they are not edited through the good
+	AddGoodReserved(ctx context.Context, arg AddGoodReservedParams) (Good, error)
+	// the value follows the movements, it does not change the version
+	AddGoodStockValue(ctx context.Context, arg AddGoodStockValueParams) (Good, error)
+	AddLotStock(ctx context.Context, arg AddLotStockParams) (LotStock, error)
+	AddPurchaseOrderLineReceived(ctx context.Context, arg AddPurchaseOrderLineReceivedParams) (PurchaseOrderLine, error)
+	AddSalesOrderLineAllocated(ctx context.Context, arg AddSalesOrderLineAllocatedParams) (SalesOrderLine, error)
+	ConsumeCostLayer(ctx context.Context, arg ConsumeCostLayerParams) (CostLayer, error)
+	// lines of the purchase order that have not been fully received yet
+	CountOpenPurchaseOrderLines(ctx context.Context, purchaseOrderID int64) (int64, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) (AuditLog, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateCostLayer(ctx context.Context, arg CreateCostLayerParams) (CostLayer, error)
+	CreateCountSession(ctx context.Context, arg CreateCountSessionParams) (CountSession, error)
+	CreateCountSessionItem(ctx context.Context, arg CreateCountSessionItemParams) (CountSessionItem, error)
+	// sets up the admin of a new database, nothing is created once there is an admin
+	CreateFirstAdmin(ctx context.Context, arg CreateFirstAdminParams) (User, error)
 	CreateGood(ctx context.Context, arg CreateGoodParams) (Good, error)
+	CreateGoodBarcode(ctx context.Context, arg CreateGoodBarcodeParams) (GoodBarcode, error)
+	CreateGoodSupplier(ctx context.Context, arg CreateGoodSupplierParams) (GoodSupplier, error)
+	CreateLabelTemplate(ctx context.Context, arg CreateLabelTemplateParams) (LabelTemplate, error)
+	CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error)
+	CreateLot(ctx context.Context, arg CreateLotParams) (Lot, error)
+	CreateLotMovement(ctx context.Context, arg CreateLotMovementParams) (LotMovement, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderLine(ctx context.Context, arg CreatePurchaseOrderLineParams) (PurchaseOrderLine, error)
+	CreateReservation(ctx context.Context, arg CreateReservationParams) (Reservation, error)
+	CreateSalesOrder(ctx context.Context, arg CreateSalesOrderParams) (SalesOrder, error)
+	CreateSalesOrderLine(ctx context.Context, arg CreateSalesOrderLineParams) (SalesOrderLine, error)
+	CreateSerial(ctx context.Context, arg CreateSerialParams) (Serial, error)
+	CreateSerialEvent(ctx context.Context, arg CreateSerialEventParams) (SerialEvent, error)
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
+	CreateTransferOrder(ctx context.Context, arg CreateTransferOrderParams) (TransferOrder, error)
+	CreateTransferOrderLine(ctx context.Context, arg CreateTransferOrderLineParams) (TransferOrderLine, error)
+	CreateTransferOrderLineLot(ctx context.Context, arg CreateTransferOrderLineLotParams) (TransferOrderLineLot, error)
 	CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, error)
-	DeleteCategory(ctx context.Context, id int64) error
-	DeleteGood(ctx context.Context, id int64) error
-	DeleteUnit(ctx context.Context, id int64) error
+	// new users start without permissions until an admin gives them a role
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserScope(ctx context.Context, arg CreateUserScopeParams) (UserScope, error)
+	CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (Warehouse, error)
+	// rows are only marked as deleted, so they can be restored
+	DeleteCategory(ctx context.Context, id int64) (Category, error)
+	// rows are only marked as deleted, so they can be restored
+	DeleteGood(ctx context.Context, id int64) (Good, error)
+	DeleteGoodBarcode(ctx context.Context, arg DeleteGoodBarcodeParams) (GoodBarcode, error)
+	DeleteGoodSupplier(ctx context.Context, arg DeleteGoodSupplierParams) error
+	DeleteLabelTemplate(ctx context.Context, id int64) error
+	DeleteLocation(ctx context.Context, id int64) error
+	DeleteSupplier(ctx context.Context, id int64) error
+	// rows are only marked as deleted, so they can be restored
+	DeleteUnit(ctx context.Context, id int64) (Unit, error)
+	DeleteUserScopes(ctx context.Context, username string) error
+	DeleteWarehouse(ctx context.Context, id int64) error
+	ExpireReservations(ctx context.Context, arg ExpireReservationsParams) ([]Reservation, error)
+	// the categories a batch at a time after the category after_id
+	ExportCategories(ctx context.Context, arg ExportCategoriesParams) ([]Category, error)
+	// the goods with the names of their category and unit, a batch at a time after the good after_id.
+	// The filters are the ones of ListGoods.
+	ExportGoods(ctx context.Context, arg ExportGoodsParams) ([]ExportGoodsRow, error)
+	// the units a batch at a time after the unit after_id
+	ExportUnits(ctx context.Context, arg ExportUnitsParams) ([]Unit, error)
+	GetBinStock(ctx context.Context, arg GetBinStockParams) (BinStock, error)
 	GetCategory(ctx context.Context, id int64) (Category, error)
+	GetCategoryIncludingDeleted(ctx context.Context, id int64) (Category, error)
+	GetCountSession(ctx context.Context, id int64) (CountSession, error)
+	GetCountSessionForUpdate(ctx context.Context, id int64) (CountSession, error)
 	GetGood(ctx context.Context, id int64) (Good, error)
+	GetGoodBalance(ctx context.Context, arg GetGoodBalanceParams) (GoodBalance, error)
+	GetGoodBarcode(ctx context.Context, barcode string) (GoodBarcode, error)
+	GetGoodBySku(ctx context.Context, sku sql.NullString) (Good, error)
+	GetGoodForUpdate(ctx context.Context, id int64) (Good, error)
+	GetGoodIncludingDeleted(ctx context.Context, id int64) (Good, error)
+	GetGoodIncludingDeletedForUpdate(ctx context.Context, id int64) (Good, error)
+	// what goes on the label of a good, with the first of its barcodes
+	GetGoodLabel(ctx context.Context, id int64) (GetGoodLabelRow, error)
+	GetGoodSupplier(ctx context.Context, arg GetGoodSupplierParams) (GoodSupplier, error)
+	GetLabelTemplate(ctx context.Context, id int64) (LabelTemplate, error)
+	GetLocation(ctx context.Context, id int64) (Location, error)
+	GetLot(ctx context.Context, id int64) (Lot, error)
+	GetLotStock(ctx context.Context, arg GetLotStockParams) (LotStock, error)
+	GetPurchaseOrder(ctx context.Context, id int64) (PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, id int64) (PurchaseOrder, error)
+	GetPurchaseOrderLine(ctx context.Context, id int64) (PurchaseOrderLine, error)
+	GetReservation(ctx context.Context, id int64) (Reservation, error)
+	GetSalesOrder(ctx context.Context, id int64) (SalesOrder, error)
+	GetSalesOrderForUpdate(ctx context.Context, id int64) (SalesOrder, error)
+	GetSerialByNumber(ctx context.Context, serialNumber string) (Serial, error)
+	GetStockMovement(ctx context.Context, id int64) (StockMovement, error)
+	GetSupplier(ctx context.Context, id int64) (Supplier, error)
+	GetTransferOrder(ctx context.Context, id int64) (TransferOrder, error)
+	GetTransferOrderForUpdate(ctx context.Context, id int64) (TransferOrder, error)
+	GetUnit(ctx context.Context, id int64) (Unit, error)
+	GetUnitIncludingDeleted(ctx context.Context, id int64) (Unit, error)
+	GetUser(ctx context.Context, username string) (User, error)
+	GetWarehouse(ctx context.Context, id int64) (Warehouse, error)
+	// every filter is optional, the time range includes from_time and excludes to_time
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]AuditLog, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	// categories are not unique by name, the section tells apart the ones of different sections
+	ListCategoriesByName(ctx context.Context, arg ListCategoriesByNameParams) ([]Category, error)
+	// what goes on the labels of all goods of a category, with the first of their barcodes
+	ListCategoryLabels(ctx context.Context, category int64) ([]ListCategoryLabelsRow, error)
+	// value of the stock of every category and the cost of the goods it issued in the period
+	ListCategoryValuations(ctx context.Context, arg ListCategoryValuationsParams) ([]ListCategoryValuationsRow, error)
+	ListCostLayers(ctx context.Context, arg ListCostLayersParams) ([]CostLayer, error)
+	// stock in the bins of the warehouse of the goods of a category or a section
+	ListCountBinSnapshot(ctx context.Context, arg ListCountBinSnapshotParams) ([]BinStock, error)
+	ListCountSessionItems(ctx context.Context, countSessionID int64) ([]CountSessionItem, error)
+	ListCountSessions(ctx context.Context, arg ListCountSessionsParams) ([]CountSession, error)
+	// stock in the warehouse outside its bins of the goods of a category or a section, zero for goods it does not hold
+	ListCountSnapshot(ctx context.Context, arg ListCountSnapshotParams) ([]ListCountSnapshotRow, error)
+	// lots holding stock that expire on the given day or before, expired lots included
+	ListExpiringLots(ctx context.Context, arg ListExpiringLotsParams) ([]ListExpiringLotsRow, error)
+	ListGoodBalances(ctx context.Context, goodID int64) ([]ListGoodBalancesRow, error)
+	ListGoodBarcodes(ctx context.Context, goodID int64) ([]GoodBarcode, error)
+	// lots of the good with the stock they hold in all warehouses, the first to expire first
+	ListGoodLots(ctx context.Context, arg ListGoodLotsParams) ([]ListGoodLotsRow, error)
+	ListGoodSerials(ctx context.Context, arg ListGoodSerialsParams) ([]Serial, error)
+	ListGoodSuppliers(ctx context.Context, goodID int64) ([]ListGoodSuppliersRow, error)
+	// value of the stock of every good and the cost of the goods it issued in the period
+	ListGoodValuations(ctx context.Context, arg ListGoodValuationsParams) ([]ListGoodValuationsRow, error)
+	// every filter is optional and the given ones all apply. The goods are sorted by up to three keys
+	// and then by id, a key is one of id, model, amount and created_at and sorts descending with a leading -.
 	ListGoods(ctx context.Context, arg ListGoodsParams) ([]Good, error)
+	// lots of the good holding stock in the warehouse in the order they are issued, the first to expire first
+	ListIssuableLotStocks(ctx context.Context, arg ListIssuableLotStocksParams) ([]ListIssuableLotStocksRow, error)
+	ListLabelTemplates(ctx context.Context, arg ListLabelTemplatesParams) ([]LabelTemplate, error)
+	ListLocationContents(ctx context.Context, arg ListLocationContentsParams) ([]ListLocationContentsRow, error)
+	ListLocations(ctx context.Context, arg ListLocationsParams) ([]Location, error)
+	ListLotMovements(ctx context.Context, stockMovementID int64) ([]LotMovement, error)
+	// goods whose available stock is below their reorder point, the lowest first
+	ListLowStockGoods(ctx context.Context, arg ListLowStockGoodsParams) ([]Good, error)
+	// layers of the good with stock left in the order they are issued, the oldest first
+	ListOpenCostLayers(ctx context.Context, goodID int64) ([]CostLayer, error)
+	// goods holding active reservations that expired at the given time
+	ListOverdueReservationGoods(ctx context.Context, now time.Time) ([]int64, error)
+	ListPurchaseOrderLines(ctx context.Context, purchaseOrderID int64) ([]PurchaseOrderLine, error)
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error)
+	ListReservations(ctx context.Context, arg ListReservationsParams) ([]Reservation, error)
+	ListSalesOrderLines(ctx context.Context, salesOrderID int64) ([]SalesOrderLine, error)
+	ListSalesOrders(ctx context.Context, arg ListSalesOrdersParams) ([]SalesOrder, error)
+	// lifecycle of the serial, the oldest event first
+	ListSerialEvents(ctx context.Context, serialID int64) ([]SerialEvent, error)
+	ListStockAlerts(ctx context.Context, arg ListStockAlertsParams) ([]StockAlert, error)
+	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListSuppliers(ctx context.Context, arg ListSuppliersParams) ([]Supplier, error)
+	// lots the line was shipped out of
+	ListTransferOrderLineLots(ctx context.Context, transferOrderLineID int64) ([]TransferOrderLineLot, error)
+	ListTransferOrderLines(ctx context.Context, transferOrderID int64) ([]TransferOrderLine, error)
+	ListTransferOrders(ctx context.Context, arg ListTransferOrdersParams) ([]TransferOrder, error)
 	ListUnits(ctx context.Context, arg ListUnitsParams) ([]Unit, error)
+	ListUnitsByName(ctx context.Context, unitName string) ([]Unit, error)
+	ListUserScopes(ctx context.Context, username string) ([]UserScope, error)
+	// bins of the warehouse holding the good, in the order of their code
+	ListWarehouseBinStocks(ctx context.Context, arg ListWarehouseBinStocksParams) ([]ListWarehouseBinStocksRow, error)
+	ListWarehouses(ctx context.Context, arg ListWarehousesParams) ([]Warehouse, error)
+	// locks the goods of the category in the order of their id
+	LockCategoryGoods(ctx context.Context, category int64) ([]int64, error)
+	// opens an alert for the goods below their reorder point that have none open, only for the given good when one is given
+	RaiseStockAlerts(ctx context.Context, goodID sql.NullInt64) ([]StockAlert, error)
+	ReleaseReservation(ctx context.Context, id int64) (Reservation, error)
+	// gives the units held by the reservation back to the stock
+	ReleaseReservationSerials(ctx context.Context, reservationID sql.NullInt64) ([]Serial, error)
+	// closes the open alerts of the goods back at their reorder point or deleted, only for the given good when one is given
+	ResolveStockAlerts(ctx context.Context, goodID sql.NullInt64) ([]StockAlert, error)
+	RestoreCategory(ctx context.Context, id int64) (Category, error)
+	RestoreGood(ctx context.Context, id int64) (Good, error)
+	RestoreUnit(ctx context.Context, id int64) (Unit, error)
+	// sets the value of the goods of the category to the value left in their cost layers
+	RevalueCategoryGoods(ctx context.Context, category int64) ([]Good, error)
+	// goods with all words of the query in their model, description or category name, or with words resembling
+	// all of them despite typos, the best match first. The snippets mark the words matched as written with <mark>,
+	// words only matched despite a typo are not marked.
+	// The candidates are looked up in the search document and trigram indexes of the goods, goods in categories whose
+	// name shares or resembles a word of the query are checked too, as the category can hold the other words.
+	SearchGoods(ctx context.Context, arg SearchGoodsParams) ([]SearchGoodsRow, error)
+	SetCategoryCostingMethod(ctx context.Context, arg SetCategoryCostingMethodParams) (Category, error)
+	SetCountSessionItemCounted(ctx context.Context, arg SetCountSessionItemCountedParams) (CountSessionItem, error)
+	SetGoodSku(ctx context.Context, arg SetGoodSkuParams) (Good, error)
+	SetGoodStockLevels(ctx context.Context, arg SetGoodStockLevelsParams) (Good, error)
+	SetStockMovementValue(ctx context.Context, arg SetStockMovementValueParams) (StockMovement, error)
+	SumBinStocks(ctx context.Context, arg SumBinStocksParams) (int64, error)
+	SumLotStocks(ctx context.Context, arg SumLotStocksParams) (int64, error)
+	// stock of the good held by the sales orders of the warehouse that have not been shipped or cancelled
+	SumWarehouseAllocated(ctx context.Context, arg SumWarehouseAllocatedParams) (int64, error)
+	// whether amounts are kept in the unit, by goods or by purchase order lines, deleted goods included
+	UnitInUse(ctx context.Context, id int64) (bool, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	// sets the status and stamps the time the session was closed
+	UpdateCountSessionStatus(ctx context.Context, arg UpdateCountSessionStatusParams) (CountSession, error)
 	UpdateGood(ctx context.Context, arg UpdateGoodParams) (Good, error)
+	UpdateGoodSupplier(ctx context.Context, arg UpdateGoodSupplierParams) (GoodSupplier, error)
+	UpdateLabelTemplate(ctx context.Context, arg UpdateLabelTemplateParams) (LabelTemplate, error)
+	UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error)
+	// sets the status and stamps the time the order was sent or closed
+	UpdatePurchaseOrderStatus(ctx context.Context, arg UpdatePurchaseOrderStatusParams) (PurchaseOrder, error)
+	// sets the status and stamps the time the order was shipped
+	UpdateSalesOrderStatus(ctx context.Context, arg UpdateSalesOrderStatusParams) (SalesOrder, error)
+	UpdateSerial(ctx context.Context, arg UpdateSerialParams) (Serial, error)
+	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error)
+	// sets the status and stamps the time the order was shipped or received
+	UpdateTransferOrderStatus(ctx context.Context, arg UpdateTransferOrderStatusParams) (TransferOrder, error)
 	UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
+	UpdateWarehouse(ctx context.Context, arg UpdateWarehouseParams) (Warehouse, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomReservation(t *testing.T, good Good, expiresAt time.Time) Reservation {
+	arg := CreateReservationParams{
+		GoodID:    good.ID,
+		Amount:    util.RandomInt(1, 3),
+		Reference: util.RandomName(),
+		ExpiresAt: expiresAt,
+	}
+
+	reservation, err := testQueries.CreateReservation(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, reservation)
+
+	require.Equal(t, arg.GoodID, reservation.GoodID)
+	require.Equal(t, arg.Amount, reservation.Amount)
+	require.Equal(t, arg.Reference, reservation.Reference)
+	require.Equal(t, ReservationStatusActive, reservation.Status)
+	require.WithinDuration(t, arg.ExpiresAt, reservation.ExpiresAt, time.Second)
+	require.False(t, reservation.ClosedAt.Valid)
+	require.NotZero(t, reservation.ID)
+	require.NotZero(t, reservation.CreatedAt)
+
+	return reservation
+}
+
+func TestCreateReservation(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	createRandomReservation(t, good, time.Now().Add(time.Hour))
+}
+
+func TestGetReservation(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	reservation1 := createRandomReservation(t, good, time.Now().Add(time.Hour))
+
+	reservation2, err := testQueries.GetReservation(context.Background(), reservation1.ID)
+	require.NoError(t, err)
+	require.Equal(t, reservation1.ID, reservation2.ID)
+	require.Equal(t, reservation1.Amount, reservation2.Amount)
+	require.Equal(t, reservation1.Status, reservation2.Status)
+	require.WithinDuration(t, reservation1.CreatedAt, reservation2.CreatedAt, time.Second)
+}
+
+func TestListReservations(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	for i := 0; i < 6; i++ {
+		createRandomReservation(t, good, time.Now().Add(time.Hour))
+	}
+
+	released := createRandomReservation(t, good, time.Now().Add(time.Hour))
+	_, err := testQueries.ReleaseReservation(context.Background(), released.ID)
+	require.NoError(t, err)
+
+	reservations, err := testQueries.ListReservations(context.Background(), ListReservationsParams{
+		GoodID: good.ID,
+		Status: sql.NullString{String: ReservationStatusActive, Valid: true},
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, reservations, 6)
+	for _, reservation := range reservations {
+		require.Equal(t, good.ID, reservation.GoodID)
+		require.Equal(t, ReservationStatusActive, reservation.Status)
+	}
+
+	reservations, err = testQueries.ListReservations(context.Background(), ListReservationsParams{
+		GoodID: good.ID,
+		Limit:  10,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, reservations, 7)
+}
+
+func TestReleaseReservation(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	reservation := createRandomReservation(t, good, time.Now().Add(time.Hour))
+
+	released, err := testQueries.ReleaseReservation(context.Background(), reservation.ID)
+	require.NoError(t, err)
+	require.Equal(t, ReservationStatusReleased, released.Status)
+	require.True(t, released.ClosedAt.Valid)
+
+	// only active reservations can be released
+	_, err = testQueries.ReleaseReservation(context.Background(), reservation.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestExpireReservations(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	overdue := createRandomReservation(t, good, time.Now().Add(-time.Minute))
+	createRandomReservation(t, good, time.Now().Add(time.Hour))
+
+	goodIDs, err := testQueries.ListOverdueReservationGoods(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Contains(t, goodIDs, good.ID)
+
+	expired, err := testQueries.ExpireReservations(context.Background(), ExpireReservationsParams{
+		GoodID: good.ID,
+		Now:    time.Now(),
+	})
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	require.Equal(t, overdue.ID, expired[0].ID)
+	require.Equal(t, ReservationStatusExpired, expired[0].Status)
+	require.True(t, expired[0].ClosedAt.Valid)
+
+	goodIDs, err = testQueries.ListOverdueReservationGoods(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.NotContains(t, goodIDs, good.ID)
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSalesOrder(t *testing.T, warehouse Warehouse) SalesOrder {
+	arg := CreateSalesOrderParams{
+		CustomerName: util.RandomName(),
+		WarehouseID:  warehouse.ID,
+		Reference:    util.RandomString(8),
+	}
+
+	salesOrder, err := testQueries.CreateSalesOrder(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.CustomerName, salesOrder.CustomerName)
+	require.Equal(t, arg.WarehouseID, salesOrder.WarehouseID)
+	require.Equal(t, arg.Reference, salesOrder.Reference)
+	require.Equal(t, SalesOrderStatusOpen, salesOrder.Status)
+	require.NotZero(t, salesOrder.ID)
+	require.NotZero(t, salesOrder.CreatedAt)
+	require.False(t, salesOrder.ShippedAt.Valid)
+
+	return salesOrder
+}
+
+func createRandomSalesOrderLine(t *testing.T, salesOrder SalesOrder, good Good) SalesOrderLine {
+	arg := CreateSalesOrderLineParams{
+		SalesOrderID: salesOrder.ID,
+		GoodID:       good.ID,
+		Amount:       util.RandomInt(2, 100),
+	}
+
+	line, err := testQueries.CreateSalesOrderLine(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.SalesOrderID, line.SalesOrderID)
+	require.Equal(t, arg.GoodID, line.GoodID)
+	require.Equal(t, arg.Amount, line.Amount)
+	require.Zero(t, line.Allocated)
+
+	return line
+}
+
+func TestCreateSalesOrder(t *testing.T) {
+	createRandomSalesOrder(t, createRandomWarehouse(t))
+}
+
+func TestListSalesOrders(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+	createRandomSalesOrder(t, warehouse)
+	cancelled := createRandomSalesOrder(t, warehouse)
+
+	_, err := testQueries.UpdateSalesOrderStatus(context.Background(), UpdateSalesOrderStatusParams{
+		ID:     cancelled.ID,
+		Status: SalesOrderStatusCancelled,
+	})
+	require.NoError(t, err)
+
+	salesOrders, err := testQueries.ListSalesOrders(context.Background(), ListSalesOrdersParams{
+		Status: sql.NullString{String: SalesOrderStatusCancelled, Valid: true},
+		Limit:  5,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, salesOrders)
+
+	for _, salesOrder := range salesOrders {
+		require.Equal(t, SalesOrderStatusCancelled, salesOrder.Status)
+	}
+}
+
+func TestUpdateSalesOrderStatus(t *testing.T) {
+	salesOrder := createRandomSalesOrder(t, createRandomWarehouse(t))
+
+	allocated, err := testQueries.UpdateSalesOrderStatus(context.Background(), UpdateSalesOrderStatusParams{
+		ID:     salesOrder.ID,
+		Status: SalesOrderStatusAllocated,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusAllocated, allocated.Status)
+	require.False(t, allocated.ShippedAt.Valid)
+
+	shipped, err := testQueries.UpdateSalesOrderStatus(context.Background(), UpdateSalesOrderStatusParams{
+		ID:     salesOrder.ID,
+		Status: SalesOrderStatusShipped,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusShipped, shipped.Status)
+	require.True(t, shipped.ShippedAt.Valid)
+
+	_, err = testQueries.UpdateSalesOrderStatus(context.Background(), UpdateSalesOrderStatusParams{
+		ID:     salesOrder.ID,
+		Status: "lost",
+	})
+	require.Error(t, err)
+}
+
+func TestAddSalesOrderLineAllocated(t *testing.T) {
+	salesOrder := createRandomSalesOrder(t, createRandomWarehouse(t))
+	line := createRandomSalesOrderLine(t, salesOrder, createRandomGood(t, createRandomCategory(t), createRandomUnit(t)))
+
+	allocated, err := testQueries.AddSalesOrderLineAllocated(context.Background(), AddSalesOrderLineAllocatedParams{
+		ID:     line.ID,
+		Amount: line.Amount,
+	})
+	require.NoError(t, err)
+	require.Equal(t, line.Amount, allocated.Allocated)
+
+	// a line cannot hold more than its amount
+	_, err = testQueries.AddSalesOrderLineAllocated(context.Background(), AddSalesOrderLineAllocatedParams{
+		ID:     line.ID,
+		Amount: 1,
+	})
+	require.Error(t, err)
+
+	lines, err := testQueries.ListSalesOrderLines(context.Background(), salesOrder.ID)
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, allocated, lines[0])
+}
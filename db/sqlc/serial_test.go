@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createSerializedGood creates an empty serialized good, its units are received with their serials
+func createSerializedGood(t *testing.T) Good {
+	good, err := testQueries.CreateGood(context.Background(), CreateGoodParams{
+		Category:   createRandomCategory(t).ID,
+		Model:      util.RandomName(),
+		Unit:       createRandomUnit(t).ID,
+		GoodDesc:   util.RandomName(),
+		Serialized: true,
+	})
+	require.NoError(t, err)
+	require.True(t, good.Serialized)
+
+	return good
+}
+
+func createRandomSerial(t *testing.T, good Good, warehouse Warehouse) Serial {
+	arg := CreateSerialParams{
+		GoodID:       good.ID,
+		SerialNumber: util.RandomString(12),
+		Status:       SerialStatusInStock,
+		WarehouseID:  sql.NullInt64{Int64: warehouse.ID, Valid: true},
+	}
+
+	serial, err := testQueries.CreateSerial(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, serial)
+
+	require.Equal(t, arg.GoodID, serial.GoodID)
+	require.Equal(t, arg.SerialNumber, serial.SerialNumber)
+	require.Equal(t, arg.Status, serial.Status)
+	require.Equal(t, arg.WarehouseID, serial.WarehouseID)
+	require.False(t, serial.ReservationID.Valid)
+	require.NotZero(t, serial.ID)
+	require.NotZero(t, serial.CreatedAt)
+
+	return serial
+}
+
+func TestCreateSerial(t *testing.T) {
+	good := createSerializedGood(t)
+	serial := createRandomSerial(t, good, createRandomWarehouse(t))
+
+	// serial numbers are unique across all goods
+	_, err := testQueries.CreateSerial(context.Background(), CreateSerialParams{
+		GoodID:       createSerializedGood(t).ID,
+		SerialNumber: serial.SerialNumber,
+		Status:       SerialStatusInStock,
+		WarehouseID:  serial.WarehouseID,
+	})
+	require.Error(t, err)
+
+	// units in stock are in a warehouse
+	_, err = testQueries.CreateSerial(context.Background(), CreateSerialParams{
+		GoodID:       good.ID,
+		SerialNumber: util.RandomString(12),
+		Status:       SerialStatusInStock,
+	})
+	require.Error(t, err)
+}
+
+func TestGetSerialByNumber(t *testing.T) {
+	serial1 := createRandomSerial(t, createSerializedGood(t), createRandomWarehouse(t))
+
+	serial2, err := testQueries.GetSerialByNumber(context.Background(), serial1.SerialNumber)
+	require.NoError(t, err)
+	require.Equal(t, serial1.ID, serial2.ID)
+	require.Equal(t, serial1.GoodID, serial2.GoodID)
+	require.Equal(t, serial1.Status, serial2.Status)
+
+	_, err = testQueries.GetSerialByNumber(context.Background(), util.RandomString(12))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestUpdateSerial(t *testing.T) {
+	serial := createRandomSerial(t, createSerializedGood(t), createRandomWarehouse(t))
+
+	shipped, err := testQueries.UpdateSerial(context.Background(), UpdateSerialParams{
+		ID:     serial.ID,
+		Status: SerialStatusShipped,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SerialStatusShipped, shipped.Status)
+	require.False(t, shipped.WarehouseID.Valid)
+	require.False(t, shipped.UpdatedAt.Before(serial.UpdatedAt))
+
+	// reserved units are held by a reservation
+	_, err = testQueries.UpdateSerial(context.Background(), UpdateSerialParams{
+		ID:          serial.ID,
+		Status:      SerialStatusReserved,
+		WarehouseID: serial.WarehouseID,
+	})
+	require.Error(t, err)
+}
+
+func TestListGoodSerials(t *testing.T) {
+	good := createSerializedGood(t)
+	warehouse := createRandomWarehouse(t)
+	for i := 0; i < 3; i++ {
+		createRandomSerial(t, good, warehouse)
+	}
+	shipped := createRandomSerial(t, good, warehouse)
+	_, err := testQueries.UpdateSerial(context.Background(), UpdateSerialParams{
+		ID:     shipped.ID,
+		Status: SerialStatusShipped,
+	})
+	require.NoError(t, err)
+
+	arg := ListGoodSerialsParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 0,
+	}
+	serials, err := testQueries.ListGoodSerials(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, serials, 4)
+
+	arg.Status = sql.NullString{String: SerialStatusInStock, Valid: true}
+	arg.WarehouseID = sql.NullInt64{Int64: warehouse.ID, Valid: true}
+	serials, err = testQueries.ListGoodSerials(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, serials, 3)
+	for i := 1; i < len(serials); i++ {
+		require.Less(t, serials[i-1].SerialNumber, serials[i].SerialNumber)
+	}
+}
+
+func TestListSerialEvents(t *testing.T) {
+	serial := createRandomSerial(t, createSerializedGood(t), createRandomWarehouse(t))
+
+	for _, status := range []string{SerialStatusInStock, SerialStatusShipped} {
+		event, err := testQueries.CreateSerialEvent(context.Background(), CreateSerialEventParams{
+			SerialID:    serial.ID,
+			Status:      status,
+			WarehouseID: serial.WarehouseID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, status, event.Status)
+	}
+
+	events, err := testQueries.ListSerialEvents(context.Background(), serial.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, SerialStatusInStock, events[0].Status)
+	require.Equal(t, SerialStatusShipped, events[1].Status)
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createLowStockGood creates a good whose whole stock is below its reorder point
+func createLowStockGood(t *testing.T) Good {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	good, err := testQueries.SetGoodStockLevels(context.Background(), SetGoodStockLevelsParams{
+		ID:           good.ID,
+		SafetyStock:  good.Amount,
+		ReorderPoint: good.Amount + 1,
+		MaxLevel:     good.Amount + 10,
+	})
+	require.NoError(t, err)
+
+	return good
+}
+
+func TestRaiseStockAlerts(t *testing.T) {
+	good := createLowStockGood(t)
+	goodID := sql.NullInt64{Int64: good.ID, Valid: true}
+
+	raised, err := testQueries.RaiseStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+	require.Len(t, raised, 1)
+	require.Equal(t, good.ID, raised[0].GoodID)
+	require.Equal(t, good.Amount, raised[0].Available)
+	require.Equal(t, good.ReorderPoint, raised[0].ReorderPoint)
+	require.False(t, raised[0].BelowSafetyStock)
+	require.False(t, raised[0].ResolvedAt.Valid)
+
+	// the open alert is not raised again
+	raised, err = testQueries.RaiseStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+	require.Empty(t, raised)
+
+	// goods with enough stock are not alerted
+	other := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	raised, err = testQueries.RaiseStockAlerts(context.Background(), sql.NullInt64{Int64: other.ID, Valid: true})
+	require.NoError(t, err)
+	require.Empty(t, raised)
+}
+
+func TestResolveStockAlerts(t *testing.T) {
+	good := createLowStockGood(t)
+	goodID := sql.NullInt64{Int64: good.ID, Valid: true}
+
+	_, err := testQueries.RaiseStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+
+	resolved, err := testQueries.ResolveStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+	require.Empty(t, resolved)
+
+	_, err = testQueries.AddGoodAmount(context.Background(), AddGoodAmountParams{ID: good.ID, Amount: 1})
+	require.NoError(t, err)
+
+	resolved, err = testQueries.ResolveStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+	require.Equal(t, good.ID, resolved[0].GoodID)
+	require.True(t, resolved[0].ResolvedAt.Valid)
+
+	// a new alert can be raised once the last one is resolved
+	_, err = testQueries.AddGoodReserved(context.Background(), AddGoodReservedParams{ID: good.ID, Amount: good.Amount})
+	require.NoError(t, err)
+
+	raised, err := testQueries.RaiseStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+	require.Len(t, raised, 1)
+	require.Equal(t, int64(1), raised[0].Available)
+	require.True(t, raised[0].BelowSafetyStock)
+}
+
+func TestListStockAlerts(t *testing.T) {
+	good := createLowStockGood(t)
+	goodID := sql.NullInt64{Int64: good.ID, Valid: true}
+
+	_, err := testQueries.RaiseStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+
+	_, err = testQueries.DeleteGood(context.Background(), good.ID)
+	require.NoError(t, err)
+
+	// deleted goods resolve their alerts
+	_, err = testQueries.ResolveStockAlerts(context.Background(), goodID)
+	require.NoError(t, err)
+
+	open, err := testQueries.ListStockAlerts(context.Background(), ListStockAlertsParams{
+		GoodID: goodID,
+		Limit:  5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, open)
+
+	all, err := testQueries.ListStockAlerts(context.Background(), ListStockAlertsParams{
+		GoodID:          goodID,
+		IncludeResolved: true,
+		Limit:           5,
+	})
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.True(t, all[0].ResolvedAt.Valid)
+}
+
+func TestListLowStockGoods(t *testing.T) {
+	good := createLowStockGood(t)
+	createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	goods, err := testQueries.ListLowStockGoods(context.Background(), ListLowStockGoodsParams{
+		Category: sql.NullInt64{Int64: good.Category, Valid: true},
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Good{good}, goods)
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomStockMovement(t *testing.T, good Good, warehouse Warehouse) StockMovement {
+	arg := CreateStockMovementParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       util.RandomInt(1, 10),
+	}
+
+	movement, err := testQueries.CreateStockMovement(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, movement)
+	require.Equal(t, arg.GoodID, movement.GoodID)
+	require.Equal(t, arg.WarehouseID, movement.WarehouseID)
+	require.Equal(t, arg.MovementType, movement.MovementType)
+	require.Equal(t, arg.Amount, movement.Amount)
+
+	require.NotZero(t, movement.ID)
+	require.NotZero(t, movement.CreatedAt)
+
+	return movement
+}
+
+func TestCreateStockMovement(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	createRandomStockMovement(t, good, warehouse)
+}
+
+func TestGetStockMovement(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	movement1 := createRandomStockMovement(t, good, warehouse)
+	movement2, err := testQueries.GetStockMovement(context.Background(), movement1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, movement2)
+
+	require.Equal(t, movement1.ID, movement2.ID)
+	require.Equal(t, movement1.GoodID, movement2.GoodID)
+	require.Equal(t, movement1.WarehouseID, movement2.WarehouseID)
+	require.Equal(t, movement1.MovementType, movement2.MovementType)
+	require.Equal(t, movement1.Amount, movement2.Amount)
+	require.WithinDuration(t, movement1.CreatedAt, movement2.CreatedAt, time.Second)
+}
+
+func TestListStockMovements(t *testing.T) {
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+
+	for i := 0; i < 10; i++ {
+		createRandomStockMovement(t, good, warehouse)
+	}
+
+	arg := ListStockMovementsParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 5,
+	}
+	movements, err := testQueries.ListStockMovements(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Len(t, movements, 5)
+
+	for _, movement := range movements {
+		require.NotEmpty(t, movement)
+		require.Equal(t, good.ID, movement.GoodID)
+	}
+}
@@ -1,12 +1,77 @@
 package db
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 )
 
+// ErrVersionMismatch is returned when an update is based on a version of the row that is not the current one
+var ErrVersionMismatch = errors.New("version mismatch, the row has been changed in the meantime")
+
 // Store provides all functions to execute do queries and transactions
 type Store interface {
 	Querier
+	StockMovementTx(ctx context.Context, arg StockMovementTxParams) (StockMovementTxResult, error)
+	CreateGoodTx(ctx context.Context, arg CreateGoodTxParams) (Good, error)
+	UpdateGoodTx(ctx context.Context, arg UpdateGoodTxParams) (Good, error)
+	SetGoodStockLevelsTx(ctx context.Context, arg SetGoodStockLevelsTxParams) (Good, error)
+	DeleteGoodTx(ctx context.Context, arg DeleteTxParams) error
+	RestoreGoodTx(ctx context.Context, arg RestoreTxParams) (Good, error)
+	CreateCategoryTx(ctx context.Context, arg CreateCategoryTxParams) (Category, error)
+	UpdateCategoryTx(ctx context.Context, arg UpdateCategoryTxParams) (Category, error)
+	DeleteCategoryTx(ctx context.Context, arg DeleteTxParams) error
+	RestoreCategoryTx(ctx context.Context, arg RestoreTxParams) (Category, error)
+	CreateUnitTx(ctx context.Context, arg CreateUnitTxParams) (Unit, error)
+	UpdateUnitTx(ctx context.Context, arg UpdateUnitTxParams) (Unit, error)
+	DeleteUnitTx(ctx context.Context, arg DeleteTxParams) error
+	RestoreUnitTx(ctx context.Context, arg RestoreTxParams) (Unit, error)
+	CreateWarehouseTx(ctx context.Context, arg CreateWarehouseTxParams) (Warehouse, error)
+	UpdateWarehouseTx(ctx context.Context, arg UpdateWarehouseTxParams) (Warehouse, error)
+	DeleteWarehouseTx(ctx context.Context, arg DeleteTxParams) error
+	CreateLocationTx(ctx context.Context, arg CreateLocationTxParams) (Location, error)
+	UpdateLocationTx(ctx context.Context, arg UpdateLocationTxParams) (Location, error)
+	DeleteLocationTx(ctx context.Context, arg DeleteTxParams) error
+	CreateUserTx(ctx context.Context, arg CreateUserTxParams) (User, error)
+	CreateFirstAdminTx(ctx context.Context, arg CreateFirstAdminParams) (User, error)
+	UpdateUserAccessTx(ctx context.Context, arg UpdateUserAccessTxParams) (UpdateUserAccessTxResult, error)
+	CreateReservationTx(ctx context.Context, arg CreateReservationTxParams) (Reservation, error)
+	ReleaseReservationTx(ctx context.Context, arg ReleaseReservationTxParams) (Reservation, error)
+	ExpireReservationsTx(ctx context.Context, arg ExpireReservationsTxParams) ([]Reservation, error)
+	CreateSupplierTx(ctx context.Context, arg CreateSupplierTxParams) (Supplier, error)
+	UpdateSupplierTx(ctx context.Context, arg UpdateSupplierTxParams) (Supplier, error)
+	DeleteSupplierTx(ctx context.Context, arg DeleteTxParams) error
+	CreateGoodSupplierTx(ctx context.Context, arg CreateGoodSupplierTxParams) (GoodSupplier, error)
+	UpdateGoodSupplierTx(ctx context.Context, arg UpdateGoodSupplierTxParams) (GoodSupplier, error)
+	DeleteGoodSupplierTx(ctx context.Context, arg DeleteGoodSupplierTxParams) error
+	CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	AddPurchaseOrderLineTx(ctx context.Context, arg AddPurchaseOrderLineTxParams) (PurchaseOrderLine, error)
+	SendPurchaseOrderTx(ctx context.Context, arg PurchaseOrderStatusTxParams) (PurchaseOrder, error)
+	ClosePurchaseOrderTx(ctx context.Context, arg PurchaseOrderStatusTxParams) (PurchaseOrder, error)
+	ReceivePurchaseOrderTx(ctx context.Context, arg ReceivePurchaseOrderTxParams) (ReceivePurchaseOrderTxResult, error)
+	CreateSalesOrderTx(ctx context.Context, arg CreateSalesOrderTxParams) (SalesOrderTxResult, error)
+	AllocateSalesOrderTx(ctx context.Context, arg SalesOrderStatusTxParams) (SalesOrderTxResult, error)
+	CancelSalesOrderTx(ctx context.Context, arg SalesOrderStatusTxParams) (SalesOrderTxResult, error)
+	GetPickListTx(ctx context.Context, salesOrderID int64) (PickList, error)
+	ShipSalesOrderTx(ctx context.Context, arg ShipSalesOrderTxParams) (ShipSalesOrderTxResult, error)
+	CreateTransferOrderTx(ctx context.Context, arg CreateTransferOrderTxParams) (TransferOrderTxResult, error)
+	ShipTransferOrderTx(ctx context.Context, arg TransferOrderStatusTxParams) (TransferOrderMovementTxResult, error)
+	ReceiveTransferOrderTx(ctx context.Context, arg TransferOrderStatusTxParams) (TransferOrderMovementTxResult, error)
+	CancelTransferOrderTx(ctx context.Context, arg TransferOrderStatusTxParams) (TransferOrder, error)
+	StartCountSessionTx(ctx context.Context, arg StartCountSessionTxParams) (CountSessionTxResult, error)
+	RecordCountsTx(ctx context.Context, arg RecordCountsTxParams) (CountSessionTxResult, error)
+	ApproveCountSessionTx(ctx context.Context, arg CountSessionStatusTxParams) (ApproveCountSessionTxResult, error)
+	CancelCountSessionTx(ctx context.Context, arg CountSessionStatusTxParams) (CountSession, error)
+	CreateLotTx(ctx context.Context, arg CreateLotTxParams) (Lot, error)
+	SetCategoryCostingMethodTx(ctx context.Context, arg SetCategoryCostingMethodTxParams) (Category, error)
+	CreateGoodBarcodeTx(ctx context.Context, arg CreateGoodBarcodeTxParams) (GoodBarcode, error)
+	DeleteGoodBarcodeTx(ctx context.Context, arg DeleteGoodBarcodeTxParams) error
+	SetGoodSkuTx(ctx context.Context, arg SetGoodSkuTxParams) (Good, error)
+	CreateLabelTemplateTx(ctx context.Context, arg CreateLabelTemplateTxParams) (LabelTemplate, error)
+	UpdateLabelTemplateTx(ctx context.Context, arg UpdateLabelTemplateTxParams) (LabelTemplate, error)
+	DeleteLabelTemplateTx(ctx context.Context, arg DeleteTxParams) error
+	ImportGoodsTx(ctx context.Context, arg ImportGoodsTxParams) ([]Good, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
@@ -22,21 +87,19 @@ func NewStore(db *sql.DB) Store {
 	}
 }
 
-// Here is place for implement all transaction in future
-
-// // execTx executes a function within a database transaction
-// func (store *Store) execTx(c context.Context, fn func(*Queries) error) error {
-// 	tx, err := store.db.BeginTx(c, nil)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	q := New(tx)
-// 	err = fn(q)
-// 	if err != nil{
-// 		if rbErr := tx.Rollback(); rbErr != nil {
-// 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-// 		}
-// 		return err
-// 	}
-// 	return tx.Commit()
-// }
+// execTx executes a function within a database transaction
+func (store *SQLStore) execTx(c context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(c, nil)
+	if err != nil {
+		return err
+	}
+	q := New(tx)
+	err = fn(q)
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
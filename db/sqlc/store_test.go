@@ -0,0 +1,2468 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inventory_management/util"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockMovementTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+
+	// the good starts with its amount in the warehouse
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	// run n concurrent receipts and issues of the same amount,
+	// the good starts with at least n/2 items so no issue can fail
+	n := 6
+	amount := int64(1)
+
+	errs := make(chan error)
+	results := make(chan StockMovementTxResult)
+
+	for i := 0; i < n; i++ {
+		arg := StockMovementTxParams{
+			GoodID:       good.ID,
+			WarehouseID:  warehouse.ID,
+			MovementType: MovementTypeReceipt,
+			Amount:       amount,
+		}
+		if i%2 == 1 {
+			arg.MovementType = MovementTypeIssue
+			arg.Amount = -amount
+		}
+
+		go func() {
+			result, err := store.StockMovementTx(context.Background(), arg)
+			errs <- err
+			results <- result
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.NotEmpty(t, result)
+
+		movement := result.Movement
+		require.NotEmpty(t, movement)
+		require.Equal(t, good.ID, movement.GoodID)
+		require.Equal(t, warehouse.ID, movement.WarehouseID)
+		require.NotZero(t, movement.ID)
+
+		_, err = store.GetStockMovement(context.Background(), movement.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, good.ID, result.Good.ID)
+		require.GreaterOrEqual(t, result.Good.Amount, int64(0))
+		require.Equal(t, result.Good.Amount, result.Balance.Amount)
+	}
+
+	// receipts and issues cancel each other out
+	updatedGood, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount, updatedGood.Amount)
+}
+
+func TestStockMovementTxInsufficientStock(t *testing.T) {
+	store := NewStore(testDB)
+
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse1 := createRandomWarehouse(t)
+	warehouse2 := createRandomWarehouse(t)
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse1.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	// the stock of one warehouse cannot be issued from another one
+	arg := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse2.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -1,
+	}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	arg.WarehouseID = warehouse1.ID
+	arg.Amount = -(good.Amount + 1)
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	movements, err := testQueries.ListStockMovements(context.Background(), ListStockMovementsParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Empty(t, movements)
+
+	updatedGood, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount, updatedGood.Amount)
+}
+
+func TestCreateGoodTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	warehouse := createRandomWarehouse(t)
+
+	arg := CreateGoodTxParams{
+		CreateGoodParams: CreateGoodParams{
+			Category: category.ID,
+			Model:    "model",
+			Unit:     unit.ID,
+			Amount:   7,
+			GoodDesc: "desc",
+		},
+		Warehouse: warehouse.ID,
+	}
+
+	good, err := store.CreateGoodTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, good.ID)
+	require.Equal(t, arg.Amount, good.Amount)
+
+	balances, err := testQueries.ListGoodBalances(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	require.Equal(t, warehouse.ID, balances[0].WarehouseID)
+	require.Equal(t, arg.Amount, balances[0].Amount)
+
+	movements, err := testQueries.ListStockMovements(context.Background(), ListStockMovementsParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	require.Equal(t, MovementTypeReceipt, movements[0].MovementType)
+	require.Equal(t, arg.Amount, movements[0].Amount)
+}
+
+func TestUpdateGoodTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	category := createRandomCategory(t)
+	unit1 := createRandomUnit(t)
+	unit2 := createUnitOfFamily(t, unit1.UnitFamily, unit1.UnitValue)
+	warehouse1 := createRandomWarehouse(t)
+	warehouse2 := createRandomWarehouse(t)
+
+	good, err := store.CreateGoodTx(context.Background(), CreateGoodTxParams{
+		CreateGoodParams: CreateGoodParams{
+			Category: category.ID,
+			Model:    "model",
+			Unit:     unit1.ID,
+			Amount:   5,
+			GoodDesc: "desc",
+		},
+		Warehouse: warehouse1.ID,
+	})
+	require.NoError(t, err)
+
+	// setting the balance of a second warehouse adds to the total
+	updatedGood, err := store.UpdateGoodTx(context.Background(), UpdateGoodTxParams{
+		ID:        good.ID,
+		Unit:      unit2.ID,
+		Warehouse: warehouse2.ID,
+		Amount:    3,
+		Version:   good.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, unit2.ID, updatedGood.Unit)
+	require.Equal(t, int64(8), updatedGood.Amount)
+
+	// lowering the balance of the first warehouse records a negative adjustment
+	updatedGood, err = store.UpdateGoodTx(context.Background(), UpdateGoodTxParams{
+		ID:        good.ID,
+		Unit:      unit2.ID,
+		Warehouse: warehouse1.ID,
+		Amount:    1,
+		Version:   updatedGood.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), updatedGood.Amount)
+
+	movements, err := testQueries.ListStockMovements(context.Background(), ListStockMovementsParams{
+		GoodID: good.ID,
+		Limit:  5,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, movements, 3)
+	require.Equal(t, MovementTypeAdjustment, movements[2].MovementType)
+	require.Equal(t, int64(-4), movements[2].Amount)
+
+	// an update based on a version read before the last update is rejected
+	_, err = store.UpdateGoodTx(context.Background(), UpdateGoodTxParams{
+		ID:        good.ID,
+		Unit:      unit2.ID,
+		Warehouse: warehouse1.ID,
+		Amount:    7,
+		Version:   good.Version,
+	})
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	current, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, updatedGood, current)
+}
+
+func TestStockMovementTxBin(t *testing.T) {
+	store := NewStore(testDB)
+
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	levels := createRandomBin(t, warehouse)
+	bin := levels[3]
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	// receive into the bin
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		LocationID:   sql.NullInt64{Int64: bin.ID, Valid: true},
+		MovementType: MovementTypeReceipt,
+		Amount:       5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), result.BinStock.Amount)
+	require.Equal(t, bin.ID, result.Movement.LocationID.Int64)
+	require.Equal(t, good.Amount+5, result.Good.Amount)
+
+	// the bin cannot give more than it holds
+	arg := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		LocationID:   sql.NullInt64{Int64: bin.ID, Valid: true},
+		MovementType: MovementTypeIssue,
+		Amount:       -6,
+	}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	// an issue without a bin cannot take the stock kept in bins
+	arg.LocationID = sql.NullInt64{}
+	arg.Amount = -(good.Amount + 1)
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	// only bins of the same warehouse can hold stock
+	arg.LocationID = sql.NullInt64{Int64: levels[2].ID, Valid: true}
+	arg.MovementType = MovementTypeReceipt
+	arg.Amount = 1
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidLocation)
+
+	otherBin := createRandomBin(t, createRandomWarehouse(t))[3]
+	arg.LocationID = sql.NullInt64{Int64: otherBin.ID, Valid: true}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidLocation)
+
+	// issue from the bin
+	result, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		LocationID:   sql.NullInt64{Int64: bin.ID, Valid: true},
+		MovementType: MovementTypeIssue,
+		Amount:       -2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), result.BinStock.Amount)
+	require.Equal(t, good.Amount+3, result.Good.Amount)
+}
+
+func TestStockMovementTxConvertsUnits(t *testing.T) {
+	store := NewStore(testDB)
+
+	kilogram := createUnitOfFamily(t, UnitFamilyMass, 1000)
+	gram := createUnitOfFamily(t, UnitFamilyMass, 1)
+	piece := createUnitOfFamily(t, UnitFamilyCount, 1)
+
+	good := createRandomGood(t, createRandomCategory(t), kilogram)
+	warehouse := createRandomWarehouse(t)
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	// 2000 g are booked as 2 kg
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       2000,
+		AmountUnit:   gram.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.Movement.Amount)
+	require.Equal(t, good.Amount+2, result.Good.Amount)
+
+	arg := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -1500,
+		AmountUnit:   gram.ID,
+	}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrFractionalAmount)
+
+	arg.Amount = -1
+	arg.AmountUnit = piece.ID
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrIncompatibleUnits)
+
+	updatedGood, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount+2, updatedGood.Amount)
+}
+
+func TestUpdateGoodTxUnitChange(t *testing.T) {
+	store := NewStore(testDB)
+
+	kilogram := createUnitOfFamily(t, UnitFamilyMass, 1000)
+	otherKilogram := createUnitOfFamily(t, UnitFamilyMass, 1000)
+	gram := createUnitOfFamily(t, UnitFamilyMass, 1)
+	warehouse := createRandomWarehouse(t)
+
+	good, err := store.CreateGoodTx(context.Background(), CreateGoodTxParams{
+		CreateGoodParams: CreateGoodParams{
+			Category: createRandomCategory(t).ID,
+			Model:    "model",
+			Unit:     kilogram.ID,
+			Amount:   3000,
+			GoodDesc: "desc",
+		},
+		Warehouse:  warehouse.ID,
+		AmountUnit: gram.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), good.Amount)
+
+	// the stock would change its meaning in a unit of another size
+	_, err = store.UpdateGoodTx(context.Background(), UpdateGoodTxParams{
+		ID:        good.ID,
+		Unit:      gram.ID,
+		Warehouse: warehouse.ID,
+		Amount:    3,
+		Version:   good.Version,
+	})
+	require.ErrorIs(t, err, ErrIncompatibleUnits)
+
+	updatedGood, err := store.UpdateGoodTx(context.Background(), UpdateGoodTxParams{
+		ID:         good.ID,
+		Unit:       otherKilogram.ID,
+		Warehouse:  warehouse.ID,
+		Amount:     5000,
+		AmountUnit: gram.ID,
+		Version:    good.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, otherKilogram.ID, updatedGood.Unit)
+	require.Equal(t, int64(5), updatedGood.Amount)
+}
+
+func TestUpdateUnitTxInUse(t *testing.T) {
+	store := NewStore(testDB)
+
+	unit := createUnitOfFamily(t, UnitFamilyMass, 1000)
+
+	// an unused unit can still be redefined
+	unit, err := store.UpdateUnitTx(context.Background(), UpdateUnitTxParams{
+		UpdateUnitParams: UpdateUnitParams{
+			ID:         unit.ID,
+			UnitName:   unit.UnitName,
+			UnitValue:  1,
+			UnitFamily: UnitFamilyMass,
+			Version:    unit.Version,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), unit.UnitValue)
+
+	createRandomGood(t, createRandomCategory(t), unit)
+
+	for _, change := range []UpdateUnitParams{
+		{UnitValue: 1000, UnitFamily: UnitFamilyMass},
+		{UnitValue: 1, UnitFamily: UnitFamilyVolume},
+	} {
+		change.ID = unit.ID
+		change.UnitName = unit.UnitName
+		change.Version = unit.Version
+		_, err = store.UpdateUnitTx(context.Background(), UpdateUnitTxParams{UpdateUnitParams: change})
+		require.ErrorIs(t, err, ErrUnitInUse)
+	}
+
+	// the name alone can change
+	renamed, err := store.UpdateUnitTx(context.Background(), UpdateUnitTxParams{
+		UpdateUnitParams: UpdateUnitParams{
+			ID:         unit.ID,
+			UnitName:   util.RandomName(),
+			UnitValue:  unit.UnitValue,
+			UnitFamily: unit.UnitFamily,
+			Version:    unit.Version,
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, unit.UnitValue, renamed.UnitValue)
+}
+
+func TestUpdateUserAccessTx(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+	category := createRandomCategory(t)
+
+	arg := UpdateUserAccessTxParams{
+		Username:   user.Username,
+		Role:       RoleClerk,
+		Categories: []int64{category.ID},
+		Sections:   []string{category.SectionName},
+	}
+	result, err := store.UpdateUserAccessTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, RoleClerk, result.User.Role)
+	require.Len(t, result.Scopes, 2)
+	require.Equal(t, category.ID, result.Scopes[0].CategoryID.Int64)
+	require.Equal(t, category.SectionName, result.Scopes[1].SectionName.String)
+
+	// the scopes are replaced, not added
+	arg = UpdateUserAccessTxParams{
+		Username: user.Username,
+		Role:     RoleAuditor,
+	}
+	result, err = store.UpdateUserAccessTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, RoleAuditor, result.User.Role)
+	require.Empty(t, result.Scopes)
+
+	scopes, err := store.ListUserScopes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, scopes)
+}
+
+func TestUpdateUserAccessTxRollback(t *testing.T) {
+	store := NewStore(testDB)
+	user := createRandomUser(t)
+
+	// the unknown category fails the transaction, the role must not change
+	arg := UpdateUserAccessTxParams{
+		Username:   user.Username,
+		Role:       RoleClerk,
+		Categories: []int64{-1},
+	}
+	_, err := store.UpdateUserAccessTx(context.Background(), arg)
+	require.Error(t, err)
+
+	user2, err := store.GetUser(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user.Role, user2.Role)
+}
+
+func TestCategoryTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	actor := util.RandomName()
+
+	category, err := store.CreateCategoryTx(context.Background(), CreateCategoryTxParams{
+		CreateCategoryParams: CreateCategoryParams{
+			CategoryName: util.RandomName(),
+			SectionName:  util.RandomName(),
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	log := lastAuditLog(t, EntityCategory, category.ID)
+	require.Equal(t, actor, log.Actor)
+	require.Equal(t, AuditActionCreate, log.Action)
+	require.JSONEq(t, "null", string(log.Before))
+
+	var after Category
+	require.NoError(t, json.Unmarshal(log.After, &after))
+	require.Equal(t, category, after)
+
+	updated, err := store.UpdateCategoryTx(context.Background(), UpdateCategoryTxParams{
+		UpdateCategoryParams: UpdateCategoryParams{
+			ID:           category.ID,
+			CategoryName: util.RandomName(),
+			SectionName:  category.SectionName,
+			Version:      category.Version,
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	log = lastAuditLog(t, EntityCategory, category.ID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+
+	var before Category
+	require.NoError(t, json.Unmarshal(log.Before, &before))
+	require.Equal(t, category, before)
+	require.NoError(t, json.Unmarshal(log.After, &after))
+	require.Equal(t, updated, after)
+
+	err = store.DeleteCategoryTx(context.Background(), DeleteTxParams{ID: category.ID, Actor: actor})
+	require.NoError(t, err)
+
+	log = lastAuditLog(t, EntityCategory, category.ID)
+	require.Equal(t, AuditActionDelete, log.Action)
+	require.NoError(t, json.Unmarshal(log.Before, &before))
+	require.Equal(t, updated, before)
+	require.NoError(t, json.Unmarshal(log.After, &after))
+	require.True(t, after.DeletedAt.Valid)
+
+	err = store.DeleteCategoryTx(context.Background(), DeleteTxParams{ID: category.ID, Actor: actor})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestStockMovementTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	actor := util.RandomName()
+
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       10,
+		Actor:        actor,
+	})
+	require.NoError(t, err)
+
+	log := lastAuditLog(t, EntityStockMovement, result.Movement.ID)
+	require.Equal(t, actor, log.Actor)
+	require.Equal(t, AuditActionCreate, log.Action)
+}
+
+func TestCreateUserTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	actor := util.RandomName()
+	user, err := store.CreateUserTx(context.Background(), CreateUserTxParams{
+		CreateUserParams: CreateUserParams{
+			Username:       util.RandomString(10),
+			HashedPassword: hashedPassword,
+			FullName:       util.RandomName(),
+			Email:          util.RandomEmail(),
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	log := lastAuditLog(t, EntityUser, user.Username)
+	require.Equal(t, actor, log.Actor)
+	require.NotContains(t, string(log.After), hashedPassword)
+}
+
+func TestCreateFirstAdminTx(t *testing.T) {
+	store := NewStore(testDB)
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	// the test database has its admin already
+	_, err = testQueries.UpdateUserRole(context.Background(), UpdateUserRoleParams{
+		Username: createRandomUser(t).Username,
+		Role:     RoleAdmin,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateFirstAdminTx(context.Background(), CreateFirstAdminParams{
+		Username:       util.RandomString(10),
+		HashedPassword: hashedPassword,
+		FullName:       util.RandomName(),
+		Email:          util.RandomEmail(),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestCreateReservationTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	// run more concurrent reservations of one item than the good has,
+	// exactly the amount of the good must succeed
+	n := int(good.Amount) + 3
+
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.CreateReservationTx(context.Background(), CreateReservationTxParams{
+				CreateReservationParams: CreateReservationParams{
+					GoodID:    good.ID,
+					Amount:    1,
+					ExpiresAt: time.Now().Add(time.Hour),
+				},
+			})
+			errs <- err
+		}()
+	}
+
+	succeeded := 0
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrInsufficientStock)
+	}
+	require.Equal(t, int(good.Amount), succeeded)
+
+	updatedGood, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount, updatedGood.Reserved)
+	require.Equal(t, good.Amount, updatedGood.Amount)
+	require.Equal(t, good.Version, updatedGood.Version)
+}
+
+func TestReleaseReservationTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	actor := util.RandomName()
+
+	reservation, err := store.CreateReservationTx(context.Background(), CreateReservationTxParams{
+		CreateReservationParams: CreateReservationParams{
+			GoodID:    good.ID,
+			Amount:    good.Amount,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	log := lastAuditLog(t, EntityReservation, reservation.ID)
+	require.Equal(t, AuditActionCreate, log.Action)
+
+	released, err := store.ReleaseReservationTx(context.Background(), ReleaseReservationTxParams{
+		ID:    reservation.ID,
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, ReservationStatusReleased, released.Status)
+
+	log = lastAuditLog(t, EntityReservation, reservation.ID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+	require.Equal(t, actor, log.Actor)
+
+	updatedGood, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Zero(t, updatedGood.Reserved)
+
+	_, err = store.ReleaseReservationTx(context.Background(), ReleaseReservationTxParams{
+		ID:    reservation.ID,
+		Actor: actor,
+	})
+	require.ErrorIs(t, err, ErrReservationClosed)
+}
+
+func TestExpireReservationsTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+
+	reservation, err := store.CreateReservationTx(context.Background(), CreateReservationTxParams{
+		CreateReservationParams: CreateReservationParams{
+			GoodID:    good.ID,
+			Amount:    good.Amount,
+			ExpiresAt: time.Now().Add(time.Minute),
+		},
+	})
+	require.NoError(t, err)
+
+	// nothing is overdue yet
+	expired, err := store.ExpireReservationsTx(context.Background(), ExpireReservationsTxParams{Now: time.Now()})
+	require.NoError(t, err)
+	for _, r := range expired {
+		require.NotEqual(t, reservation.ID, r.ID)
+	}
+
+	expired, err = store.ExpireReservationsTx(context.Background(), ExpireReservationsTxParams{
+		Now: time.Now().Add(2 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	var found bool
+	for _, r := range expired {
+		if r.ID == reservation.ID {
+			found = true
+			require.Equal(t, ReservationStatusExpired, r.Status)
+		}
+	}
+	require.True(t, found)
+
+	updatedGood, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Zero(t, updatedGood.Reserved)
+}
+
+func TestStockMovementTxReserved(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateReservationTx(context.Background(), CreateReservationTxParams{
+		CreateReservationParams: CreateReservationParams{
+			GoodID:    good.ID,
+			Amount:    1,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+
+	// the reserved item cannot be issued
+	arg := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -good.Amount,
+	}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	arg.Amount = -(good.Amount - 1)
+	result, err := store.StockMovementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.Good.Amount)
+	require.Equal(t, int64(1), result.Good.Reserved)
+}
+
+func TestStockMovementTxOverdueReservation(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	reservation, err := store.CreateReservationTx(context.Background(), CreateReservationTxParams{
+		CreateReservationParams: CreateReservationParams{
+			GoodID:    good.ID,
+			Amount:    1,
+			ExpiresAt: time.Now().Add(-time.Minute),
+		},
+	})
+	require.NoError(t, err)
+
+	// the overdue reservation is expired by the issue instead of holding back its stock
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -good.Amount,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Good.Amount)
+	require.Zero(t, result.Good.Reserved)
+
+	expired, err := testQueries.GetReservation(context.Background(), reservation.ID)
+	require.NoError(t, err)
+	require.Equal(t, ReservationStatusExpired, expired.Status)
+	require.True(t, expired.ClosedAt.Valid)
+}
+
+func TestGoodSupplierTxAudit(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	supplier := createRandomSupplier(t)
+	actor := util.RandomName()
+
+	goodSupplier, err := store.CreateGoodSupplierTx(context.Background(), CreateGoodSupplierTxParams{
+		CreateGoodSupplierParams: CreateGoodSupplierParams{
+			GoodID:      good.ID,
+			SupplierID:  supplier.ID,
+			PartNumber:  util.RandomString(10),
+			UnitPrice:   util.RandomInt(0, 1000),
+			MinOrderQty: 1,
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	entityID := fmt.Sprintf("%d/%d", good.ID, supplier.ID)
+	log := lastAuditLog(t, EntityGoodSupplier, entityID)
+	require.Equal(t, actor, log.Actor)
+	require.Equal(t, AuditActionCreate, log.Action)
+
+	var after GoodSupplier
+	require.NoError(t, json.Unmarshal(log.After, &after))
+	require.Equal(t, goodSupplier.PartNumber, after.PartNumber)
+
+	err = store.DeleteSupplierTx(context.Background(), DeleteTxParams{ID: supplier.ID, Actor: actor})
+	require.Error(t, err)
+
+	err = store.DeleteGoodSupplierTx(context.Background(), DeleteGoodSupplierTxParams{
+		GoodID:     good.ID,
+		SupplierID: supplier.ID,
+		Actor:      actor,
+	})
+	require.NoError(t, err)
+
+	log = lastAuditLog(t, EntityGoodSupplier, entityID)
+	require.Equal(t, AuditActionDelete, log.Action)
+	require.JSONEq(t, "null", string(log.After))
+
+	err = store.DeleteSupplierTx(context.Background(), DeleteTxParams{ID: supplier.ID, Actor: actor})
+	require.NoError(t, err)
+
+	log = lastAuditLog(t, EntitySupplier, supplier.ID)
+	require.Equal(t, AuditActionDelete, log.Action)
+}
+
+func TestPurchaseOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	good1 := createRandomGood(t, category, unit)
+	good2 := createRandomGood(t, category, unit)
+	warehouse := createRandomWarehouse(t)
+	supplier := createRandomSupplier(t)
+	actor := util.RandomName()
+
+	created, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: CreatePurchaseOrderParams{
+			SupplierID:  supplier.ID,
+			WarehouseID: warehouse.ID,
+		},
+		Lines: []CreatePurchaseOrderLineParams{
+			{GoodID: good1.ID, Ordered: 10},
+			{GoodID: good2.ID, Ordered: 5},
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusDraft, created.PurchaseOrder.Status)
+	require.Len(t, created.Lines, 2)
+	// lines default to the unit of their good
+	require.Equal(t, unit.ID, created.Lines[0].UnitID)
+
+	purchaseOrderID := created.PurchaseOrder.ID
+	line1, line2 := created.Lines[0], created.Lines[1]
+
+	_, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:       purchaseOrderID,
+		Receipts: []PurchaseOrderReceipt{{LineID: line1.ID, Amount: 1}},
+		Currency: supplier.Currency,
+		Actor:    actor,
+	})
+	require.ErrorIs(t, err, ErrPurchaseOrderStatus)
+
+	sent, err := store.SendPurchaseOrderTx(context.Background(), PurchaseOrderStatusTxParams{ID: purchaseOrderID, Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusSent, sent.Status)
+
+	_, err = store.AddPurchaseOrderLineTx(context.Background(), AddPurchaseOrderLineTxParams{
+		CreatePurchaseOrderLineParams: CreatePurchaseOrderLineParams{
+			PurchaseOrderID: purchaseOrderID,
+			GoodID:          good1.ID,
+			Ordered:         1,
+		},
+		Actor: actor,
+	})
+	require.ErrorIs(t, err, ErrPurchaseOrderStatus)
+
+	// line 1 is under-delivered, line 2 over-delivered
+	result, err := store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID: purchaseOrderID,
+		Receipts: []PurchaseOrderReceipt{
+			{LineID: line2.ID, Amount: 7},
+			{LineID: line1.ID, Amount: 4},
+		},
+		Currency: supplier.Currency,
+		Actor:    actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusPartiallyReceived, result.PurchaseOrder.Status)
+	require.Len(t, result.Movements, 2)
+	require.Equal(t, int64(4), result.Lines[0].Received)
+	require.Equal(t, int64(7), result.Lines[1].Received)
+
+	updatedGood, err := testQueries.GetGood(context.Background(), good2.ID)
+	require.NoError(t, err)
+	require.Equal(t, good2.Amount+7, updatedGood.Amount)
+
+	balance, err := testQueries.GetGoodBalance(context.Background(), GetGoodBalanceParams{
+		GoodID:      good1.ID,
+		WarehouseID: warehouse.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), balance.Amount)
+
+	log := lastAuditLog(t, EntityPurchaseOrderLine, line1.ID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+	require.Equal(t, actor, log.Actor)
+
+	// a line of another order is rejected and nothing is booked
+	other, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: CreatePurchaseOrderParams{
+			SupplierID:  supplier.ID,
+			WarehouseID: warehouse.ID,
+		},
+		Lines: []CreatePurchaseOrderLineParams{{GoodID: good1.ID, Ordered: 1}},
+	})
+	require.NoError(t, err)
+
+	_, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID: purchaseOrderID,
+		Receipts: []PurchaseOrderReceipt{
+			{LineID: line1.ID, Amount: 6},
+			{LineID: other.Lines[0].ID, Amount: 1},
+		},
+		Currency: supplier.Currency,
+	})
+	require.ErrorIs(t, err, ErrInvalidPurchaseOrderLine)
+
+	result, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:       purchaseOrderID,
+		Receipts: []PurchaseOrderReceipt{{LineID: line1.ID, Amount: 6}},
+		Currency: supplier.Currency,
+		Actor:    actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusClosed, result.PurchaseOrder.Status)
+	require.True(t, result.PurchaseOrder.ClosedAt.Valid)
+
+	log = lastAuditLog(t, EntityPurchaseOrder, purchaseOrderID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+
+	_, err = store.ClosePurchaseOrderTx(context.Background(), PurchaseOrderStatusTxParams{ID: purchaseOrderID, Actor: actor})
+	require.ErrorIs(t, err, ErrPurchaseOrderStatus)
+}
+
+func TestPurchaseOrderTxCurrency(t *testing.T) {
+	store := NewStore(testDB)
+	warehouse := createRandomWarehouse(t)
+	good1 := createValuedGood(t, store, createRandomCategory(t), warehouse, 10, 100)
+	good2 := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	supplier := createRandomSupplier(t)
+
+	// the prices of the supplier are not in the currency the stock is valued in
+	created, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: CreatePurchaseOrderParams{
+			SupplierID:  supplier.ID,
+			WarehouseID: warehouse.ID,
+		},
+		Lines: []CreatePurchaseOrderLineParams{
+			{GoodID: good1.ID, Ordered: 5, UnitPrice: 250},
+			{GoodID: good2.ID, Ordered: 3, UnitPrice: math.MaxInt64 / 2},
+		},
+	})
+	require.NoError(t, err)
+	_, err = store.SendPurchaseOrderTx(context.Background(), PurchaseOrderStatusTxParams{ID: created.PurchaseOrder.ID})
+	require.NoError(t, err)
+	line1, line2 := created.Lines[0], created.Lines[1]
+
+	receive := func(currency string, receipt PurchaseOrderReceipt) (ReceivePurchaseOrderTxResult, error) {
+		return store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+			ID:       created.PurchaseOrder.ID,
+			Receipts: []PurchaseOrderReceipt{receipt},
+			Currency: currency,
+		})
+	}
+
+	// without a unit cost the receipt comes in at the average cost of the good
+	result, err := receive("CHF", PurchaseOrderReceipt{LineID: line1.ID, Amount: 2})
+	require.NoError(t, err)
+	require.Len(t, result.Movements, 1)
+	require.Equal(t, int64(200), result.Movements[0].Value)
+
+	result, err = receive("CHF", PurchaseOrderReceipt{LineID: line1.ID, Amount: 2, UnitCost: sql.NullInt64{Int64: 120, Valid: true}})
+	require.NoError(t, err)
+	require.Equal(t, int64(240), result.Movements[0].Value)
+
+	// the price of the line values the receipt once the stock is valued in the currency of the supplier
+	result, err = receive(supplier.Currency, PurchaseOrderReceipt{LineID: line1.ID, Amount: 1})
+	require.NoError(t, err)
+	require.Equal(t, int64(250), result.Movements[0].Value)
+
+	good1, err = testQueries.GetGood(context.Background(), good1.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000+200+240+250), good1.StockValue)
+
+	// the value of the receipt does not fit into the value column
+	_, err = receive(supplier.Currency, PurchaseOrderReceipt{LineID: line2.ID, Amount: 3})
+	require.ErrorIs(t, err, ErrAmountOverflow)
+	_, err = receive("CHF", PurchaseOrderReceipt{LineID: line2.ID, Amount: 3, UnitCost: sql.NullInt64{Int64: math.MaxInt64 / 2, Valid: true}})
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestSendPurchaseOrderTxWithoutLines(t *testing.T) {
+	store := NewStore(testDB)
+	purchaseOrder := createRandomPurchaseOrder(t, createRandomSupplier(t), createRandomWarehouse(t))
+
+	_, err := store.SendPurchaseOrderTx(context.Background(), PurchaseOrderStatusTxParams{ID: purchaseOrder.ID})
+	require.ErrorIs(t, err, ErrPurchaseOrderStatus)
+
+	// a draft can be closed instead of being sent
+	closed, err := store.ClosePurchaseOrderTx(context.Background(), PurchaseOrderStatusTxParams{ID: purchaseOrder.ID})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderStatusClosed, closed.Status)
+}
+
+func TestSalesOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	good1 := createRandomGood(t, category, unit)
+	good2 := createRandomGood(t, category, unit)
+	warehouse := createRandomWarehouse(t)
+	bin := createRandomBin(t, warehouse)[3]
+	actor := util.RandomName()
+
+	// good 1 sits in a bin, all of good 2 lies loose in the warehouse
+	_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good1.ID,
+		WarehouseID:  warehouse.ID,
+		LocationID:   sql.NullInt64{Int64: bin.ID, Valid: true},
+		MovementType: MovementTypeReceipt,
+		Amount:       8,
+	})
+	require.NoError(t, err)
+	good1, err = testQueries.GetGood(context.Background(), good1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good2.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good2.Amount,
+	})
+	require.NoError(t, err)
+
+	created, err := store.CreateSalesOrderTx(context.Background(), CreateSalesOrderTxParams{
+		CreateSalesOrderParams: CreateSalesOrderParams{
+			CustomerName: util.RandomName(),
+			WarehouseID:  warehouse.ID,
+		},
+		Lines: []SalesOrderLineParams{
+			{GoodID: good1.ID, Amount: 5},
+			{GoodID: good2.ID, Amount: good2.Amount + 1},
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusOpen, created.SalesOrder.Status)
+	require.Len(t, created.Lines, 2)
+
+	salesOrderID := created.SalesOrder.ID
+	line1, line2 := created.Lines[0], created.Lines[1]
+
+	_, err = store.ShipSalesOrderTx(context.Background(), ShipSalesOrderTxParams{
+		ID:    salesOrderID,
+		Picks: []SalesOrderPick{{LineID: line1.ID, Amount: line1.Amount}},
+	})
+	require.ErrorIs(t, err, ErrSalesOrderStatus)
+
+	// good 2 is one short, so the order stays open
+	allocated, err := store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrderID, Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusOpen, allocated.SalesOrder.Status)
+	require.Equal(t, line1.Amount, allocated.Lines[0].Allocated)
+	require.Equal(t, good2.Amount, allocated.Lines[1].Allocated)
+
+	reservedGood, err := testQueries.GetGood(context.Background(), good2.ID)
+	require.NoError(t, err)
+	require.Equal(t, good2.Reserved+good2.Amount, reservedGood.Reserved)
+
+	log := lastAuditLog(t, EntitySalesOrderLine, line2.ID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+	require.Equal(t, actor, log.Actor)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good2.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       1,
+	})
+	require.NoError(t, err)
+
+	allocated, err = store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrderID, Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusAllocated, allocated.SalesOrder.Status)
+	require.Equal(t, line2.Amount, allocated.Lines[1].Allocated)
+
+	// good 1 is picked from its bin, good 2 from the loose stock listed last
+	pickList, err := store.GetPickListTx(context.Background(), salesOrderID)
+	require.NoError(t, err)
+	require.Len(t, pickList.Locations, 2)
+	require.Equal(t, bin.ID, pickList.Locations[0].LocationID.Int64)
+	require.Equal(t, []PickListLine{{LineID: line1.ID, GoodID: good1.ID, Amount: line1.Amount}}, pickList.Locations[0].Lines)
+	require.False(t, pickList.Locations[1].LocationID.Valid)
+	require.Equal(t, []PickListLine{{LineID: line2.ID, GoodID: good2.ID, Amount: line2.Amount}}, pickList.Locations[1].Lines)
+
+	// short picks are rejected and nothing is booked
+	_, err = store.ShipSalesOrderTx(context.Background(), ShipSalesOrderTxParams{
+		ID: salesOrderID,
+		Picks: []SalesOrderPick{
+			{LineID: line1.ID, LocationID: sql.NullInt64{Int64: bin.ID, Valid: true}, Amount: line1.Amount},
+			{LineID: line2.ID, Amount: line2.Amount - 1},
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidPick)
+
+	shipped, err := store.ShipSalesOrderTx(context.Background(), ShipSalesOrderTxParams{
+		ID: salesOrderID,
+		Picks: []SalesOrderPick{
+			{LineID: line2.ID, Amount: line2.Amount},
+			{LineID: line1.ID, LocationID: sql.NullInt64{Int64: bin.ID, Valid: true}, Amount: line1.Amount},
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusShipped, shipped.SalesOrder.Status)
+	require.True(t, shipped.SalesOrder.ShippedAt.Valid)
+	require.Len(t, shipped.Movements, 2)
+
+	shippedGood, err := testQueries.GetGood(context.Background(), good1.ID)
+	require.NoError(t, err)
+	require.Equal(t, good1.Amount-line1.Amount, shippedGood.Amount)
+	require.Equal(t, good1.Reserved, shippedGood.Reserved)
+
+	binStock, err := testQueries.GetBinStock(context.Background(), GetBinStockParams{
+		GoodID:     good1.ID,
+		LocationID: bin.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 8-line1.Amount, binStock.Amount)
+
+	log = lastAuditLog(t, EntitySalesOrder, salesOrderID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+
+	_, err = store.CancelSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrderID, Actor: actor})
+	require.ErrorIs(t, err, ErrSalesOrderStatus)
+}
+
+func TestCancelSalesOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	salesOrder := createRandomSalesOrder(t, warehouse)
+	line := createRandomSalesOrderLine(t, salesOrder, good)
+
+	_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       line.Amount,
+	})
+	require.NoError(t, err)
+
+	allocated, err := store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrder.ID})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusAllocated, allocated.SalesOrder.Status)
+
+	_, err = store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrder.ID})
+	require.ErrorIs(t, err, ErrSalesOrderStatus)
+
+	cancelled, err := store.CancelSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrder.ID})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusCancelled, cancelled.SalesOrder.Status)
+	require.Zero(t, cancelled.Lines[0].Allocated)
+
+	released, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Reserved, released.Reserved)
+}
+
+func TestAllocateSalesOrderTxWarehouseStock(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	other := createRandomWarehouse(t)
+
+	// the stock the good was created with lies in the other warehouse, 3 more arrive in the warehouse
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: other.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       3,
+	})
+	require.NoError(t, err)
+
+	allocate := func(warehouse Warehouse, amount int64) SalesOrderTxResult {
+		created, err := store.CreateSalesOrderTx(context.Background(), CreateSalesOrderTxParams{
+			CreateSalesOrderParams: CreateSalesOrderParams{
+				CustomerName: util.RandomName(),
+				WarehouseID:  warehouse.ID,
+			},
+			Lines: []SalesOrderLineParams{{GoodID: good.ID, Amount: amount}},
+		})
+		require.NoError(t, err)
+
+		allocated, err := store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: created.SalesOrder.ID})
+		require.NoError(t, err)
+		return allocated
+	}
+
+	first := allocate(warehouse, 2)
+	require.Equal(t, SalesOrderStatusAllocated, first.SalesOrder.Status)
+	require.Equal(t, int64(2), first.Lines[0].Allocated)
+
+	// the stock of the other warehouse is not handed out, nor the stock the first order holds
+	second := allocate(warehouse, 2)
+	require.Equal(t, SalesOrderStatusOpen, second.SalesOrder.Status)
+	require.Equal(t, int64(1), second.Lines[0].Allocated)
+
+	third := allocate(other, good.Amount)
+	require.Equal(t, SalesOrderStatusAllocated, third.SalesOrder.Status)
+	require.Equal(t, good.Amount, third.Lines[0].Allocated)
+}
+
+func TestStockMovementTxWarehouseAllocations(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	other := createRandomWarehouse(t)
+
+	// the other warehouse holds the stock the good was created with, 3 arrive in the warehouse
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: other.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       3,
+	})
+	require.NoError(t, err)
+
+	created, err := store.CreateSalesOrderTx(context.Background(), CreateSalesOrderTxParams{
+		CreateSalesOrderParams: CreateSalesOrderParams{
+			CustomerName: util.RandomName(),
+			WarehouseID:  warehouse.ID,
+		},
+		Lines: []SalesOrderLineParams{{GoodID: good.ID, Amount: 2}},
+	})
+	require.NoError(t, err)
+
+	allocated, err := store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: created.SalesOrder.ID})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusAllocated, allocated.SalesOrder.Status)
+
+	// the spare stock of the other warehouse does not free the allocated stock of the warehouse
+	issue := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -2,
+	}
+	_, err = store.StockMovementTx(context.Background(), issue)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	issue.MovementType = MovementTypeTransferOut
+	_, err = store.StockMovementTx(context.Background(), issue)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	issue.MovementType = MovementTypeIssue
+	issue.Amount = -1
+	_, err = store.StockMovementTx(context.Background(), issue)
+	require.NoError(t, err)
+
+	// the order itself ships the stock it holds
+	shipped, err := store.ShipSalesOrderTx(context.Background(), ShipSalesOrderTxParams{
+		ID:    created.SalesOrder.ID,
+		Picks: []SalesOrderPick{{LineID: allocated.Lines[0].ID, Amount: 2}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, SalesOrderStatusShipped, shipped.SalesOrder.Status)
+
+	balance, err := testQueries.GetGoodBalance(context.Background(), GetGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+	})
+	require.NoError(t, err)
+	require.Zero(t, balance.Amount)
+}
+
+func TestTransferOrderTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	from := createRandomWarehouse(t)
+	to := createRandomWarehouse(t)
+	bin := createRandomBin(t, to)[3]
+	actor := util.RandomName()
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: from.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateTransferOrderTx(context.Background(), CreateTransferOrderTxParams{
+		CreateTransferOrderParams: CreateTransferOrderParams{FromWarehouseID: from.ID, ToWarehouseID: from.ID},
+		Lines:                     []TransferOrderLineParams{{GoodID: good.ID, Amount: 1}},
+	})
+	require.ErrorIs(t, err, ErrSameWarehouse)
+
+	created, err := store.CreateTransferOrderTx(context.Background(), CreateTransferOrderTxParams{
+		CreateTransferOrderParams: CreateTransferOrderParams{FromWarehouseID: from.ID, ToWarehouseID: to.ID},
+		Lines: []TransferOrderLineParams{
+			{GoodID: good.ID, Amount: 2, ToLocationID: sql.NullInt64{Int64: bin.ID, Valid: true}},
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, TransferOrderStatusDraft, created.TransferOrder.Status)
+	require.Len(t, created.Lines, 1)
+
+	transferOrderID := created.TransferOrder.ID
+
+	_, err = store.ReceiveTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrderID, Actor: actor})
+	require.ErrorIs(t, err, ErrTransferOrderStatus)
+
+	shipped, err := store.ShipTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrderID, Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, TransferOrderStatusInTransit, shipped.TransferOrder.Status)
+	require.Len(t, shipped.Movements, 1)
+	require.Equal(t, MovementTypeTransferOut, shipped.Movements[0].MovementType)
+	require.Equal(t, int64(-2), shipped.Movements[0].Amount)
+
+	inTransit, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount-2, inTransit.Amount)
+	require.Equal(t, int64(2), inTransit.InTransit)
+
+	balance, err := testQueries.GetGoodBalance(context.Background(), GetGoodBalanceParams{GoodID: good.ID, WarehouseID: from.ID})
+	require.NoError(t, err)
+	require.Equal(t, good.Amount-2, balance.Amount)
+
+	log := lastAuditLog(t, EntityTransferOrder, transferOrderID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+	require.Equal(t, actor, log.Actor)
+
+	_, err = store.CancelTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrderID, Actor: actor})
+	require.ErrorIs(t, err, ErrTransferOrderStatus)
+
+	received, err := store.ReceiveTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrderID, Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, TransferOrderStatusReceived, received.TransferOrder.Status)
+	require.True(t, received.TransferOrder.ReceivedAt.Valid)
+	require.Equal(t, MovementTypeTransferIn, received.Movements[0].MovementType)
+
+	arrived, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount, arrived.Amount)
+	require.Zero(t, arrived.InTransit)
+
+	binStock, err := testQueries.GetBinStock(context.Background(), GetBinStockParams{GoodID: good.ID, LocationID: bin.ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), binStock.Amount)
+}
+
+func TestShipTransferOrderTxInsufficientStock(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	transferOrder := createRandomTransferOrder(t, createRandomWarehouse(t), createRandomWarehouse(t))
+
+	_, err := testQueries.CreateTransferOrderLine(context.Background(), CreateTransferOrderLineParams{
+		TransferOrderID: transferOrder.ID,
+		GoodID:          good.ID,
+		Amount:          1,
+	})
+	require.NoError(t, err)
+
+	// the source warehouse holds none of the good
+	_, err = store.ShipTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrder.ID})
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	unchanged, err := testQueries.GetTransferOrder(context.Background(), transferOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, TransferOrderStatusDraft, unchanged.Status)
+
+	cancelled, err := store.CancelTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrder.ID})
+	require.NoError(t, err)
+	require.Equal(t, TransferOrderStatusCancelled, cancelled.Status)
+}
+
+func TestTransferOrderTxLots(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	from := createRandomWarehouse(t)
+	to := createRandomWarehouse(t)
+	sooner := createRandomLot(t, good, 10)
+	later := createRandomLot(t, good, 60)
+
+	for _, lot := range []Lot{sooner, later} {
+		_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+			GoodID:       good.ID,
+			WarehouseID:  from.ID,
+			LotID:        sql.NullInt64{Int64: lot.ID, Valid: true},
+			MovementType: MovementTypeReceipt,
+			Amount:       3,
+		})
+		require.NoError(t, err)
+	}
+
+	created, err := store.CreateTransferOrderTx(context.Background(), CreateTransferOrderTxParams{
+		CreateTransferOrderParams: CreateTransferOrderParams{FromWarehouseID: from.ID, ToWarehouseID: to.ID},
+		Lines:                     []TransferOrderLineParams{{GoodID: good.ID, Amount: 4}},
+	})
+	require.NoError(t, err)
+	lineID := created.Lines[0].ID
+
+	// the line is shipped out of the lot expiring first and records the lots it left
+	_, err = store.ShipTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: created.TransferOrder.ID})
+	require.NoError(t, err)
+
+	lineLots, err := testQueries.ListTransferOrderLineLots(context.Background(), lineID)
+	require.NoError(t, err)
+	require.Equal(t, []TransferOrderLineLot{
+		{TransferOrderLineID: lineID, LotID: sooner.ID, Amount: 3},
+		{TransferOrderLineID: lineID, LotID: later.ID, Amount: 1},
+	}, lineLots)
+
+	// the destination receives the same lots
+	received, err := store.ReceiveTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: created.TransferOrder.ID})
+	require.NoError(t, err)
+	require.Equal(t, TransferOrderStatusReceived, received.TransferOrder.Status)
+
+	for lotID, amount := range map[int64]int64{sooner.ID: 3, later.ID: 1} {
+		lotStock, err := testQueries.GetLotStock(context.Background(), GetLotStockParams{
+			LotID:       lotID,
+			WarehouseID: to.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, amount, lotStock.Amount)
+	}
+
+	lotMovements, err := testQueries.ListLotMovements(context.Background(), received.Movements[0].ID)
+	require.NoError(t, err)
+	require.Len(t, lotMovements, 2)
+}
+
+func TestCountSessionTx(t *testing.T) {
+	store := NewStore(testDB)
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	good := createRandomGood(t, category, unit)
+	other := createRandomGood(t, category, unit)
+	warehouse := createRandomWarehouse(t)
+	actor := util.RandomName()
+
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	_, err = store.StartCountSessionTx(context.Background(), StartCountSessionTxParams{
+		CreateCountSessionParams: CreateCountSessionParams{
+			WarehouseID: warehouse.ID,
+			CategoryID:  sql.NullInt64{Int64: category.ID, Valid: true},
+			SectionName: sql.NullString{String: category.SectionName, Valid: true},
+		},
+	})
+	require.ErrorIs(t, err, ErrCountScope)
+
+	started, err := store.StartCountSessionTx(context.Background(), StartCountSessionTxParams{
+		CreateCountSessionParams: CreateCountSessionParams{
+			WarehouseID: warehouse.ID,
+			CategoryID:  sql.NullInt64{Int64: category.ID, Valid: true},
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CountSessionStatusOpen, started.CountSession.Status)
+	require.Len(t, started.Items, 2)
+	require.Equal(t, good.ID, started.Items[0].GoodID)
+	require.Equal(t, good.Amount, started.Items[0].Snapshot)
+	require.Equal(t, other.ID, started.Items[1].GoodID)
+	require.Zero(t, started.Items[1].Snapshot)
+
+	countSessionID := started.CountSession.ID
+	log := lastAuditLog(t, EntityCountSession, countSessionID)
+	require.Equal(t, AuditActionCreate, log.Action)
+
+	// stock moving after the start does not change the snapshot
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.RecordCountsTx(context.Background(), RecordCountsTxParams{
+		ID:     countSessionID,
+		Counts: []CountEntry{{ItemID: started.Items[0].ID - 1_000_000, Counted: 1}},
+	})
+	require.ErrorIs(t, err, ErrInvalidCountItem)
+
+	counted, err := store.RecordCountsTx(context.Background(), RecordCountsTxParams{
+		ID:     countSessionID,
+		Counts: []CountEntry{{ItemID: started.Items[0].ID, Counted: good.Amount - 2}},
+		Actor:  actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sql.NullInt64{Int64: good.Amount - 2, Valid: true}, counted.Items[0].Counted)
+	require.Equal(t, actor, counted.Items[0].CountedBy)
+	require.False(t, counted.Items[1].Counted.Valid)
+
+	log = lastAuditLog(t, EntityCountSessionItem, started.Items[0].ID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+	require.Equal(t, actor, log.Actor)
+
+	_, err = store.ApproveCountSessionTx(context.Background(), CountSessionStatusTxParams{ID: countSessionID, Actor: actor})
+	require.ErrorIs(t, err, ErrCountIncomplete)
+
+	_, err = store.RecordCountsTx(context.Background(), RecordCountsTxParams{
+		ID:     countSessionID,
+		Counts: []CountEntry{{ItemID: started.Items[1].ID, Counted: 0}},
+		Actor:  actor,
+	})
+	require.NoError(t, err)
+
+	approved, err := store.ApproveCountSessionTx(context.Background(), CountSessionStatusTxParams{ID: countSessionID, Actor: actor})
+	require.NoError(t, err)
+	require.Equal(t, CountSessionStatusApproved, approved.CountSession.Status)
+	require.True(t, approved.CountSession.ClosedAt.Valid)
+	require.Len(t, approved.Movements, 1)
+	require.Equal(t, MovementTypeAdjustment, approved.Movements[0].MovementType)
+	require.Equal(t, int64(-2), approved.Movements[0].Amount)
+
+	adjusted, err := testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.Amount-1, adjusted.Amount)
+
+	log = lastAuditLog(t, EntityCountSession, countSessionID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+
+	_, err = store.RecordCountsTx(context.Background(), RecordCountsTxParams{
+		ID:     countSessionID,
+		Counts: []CountEntry{{ItemID: started.Items[0].ID, Counted: 1}},
+	})
+	require.ErrorIs(t, err, ErrCountSessionStatus)
+
+	_, err = store.CancelCountSessionTx(context.Background(), CountSessionStatusTxParams{ID: countSessionID})
+	require.ErrorIs(t, err, ErrCountSessionStatus)
+}
+
+func TestCountSessionTxBins(t *testing.T) {
+	store := NewStore(testDB)
+	category := createRandomCategory(t)
+	good := createRandomGood(t, category, createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	bin := createRandomBin(t, warehouse)[3]
+
+	// the stock the good was created with lies outside the bins, 3 more are put into the bin
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		LocationID:   sql.NullInt64{Int64: bin.ID, Valid: true},
+		MovementType: MovementTypeReceipt,
+		Amount:       3,
+	})
+	require.NoError(t, err)
+
+	started, err := store.StartCountSessionTx(context.Background(), StartCountSessionTxParams{
+		CreateCountSessionParams: CreateCountSessionParams{
+			WarehouseID: warehouse.ID,
+			CategoryID:  sql.NullInt64{Int64: category.ID, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, started.Items, 2)
+	require.False(t, started.Items[0].LocationID.Valid)
+	require.Equal(t, good.Amount, started.Items[0].Snapshot)
+	require.Equal(t, sql.NullInt64{Int64: bin.ID, Valid: true}, started.Items[1].LocationID)
+	require.Equal(t, int64(3), started.Items[1].Snapshot)
+
+	_, err = store.RecordCountsTx(context.Background(), RecordCountsTxParams{
+		ID: started.CountSession.ID,
+		Counts: []CountEntry{
+			{ItemID: started.Items[0].ID, Counted: good.Amount},
+			{ItemID: started.Items[1].ID, Counted: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	// the missing stock is taken out of the bin it was counted in
+	approved, err := store.ApproveCountSessionTx(context.Background(), CountSessionStatusTxParams{ID: started.CountSession.ID})
+	require.NoError(t, err)
+	require.Len(t, approved.Movements, 1)
+	require.Equal(t, int64(-2), approved.Movements[0].Amount)
+	require.Equal(t, sql.NullInt64{Int64: bin.ID, Valid: true}, approved.Movements[0].LocationID)
+
+	binStock, err := testQueries.GetBinStock(context.Background(), GetBinStockParams{
+		GoodID:     good.ID,
+		LocationID: bin.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), binStock.Amount)
+}
+
+func TestStartCountSessionTxLocation(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	bins := createRandomBin(t, warehouse)
+
+	_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		LocationID:   sql.NullInt64{Int64: bins[3].ID, Valid: true},
+		MovementType: MovementTypeReceipt,
+		Amount:       3,
+	})
+	require.NoError(t, err)
+
+	_, err = store.StartCountSessionTx(context.Background(), StartCountSessionTxParams{
+		CreateCountSessionParams: CreateCountSessionParams{
+			WarehouseID: createRandomWarehouse(t).ID,
+			LocationID:  sql.NullInt64{Int64: bins[0].ID, Valid: true},
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidLocation)
+
+	started, err := store.StartCountSessionTx(context.Background(), StartCountSessionTxParams{
+		CreateCountSessionParams: CreateCountSessionParams{
+			WarehouseID: warehouse.ID,
+			LocationID:  sql.NullInt64{Int64: bins[0].ID, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, started.Items, 1)
+	require.Equal(t, good.ID, started.Items[0].GoodID)
+	require.Equal(t, sql.NullInt64{Int64: bins[3].ID, Valid: true}, started.Items[0].LocationID)
+	require.Equal(t, int64(3), started.Items[0].Snapshot)
+
+	cancelled, err := store.CancelCountSessionTx(context.Background(), CountSessionStatusTxParams{ID: started.CountSession.ID})
+	require.NoError(t, err)
+	require.Equal(t, CountSessionStatusCancelled, cancelled.Status)
+}
+
+func TestSetGoodStockLevelsTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	actor := util.RandomName()
+
+	arg := SetGoodStockLevelsTxParams{
+		SetGoodStockLevelsParams: SetGoodStockLevelsParams{
+			ID:           good.ID,
+			SafetyStock:  2,
+			ReorderPoint: 4,
+			MaxLevel:     12,
+		},
+		Version: good.Version + 1,
+		Actor:   actor,
+	}
+	_, err := store.SetGoodStockLevelsTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	arg.Version = good.Version
+	updated, err := store.SetGoodStockLevelsTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), updated.ReorderPoint)
+	require.Equal(t, good.Version+1, updated.Version)
+
+	log := lastAuditLog(t, EntityGood, good.ID)
+	require.Equal(t, AuditActionUpdate, log.Action)
+	require.Equal(t, actor, log.Actor)
+}
+
+func TestCreateLotTx(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	actor := util.RandomName()
+
+	lot, err := store.CreateLotTx(context.Background(), CreateLotTxParams{
+		CreateLotParams: CreateLotParams{
+			GoodID:    good.ID,
+			LotNumber: util.RandomString(8),
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, good.ID, lot.GoodID)
+	require.False(t, lot.ExpiresOn.Valid)
+
+	log := lastAuditLog(t, EntityLot, lot.ID)
+	require.Equal(t, AuditActionCreate, log.Action)
+	require.Equal(t, actor, log.Actor)
+
+	_, err = store.CreateLotTx(context.Background(), CreateLotTxParams{
+		CreateLotParams: CreateLotParams{
+			GoodID:    0,
+			LotNumber: util.RandomString(8),
+		},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestStockMovementTxLots(t *testing.T) {
+	store := NewStore(testDB)
+	good := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	warehouse := createRandomWarehouse(t)
+	sooner := createRandomLot(t, good, 10)
+	later := createRandomLot(t, good, 60)
+	expired := createRandomLot(t, good, -1)
+
+	// the stock of the good is kept outside of lots
+	_, err := testQueries.AddGoodBalance(context.Background(), AddGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouse.ID,
+		Amount:      good.Amount,
+	})
+	require.NoError(t, err)
+
+	for _, lot := range []Lot{sooner, later, expired} {
+		result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+			GoodID:       good.ID,
+			WarehouseID:  warehouse.ID,
+			LotID:        sql.NullInt64{Int64: lot.ID, Valid: true},
+			MovementType: MovementTypeReceipt,
+			Amount:       3,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Lots, 1)
+		require.Equal(t, lot.ID, result.Lots[0].LotID)
+	}
+
+	// issues take the lot expiring first and skip the expired one
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -4,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Lots, 2)
+	require.Equal(t, sooner.ID, result.Lots[0].LotID)
+	require.Equal(t, int64(-3), result.Lots[0].Amount)
+	require.Equal(t, later.ID, result.Lots[1].LotID)
+	require.Equal(t, int64(-1), result.Lots[1].Amount)
+
+	lotMovements, err := testQueries.ListLotMovements(context.Background(), result.Movement.ID)
+	require.NoError(t, err)
+	require.Len(t, lotMovements, 2)
+
+	// a named lot cannot give more than it holds
+	arg := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		LotID:        sql.NullInt64{Int64: later.ID, Valid: true},
+		MovementType: MovementTypeIssue,
+		Amount:       -3,
+	}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	arg.Amount = -2
+	result, err = store.StockMovementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Lots, 1)
+
+	// a lot of another good is refused
+	other := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	arg.LotID = sql.NullInt64{Int64: createRandomLot(t, other, 10).ID, Valid: true}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidLot)
+
+	// the stock outside of lots is issued, the expired lot is not
+	arg = StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -(good.Amount + 1),
+	}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	// adjustments take expired lots as well
+	arg.MovementType = MovementTypeAdjustment
+	arg.Amount = -1
+	result, err = store.StockMovementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Lots, 1)
+	require.Equal(t, expired.ID, result.Lots[0].LotID)
+	require.Equal(t, int64(-1), result.Lots[0].Amount)
+}
+
+func TestStockMovementTxSerials(t *testing.T) {
+	store := NewStore(testDB)
+	good := createSerializedGood(t)
+	warehouse := createRandomWarehouse(t)
+	serials := []string{util.RandomString(12), util.RandomString(12)}
+
+	arg := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       2,
+		Serials:      serials[:1],
+	}
+	_, err := store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	arg.Serials = []string{serials[0], serials[0]}
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	arg.Serials = serials
+	result, err := store.StockMovementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Serials, 2)
+	for _, serial := range result.Serials {
+		require.Equal(t, SerialStatusInStock, serial.Status)
+		require.Equal(t, warehouse.ID, serial.WarehouseID.Int64)
+	}
+
+	// a unit cannot be received twice
+	arg.Amount = 1
+	arg.Serials = serials[:1]
+	_, err = store.StockMovementTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	// only units in the warehouse are issued
+	issue := StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  createRandomWarehouse(t).ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -1,
+		Serials:      serials[:1],
+	}
+	_, err = store.StockMovementTx(context.Background(), issue)
+	require.Error(t, err)
+
+	issue.WarehouseID = warehouse.ID
+	result, err = store.StockMovementTx(context.Background(), issue)
+	require.NoError(t, err)
+	require.Equal(t, SerialStatusShipped, result.Serials[0].Status)
+	require.False(t, result.Serials[0].WarehouseID.Valid)
+
+	// a shipped unit received again is a return
+	result, err = store.StockMovementTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, SerialStatusReturned, result.Serials[0].Status)
+
+	events, err := testQueries.ListSerialEvents(context.Background(), result.Serials[0].ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, SerialStatusInStock, events[0].Status)
+	require.Equal(t, SerialStatusShipped, events[1].Status)
+	require.Equal(t, SerialStatusReturned, events[2].Status)
+	require.Equal(t, result.Movement.ID, events[2].StockMovementID.Int64)
+
+	// other goods take no serials
+	other := createRandomGood(t, createRandomCategory(t), createRandomUnit(t))
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       other.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       1,
+		Serials:      []string{util.RandomString(12)},
+	})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+}
+
+func TestReservationTxSerials(t *testing.T) {
+	store := NewStore(testDB)
+	good := createSerializedGood(t)
+	warehouse := createRandomWarehouse(t)
+	serials := []string{util.RandomString(12), util.RandomString(12)}
+
+	_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       2,
+		Serials:      serials,
+	})
+	require.NoError(t, err)
+
+	reservation, err := store.CreateReservationTx(context.Background(), CreateReservationTxParams{
+		CreateReservationParams: CreateReservationParams{
+			GoodID:    good.ID,
+			Amount:    1,
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+		Serials: serials[:1],
+	})
+	require.NoError(t, err)
+
+	serial, err := testQueries.GetSerialByNumber(context.Background(), serials[0])
+	require.NoError(t, err)
+	require.Equal(t, SerialStatusReserved, serial.Status)
+	require.Equal(t, reservation.ID, serial.ReservationID.Int64)
+
+	// a reserved unit cannot be issued
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -1,
+		Serials:      serials[:1],
+	})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	_, err = store.ReleaseReservationTx(context.Background(), ReleaseReservationTxParams{ID: reservation.ID})
+	require.NoError(t, err)
+
+	serial, err = testQueries.GetSerialByNumber(context.Background(), serials[0])
+	require.NoError(t, err)
+	require.Equal(t, SerialStatusInStock, serial.Status)
+	require.False(t, serial.ReservationID.Valid)
+
+	events, err := testQueries.ListSerialEvents(context.Background(), serial.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, SerialStatusReserved, events[1].Status)
+	require.Equal(t, reservation.ID, events[2].ReservationID.Int64)
+}
+
+func TestOrderTxSerials(t *testing.T) {
+	store := NewStore(testDB)
+	good := createSerializedGood(t)
+	warehouse := createRandomWarehouse(t)
+	serials := []string{util.RandomString(12), util.RandomString(12), util.RandomString(12)}
+
+	// purchase orders receive their units with one serial each
+	supplier := createRandomSupplier(t)
+	purchaseOrder, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		CreatePurchaseOrderParams: CreatePurchaseOrderParams{
+			SupplierID:  supplier.ID,
+			WarehouseID: warehouse.ID,
+		},
+		Lines: []CreatePurchaseOrderLineParams{{GoodID: good.ID, UnitID: good.Unit, Ordered: 3}},
+	})
+	require.NoError(t, err)
+	_, err = store.SendPurchaseOrderTx(context.Background(), PurchaseOrderStatusTxParams{ID: purchaseOrder.PurchaseOrder.ID})
+	require.NoError(t, err)
+
+	receipt := PurchaseOrderReceipt{LineID: purchaseOrder.Lines[0].ID, Amount: 3}
+	_, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:       purchaseOrder.PurchaseOrder.ID,
+		Receipts: []PurchaseOrderReceipt{receipt},
+		Currency: supplier.Currency,
+	})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	receipt.Serials = serials
+	_, err = store.ReceivePurchaseOrderTx(context.Background(), ReceivePurchaseOrderTxParams{
+		ID:       purchaseOrder.PurchaseOrder.ID,
+		Receipts: []PurchaseOrderReceipt{receipt},
+		Currency: supplier.Currency,
+	})
+	require.NoError(t, err)
+
+	// adjustments of the good name the units they add or remove
+	good, err = testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	update := UpdateGoodTxParams{
+		ID:        good.ID,
+		Unit:      good.Unit,
+		Warehouse: warehouse.ID,
+		Amount:    2,
+		Version:   good.Version,
+	}
+	_, err = store.UpdateGoodTx(context.Background(), update)
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	update.Serials = serials[2:]
+	good, err = store.UpdateGoodTx(context.Background(), update)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), good.Amount)
+
+	// sales orders ship the picked units
+	salesOrder, err := store.CreateSalesOrderTx(context.Background(), CreateSalesOrderTxParams{
+		CreateSalesOrderParams: CreateSalesOrderParams{
+			CustomerName: util.RandomName(),
+			WarehouseID:  warehouse.ID,
+		},
+		Lines: []SalesOrderLineParams{{GoodID: good.ID, Amount: 2}},
+	})
+	require.NoError(t, err)
+	_, err = store.AllocateSalesOrderTx(context.Background(), SalesOrderStatusTxParams{ID: salesOrder.SalesOrder.ID})
+	require.NoError(t, err)
+
+	pick := SalesOrderPick{LineID: salesOrder.Lines[0].ID, Amount: 2}
+	_, err = store.ShipSalesOrderTx(context.Background(), ShipSalesOrderTxParams{
+		ID:    salesOrder.SalesOrder.ID,
+		Picks: []SalesOrderPick{pick},
+	})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	pick.Serials = serials[:2]
+	_, err = store.ShipSalesOrderTx(context.Background(), ShipSalesOrderTxParams{
+		ID:    salesOrder.SalesOrder.ID,
+		Picks: []SalesOrderPick{pick},
+	})
+	require.NoError(t, err)
+
+	for _, serialNumber := range serials {
+		serial, err := testQueries.GetSerialByNumber(context.Background(), serialNumber)
+		require.NoError(t, err)
+		require.Equal(t, SerialStatusShipped, serial.Status)
+	}
+
+	// transfers and counts cannot name the units they move, so serialized goods are rejected
+	to := createRandomWarehouse(t)
+	_, err = store.CreateTransferOrderTx(context.Background(), CreateTransferOrderTxParams{
+		CreateTransferOrderParams: CreateTransferOrderParams{FromWarehouseID: warehouse.ID, ToWarehouseID: to.ID},
+		Lines:                     []TransferOrderLineParams{{GoodID: good.ID, Amount: 1}},
+	})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	transferOrder := createRandomTransferOrder(t, warehouse, to)
+	_, err = testQueries.CreateTransferOrderLine(context.Background(), CreateTransferOrderLineParams{
+		TransferOrderID: transferOrder.ID,
+		GoodID:          good.ID,
+		Amount:          1,
+	})
+	require.NoError(t, err)
+	_, err = store.ShipTransferOrderTx(context.Background(), TransferOrderStatusTxParams{ID: transferOrder.ID})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       1,
+		Serials:      serials[:1],
+	})
+	require.NoError(t, err)
+
+	countSession, err := store.StartCountSessionTx(context.Background(), StartCountSessionTxParams{
+		CreateCountSessionParams: CreateCountSessionParams{
+			WarehouseID: warehouse.ID,
+			CategoryID:  sql.NullInt64{Int64: good.Category, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, countSession.Items, 1)
+
+	_, err = store.RecordCountsTx(context.Background(), RecordCountsTxParams{
+		ID:     countSession.CountSession.ID,
+		Counts: []CountEntry{{ItemID: countSession.Items[0].ID, Counted: 0}},
+	})
+	require.NoError(t, err)
+	_, err = store.ApproveCountSessionTx(context.Background(), CountSessionStatusTxParams{ID: countSession.CountSession.ID})
+	require.ErrorIs(t, err, ErrSerialMismatch)
+}
+
+// createValuedGood creates a good of the category holding the amount at the given cost per unit
+func createValuedGood(t *testing.T, store Store, category Category, warehouse Warehouse, amount, unitCost int64) Good {
+	good, err := store.CreateGoodTx(context.Background(), CreateGoodTxParams{
+		CreateGoodParams: CreateGoodParams{
+			Category: category.ID,
+			Model:    util.RandomName(),
+			Unit:     createRandomUnit(t).ID,
+			Amount:   amount,
+			GoodDesc: "desc",
+		},
+		Warehouse: warehouse.ID,
+		UnitCost:  sql.NullInt64{Int64: unitCost, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, amount*unitCost, good.StockValue)
+	return good
+}
+
+func TestStockMovementTxValuation(t *testing.T) {
+	store := NewStore(testDB)
+	warehouse := createRandomWarehouse(t)
+
+	fifo := createRandomCategory(t)
+	require.Equal(t, CostingMethodFIFO, fifo.CostingMethod)
+	category := createRandomCategory(t)
+	average, err := store.SetCategoryCostingMethodTx(context.Background(), SetCategoryCostingMethodTxParams{
+		SetCategoryCostingMethodParams: SetCategoryCostingMethodParams{
+			ID:            category.ID,
+			CostingMethod: CostingMethodAverage,
+			Version:       category.Version,
+		},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		category   Category
+		issueValue int64
+		stockValue int64
+	}{
+		// the issue takes the 10 units at 100 and 5 of the units at 300
+		{category: fifo, issueValue: 2500, stockValue: 1500},
+		// the issue takes 15 of 20 units worth 4000 together
+		{category: average, issueValue: 3000, stockValue: 1000},
+	}
+
+	for _, tc := range testCases {
+		good := createValuedGood(t, store, tc.category, warehouse, 10, 100)
+
+		result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+			GoodID:       good.ID,
+			WarehouseID:  warehouse.ID,
+			MovementType: MovementTypeReceipt,
+			Amount:       10,
+			Value:        sql.NullInt64{Int64: 3000, Valid: true},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(3000), result.Movement.Value)
+		require.Equal(t, int64(4000), result.Good.StockValue)
+
+		result, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+			GoodID:       good.ID,
+			WarehouseID:  warehouse.ID,
+			MovementType: MovementTypeIssue,
+			Amount:       -15,
+		})
+		require.NoError(t, err)
+		require.Equal(t, -tc.issueValue, result.Movement.Value)
+		require.Equal(t, tc.stockValue, result.Good.StockValue)
+
+		layers, err := testQueries.ListOpenCostLayers(context.Background(), good.ID)
+		require.NoError(t, err)
+		require.Len(t, layers, 1)
+		require.Equal(t, int64(5), layers[0].Remaining)
+		require.Equal(t, int64(1500), layers[0].RemainingValue)
+
+		// a receipt without a value comes in at the average cost of the good
+		result, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+			GoodID:       good.ID,
+			WarehouseID:  warehouse.ID,
+			MovementType: MovementTypeReceipt,
+			Amount:       5,
+		})
+		require.NoError(t, err)
+		require.Equal(t, tc.stockValue, result.Movement.Value)
+		require.Equal(t, 2*tc.stockValue, result.Good.StockValue)
+	}
+}
+
+func TestAverageValue(t *testing.T) {
+	// the product of the value and the amount does not fit into 64 bits
+	require.Equal(t, int64(math.MaxInt64/4), averageValue(math.MaxInt64/2, 1000, 500))
+	require.Equal(t, int64(333), averageValue(1000, 3, 1))
+	require.Equal(t, int64(1000), averageValue(1000, 3, 3))
+	require.Zero(t, averageValue(1000, 0, 1))
+
+	value, err := MultiplyUnitCost(250, 4)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), value)
+
+	_, err = MultiplyUnitCost(math.MaxInt64/2+1, 2)
+	require.ErrorIs(t, err, ErrAmountOverflow)
+}
+
+func TestSetCategoryCostingMethodTx(t *testing.T) {
+	store := NewStore(testDB)
+	warehouse := createRandomWarehouse(t)
+	category := createRandomCategory(t)
+
+	arg := SetCategoryCostingMethodTxParams{
+		SetCategoryCostingMethodParams: SetCategoryCostingMethodParams{
+			ID:            category.ID,
+			CostingMethod: CostingMethodAverage,
+			Version:       category.Version,
+		},
+		Actor: util.RandomName(),
+	}
+	average, err := store.SetCategoryCostingMethodTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, CostingMethodAverage, average.CostingMethod)
+	require.Equal(t, category.Version+1, average.Version)
+
+	// the change is based on a version that is gone
+	_, err = store.SetCategoryCostingMethodTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	good := createValuedGood(t, store, average, warehouse, 10, 100)
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeReceipt,
+		Amount:       10,
+		Value:        sql.NullInt64{Int64: 3000, Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  warehouse.ID,
+		MovementType: MovementTypeIssue,
+		Amount:       -15,
+	})
+	require.NoError(t, err)
+
+	// switching to FIFO revalues the goods at the value left in their layers
+	fifo, err := store.SetCategoryCostingMethodTx(context.Background(), SetCategoryCostingMethodTxParams{
+		SetCategoryCostingMethodParams: SetCategoryCostingMethodParams{
+			ID:            category.ID,
+			CostingMethod: CostingMethodFIFO,
+			Version:       average.Version,
+		},
+		Actor: arg.Actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, CostingMethodFIFO, fifo.CostingMethod)
+
+	good, err = testQueries.GetGood(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), good.StockValue)
+
+	logs, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		EntityType: sql.NullString{String: EntityCategory, Valid: true},
+		EntityID:   sql.NullString{String: fmt.Sprint(category.ID), Valid: true},
+		Limit:      5,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+}
+
+func TestGoodIdentifiersTx(t *testing.T) {
+	store := NewStore(testDB)
+	actor := util.RandomName()
+	sku := sql.NullString{String: util.RandomString(10), Valid: true}
+	barcodes := []BarcodeEntry{
+		{Barcode: util.RandomEAN13(), Symbology: util.BarcodeEAN13},
+		{Barcode: util.RandomString(12), Symbology: util.BarcodeCode128},
+	}
+
+	good, err := store.CreateGoodTx(context.Background(), CreateGoodTxParams{
+		CreateGoodParams: CreateGoodParams{
+			Category: createRandomCategory(t).ID,
+			Model:    "model",
+			Unit:     createRandomUnit(t).ID,
+			Amount:   3,
+			GoodDesc: "desc",
+			Sku:      sku,
+		},
+		Warehouse: createRandomWarehouse(t).ID,
+		Barcodes:  barcodes,
+		Actor:     actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, sku, good.Sku)
+
+	created, err := testQueries.ListGoodBarcodes(context.Background(), good.ID)
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	require.Equal(t, barcodes[0].Barcode, created[0].Barcode)
+	require.Equal(t, barcodes[1].Symbology, created[1].Symbology)
+
+	// a barcode of another good rolls the whole good back
+	_, err = store.CreateGoodTx(context.Background(), CreateGoodTxParams{
+		CreateGoodParams: CreateGoodParams{
+			Category: createRandomCategory(t).ID,
+			Model:    "model",
+			Unit:     createRandomUnit(t).ID,
+			Amount:   3,
+			GoodDesc: "desc",
+		},
+		Warehouse: createRandomWarehouse(t).ID,
+		Barcodes:  barcodes[:1],
+	})
+	require.Error(t, err)
+
+	barcode, err := store.CreateGoodBarcodeTx(context.Background(), CreateGoodBarcodeTxParams{
+		CreateGoodBarcodeParams: CreateGoodBarcodeParams{
+			GoodID:    good.ID,
+			Barcode:   util.RandomEAN13(),
+			Symbology: util.BarcodeEAN13,
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, good.ID, barcode.GoodID)
+
+	err = store.DeleteGoodBarcodeTx(context.Background(), DeleteGoodBarcodeTxParams{
+		DeleteGoodBarcodeParams: DeleteGoodBarcodeParams{
+			GoodID:  good.ID,
+			Barcode: barcodes[0].Barcode,
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	logs, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		EntityType: sql.NullString{String: EntityGoodBarcode, Valid: true},
+		Actor:      sql.NullString{String: actor, Valid: true},
+		Limit:      5,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 4)
+
+	// the sku is cleared with the version the good is at
+	_, err = store.SetGoodSkuTx(context.Background(), SetGoodSkuTxParams{
+		SetGoodSkuParams: SetGoodSkuParams{ID: good.ID},
+		Version:          good.Version - 1,
+		Actor:            actor,
+	})
+	require.ErrorIs(t, err, ErrVersionMismatch)
+
+	cleared, err := store.SetGoodSkuTx(context.Background(), SetGoodSkuTxParams{
+		SetGoodSkuParams: SetGoodSkuParams{ID: good.ID},
+		Version:          good.Version,
+		Actor:            actor,
+	})
+	require.NoError(t, err)
+	require.False(t, cleared.Sku.Valid)
+	require.Equal(t, good.Version+1, cleared.Version)
+}
+
+func TestLabelTemplateTx(t *testing.T) {
+	store := NewStore(testDB)
+	actor := util.RandomName()
+
+	template, err := store.CreateLabelTemplateTx(context.Background(), CreateLabelTemplateTxParams{
+		CreateLabelTemplateParams: CreateLabelTemplateParams{
+			TemplateName: util.RandomName() + util.RandomString(6),
+			WidthMm:      50,
+			HeightMm:     30,
+			Dpi:          203,
+			CodeType:     "barcode",
+			ShowModel:    true,
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+
+	updated, err := store.UpdateLabelTemplateTx(context.Background(), UpdateLabelTemplateTxParams{
+		UpdateLabelTemplateParams: UpdateLabelTemplateParams{
+			ID:           template.ID,
+			TemplateName: template.TemplateName,
+			WidthMm:      template.WidthMm,
+			HeightMm:     template.HeightMm,
+			Dpi:          300,
+			CodeType:     "qr",
+		},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(300), updated.Dpi)
+
+	err = store.DeleteLabelTemplateTx(context.Background(), DeleteTxParams{ID: template.ID, Actor: actor})
+	require.NoError(t, err)
+
+	err = store.DeleteLabelTemplateTx(context.Background(), DeleteTxParams{ID: template.ID, Actor: actor})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	logs, err := testQueries.ListAuditLogs(context.Background(), ListAuditLogsParams{
+		EntityType: sql.NullString{String: EntityLabelTemplate, Valid: true},
+		Actor:      sql.NullString{String: actor, Valid: true},
+		Limit:      5,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+}
+
+func TestImportGoodsTx(t *testing.T) {
+	store := NewStore(testDB)
+	actor := util.RandomName()
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	warehouse := createRandomWarehouse(t)
+
+	newGood := func(sku string) CreateGoodTxParams {
+		return CreateGoodTxParams{
+			CreateGoodParams: CreateGoodParams{
+				Category: category.ID,
+				Model:    "model",
+				Unit:     unit.ID,
+				Amount:   4,
+				GoodDesc: "desc",
+				Sku:      sql.NullString{String: sku, Valid: true},
+			},
+			Warehouse: warehouse.ID,
+		}
+	}
+
+	sku := util.RandomString(10)
+	goods, err := store.ImportGoodsTx(context.Background(), ImportGoodsTxParams{
+		Goods: []CreateGoodTxParams{newGood(sku), newGood(util.RandomString(10))},
+		Actor: actor,
+	})
+	require.NoError(t, err)
+	require.Len(t, goods, 2)
+
+	for _, good := range goods {
+		balances, err := testQueries.ListGoodBalances(context.Background(), good.ID)
+		require.NoError(t, err)
+		require.Len(t, balances, 1)
+		require.Equal(t, int64(4), balances[0].Amount)
+
+		require.Equal(t, actor, lastAuditLog(t, EntityGood, good.ID).Actor)
+	}
+
+	// a sku taken by the first import rolls every good back
+	rolledBack := util.RandomString(10)
+	_, err = store.ImportGoodsTx(context.Background(), ImportGoodsTxParams{
+		Goods: []CreateGoodTxParams{newGood(rolledBack), newGood(sku)},
+		Actor: actor,
+	})
+	require.Error(t, err)
+
+	var rowErr *ImportRowError
+	require.True(t, errors.As(err, &rowErr))
+	require.Equal(t, 1, rowErr.Index)
+
+	_, err = testQueries.GetGoodBySku(context.Background(), sql.NullString{String: rolledBack, Valid: true})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
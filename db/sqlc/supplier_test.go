@@ -0,0 +1,118 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomSupplier(t *testing.T) Supplier {
+	arg := CreateSupplierParams{
+		SupplierName: util.RandomName(),
+		ContactName:  util.RandomName(),
+		Email:        util.RandomEmail(),
+		Phone:        util.RandomString(10),
+		Address:      util.RandomString(12),
+		LeadTimeDays: int32(util.RandomInt(0, 30)),
+		Currency:     util.RandomCurrency(),
+	}
+
+	supplier, err := testQueries.CreateSupplier(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, supplier)
+
+	require.Equal(t, arg.SupplierName, supplier.SupplierName)
+	require.Equal(t, arg.ContactName, supplier.ContactName)
+	require.Equal(t, arg.Email, supplier.Email)
+	require.Equal(t, arg.Phone, supplier.Phone)
+	require.Equal(t, arg.Address, supplier.Address)
+	require.Equal(t, arg.LeadTimeDays, supplier.LeadTimeDays)
+	require.Equal(t, arg.Currency, supplier.Currency)
+	require.NotZero(t, supplier.ID)
+	require.NotZero(t, supplier.CreatedAt)
+
+	return supplier
+}
+
+func TestCreateSupplier(t *testing.T) {
+	createRandomSupplier(t)
+}
+
+func TestCreateSupplierNegativeLeadTime(t *testing.T) {
+	_, err := testQueries.CreateSupplier(context.Background(), CreateSupplierParams{
+		SupplierName: util.RandomName(),
+		LeadTimeDays: -1,
+		Currency:     util.RandomCurrency(),
+	})
+	require.Error(t, err)
+}
+
+func TestGetSupplier(t *testing.T) {
+	supplier1 := createRandomSupplier(t)
+	supplier2, err := testQueries.GetSupplier(context.Background(), supplier1.ID)
+
+	require.NoError(t, err)
+	require.Equal(t, supplier1.ID, supplier2.ID)
+	require.Equal(t, supplier1.SupplierName, supplier2.SupplierName)
+	require.Equal(t, supplier1.Currency, supplier2.Currency)
+	require.WithinDuration(t, supplier1.CreatedAt, supplier2.CreatedAt, time.Second)
+}
+
+func TestListSuppliers(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		createRandomSupplier(t)
+	}
+
+	arg := ListSuppliersParams{
+		Limit:  5,
+		Offset: 5,
+	}
+	suppliers, err := testQueries.ListSuppliers(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Len(t, suppliers, 5)
+
+	for _, supplier := range suppliers {
+		require.NotEmpty(t, supplier)
+	}
+}
+
+func TestUpdateSupplier(t *testing.T) {
+	supplier1 := createRandomSupplier(t)
+
+	arg := UpdateSupplierParams{
+		ID:           supplier1.ID,
+		SupplierName: util.RandomName(),
+		ContactName:  util.RandomName(),
+		Email:        util.RandomEmail(),
+		Phone:        util.RandomString(10),
+		Address:      util.RandomString(12),
+		LeadTimeDays: supplier1.LeadTimeDays + 1,
+		Currency:     util.RandomCurrency(),
+	}
+	supplier2, err := testQueries.UpdateSupplier(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Equal(t, supplier1.ID, supplier2.ID)
+	require.Equal(t, arg.SupplierName, supplier2.SupplierName)
+	require.Equal(t, arg.ContactName, supplier2.ContactName)
+	require.Equal(t, arg.Email, supplier2.Email)
+	require.Equal(t, arg.LeadTimeDays, supplier2.LeadTimeDays)
+	require.Equal(t, arg.Currency, supplier2.Currency)
+}
+
+func TestDeleteSupplier(t *testing.T) {
+	supplier1 := createRandomSupplier(t)
+
+	err := testQueries.DeleteSupplier(context.Background(), supplier1.ID)
+	require.NoError(t, err)
+
+	supplier2, err := testQueries.GetSupplier(context.Background(), supplier1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, supplier2)
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomTransferOrder(t *testing.T, from, to Warehouse) TransferOrder {
+	arg := CreateTransferOrderParams{
+		FromWarehouseID: from.ID,
+		ToWarehouseID:   to.ID,
+		Reference:       util.RandomString(8),
+	}
+
+	transferOrder, err := testQueries.CreateTransferOrder(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.FromWarehouseID, transferOrder.FromWarehouseID)
+	require.Equal(t, arg.ToWarehouseID, transferOrder.ToWarehouseID)
+	require.Equal(t, arg.Reference, transferOrder.Reference)
+	require.Equal(t, TransferOrderStatusDraft, transferOrder.Status)
+	require.NotZero(t, transferOrder.ID)
+	require.NotZero(t, transferOrder.CreatedAt)
+	require.False(t, transferOrder.ShippedAt.Valid)
+	require.False(t, transferOrder.ReceivedAt.Valid)
+
+	return transferOrder
+}
+
+func TestCreateTransferOrder(t *testing.T) {
+	createRandomTransferOrder(t, createRandomWarehouse(t), createRandomWarehouse(t))
+}
+
+func TestCreateTransferOrderSameWarehouse(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+
+	_, err := testQueries.CreateTransferOrder(context.Background(), CreateTransferOrderParams{
+		FromWarehouseID: warehouse.ID,
+		ToWarehouseID:   warehouse.ID,
+	})
+	require.Error(t, err)
+}
+
+func TestListTransferOrders(t *testing.T) {
+	warehouse := createRandomWarehouse(t)
+	outgoing := createRandomTransferOrder(t, warehouse, createRandomWarehouse(t))
+	incoming := createRandomTransferOrder(t, createRandomWarehouse(t), warehouse)
+	createRandomTransferOrder(t, createRandomWarehouse(t), createRandomWarehouse(t))
+
+	transferOrders, err := testQueries.ListTransferOrders(context.Background(), ListTransferOrdersParams{
+		WarehouseID: sql.NullInt64{Int64: warehouse.ID, Valid: true},
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	require.Len(t, transferOrders, 2)
+	require.Equal(t, outgoing.ID, transferOrders[0].ID)
+	require.Equal(t, incoming.ID, transferOrders[1].ID)
+
+	transferOrders, err = testQueries.ListTransferOrders(context.Background(), ListTransferOrdersParams{
+		Status:      sql.NullString{String: TransferOrderStatusInTransit, Valid: true},
+		WarehouseID: sql.NullInt64{Int64: warehouse.ID, Valid: true},
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, transferOrders)
+}
+
+func TestUpdateTransferOrderStatus(t *testing.T) {
+	transferOrder := createRandomTransferOrder(t, createRandomWarehouse(t), createRandomWarehouse(t))
+
+	shipped, err := testQueries.UpdateTransferOrderStatus(context.Background(), UpdateTransferOrderStatusParams{
+		ID:     transferOrder.ID,
+		Status: TransferOrderStatusInTransit,
+	})
+	require.NoError(t, err)
+	require.True(t, shipped.ShippedAt.Valid)
+	require.False(t, shipped.ReceivedAt.Valid)
+
+	received, err := testQueries.UpdateTransferOrderStatus(context.Background(), UpdateTransferOrderStatusParams{
+		ID:     transferOrder.ID,
+		Status: TransferOrderStatusReceived,
+	})
+	require.NoError(t, err)
+	require.Equal(t, shipped.ShippedAt, received.ShippedAt)
+	require.True(t, received.ReceivedAt.Valid)
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+)
+
+// BarcodeEntry is a barcode of a good with the symbology it is printed in
+type BarcodeEntry struct {
+	Barcode   string `json:"barcode"`
+	Symbology string `json:"symbology"`
+}
+
+// createGoodBarcodes labels the good with the barcodes and records each of them in the audit log
+func createGoodBarcodes(ctx context.Context, q *Queries, actor string, goodID int64, barcodes []BarcodeEntry) ([]GoodBarcode, error) {
+	result := []GoodBarcode{}
+
+	for _, barcode := range barcodes {
+		goodBarcode, err := q.CreateGoodBarcode(ctx, CreateGoodBarcodeParams{
+			GoodID:    goodID,
+			Barcode:   barcode.Barcode,
+			Symbology: barcode.Symbology,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = recordAudit(ctx, q, actor, AuditActionCreate, EntityGoodBarcode, goodBarcode.ID, nil, goodBarcode)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, goodBarcode)
+	}
+
+	return result, nil
+}
+
+// CreateGoodBarcodeTxParams contains the input parameters of the create good barcode transaction
+type CreateGoodBarcodeTxParams struct {
+	CreateGoodBarcodeParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateGoodBarcodeTx labels a good with one more barcode and records it in the audit log
+// within a single database transaction. A barcode belongs to one good only.
+func (store *SQLStore) CreateGoodBarcodeTx(ctx context.Context, arg CreateGoodBarcodeTxParams) (GoodBarcode, error) {
+	var result GoodBarcode
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetGood(ctx, arg.GoodID)
+		if err != nil {
+			return err
+		}
+
+		barcodes, err := createGoodBarcodes(ctx, q, arg.Actor, arg.GoodID, []BarcodeEntry{{
+			Barcode:   arg.Barcode,
+			Symbology: arg.Symbology,
+		}})
+		if err != nil {
+			return err
+		}
+
+		result = barcodes[0]
+		return nil
+	})
+
+	return result, err
+}
+
+// DeleteGoodBarcodeTxParams contains the input parameters of the delete good barcode transaction
+type DeleteGoodBarcodeTxParams struct {
+	DeleteGoodBarcodeParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// DeleteGoodBarcodeTx removes a barcode from a good and keeps it in the audit log within a single database transaction.
+// The barcode can be given to another good afterwards.
+func (store *SQLStore) DeleteGoodBarcodeTx(ctx context.Context, arg DeleteGoodBarcodeTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.DeleteGoodBarcode(ctx, arg.DeleteGoodBarcodeParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityGoodBarcode, before.ID, before, nil)
+	})
+}
+
+// SetGoodSkuTxParams contains the input parameters of the set good sku transaction
+type SetGoodSkuTxParams struct {
+	SetGoodSkuParams
+	// version of the good the update is based on
+	Version int64 `json:"version"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// SetGoodSkuTx changes or clears the stock keeping unit of a good and records both versions of the good
+// in the audit log within a single database transaction.
+// ErrVersionMismatch is returned when the good has been changed since the given version.
+func (store *SQLStore) SetGoodSkuTx(ctx context.Context, arg SetGoodSkuTxParams) (Good, error) {
+	var result Good
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetGoodForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Version != arg.Version {
+			return ErrVersionMismatch
+		}
+
+		result, err = q.SetGoodSku(ctx, arg.SetGoodSkuParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityGood, result.ID, before, result)
+	})
+
+	return result, err
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// CreateCategoryTxParams contains the input parameters of the create category transaction
+type CreateCategoryTxParams struct {
+	CreateCategoryParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateCategoryTx creates a category and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateCategoryTx(ctx context.Context, arg CreateCategoryTxParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateCategory(ctx, arg.CreateCategoryParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityCategory, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateCategoryTxParams contains the input parameters of the update category transaction
+type UpdateCategoryTxParams struct {
+	UpdateCategoryParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateCategoryTx updates a category and records both versions of it in the audit log within a single database transaction.
+// ErrVersionMismatch is returned when the category has been changed since the given version.
+func (store *SQLStore) UpdateCategoryTx(ctx context.Context, arg UpdateCategoryTxParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetCategory(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Version != arg.Version {
+			return ErrVersionMismatch
+		}
+
+		result, err = q.UpdateCategory(ctx, arg.UpdateCategoryParams)
+		if err == sql.ErrNoRows {
+			// a concurrent transaction changed the version since it was read
+			return ErrVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityCategory, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteCategoryTx marks a category as deleted and records it in the audit log within a single database transaction.
+func (store *SQLStore) DeleteCategoryTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetCategory(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		after, err := q.DeleteCategory(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityCategory, arg.ID, before, after)
+	})
+}
+
+// RestoreCategoryTx brings back a deleted category and records it in the audit log within a single database transaction.
+func (store *SQLStore) RestoreCategoryTx(ctx context.Context, arg RestoreTxParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetCategoryIncludingDeleted(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.RestoreCategory(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionRestore, EntityCategory, arg.ID, before, result)
+	})
+
+	return result, err
+}
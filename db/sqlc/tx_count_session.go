@@ -0,0 +1,355 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Statuses of a count session, counts are entered while a session is open
+const (
+	CountSessionStatusOpen      = "open"
+	CountSessionStatusApproved  = "approved"
+	CountSessionStatusCancelled = "cancelled"
+)
+
+// ErrCountSessionStatus is returned when the status of a count session does not allow the change
+var ErrCountSessionStatus = errors.New("count session status does not allow this")
+
+// ErrCountScope is returned when a count session is not scoped to exactly one category, section or location
+var ErrCountScope = errors.New("count session must count one category, section or location")
+
+// ErrInvalidCountItem is returned when a count names an item that is not part of the session
+var ErrInvalidCountItem = errors.New("item is not part of the count session")
+
+// ErrCountIncomplete is returned when a count session with uncounted items is approved
+var ErrCountIncomplete = errors.New("count session has uncounted items")
+
+// CountSessionTxResult is the result of the count session transactions
+type CountSessionTxResult struct {
+	CountSession CountSession       `json:"count_session"`
+	Items        []CountSessionItem `json:"items"`
+}
+
+// StartCountSessionTxParams contains the input parameters of the start count session transaction
+type StartCountSessionTxParams struct {
+	CreateCountSessionParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// StartCountSessionTx opens a count session and freezes the stock it counts as the snapshot of its items
+// within a single database transaction. Sessions of a category or a section count every bin of the warehouse
+// holding their goods and the stock outside the bins, sessions of a location count the bins below it.
+func (store *SQLStore) StartCountSessionTx(ctx context.Context, arg StartCountSessionTxParams) (CountSessionTxResult, error) {
+	var result CountSessionTxResult
+
+	scopes := 0
+	for _, valid := range []bool{arg.CategoryID.Valid, arg.SectionName.Valid, arg.LocationID.Valid} {
+		if valid {
+			scopes++
+		}
+	}
+	if scopes != 1 {
+		return result, ErrCountScope
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetWarehouse(ctx, arg.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		var snapshot []CreateCountSessionItemParams
+		if arg.LocationID.Valid {
+			location, err := q.GetLocation(ctx, arg.LocationID.Int64)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return ErrInvalidLocation
+				}
+				return err
+			}
+			if location.WarehouseID != arg.WarehouseID {
+				return ErrInvalidLocation
+			}
+
+			contents, err := q.ListLocationContents(ctx, ListLocationContentsParams{ID: location.ID})
+			if err != nil {
+				return err
+			}
+			for _, content := range contents {
+				snapshot = append(snapshot, CreateCountSessionItemParams{
+					GoodID:     content.GoodID,
+					LocationID: sql.NullInt64{Int64: content.LocationID, Valid: true},
+					Snapshot:   content.Amount,
+				})
+			}
+		} else {
+			if arg.CategoryID.Valid {
+				_, err = q.GetCategory(ctx, arg.CategoryID.Int64)
+				if err != nil {
+					return err
+				}
+			}
+
+			balances, err := q.ListCountSnapshot(ctx, ListCountSnapshotParams{
+				WarehouseID: arg.WarehouseID,
+				CategoryID:  arg.CategoryID,
+				SectionName: arg.SectionName,
+			})
+			if err != nil {
+				return err
+			}
+			for _, balance := range balances {
+				snapshot = append(snapshot, CreateCountSessionItemParams{
+					GoodID:   balance.GoodID,
+					Snapshot: balance.Amount,
+				})
+			}
+
+			// binned stock is counted bin by bin, so that its variances are posted to the bins holding it
+			bins, err := q.ListCountBinSnapshot(ctx, ListCountBinSnapshotParams{
+				WarehouseID: arg.WarehouseID,
+				CategoryID:  arg.CategoryID,
+				SectionName: arg.SectionName,
+			})
+			if err != nil {
+				return err
+			}
+			for _, bin := range bins {
+				snapshot = append(snapshot, CreateCountSessionItemParams{
+					GoodID:     bin.GoodID,
+					LocationID: sql.NullInt64{Int64: bin.LocationID, Valid: true},
+					Snapshot:   bin.Amount,
+				})
+			}
+			sort.SliceStable(snapshot, func(i, j int) bool {
+				return snapshot[i].GoodID < snapshot[j].GoodID
+			})
+		}
+
+		result.CountSession, err = q.CreateCountSession(ctx, arg.CreateCountSessionParams)
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityCountSession, result.CountSession.ID, nil, result.CountSession)
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]CountSessionItem, len(snapshot))
+		for i, itemArg := range snapshot {
+			itemArg.CountSessionID = result.CountSession.ID
+			result.Items[i], err = q.CreateCountSessionItem(ctx, itemArg)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// CountEntry is the quantity counted for an item of a count session
+type CountEntry struct {
+	ItemID int64 `json:"item_id"`
+	// in the unit of the good
+	Counted int64 `json:"counted"`
+}
+
+// RecordCountsTxParams contains the input parameters of the record counts transaction
+type RecordCountsTxParams struct {
+	ID     int64        `json:"id"`
+	Counts []CountEntry `json:"counts"`
+	// username of the user making the change, recorded in the audit log and as the counter of the items
+	Actor string `json:"actor"`
+}
+
+// RecordCountsTx enters counted quantities for items of an open count session within a single database transaction.
+// Counting an item again replaces its earlier count.
+func (store *SQLStore) RecordCountsTx(ctx context.Context, arg RecordCountsTxParams) (CountSessionTxResult, error) {
+	var result CountSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.CountSession, err = q.GetCountSessionForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if result.CountSession.Status != CountSessionStatusOpen {
+			return fmt.Errorf("%w: the session is %s", ErrCountSessionStatus, result.CountSession.Status)
+		}
+
+		items, err := q.ListCountSessionItems(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int64]CountSessionItem, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+
+		for _, count := range arg.Counts {
+			before, ok := byID[count.ItemID]
+			if !ok {
+				return fmt.Errorf("%w: item %d", ErrInvalidCountItem, count.ItemID)
+			}
+
+			item, err := q.SetCountSessionItemCounted(ctx, SetCountSessionItemCountedParams{
+				ID:        count.ItemID,
+				Counted:   count.Counted,
+				CountedBy: arg.Actor,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityCountSessionItem, item.ID, before, item)
+			if err != nil {
+				return err
+			}
+			byID[item.ID] = item
+		}
+
+		result.Items = make([]CountSessionItem, len(items))
+		for i, item := range items {
+			result.Items[i] = byID[item.ID]
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// CountSessionStatusTxParams contains the input parameters of the transactions closing a count session
+type CountSessionStatusTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// ApproveCountSessionTxResult is the result of the approve count session transaction
+type ApproveCountSessionTxResult struct {
+	CountSessionTxResult
+	Movements []StockMovement `json:"movements"`
+}
+
+// ApproveCountSessionTx posts the variance of every counted item against its snapshot as an adjustment
+// and closes the count session within a single database transaction. All items must be counted.
+// Variances of serialized goods are rejected, they are corrected with stock movements naming the serials.
+func (store *SQLStore) ApproveCountSessionTx(ctx context.Context, arg CountSessionStatusTxParams) (ApproveCountSessionTxResult, error) {
+	var result ApproveCountSessionTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetCountSessionForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != CountSessionStatusOpen {
+			return fmt.Errorf("%w: the session is %s", ErrCountSessionStatus, before.Status)
+		}
+
+		result.Items, err = q.ListCountSessionItems(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		uncounted := 0
+		for _, item := range result.Items {
+			if !item.Counted.Valid {
+				uncounted++
+			}
+		}
+		if uncounted > 0 {
+			return fmt.Errorf("%w: %d of %d are not counted", ErrCountIncomplete, uncounted, len(result.Items))
+		}
+
+		// goods are locked in the order of their id
+		items := append([]CountSessionItem(nil), result.Items...)
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].GoodID < items[j].GoodID
+		})
+
+		result.Movements = []StockMovement{}
+		for _, item := range items {
+			variance := item.Counted.Int64 - item.Snapshot
+			if variance == 0 {
+				continue
+			}
+
+			// the count is posted even when the good has been deleted since the session started
+			good, err := q.GetGoodIncludingDeletedForUpdate(ctx, item.GoodID)
+			if err != nil {
+				return err
+			}
+
+			// a count does not tell which units are missing or found
+			err = checkNotSerialized(good)
+			if err != nil {
+				return err
+			}
+
+			moved, err := moveStock(ctx, q, good, StockMovementTxParams{
+				GoodID:       good.ID,
+				WarehouseID:  before.WarehouseID,
+				LocationID:   item.LocationID,
+				MovementType: MovementTypeAdjustment,
+				Amount:       variance,
+				Actor:        arg.Actor,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityStockMovement, moved.Movement.ID, nil, moved.Movement)
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, moved.Movement)
+		}
+
+		result.CountSession, err = q.UpdateCountSessionStatus(ctx, UpdateCountSessionStatusParams{
+			ID:     arg.ID,
+			Status: CountSessionStatusApproved,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityCountSession, arg.ID, before, result.CountSession)
+	})
+
+	return result, err
+}
+
+// CancelCountSessionTx closes an open count session without posting its counts within a single database transaction.
+func (store *SQLStore) CancelCountSessionTx(ctx context.Context, arg CountSessionStatusTxParams) (CountSession, error) {
+	var result CountSession
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetCountSessionForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != CountSessionStatusOpen {
+			return fmt.Errorf("%w: the session is %s", ErrCountSessionStatus, before.Status)
+		}
+
+		result, err = q.UpdateCountSessionStatus(ctx, UpdateCountSessionStatusParams{
+			ID:     arg.ID,
+			Status: CountSessionStatusCancelled,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityCountSession, result.ID, before, result)
+	})
+
+	return result, err
+}
@@ -0,0 +1,316 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// CreateGoodTxParams contains the input parameters of the create good transaction
+type CreateGoodTxParams struct {
+	CreateGoodParams
+	Warehouse int64 `json:"warehouse"`
+	// optional unit the amount is given in, zero for the unit of the good
+	AmountUnit int64 `json:"amount_unit"`
+	// serial numbers of the initial units of a serialized good
+	Serials []string `json:"serials"`
+	// optional cost of one unit of the initial amount as given, in minor units of the inventory currency
+	UnitCost sql.NullInt64 `json:"unit_cost"`
+	// barcodes the good is labelled with
+	Barcodes []BarcodeEntry `json:"barcodes"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateGoodTx creates a good with its barcodes and books its initial amount as a receipt into the given warehouse.
+// The good is recorded in the audit log once its amount is booked.
+func (store *SQLStore) CreateGoodTx(ctx context.Context, arg CreateGoodTxParams) (Good, error) {
+	var result Good
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = insertGood(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+// insertGood creates a good with its barcodes, books its initial amount and records it in the audit log
+func insertGood(ctx context.Context, q *Queries, arg CreateGoodTxParams) (Good, error) {
+	// the good starts empty, the initial amount is booked through the ledger
+	initialAmount := arg.Amount
+	arg.CreateGoodParams.Amount = 0
+
+	var value sql.NullInt64
+	if arg.UnitCost.Valid {
+		total, err := MultiplyUnitCost(arg.UnitCost.Int64, initialAmount)
+		if err != nil {
+			return Good{}, err
+		}
+		value = sql.NullInt64{Int64: total, Valid: true}
+	}
+
+	// deleted categories and units still satisfy the foreign keys
+	_, err := q.GetCategory(ctx, arg.Category)
+	if err != nil {
+		return Good{}, err
+	}
+
+	_, err = q.GetUnit(ctx, arg.Unit)
+	if err != nil {
+		return Good{}, err
+	}
+
+	good, err := q.CreateGood(ctx, arg.CreateGoodParams)
+	if err != nil {
+		return Good{}, err
+	}
+
+	_, err = createGoodBarcodes(ctx, q, arg.Actor, good.ID, arg.Barcodes)
+	if err != nil {
+		return Good{}, err
+	}
+
+	initialAmount, err = toGoodUnit(ctx, q, good, arg.AmountUnit, initialAmount)
+	if err != nil {
+		return Good{}, err
+	}
+
+	movement, err := moveStock(ctx, q, good, StockMovementTxParams{
+		GoodID:       good.ID,
+		WarehouseID:  arg.Warehouse,
+		MovementType: MovementTypeReceipt,
+		Amount:       initialAmount,
+		Value:        value,
+		Serials:      arg.Serials,
+	})
+	if err != nil {
+		return Good{}, err
+	}
+
+	err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityGood, movement.Good.ID, nil, movement.Good)
+	return movement.Good, err
+}
+
+// ImportRowError tells which good of an import stopped it
+type ImportRowError struct {
+	// Index of the good in the import, starting at zero
+	Index int
+	Err   error
+}
+
+func (e *ImportRowError) Error() string {
+	return fmt.Sprintf("good %d of the import: %v", e.Index, e.Err)
+}
+
+func (e *ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportGoodsTxParams contains the input parameters of the import goods transaction
+type ImportGoodsTxParams struct {
+	Goods []CreateGoodTxParams `json:"goods"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// ImportGoodsTx creates the goods like CreateGoodTx within a single database transaction,
+// so either all of them are created or none. The error of the good that stopped the import
+// is returned as an ImportRowError.
+func (store *SQLStore) ImportGoodsTx(ctx context.Context, arg ImportGoodsTxParams) ([]Good, error) {
+	var result []Good
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		result = make([]Good, len(arg.Goods))
+		for i, good := range arg.Goods {
+			good.Actor = arg.Actor
+
+			var err error
+			result[i], err = insertGood(ctx, q, good)
+			if err != nil {
+				return &ImportRowError{Index: i, Err: err}
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// UpdateGoodTxParams contains the input parameters of the update good transaction
+type UpdateGoodTxParams struct {
+	ID        int64 `json:"id"`
+	Unit      int64 `json:"unit"`
+	Warehouse int64 `json:"warehouse"`
+	// new balance of the good in the warehouse
+	Amount int64 `json:"amount"`
+	// optional unit the amount is given in, zero for the unit of the good
+	AmountUnit int64 `json:"amount_unit"`
+	// serial numbers of the units added or removed by the adjustment of a serialized good
+	Serials []string `json:"serials"`
+	// version of the good the update is based on
+	Version int64 `json:"version"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateGoodTx changes the unit of a good and sets its balance in the given warehouse.
+// The difference to the current balance is recorded as an adjustment in the ledger.
+// Stock is kept in the unit of the good, so it can only be switched to an equivalent unit.
+// Both versions of the good are recorded in the audit log.
+// ErrVersionMismatch is returned when the good has been changed since the given version.
+func (store *SQLStore) UpdateGoodTx(ctx context.Context, arg UpdateGoodTxParams) (Good, error) {
+	var result Good
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		good, err := q.GetGoodForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		// the row stays locked until the end of the transaction, so the version cannot change anymore
+		if good.Version != arg.Version {
+			return ErrVersionMismatch
+		}
+		before := good
+
+		if arg.Unit != good.Unit {
+			err = checkEquivalentUnits(ctx, q, good.Unit, arg.Unit)
+			if err != nil {
+				return err
+			}
+		}
+
+		arg.Amount, err = toGoodUnit(ctx, q, good, arg.AmountUnit, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		balance, err := q.GetGoodBalance(ctx, GetGoodBalanceParams{
+			GoodID:      arg.ID,
+			WarehouseID: arg.Warehouse,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if delta := arg.Amount - balance.Amount; delta != 0 || len(arg.Serials) > 0 {
+			movement, err := moveStock(ctx, q, good, StockMovementTxParams{
+				GoodID:       arg.ID,
+				WarehouseID:  arg.Warehouse,
+				MovementType: MovementTypeAdjustment,
+				Amount:       delta,
+				Serials:      arg.Serials,
+			})
+			if err != nil {
+				return err
+			}
+			good = movement.Good
+		}
+
+		result, err = q.UpdateGood(ctx, UpdateGoodParams{
+			ID:     arg.ID,
+			Unit:   arg.Unit,
+			Amount: good.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityGood, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// checkEquivalentUnits makes sure that amounts kept in the current unit keep their meaning in the next one.
+func checkEquivalentUnits(ctx context.Context, q *Queries, currentID, nextID int64) error {
+	current, err := q.GetUnitIncludingDeleted(ctx, currentID)
+	if err != nil {
+		return err
+	}
+
+	next, err := q.GetUnit(ctx, nextID)
+	if err != nil {
+		return err
+	}
+
+	if current.UnitFamily != next.UnitFamily || current.UnitValue != next.UnitValue {
+		return fmt.Errorf("%w: the stock of the good is kept in %s and cannot be switched to %s",
+			ErrIncompatibleUnits, current.UnitName, next.UnitName)
+	}
+	return nil
+}
+
+// SetGoodStockLevelsTxParams contains the input parameters of the set good stock levels transaction
+type SetGoodStockLevelsTxParams struct {
+	SetGoodStockLevelsParams
+	// version of the good the update is based on
+	Version int64 `json:"version"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// SetGoodStockLevelsTx changes the reorder point, safety stock and max level of a good and records
+// both versions of the good in the audit log within a single database transaction.
+// ErrVersionMismatch is returned when the good has been changed since the given version.
+func (store *SQLStore) SetGoodStockLevelsTx(ctx context.Context, arg SetGoodStockLevelsTxParams) (Good, error) {
+	var result Good
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetGoodForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Version != arg.Version {
+			return ErrVersionMismatch
+		}
+
+		result, err = q.SetGoodStockLevels(ctx, arg.SetGoodStockLevelsParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityGood, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteGoodTx marks a good as deleted and records it in the audit log within a single database transaction.
+func (store *SQLStore) DeleteGoodTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetGoodForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		after, err := q.DeleteGood(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityGood, arg.ID, before, after)
+	})
+}
+
+// RestoreGoodTx brings back a deleted good and records it in the audit log within a single database transaction.
+func (store *SQLStore) RestoreGoodTx(ctx context.Context, arg RestoreTxParams) (Good, error) {
+	var result Good
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetGoodIncludingDeleted(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.RestoreGood(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionRestore, EntityGood, arg.ID, before, result)
+	})
+
+	return result, err
+}
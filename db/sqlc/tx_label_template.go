@@ -0,0 +1,73 @@
+package db
+
+import "context"
+
+// CreateLabelTemplateTxParams contains the input parameters of the create label template transaction
+type CreateLabelTemplateTxParams struct {
+	CreateLabelTemplateParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateLabelTemplateTx creates a label template and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateLabelTemplateTx(ctx context.Context, arg CreateLabelTemplateTxParams) (LabelTemplate, error) {
+	var result LabelTemplate
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateLabelTemplate(ctx, arg.CreateLabelTemplateParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityLabelTemplate, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateLabelTemplateTxParams contains the input parameters of the update label template transaction
+type UpdateLabelTemplateTxParams struct {
+	UpdateLabelTemplateParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateLabelTemplateTx updates a label template and records both versions of it in the audit log within a single database transaction.
+func (store *SQLStore) UpdateLabelTemplateTx(ctx context.Context, arg UpdateLabelTemplateTxParams) (LabelTemplate, error) {
+	var result LabelTemplate
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetLabelTemplate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateLabelTemplate(ctx, arg.UpdateLabelTemplateParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityLabelTemplate, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteLabelTemplateTx deletes a label template and keeps its last version in the audit log within a single database transaction.
+func (store *SQLStore) DeleteLabelTemplateTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetLabelTemplate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteLabelTemplate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityLabelTemplate, arg.ID, before, nil)
+	})
+}
@@ -0,0 +1,73 @@
+package db
+
+import "context"
+
+// CreateLocationTxParams contains the input parameters of the create location transaction
+type CreateLocationTxParams struct {
+	CreateLocationParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateLocationTx creates a location and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateLocationTx(ctx context.Context, arg CreateLocationTxParams) (Location, error) {
+	var result Location
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateLocation(ctx, arg.CreateLocationParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityLocation, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateLocationTxParams contains the input parameters of the update location transaction
+type UpdateLocationTxParams struct {
+	UpdateLocationParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateLocationTx updates a location and records both versions of it in the audit log within a single database transaction.
+func (store *SQLStore) UpdateLocationTx(ctx context.Context, arg UpdateLocationTxParams) (Location, error) {
+	var result Location
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetLocation(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateLocation(ctx, arg.UpdateLocationParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityLocation, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteLocationTx deletes a location and keeps its last version in the audit log within a single database transaction.
+func (store *SQLStore) DeleteLocationTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetLocation(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteLocation(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityLocation, arg.ID, before, nil)
+	})
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidLot is returned when a movement names a lot that is not a lot of its good
+var ErrInvalidLot = errors.New("lot is not a lot of the good")
+
+// LotAmount is a part of a movement booked into a lot
+type LotAmount struct {
+	LotID int64 `json:"lot_id"`
+	// in the unit of the good
+	Amount int64 `json:"amount"`
+}
+
+// CreateLotTxParams contains the input parameters of the create lot transaction
+type CreateLotTxParams struct {
+	CreateLotParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateLotTx creates a lot of a good and records it in the audit log within a single database transaction.
+// The lot starts empty, stock is booked into it by receipts naming it.
+func (store *SQLStore) CreateLotTx(ctx context.Context, arg CreateLotTxParams) (Lot, error) {
+	var result Lot
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetGood(ctx, arg.GoodID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateLot(ctx, arg.CreateLotParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityLot, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// moveLots books a movement against the lots of its good in its warehouse. A movement naming a lot
+// is booked against that lot only, an increase naming several lots is split between them. Other decreases
+// take the lots first expired first out and the stock kept outside of lots last, so the lots never hold
+// more than the balance. Issues and transfers leave expired lots alone, adjustments record what is really there.
+// The balance is the one before the movement.
+func moveLots(ctx context.Context, q *Queries, arg StockMovementTxParams, balance GoodBalance, movementID int64) ([]LotMovement, error) {
+	lots := []LotMovement{}
+
+	book := func(lotID, amount int64) error {
+		_, err := q.AddLotStock(ctx, AddLotStockParams{
+			LotID:       lotID,
+			WarehouseID: arg.WarehouseID,
+			Amount:      amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		lotMovement, err := q.CreateLotMovement(ctx, CreateLotMovementParams{
+			StockMovementID: movementID,
+			LotID:           lotID,
+			Amount:          amount,
+		})
+		if err != nil {
+			return err
+		}
+		lots = append(lots, lotMovement)
+		return nil
+	}
+
+	if arg.LotID.Valid {
+		lot, err := q.GetLot(ctx, arg.LotID.Int64)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return nil, ErrInvalidLot
+			}
+			return nil, err
+		}
+		if lot.GoodID != arg.GoodID {
+			return nil, ErrInvalidLot
+		}
+
+		lotStock, err := q.GetLotStock(ctx, GetLotStockParams{
+			LotID:       lot.ID,
+			WarehouseID: arg.WarehouseID,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		if lotStock.Amount+arg.Amount < 0 {
+			return nil, fmt.Errorf("%w: lot %s holds %d in the warehouse", ErrInsufficientStock, lot.LotNumber, lotStock.Amount)
+		}
+
+		return lots, book(lot.ID, arg.Amount)
+	}
+
+	if len(arg.IntoLots) > 0 {
+		remaining := arg.Amount
+		for _, part := range arg.IntoLots {
+			lot, err := q.GetLot(ctx, part.LotID)
+			if err != nil {
+				if err == sql.ErrNoRows {
+					return nil, ErrInvalidLot
+				}
+				return nil, err
+			}
+			if lot.GoodID != arg.GoodID || part.Amount <= 0 || part.Amount > remaining {
+				return nil, ErrInvalidLot
+			}
+
+			err = book(lot.ID, part.Amount)
+			if err != nil {
+				return nil, err
+			}
+			remaining -= part.Amount
+		}
+		return lots, nil
+	}
+
+	if arg.Amount >= 0 {
+		return lots, nil
+	}
+
+	lotted, err := q.SumLotStocks(ctx, SumLotStocksParams{
+		GoodID:      arg.GoodID,
+		WarehouseID: arg.WarehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	issuable, err := q.ListIssuableLotStocks(ctx, ListIssuableLotStocksParams{
+		GoodID:         arg.GoodID,
+		WarehouseID:    arg.WarehouseID,
+		IncludeExpired: arg.MovementType != MovementTypeIssue && arg.MovementType != MovementTypeTransferOut,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	remaining := -arg.Amount
+	for _, lotStock := range issuable {
+		if remaining == 0 {
+			break
+		}
+
+		taken := remaining
+		if lotStock.Amount < taken {
+			taken = lotStock.Amount
+		}
+		err = book(lotStock.LotID, -taken)
+		if err != nil {
+			return nil, err
+		}
+		remaining -= taken
+	}
+
+	if unlotted := balance.Amount - lotted; remaining > unlotted {
+		return nil, fmt.Errorf("%w: %d of the good are in expired lots", ErrInsufficientStock, lotted-issuableTotal(issuable))
+	}
+	return lots, nil
+}
+
+func issuableTotal(lotStocks []ListIssuableLotStocksRow) int64 {
+	var total int64
+	for _, lotStock := range lotStocks {
+		total += lotStock.Amount
+	}
+	return total
+}
@@ -0,0 +1,368 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Statuses of a purchase order, lines can only be added to drafts and only sent orders can be received
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusClosed            = "closed"
+)
+
+// ErrPurchaseOrderStatus is returned when the status of a purchase order does not allow the change
+var ErrPurchaseOrderStatus = errors.New("purchase order status does not allow this")
+
+// ErrInvalidPurchaseOrderLine is returned when a receipt names a line that is not part of the purchase order
+var ErrInvalidPurchaseOrderLine = errors.New("line is not part of the purchase order")
+
+// PurchaseOrderTxResult is the result of the purchase order transactions
+type PurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder       `json:"purchase_order"`
+	Lines         []PurchaseOrderLine `json:"lines"`
+}
+
+// CreatePurchaseOrderTxParams contains the input parameters of the create purchase order transaction
+type CreatePurchaseOrderTxParams struct {
+	CreatePurchaseOrderParams
+	// lines of the order, their purchase order id is filled in by the transaction
+	Lines []CreatePurchaseOrderLineParams `json:"lines"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreatePurchaseOrderTx creates a draft purchase order with its lines and records them in the audit log
+// within a single database transaction.
+func (store *SQLStore) CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetWarehouse(ctx, arg.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		result.PurchaseOrder, err = q.CreatePurchaseOrder(ctx, arg.CreatePurchaseOrderParams)
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityPurchaseOrder, result.PurchaseOrder.ID, nil, result.PurchaseOrder)
+		if err != nil {
+			return err
+		}
+
+		result.Lines = make([]PurchaseOrderLine, 0, len(arg.Lines))
+		for _, lineArg := range arg.Lines {
+			lineArg.PurchaseOrderID = result.PurchaseOrder.ID
+
+			line, err := addPurchaseOrderLine(ctx, q, lineArg, arg.Actor)
+			if err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, line)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// AddPurchaseOrderLineTxParams contains the input parameters of the add purchase order line transaction
+type AddPurchaseOrderLineTxParams struct {
+	CreatePurchaseOrderLineParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// AddPurchaseOrderLineTx adds a line to a draft purchase order within a single database transaction.
+func (store *SQLStore) AddPurchaseOrderLineTx(ctx context.Context, arg AddPurchaseOrderLineTxParams) (PurchaseOrderLine, error) {
+	var result PurchaseOrderLine
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		purchaseOrder, err := q.GetPurchaseOrderForUpdate(ctx, arg.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+		if purchaseOrder.Status != PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: lines cannot be added to a %s order", ErrPurchaseOrderStatus, purchaseOrder.Status)
+		}
+
+		result, err = addPurchaseOrderLine(ctx, q, arg.CreatePurchaseOrderLineParams, arg.Actor)
+		return err
+	})
+
+	return result, err
+}
+
+// addPurchaseOrderLine creates a line ordering a good in the unit of the line, zero for the unit of the good.
+// The ordered amount must convert into a whole amount of the unit of the good so it can be received.
+func addPurchaseOrderLine(ctx context.Context, q *Queries, arg CreatePurchaseOrderLineParams, actor string) (PurchaseOrderLine, error) {
+	good, err := q.GetGood(ctx, arg.GoodID)
+	if err != nil {
+		return PurchaseOrderLine{}, err
+	}
+
+	if arg.UnitID == 0 {
+		arg.UnitID = good.Unit
+	}
+	_, err = toGoodUnit(ctx, q, good, arg.UnitID, arg.Ordered)
+	if err != nil {
+		return PurchaseOrderLine{}, err
+	}
+
+	line, err := q.CreatePurchaseOrderLine(ctx, arg)
+	if err != nil {
+		return line, err
+	}
+
+	err = recordAudit(ctx, q, actor, AuditActionCreate, EntityPurchaseOrderLine, line.ID, nil, line)
+	return line, err
+}
+
+// PurchaseOrderStatusTxParams contains the input parameters of the transactions changing the status of a purchase order
+type PurchaseOrderStatusTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// SendPurchaseOrderTx marks a draft purchase order with at least one line as sent within a single database transaction.
+func (store *SQLStore) SendPurchaseOrderTx(ctx context.Context, arg PurchaseOrderStatusTxParams) (PurchaseOrder, error) {
+	var result PurchaseOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetPurchaseOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != PurchaseOrderStatusDraft {
+			return fmt.Errorf("%w: a %s order cannot be sent", ErrPurchaseOrderStatus, before.Status)
+		}
+
+		// nothing has been received on a draft, so every line is open
+		openLines, err := q.CountOpenPurchaseOrderLines(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if openLines == 0 {
+			return fmt.Errorf("%w: an order without lines cannot be sent", ErrPurchaseOrderStatus)
+		}
+
+		result, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+			ID:     arg.ID,
+			Status: PurchaseOrderStatusSent,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityPurchaseOrder, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// ClosePurchaseOrderTx closes a purchase order that is not expected to be delivered in full within a single
+// database transaction. The lines keep their received amounts, so the under-delivery stays visible.
+func (store *SQLStore) ClosePurchaseOrderTx(ctx context.Context, arg PurchaseOrderStatusTxParams) (PurchaseOrder, error) {
+	var result PurchaseOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetPurchaseOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status == PurchaseOrderStatusClosed {
+			return fmt.Errorf("%w: the order is already closed", ErrPurchaseOrderStatus)
+		}
+
+		result, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+			ID:     arg.ID,
+			Status: PurchaseOrderStatusClosed,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityPurchaseOrder, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// PurchaseOrderReceipt is the amount of one line delivered by the supplier
+type PurchaseOrderReceipt struct {
+	LineID int64 `json:"line_id"`
+	// in the unit of the line
+	Amount int64 `json:"amount"`
+	// optional bin the goods are put into
+	LocationID sql.NullInt64 `json:"location_id"`
+	// serial numbers of the units received, one per unit of the good for serialized goods and none for others
+	Serials []string `json:"serials"`
+	// optional cost of one unit of the line in minor units of the inventory currency, values the receipt
+	// in place of the price of the line
+	UnitCost sql.NullInt64 `json:"unit_cost"`
+}
+
+// ReceivePurchaseOrderTxParams contains the input parameters of the receive purchase order transaction
+type ReceivePurchaseOrderTxParams struct {
+	ID       int64                  `json:"id"`
+	Receipts []PurchaseOrderReceipt `json:"receipts"`
+	// currency the stock is valued in, prices in other currencies do not value the receipts
+	Currency string `json:"currency"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// ReceivePurchaseOrderTxResult is the result of the receive purchase order transaction
+type ReceivePurchaseOrderTxResult struct {
+	PurchaseOrderTxResult
+	Movements []StockMovement `json:"movements"`
+}
+
+// ReceivePurchaseOrderTx books the receipts of a sent purchase order into its warehouse within a single database transaction.
+// Every receipt is converted into the unit of its good and posted as a stock movement, lines may receive more than ordered.
+// The order is closed once every line has been received in full and is partially received until then.
+// Receipts are valued at their unit cost, or at the prices of their lines when the supplier is paid in the inventory
+// currency. Receipts from suppliers paid in other currencies without a unit cost are valued at the average cost of the good.
+func (store *SQLStore) ReceivePurchaseOrderTx(ctx context.Context, arg ReceivePurchaseOrderTxParams) (ReceivePurchaseOrderTxResult, error) {
+	var result ReceivePurchaseOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// the row lock of the order serializes the receipts of its lines
+		before, err := q.GetPurchaseOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != PurchaseOrderStatusSent && before.Status != PurchaseOrderStatusPartiallyReceived {
+			return fmt.Errorf("%w: a %s order cannot be received", ErrPurchaseOrderStatus, before.Status)
+		}
+
+		// the prices are in the currency the supplier is paid in now
+		supplier, err := q.GetSupplier(ctx, before.SupplierID)
+		if err != nil {
+			return err
+		}
+
+		lines := make(map[int64]PurchaseOrderLine, len(arg.Receipts))
+		for _, receipt := range arg.Receipts {
+			if _, ok := lines[receipt.LineID]; ok {
+				return fmt.Errorf("%w: line %d is received twice", ErrInvalidPurchaseOrderLine, receipt.LineID)
+			}
+
+			line, err := q.GetPurchaseOrderLine(ctx, receipt.LineID)
+			if err == sql.ErrNoRows || (err == nil && line.PurchaseOrderID != before.ID) {
+				return fmt.Errorf("%w: line %d", ErrInvalidPurchaseOrderLine, receipt.LineID)
+			}
+			if err != nil {
+				return err
+			}
+			lines[line.ID] = line
+		}
+
+		// goods are locked in the order of their id, so receipts of different orders cannot deadlock
+		receipts := append([]PurchaseOrderReceipt(nil), arg.Receipts...)
+		sort.SliceStable(receipts, func(i, j int) bool {
+			return lines[receipts[i].LineID].GoodID < lines[receipts[j].LineID].GoodID
+		})
+
+		result.Movements = make([]StockMovement, 0, len(receipts))
+		for _, receipt := range receipts {
+			line := lines[receipt.LineID]
+
+			good, err := q.GetGoodForUpdate(ctx, line.GoodID)
+			if err != nil {
+				return err
+			}
+
+			amount, err := toGoodUnit(ctx, q, good, line.UnitID, receipt.Amount)
+			if err != nil {
+				return err
+			}
+
+			unitCost := receipt.UnitCost
+			if !unitCost.Valid && supplier.Currency == arg.Currency {
+				unitCost = sql.NullInt64{Int64: line.UnitPrice, Valid: true}
+			}
+			var value sql.NullInt64
+			if unitCost.Valid {
+				value.Int64, err = MultiplyUnitCost(unitCost.Int64, receipt.Amount)
+				if err != nil {
+					return err
+				}
+				value.Valid = true
+			}
+
+			moved, err := moveStock(ctx, q, good, StockMovementTxParams{
+				GoodID:       good.ID,
+				WarehouseID:  before.WarehouseID,
+				LocationID:   receipt.LocationID,
+				MovementType: MovementTypeReceipt,
+				Amount:       amount,
+				Serials:      receipt.Serials,
+				Value:        value,
+				Actor:        arg.Actor,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityStockMovement, moved.Movement.ID, nil, moved.Movement)
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, moved.Movement)
+
+			updated, err := q.AddPurchaseOrderLineReceived(ctx, AddPurchaseOrderLineReceivedParams{
+				ID:     line.ID,
+				Amount: receipt.Amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityPurchaseOrderLine, line.ID, line, updated)
+			if err != nil {
+				return err
+			}
+		}
+
+		openLines, err := q.CountOpenPurchaseOrderLines(ctx, before.ID)
+		if err != nil {
+			return err
+		}
+
+		status := PurchaseOrderStatusPartiallyReceived
+		if openLines == 0 {
+			status = PurchaseOrderStatusClosed
+		}
+
+		result.PurchaseOrder = before
+		if status != before.Status {
+			result.PurchaseOrder, err = q.UpdatePurchaseOrderStatus(ctx, UpdatePurchaseOrderStatusParams{
+				ID:     before.ID,
+				Status: status,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityPurchaseOrder, before.ID, before, result.PurchaseOrder)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Lines, err = q.ListPurchaseOrderLines(ctx, before.ID)
+		return err
+	})
+
+	return result, err
+}
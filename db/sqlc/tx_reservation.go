@@ -0,0 +1,216 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Statuses of a reservation, only active reservations hold stock
+const (
+	ReservationStatusActive   = "active"
+	ReservationStatusReleased = "released"
+	ReservationStatusExpired  = "expired"
+)
+
+// ErrReservationClosed is returned when a reservation that is not active anymore is released
+var ErrReservationClosed = errors.New("reservation is not active")
+
+// CreateReservationTxParams contains the input parameters of the create reservation transaction
+type CreateReservationTxParams struct {
+	CreateReservationParams
+	// optional unit the amount is given in, zero for the unit of the good
+	AmountUnit int64 `json:"amount_unit"`
+	// optional serial numbers of the units of a serialized good to hold, one per unit of the amount
+	Serials []string `json:"serials"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateReservationTx holds stock of a good when enough of it is available within a single database transaction.
+// The row lock of the good serializes the reservations of the good, so the same stock is never promised twice.
+// Overdue reservations of the good are expired first, the amount is converted into the unit of the good.
+// Units of a serialized good named by their serials are held as well and returned with the reservation.
+func (store *SQLStore) CreateReservationTx(ctx context.Context, arg CreateReservationTxParams) (Reservation, error) {
+	var result Reservation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		good, err := q.GetGoodForUpdate(ctx, arg.GoodID)
+		if err != nil {
+			return err
+		}
+
+		good, _, err = expireGoodReservations(ctx, q, good, time.Now(), arg.Actor)
+		if err != nil {
+			return err
+		}
+
+		arg.Amount, err = toGoodUnit(ctx, q, good, arg.AmountUnit, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		if available := good.Amount - good.Reserved; arg.Amount > available {
+			return fmt.Errorf("%w: %d of the good are available", ErrInsufficientStock, available)
+		}
+
+		if len(arg.Serials) > 0 {
+			err = checkSerials(good, arg.Serials, arg.Amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		result, err = q.CreateReservation(ctx, arg.CreateReservationParams)
+		if err != nil {
+			return err
+		}
+
+		err = reserveSerials(ctx, q, result, arg.Serials)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddGoodReserved(ctx, AddGoodReservedParams{
+			ID:     good.ID,
+			Amount: result.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityReservation, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// ReleaseReservationTxParams contains the input parameters of the release reservation transaction
+type ReleaseReservationTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// ReleaseReservationTx gives the stock and the serials held by an active reservation back within a single database transaction.
+// ErrReservationClosed is returned when the reservation has already been released or has expired.
+func (store *SQLStore) ReleaseReservationTx(ctx context.Context, arg ReleaseReservationTxParams) (Reservation, error) {
+	var result Reservation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetReservation(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		// the status of a reservation only changes under the row lock of its good
+		_, err = q.GetGoodIncludingDeletedForUpdate(ctx, before.GoodID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.ReleaseReservation(ctx, arg.ID)
+		if err == sql.ErrNoRows {
+			return ErrReservationClosed
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = q.AddGoodReserved(ctx, AddGoodReservedParams{
+			ID:     result.GoodID,
+			Amount: -result.Amount,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = releaseSerials(ctx, q, result.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityReservation, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// ExpireReservationsTxParams contains the input parameters of the expire reservations transaction
+type ExpireReservationsTxParams struct {
+	// reservations expiring at or before this time are expired
+	Now time.Time `json:"now"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// ExpireReservationsTx expires every overdue active reservation and gives its stock back to the good
+// within a single database transaction. Goods are locked in the order of their id.
+func (store *SQLStore) ExpireReservationsTx(ctx context.Context, arg ExpireReservationsTxParams) ([]Reservation, error) {
+	result := []Reservation{}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		goodIDs, err := q.ListOverdueReservationGoods(ctx, arg.Now)
+		if err != nil {
+			return err
+		}
+
+		for _, goodID := range goodIDs {
+			good, err := q.GetGoodIncludingDeletedForUpdate(ctx, goodID)
+			if err != nil {
+				return err
+			}
+
+			_, expired, err := expireGoodReservations(ctx, q, good, arg.Now, arg.Actor)
+			if err != nil {
+				return err
+			}
+			result = append(result, expired...)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// expireGoodReservations expires the overdue active reservations of a good, takes them off its reserved total
+// and gives their serials back to the stock.
+// The caller must hold the row lock of the good.
+func expireGoodReservations(ctx context.Context, q *Queries, good Good, now time.Time, actor string) (Good, []Reservation, error) {
+	expired, err := q.ExpireReservations(ctx, ExpireReservationsParams{
+		GoodID: good.ID,
+		Now:    now,
+	})
+	if err != nil {
+		return good, nil, err
+	}
+	if len(expired) == 0 {
+		return good, expired, nil
+	}
+
+	var total int64
+	for _, reservation := range expired {
+		total += reservation.Amount
+
+		before := reservation
+		before.Status = ReservationStatusActive
+		before.ClosedAt = sql.NullTime{}
+		err = recordAudit(ctx, q, actor, AuditActionUpdate, EntityReservation, reservation.ID, before, reservation)
+		if err != nil {
+			return good, nil, err
+		}
+
+		err = releaseSerials(ctx, q, reservation.ID)
+		if err != nil {
+			return good, nil, err
+		}
+	}
+
+	good, err = q.AddGoodReserved(ctx, AddGoodReservedParams{
+		ID:     good.ID,
+		Amount: -total,
+	})
+	return good, expired, err
+}
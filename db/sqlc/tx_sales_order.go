@@ -0,0 +1,561 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Statuses of a sales order, an order is allocated once all of its lines hold their full amount
+const (
+	SalesOrderStatusOpen      = "open"
+	SalesOrderStatusAllocated = "allocated"
+	SalesOrderStatusShipped   = "shipped"
+	SalesOrderStatusCancelled = "cancelled"
+)
+
+// ErrSalesOrderStatus is returned when the status of a sales order does not allow the change
+var ErrSalesOrderStatus = errors.New("sales order status does not allow this")
+
+// ErrInvalidPick is returned when the picks of a shipment do not cover the lines of the sales order exactly
+var ErrInvalidPick = errors.New("picks do not match the lines of the sales order")
+
+// SalesOrderTxResult is the result of the sales order transactions
+type SalesOrderTxResult struct {
+	SalesOrder SalesOrder       `json:"sales_order"`
+	Lines      []SalesOrderLine `json:"lines"`
+}
+
+// SalesOrderLineParams contains the input parameters of a line of a new sales order
+type SalesOrderLineParams struct {
+	GoodID int64 `json:"good_id"`
+	Amount int64 `json:"amount"`
+	// optional unit the amount is given in, zero for the unit of the good
+	AmountUnit int64 `json:"amount_unit"`
+}
+
+// CreateSalesOrderTxParams contains the input parameters of the create sales order transaction
+type CreateSalesOrderTxParams struct {
+	CreateSalesOrderParams
+	Lines []SalesOrderLineParams `json:"lines"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateSalesOrderTx creates an open sales order with its lines and records them in the audit log
+// within a single database transaction. The amounts of the lines are converted into the units of their goods.
+func (store *SQLStore) CreateSalesOrderTx(ctx context.Context, arg CreateSalesOrderTxParams) (SalesOrderTxResult, error) {
+	var result SalesOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetWarehouse(ctx, arg.WarehouseID)
+		if err != nil {
+			return err
+		}
+
+		result.SalesOrder, err = q.CreateSalesOrder(ctx, arg.CreateSalesOrderParams)
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntitySalesOrder, result.SalesOrder.ID, nil, result.SalesOrder)
+		if err != nil {
+			return err
+		}
+
+		result.Lines = make([]SalesOrderLine, 0, len(arg.Lines))
+		for _, lineArg := range arg.Lines {
+			good, err := q.GetGood(ctx, lineArg.GoodID)
+			if err != nil {
+				return err
+			}
+
+			amount, err := toGoodUnit(ctx, q, good, lineArg.AmountUnit, lineArg.Amount)
+			if err != nil {
+				return err
+			}
+
+			line, err := q.CreateSalesOrderLine(ctx, CreateSalesOrderLineParams{
+				SalesOrderID: result.SalesOrder.ID,
+				GoodID:       good.ID,
+				Amount:       amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntitySalesOrderLine, line.ID, nil, line)
+			if err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, line)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// SalesOrderStatusTxParams contains the input parameters of the transactions changing the status of a sales order
+type SalesOrderStatusTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// AllocateSalesOrderTx holds the available stock of the goods for the open lines of a sales order
+// within a single database transaction. Lines take what is available and can be topped up by allocating again
+// once more stock has arrived, the order becomes allocated when every line holds its full amount.
+func (store *SQLStore) AllocateSalesOrderTx(ctx context.Context, arg SalesOrderStatusTxParams) (SalesOrderTxResult, error) {
+	var result SalesOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetSalesOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != SalesOrderStatusOpen {
+			return fmt.Errorf("%w: a %s order cannot be allocated", ErrSalesOrderStatus, before.Status)
+		}
+
+		lines, err := q.ListSalesOrderLines(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		complete := true
+		for _, line := range linesByGood(lines) {
+			if line.Allocated == line.Amount {
+				continue
+			}
+
+			good, err := q.GetGoodForUpdate(ctx, line.GoodID)
+			if err != nil {
+				return err
+			}
+
+			// overdue reservations give their stock back before it is handed out again
+			good, _, err = expireGoodReservations(ctx, q, good, now, arg.Actor)
+			if err != nil {
+				return err
+			}
+
+			available, err := warehouseAvailable(ctx, q, good, before.WarehouseID)
+			if err != nil {
+				return err
+			}
+
+			amount := line.Amount - line.Allocated
+			if amount > available {
+				amount = available
+				complete = false
+			}
+			if amount <= 0 {
+				continue
+			}
+
+			_, err = q.AddGoodReserved(ctx, AddGoodReservedParams{
+				ID:     good.ID,
+				Amount: amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			updated, err := q.AddSalesOrderLineAllocated(ctx, AddSalesOrderLineAllocatedParams{
+				ID:     line.ID,
+				Amount: amount,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntitySalesOrderLine, line.ID, line, updated)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.SalesOrder = before
+		if complete {
+			result.SalesOrder, err = q.UpdateSalesOrderStatus(ctx, UpdateSalesOrderStatusParams{
+				ID:     arg.ID,
+				Status: SalesOrderStatusAllocated,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntitySalesOrder, arg.ID, before, result.SalesOrder)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Lines, err = q.ListSalesOrderLines(ctx, arg.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// warehouseAvailable is the stock of the good in the warehouse that is neither allocated to the unshipped sales
+// orders of the warehouse nor held by the reservations of the good, which are not bound to a warehouse.
+// The good must be locked, so that the stock cannot be handed out twice.
+func warehouseAvailable(ctx context.Context, q *Queries, good Good, warehouseID int64) (int64, error) {
+	balance, err := q.GetGoodBalance(ctx, GetGoodBalanceParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouseID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	allocated, err := q.SumWarehouseAllocated(ctx, SumWarehouseAllocatedParams{
+		GoodID:      good.ID,
+		WarehouseID: warehouseID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	available := balance.Amount - allocated
+	if total := good.Amount - good.Reserved; total < available {
+		available = total
+	}
+	return available, nil
+}
+
+// CancelSalesOrderTx cancels a sales order that has not been shipped and gives its allocated stock back to the goods
+// within a single database transaction.
+func (store *SQLStore) CancelSalesOrderTx(ctx context.Context, arg SalesOrderStatusTxParams) (SalesOrderTxResult, error) {
+	var result SalesOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetSalesOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != SalesOrderStatusOpen && before.Status != SalesOrderStatusAllocated {
+			return fmt.Errorf("%w: a %s order cannot be cancelled", ErrSalesOrderStatus, before.Status)
+		}
+
+		lines, err := q.ListSalesOrderLines(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, line := range linesByGood(lines) {
+			if line.Allocated == 0 {
+				continue
+			}
+
+			// the allocation is given back even when the good has been deleted since
+			_, err = q.GetGoodIncludingDeletedForUpdate(ctx, line.GoodID)
+			if err != nil {
+				return err
+			}
+
+			_, err = q.AddGoodReserved(ctx, AddGoodReservedParams{
+				ID:     line.GoodID,
+				Amount: -line.Allocated,
+			})
+			if err != nil {
+				return err
+			}
+
+			updated, err := q.AddSalesOrderLineAllocated(ctx, AddSalesOrderLineAllocatedParams{
+				ID:     line.ID,
+				Amount: -line.Allocated,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntitySalesOrderLine, line.ID, line, updated)
+			if err != nil {
+				return err
+			}
+		}
+
+		result.SalesOrder, err = q.UpdateSalesOrderStatus(ctx, UpdateSalesOrderStatusParams{
+			ID:     arg.ID,
+			Status: SalesOrderStatusCancelled,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntitySalesOrder, arg.ID, before, result.SalesOrder)
+		if err != nil {
+			return err
+		}
+
+		result.Lines, err = q.ListSalesOrderLines(ctx, arg.ID)
+		return err
+	})
+
+	return result, err
+}
+
+// linesByGood orders the lines by their good, so goods are locked in the order of their id
+func linesByGood(lines []SalesOrderLine) []SalesOrderLine {
+	sorted := append([]SalesOrderLine(nil), lines...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].GoodID < sorted[j].GoodID
+	})
+	return sorted
+}
+
+// PickListLine is the amount of a line to take from a location
+type PickListLine struct {
+	LineID int64 `json:"line_id"`
+	GoodID int64 `json:"good_id"`
+	// in the unit of the good
+	Amount int64 `json:"amount"`
+}
+
+// PickListLocation groups the lines to pick at one location
+type PickListLocation struct {
+	// null for stock of the warehouse that is not put away in a bin
+	LocationID   sql.NullInt64  `json:"location_id"`
+	LocationCode string         `json:"location_code"`
+	Lines        []PickListLine `json:"lines"`
+}
+
+// PickList tells where to take the allocated stock of a sales order from
+type PickList struct {
+	SalesOrderID int64              `json:"sales_order_id"`
+	WarehouseID  int64              `json:"warehouse_id"`
+	Locations    []PickListLocation `json:"locations"`
+}
+
+// GetPickListTx plans the picks of the allocated amounts of a sales order grouped by the location to take them from
+// within a single database transaction. Bins are emptied in the order of their code, whatever the bins of the
+// warehouse do not hold is taken from its loose stock, which is listed last.
+func (store *SQLStore) GetPickListTx(ctx context.Context, salesOrderID int64) (PickList, error) {
+	var result PickList
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		salesOrder, err := q.GetSalesOrder(ctx, salesOrderID)
+		if err != nil {
+			return err
+		}
+		if salesOrder.Status != SalesOrderStatusOpen && salesOrder.Status != SalesOrderStatusAllocated {
+			return fmt.Errorf("%w: a %s order is not picked", ErrSalesOrderStatus, salesOrder.Status)
+		}
+
+		lines, err := q.ListSalesOrderLines(ctx, salesOrderID)
+		if err != nil {
+			return err
+		}
+
+		result.SalesOrderID = salesOrder.ID
+		result.WarehouseID = salesOrder.WarehouseID
+		result.Locations = []PickListLocation{}
+
+		groups := make(map[int64]int)
+		loose := PickListLocation{Lines: []PickListLine{}}
+		// stock taken from a bin by an earlier line of the same good
+		taken := make(map[[2]int64]int64)
+
+		for _, line := range lines {
+			remaining := line.Allocated
+			if remaining == 0 {
+				continue
+			}
+
+			bins, err := q.ListWarehouseBinStocks(ctx, ListWarehouseBinStocksParams{
+				GoodID:      line.GoodID,
+				WarehouseID: salesOrder.WarehouseID,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, bin := range bins {
+				key := [2]int64{bin.GoodID, bin.LocationID}
+				amount := bin.Amount - taken[key]
+				if amount <= 0 {
+					continue
+				}
+				if amount > remaining {
+					amount = remaining
+				}
+				taken[key] += amount
+				remaining -= amount
+
+				i, ok := groups[bin.LocationID]
+				if !ok {
+					i = len(result.Locations)
+					groups[bin.LocationID] = i
+					result.Locations = append(result.Locations, PickListLocation{
+						LocationID:   sql.NullInt64{Int64: bin.LocationID, Valid: true},
+						LocationCode: bin.LocationCode,
+					})
+				}
+				result.Locations[i].Lines = append(result.Locations[i].Lines, PickListLine{
+					LineID: line.ID,
+					GoodID: line.GoodID,
+					Amount: amount,
+				})
+				if remaining == 0 {
+					break
+				}
+			}
+
+			if remaining > 0 {
+				loose.Lines = append(loose.Lines, PickListLine{
+					LineID: line.ID,
+					GoodID: line.GoodID,
+					Amount: remaining,
+				})
+			}
+		}
+
+		sort.SliceStable(result.Locations, func(i, j int) bool {
+			return result.Locations[i].LocationCode < result.Locations[j].LocationCode
+		})
+		if len(loose.Lines) > 0 {
+			result.Locations = append(result.Locations, loose)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// SalesOrderPick is an amount of a line taken from a location
+type SalesOrderPick struct {
+	LineID int64 `json:"line_id"`
+	// optional bin the goods are taken from, null for the loose stock of the warehouse
+	LocationID sql.NullInt64 `json:"location_id"`
+	// in the unit of the good
+	Amount int64 `json:"amount"`
+	// serial numbers of the units picked, one per unit for serialized goods and none for others
+	Serials []string `json:"serials"`
+}
+
+// ShipSalesOrderTxParams contains the input parameters of the ship sales order transaction
+type ShipSalesOrderTxParams struct {
+	ID    int64            `json:"id"`
+	Picks []SalesOrderPick `json:"picks"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// ShipSalesOrderTxResult is the result of the ship sales order transaction
+type ShipSalesOrderTxResult struct {
+	SalesOrderTxResult
+	Movements []StockMovement `json:"movements"`
+}
+
+// ShipSalesOrderTx confirms the shipment of an allocated sales order within a single database transaction.
+// The picks must add up to the amount of every line, each of them is issued from the warehouse of the order
+// after the allocation of its line has been taken off the reserved total. The order is marked shipped before
+// its picks are issued, so that its allocations no longer hold the stock of the warehouse.
+// Either every pick is booked or none.
+func (store *SQLStore) ShipSalesOrderTx(ctx context.Context, arg ShipSalesOrderTxParams) (ShipSalesOrderTxResult, error) {
+	var result ShipSalesOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetSalesOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != SalesOrderStatusAllocated {
+			return fmt.Errorf("%w: a %s order cannot be shipped", ErrSalesOrderStatus, before.Status)
+		}
+
+		lines, err := q.ListSalesOrderLines(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int64]SalesOrderLine, len(lines))
+		picked := make(map[int64]int64, len(lines))
+		for _, line := range lines {
+			byID[line.ID] = line
+		}
+		for _, pick := range arg.Picks {
+			if _, ok := byID[pick.LineID]; !ok {
+				return fmt.Errorf("%w: line %d is not part of the order", ErrInvalidPick, pick.LineID)
+			}
+			picked[pick.LineID] += pick.Amount
+		}
+		for _, line := range lines {
+			if picked[line.ID] != line.Amount {
+				return fmt.Errorf("%w: %d of %d of line %d are picked", ErrInvalidPick, picked[line.ID], line.Amount, line.ID)
+			}
+		}
+
+		result.SalesOrder, err = q.UpdateSalesOrderStatus(ctx, UpdateSalesOrderStatusParams{
+			ID:     arg.ID,
+			Status: SalesOrderStatusShipped,
+		})
+		if err != nil {
+			return err
+		}
+
+		picks := append([]SalesOrderPick(nil), arg.Picks...)
+		sort.SliceStable(picks, func(i, j int) bool {
+			return byID[picks[i].LineID].GoodID < byID[picks[j].LineID].GoodID
+		})
+
+		released := make(map[int64]bool, len(lines))
+		result.Movements = make([]StockMovement, 0, len(picks))
+		for _, pick := range picks {
+			line := byID[pick.LineID]
+
+			good, err := q.GetGoodForUpdate(ctx, line.GoodID)
+			if err != nil {
+				return err
+			}
+
+			// the allocation of the line turns into the issue, so it no longer holds stock
+			if !released[line.ID] {
+				good, err = q.AddGoodReserved(ctx, AddGoodReservedParams{
+					ID:     good.ID,
+					Amount: -line.Allocated,
+				})
+				if err != nil {
+					return err
+				}
+				released[line.ID] = true
+			}
+
+			moved, err := moveStock(ctx, q, good, StockMovementTxParams{
+				GoodID:       good.ID,
+				WarehouseID:  before.WarehouseID,
+				LocationID:   pick.LocationID,
+				MovementType: MovementTypeIssue,
+				Amount:       -pick.Amount,
+				Serials:      pick.Serials,
+				Actor:        arg.Actor,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityStockMovement, moved.Movement.ID, nil, moved.Movement)
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, moved.Movement)
+		}
+
+		err = recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntitySalesOrder, arg.ID, before, result.SalesOrder)
+		if err != nil {
+			return err
+		}
+
+		result.Lines = lines
+		return nil
+	})
+
+	return result, err
+}
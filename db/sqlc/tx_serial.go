@@ -0,0 +1,200 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// Statuses of a serial, units in stock and returned units can be issued and reserved
+const (
+	SerialStatusInStock  = "in_stock"
+	SerialStatusReserved = "reserved"
+	SerialStatusShipped  = "shipped"
+	SerialStatusReturned = "returned"
+)
+
+// ErrSerialMismatch is returned when the serials given with a change of stock do not match its good or amount,
+// or name units that are not where the change expects them
+var ErrSerialMismatch = errors.New("serials do not match")
+
+// checkSerials checks that a serialized good is given one distinct serial per unit of the amount
+// and that no serials are given for other goods.
+func checkSerials(good Good, serials []string, amount int64) error {
+	if !good.Serialized {
+		if len(serials) > 0 {
+			return fmt.Errorf("%w: the good is not serialized", ErrSerialMismatch)
+		}
+		return nil
+	}
+
+	if amount < 0 {
+		amount = -amount
+	}
+	if int64(len(serials)) != amount {
+		return fmt.Errorf("%w: %d serials are given for %d units", ErrSerialMismatch, len(serials), amount)
+	}
+
+	given := make(map[string]bool, len(serials))
+	for _, serialNumber := range serials {
+		if given[serialNumber] {
+			return fmt.Errorf("%w: serial %s is given twice", ErrSerialMismatch, serialNumber)
+		}
+		given[serialNumber] = true
+	}
+	return nil
+}
+
+// checkNotSerialized rejects serialized goods in changes of stock that cannot name the units they move
+func checkNotSerialized(good Good) error {
+	if good.Serialized {
+		return fmt.Errorf("%w: good %d is serialized, its units are moved by stock movements naming their serials",
+			ErrSerialMismatch, good.ID)
+	}
+	return nil
+}
+
+// getGoodSerial gets a serial of the good by its number, known is false when the serial has never been seen
+func getGoodSerial(ctx context.Context, q *Queries, goodID int64, serialNumber string) (serial Serial, known bool, err error) {
+	serial, err = q.GetSerialByNumber(ctx, serialNumber)
+	if err == sql.ErrNoRows {
+		return serial, false, nil
+	}
+	if err != nil {
+		return serial, false, err
+	}
+	if serial.GoodID != goodID {
+		return serial, true, fmt.Errorf("%w: serial %s is a unit of another good", ErrSerialMismatch, serialNumber)
+	}
+	return serial, true, nil
+}
+
+// moveSerials applies a movement to the serials it names and records an event for each of them.
+// Increases put new serials in stock and take back units that have been shipped, a receipt of a shipped unit is a return.
+// Decreases ship the units, which must sit unreserved in the warehouse of the movement.
+// The serials must have been checked against the movement with checkSerials.
+func moveSerials(ctx context.Context, q *Queries, arg StockMovementTxParams, movementID int64) ([]Serial, error) {
+	serials := []Serial{}
+	warehouseID := sql.NullInt64{Int64: arg.WarehouseID, Valid: true}
+
+	for _, serialNumber := range arg.Serials {
+		serial, known, err := getGoodSerial(ctx, q, arg.GoodID, serialNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case arg.Amount > 0 && !known:
+			serial, err = q.CreateSerial(ctx, CreateSerialParams{
+				GoodID:       arg.GoodID,
+				SerialNumber: serialNumber,
+				Status:       SerialStatusInStock,
+				WarehouseID:  warehouseID,
+			})
+		case arg.Amount > 0:
+			if serial.Status != SerialStatusShipped {
+				return nil, fmt.Errorf("%w: serial %s is already in stock", ErrSerialMismatch, serialNumber)
+			}
+			status := SerialStatusInStock
+			if arg.MovementType == MovementTypeReceipt {
+				status = SerialStatusReturned
+			}
+			serial, err = q.UpdateSerial(ctx, UpdateSerialParams{
+				ID:          serial.ID,
+				Status:      status,
+				WarehouseID: warehouseID,
+			})
+		default:
+			if !known || serial.WarehouseID != warehouseID {
+				return nil, fmt.Errorf("%w: serial %s is not in stock in the warehouse", ErrSerialMismatch, serialNumber)
+			}
+			if serial.Status == SerialStatusReserved {
+				return nil, fmt.Errorf("%w: serial %s is reserved", ErrSerialMismatch, serialNumber)
+			}
+			serial, err = q.UpdateSerial(ctx, UpdateSerialParams{
+				ID:     serial.ID,
+				Status: SerialStatusShipped,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = q.CreateSerialEvent(ctx, CreateSerialEventParams{
+			SerialID:        serial.ID,
+			Status:          serial.Status,
+			WarehouseID:     warehouseID,
+			StockMovementID: sql.NullInt64{Int64: movementID, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		serials = append(serials, serial)
+	}
+
+	return serials, nil
+}
+
+// reserveSerials holds the named units in stock for the reservation and records an event for each of them.
+// The serials must have been checked against the reservation with checkSerials.
+func reserveSerials(ctx context.Context, q *Queries, reservation Reservation, serials []string) error {
+	reservationID := sql.NullInt64{Int64: reservation.ID, Valid: true}
+
+	for _, serialNumber := range serials {
+		serial, known, err := getGoodSerial(ctx, q, reservation.GoodID, serialNumber)
+		if err != nil {
+			return err
+		}
+		if !known || (serial.Status != SerialStatusInStock && serial.Status != SerialStatusReturned) {
+			return fmt.Errorf("%w: serial %s is not available", ErrSerialMismatch, serialNumber)
+		}
+
+		serial, err = q.UpdateSerial(ctx, UpdateSerialParams{
+			ID:            serial.ID,
+			Status:        SerialStatusReserved,
+			WarehouseID:   serial.WarehouseID,
+			ReservationID: reservationID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreateSerialEvent(ctx, CreateSerialEventParams{
+			SerialID:      serial.ID,
+			Status:        serial.Status,
+			WarehouseID:   serial.WarehouseID,
+			ReservationID: reservationID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// releaseSerials gives the units held by a reservation that is not active anymore back to the stock
+// and records an event for each of them.
+func releaseSerials(ctx context.Context, q *Queries, reservationID int64) error {
+	id := sql.NullInt64{Int64: reservationID, Valid: true}
+
+	serials, err := q.ReleaseReservationSerials(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, serial := range serials {
+		_, err = q.CreateSerialEvent(ctx, CreateSerialEventParams{
+			SerialID:      serial.ID,
+			Status:        serial.Status,
+			WarehouseID:   serial.WarehouseID,
+			ReservationID: id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
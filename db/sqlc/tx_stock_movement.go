@@ -0,0 +1,238 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Types of stock movement recorded in the ledger
+const (
+	MovementTypeReceipt     = "receipt"
+	MovementTypeIssue       = "issue"
+	MovementTypeAdjustment  = "adjustment"
+	MovementTypeTransferOut = "transfer_out"
+	MovementTypeTransferIn  = "transfer_in"
+)
+
+// Levels of the location hierarchy below a warehouse, goods are stocked in bins
+const (
+	LocationTypeZone  = "zone"
+	LocationTypeAisle = "aisle"
+	LocationTypeRack  = "rack"
+	LocationTypeBin   = "bin"
+)
+
+// ErrInsufficientStock is returned when a movement would drive an amount below zero
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// ErrInvalidLocation is returned when a movement names a location that is not a bin of its warehouse
+var ErrInvalidLocation = errors.New("location is not a bin of the warehouse")
+
+// StockMovementTxParams contains the input parameters of the stock movement transaction
+type StockMovementTxParams struct {
+	GoodID      int64 `json:"good_id"`
+	WarehouseID int64 `json:"warehouse_id"`
+	// optional bin the goods are put into or taken from
+	LocationID sql.NullInt64 `json:"location_id"`
+	// optional lot the goods are booked into or taken from, decreases without one take the lots first expired first out
+	LotID sql.NullInt64 `json:"lot_id"`
+	// optional parts of an increase booked into several lots, the rest is kept outside of lots
+	IntoLots     []LotAmount `json:"into_lots"`
+	MovementType string      `json:"movement_type"`
+	// positive for receipts, negative for issues
+	Amount int64 `json:"amount"`
+	// optional value of an increase in minor units of the inventory currency, increases without one
+	// are valued at the average cost of the good, decreases are valued by the costing method of its category
+	Value sql.NullInt64 `json:"value"`
+	// serial numbers of the units moved, one per unit of the amount for serialized goods and none for others
+	Serials []string `json:"serials"`
+	// optional unit the amount is given in, zero for the unit of the good
+	AmountUnit int64 `json:"amount_unit"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// StockMovementTxResult is the result of the stock movement transaction
+type StockMovementTxResult struct {
+	Good     Good          `json:"good"`
+	Balance  GoodBalance   `json:"balance"`
+	BinStock BinStock      `json:"bin_stock"`
+	Movement StockMovement `json:"movement"`
+	// parts of the movement booked against lots
+	Lots []LotMovement `json:"lots"`
+	// units moved by the movement with their new status
+	Serials []Serial `json:"serials"`
+}
+
+// StockMovementTx records a signed stock movement for a good in a warehouse and adjusts
+// the warehouse balance and the total amount of the good within a single database transaction.
+// The amount is converted into the unit of the good before it is booked, the movement is recorded in the audit log.
+func (store *SQLStore) StockMovementTx(ctx context.Context, arg StockMovementTxParams) (StockMovementTxResult, error) {
+	var result StockMovementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		good, err := q.GetGoodForUpdate(ctx, arg.GoodID)
+		if err != nil {
+			return err
+		}
+
+		arg.Amount, err = toGoodUnit(ctx, q, good, arg.AmountUnit, arg.Amount)
+		if err != nil {
+			return err
+		}
+
+		result, err = moveStock(ctx, q, good, arg)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityStockMovement, result.Movement.ID, nil, result.Movement)
+	})
+
+	return result, err
+}
+
+// moveStock records a movement and applies it to the bin, the warehouse balance, the good total and the stock value.
+// Stock that sits in a bin can only be issued from that bin, serialized goods move the units named by their serials.
+// Overdue reservations of the good are expired first, so they never hold back the stock of a movement.
+// The caller must hold the row lock of the good, which serializes all movements of the good.
+func moveStock(ctx context.Context, q *Queries, good Good, arg StockMovementTxParams) (StockMovementTxResult, error) {
+	var result StockMovementTxResult
+
+	err := checkSerials(good, arg.Serials, arg.Amount)
+	if err != nil {
+		return result, err
+	}
+
+	good, _, err = expireGoodReservations(ctx, q, good, time.Now(), arg.Actor)
+	if err != nil {
+		return result, err
+	}
+
+	balance, err := q.GetGoodBalance(ctx, GetGoodBalanceParams{
+		GoodID:      arg.GoodID,
+		WarehouseID: arg.WarehouseID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return result, err
+	}
+
+	if balance.Amount+arg.Amount < 0 || good.Amount+arg.Amount < 0 {
+		return result, ErrInsufficientStock
+	}
+	// issues and transfers must leave the reserved stock and the allocations of the warehouse,
+	// adjustments record what is really there
+	if arg.MovementType == MovementTypeIssue || arg.MovementType == MovementTypeTransferOut {
+		available, err := warehouseAvailable(ctx, q, good, arg.WarehouseID)
+		if err != nil {
+			return result, err
+		}
+		if -arg.Amount > available {
+			return result, fmt.Errorf("%w: %d of the good are available in the warehouse", ErrInsufficientStock, available)
+		}
+	}
+
+	if arg.LocationID.Valid {
+		result.BinStock, err = moveBinStock(ctx, q, arg)
+		if err != nil {
+			return result, err
+		}
+	} else if arg.Amount < 0 {
+		binned, err := q.SumBinStocks(ctx, SumBinStocksParams{
+			GoodID:      arg.GoodID,
+			WarehouseID: arg.WarehouseID,
+		})
+		if err != nil {
+			return result, err
+		}
+		if balance.Amount-binned+arg.Amount < 0 {
+			return result, ErrInsufficientStock
+		}
+	}
+
+	result.Movement, err = q.CreateStockMovement(ctx, CreateStockMovementParams{
+		GoodID:       arg.GoodID,
+		WarehouseID:  arg.WarehouseID,
+		LocationID:   arg.LocationID,
+		MovementType: arg.MovementType,
+		Amount:       arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Lots, err = moveLots(ctx, q, arg, balance, result.Movement.ID)
+	if err != nil {
+		return result, err
+	}
+
+	result.Serials, err = moveSerials(ctx, q, arg, result.Movement.ID)
+	if err != nil {
+		return result, err
+	}
+
+	value, err := moveValue(ctx, q, good, arg, result.Movement.ID)
+	if err != nil {
+		return result, err
+	}
+	if value != 0 {
+		result.Movement, err = q.SetStockMovementValue(ctx, SetStockMovementValueParams{
+			ID:    result.Movement.ID,
+			Value: value,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	result.Balance, err = q.AddGoodBalance(ctx, AddGoodBalanceParams{
+		GoodID:      arg.GoodID,
+		WarehouseID: arg.WarehouseID,
+		Amount:      arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.Good, err = q.AddGoodAmount(ctx, AddGoodAmountParams{
+		ID:     arg.GoodID,
+		Amount: arg.Amount,
+	})
+	return result, err
+}
+
+// moveBinStock applies a movement to the stock of the bin it names.
+func moveBinStock(ctx context.Context, q *Queries, arg StockMovementTxParams) (BinStock, error) {
+	var binStock BinStock
+
+	location, err := q.GetLocation(ctx, arg.LocationID.Int64)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return binStock, ErrInvalidLocation
+		}
+		return binStock, err
+	}
+	if location.LocationType != LocationTypeBin || location.WarehouseID != arg.WarehouseID {
+		return binStock, ErrInvalidLocation
+	}
+
+	binStock, err = q.GetBinStock(ctx, GetBinStockParams{
+		GoodID:     arg.GoodID,
+		LocationID: location.ID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return binStock, err
+	}
+	if binStock.Amount+arg.Amount < 0 {
+		return binStock, ErrInsufficientStock
+	}
+
+	return q.AddBinStock(ctx, AddBinStockParams{
+		GoodID:     arg.GoodID,
+		LocationID: location.ID,
+		Amount:     arg.Amount,
+	})
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// CreateSupplierTxParams contains the input parameters of the create supplier transaction
+type CreateSupplierTxParams struct {
+	CreateSupplierParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateSupplierTx creates a supplier and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateSupplierTx(ctx context.Context, arg CreateSupplierTxParams) (Supplier, error) {
+	var result Supplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateSupplier(ctx, arg.CreateSupplierParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntitySupplier, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateSupplierTxParams contains the input parameters of the update supplier transaction
+type UpdateSupplierTxParams struct {
+	UpdateSupplierParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateSupplierTx updates a supplier and records both versions of it in the audit log within a single database transaction.
+func (store *SQLStore) UpdateSupplierTx(ctx context.Context, arg UpdateSupplierTxParams) (Supplier, error) {
+	var result Supplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetSupplier(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateSupplier(ctx, arg.UpdateSupplierParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntitySupplier, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteSupplierTx deletes a supplier and keeps its last version in the audit log within a single database transaction.
+// Suppliers that are still linked to goods cannot be deleted.
+func (store *SQLStore) DeleteSupplierTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetSupplier(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteSupplier(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntitySupplier, arg.ID, before, nil)
+	})
+}
+
+// goodSupplierID identifies the link of a good to a supplier in the audit log
+func goodSupplierID(goodID, supplierID int64) string {
+	return fmt.Sprintf("%d/%d", goodID, supplierID)
+}
+
+// CreateGoodSupplierTxParams contains the input parameters of the create good supplier transaction
+type CreateGoodSupplierTxParams struct {
+	CreateGoodSupplierParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateGoodSupplierTx links a good to a supplier and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateGoodSupplierTx(ctx context.Context, arg CreateGoodSupplierTxParams) (GoodSupplier, error) {
+	var result GoodSupplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// deleted goods still satisfy the foreign key
+		_, err := q.GetGood(ctx, arg.GoodID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.CreateGoodSupplier(ctx, arg.CreateGoodSupplierParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityGoodSupplier,
+			goodSupplierID(result.GoodID, result.SupplierID), nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateGoodSupplierTxParams contains the input parameters of the update good supplier transaction
+type UpdateGoodSupplierTxParams struct {
+	UpdateGoodSupplierParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateGoodSupplierTx updates the terms a supplier sells a good under and records both versions of them
+// in the audit log within a single database transaction.
+func (store *SQLStore) UpdateGoodSupplierTx(ctx context.Context, arg UpdateGoodSupplierTxParams) (GoodSupplier, error) {
+	var result GoodSupplier
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetGoodSupplier(ctx, GetGoodSupplierParams{
+			GoodID:     arg.GoodID,
+			SupplierID: arg.SupplierID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateGoodSupplier(ctx, arg.UpdateGoodSupplierParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityGoodSupplier,
+			goodSupplierID(result.GoodID, result.SupplierID), before, result)
+	})
+
+	return result, err
+}
+
+// DeleteGoodSupplierTxParams contains the input parameters of the delete good supplier transaction
+type DeleteGoodSupplierTxParams struct {
+	GoodID     int64 `json:"good_id"`
+	SupplierID int64 `json:"supplier_id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// DeleteGoodSupplierTx unlinks a good from a supplier and keeps the last version of the link in the audit log
+// within a single database transaction.
+func (store *SQLStore) DeleteGoodSupplierTx(ctx context.Context, arg DeleteGoodSupplierTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetGoodSupplier(ctx, GetGoodSupplierParams{
+			GoodID:     arg.GoodID,
+			SupplierID: arg.SupplierID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteGoodSupplier(ctx, DeleteGoodSupplierParams{
+			GoodID:     arg.GoodID,
+			SupplierID: arg.SupplierID,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityGoodSupplier,
+			goodSupplierID(arg.GoodID, arg.SupplierID), before, nil)
+	})
+}
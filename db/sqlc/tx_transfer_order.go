@@ -0,0 +1,288 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Statuses of a transfer order, the goods of an order in transit have left the source but not reached the destination
+const (
+	TransferOrderStatusDraft     = "draft"
+	TransferOrderStatusInTransit = "in_transit"
+	TransferOrderStatusReceived  = "received"
+	TransferOrderStatusCancelled = "cancelled"
+)
+
+// ErrTransferOrderStatus is returned when the status of a transfer order does not allow the change
+var ErrTransferOrderStatus = errors.New("transfer order status does not allow this")
+
+// ErrSameWarehouse is returned when a transfer order would move goods into the warehouse they come from
+var ErrSameWarehouse = errors.New("transfer order must move goods between two warehouses")
+
+// TransferOrderTxResult is the result of the transfer order transactions
+type TransferOrderTxResult struct {
+	TransferOrder TransferOrder       `json:"transfer_order"`
+	Lines         []TransferOrderLine `json:"lines"`
+}
+
+// TransferOrderLineParams contains the input parameters of a line of a new transfer order
+type TransferOrderLineParams struct {
+	GoodID int64 `json:"good_id"`
+	Amount int64 `json:"amount"`
+	// optional unit the amount is given in, zero for the unit of the good
+	AmountUnit     int64         `json:"amount_unit"`
+	FromLocationID sql.NullInt64 `json:"from_location_id"`
+	ToLocationID   sql.NullInt64 `json:"to_location_id"`
+}
+
+// CreateTransferOrderTxParams contains the input parameters of the create transfer order transaction
+type CreateTransferOrderTxParams struct {
+	CreateTransferOrderParams
+	Lines []TransferOrderLineParams `json:"lines"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateTransferOrderTx creates a draft transfer order with its lines and records them in the audit log
+// within a single database transaction. The amounts of the lines are converted into the units of their goods.
+// Lines cannot name serials, so serialized goods are moved between warehouses with stock movements instead.
+func (store *SQLStore) CreateTransferOrderTx(ctx context.Context, arg CreateTransferOrderTxParams) (TransferOrderTxResult, error) {
+	var result TransferOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.FromWarehouseID == arg.ToWarehouseID {
+			return ErrSameWarehouse
+		}
+		for _, warehouseID := range []int64{arg.FromWarehouseID, arg.ToWarehouseID} {
+			_, err := q.GetWarehouse(ctx, warehouseID)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		result.TransferOrder, err = q.CreateTransferOrder(ctx, arg.CreateTransferOrderParams)
+		if err != nil {
+			return err
+		}
+
+		err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityTransferOrder, result.TransferOrder.ID, nil, result.TransferOrder)
+		if err != nil {
+			return err
+		}
+
+		result.Lines = make([]TransferOrderLine, 0, len(arg.Lines))
+		for _, lineArg := range arg.Lines {
+			good, err := q.GetGood(ctx, lineArg.GoodID)
+			if err != nil {
+				return err
+			}
+
+			err = checkNotSerialized(good)
+			if err != nil {
+				return err
+			}
+
+			amount, err := toGoodUnit(ctx, q, good, lineArg.AmountUnit, lineArg.Amount)
+			if err != nil {
+				return err
+			}
+
+			line, err := q.CreateTransferOrderLine(ctx, CreateTransferOrderLineParams{
+				TransferOrderID: result.TransferOrder.ID,
+				GoodID:          good.ID,
+				Amount:          amount,
+				FromLocationID:  lineArg.FromLocationID,
+				ToLocationID:    lineArg.ToLocationID,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityTransferOrderLine, line.ID, nil, line)
+			if err != nil {
+				return err
+			}
+			result.Lines = append(result.Lines, line)
+		}
+		return nil
+	})
+
+	return result, err
+}
+
+// TransferOrderStatusTxParams contains the input parameters of the transactions changing the status of a transfer order
+type TransferOrderStatusTxParams struct {
+	ID int64 `json:"id"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// TransferOrderMovementTxResult is the result of the transactions moving the goods of a transfer order
+type TransferOrderMovementTxResult struct {
+	TransferOrderTxResult
+	Movements []StockMovement `json:"movements"`
+}
+
+// ShipTransferOrderTx issues the lines of a draft transfer order from its source warehouse and holds them
+// in the in-transit total of their goods within a single database transaction. The lots every line is taken
+// out of are recorded with the line. Either every line ships or none.
+func (store *SQLStore) ShipTransferOrderTx(ctx context.Context, arg TransferOrderStatusTxParams) (TransferOrderMovementTxResult, error) {
+	return store.moveTransferOrder(ctx, arg, TransferOrderStatusDraft, TransferOrderStatusInTransit)
+}
+
+// ReceiveTransferOrderTx books the lines of a transfer order in transit into its destination warehouse and takes them
+// off the in-transit total of their goods within a single database transaction. Every line is booked back into
+// the lots it was shipped out of. Either every line is received or none.
+func (store *SQLStore) ReceiveTransferOrderTx(ctx context.Context, arg TransferOrderStatusTxParams) (TransferOrderMovementTxResult, error) {
+	return store.moveTransferOrder(ctx, arg, TransferOrderStatusInTransit, TransferOrderStatusReceived)
+}
+
+// moveTransferOrder moves the goods of a transfer order one step, from the source into transit
+// or from transit into the destination, and sets the status the step leads to.
+func (store *SQLStore) moveTransferOrder(ctx context.Context, arg TransferOrderStatusTxParams, from, to string) (TransferOrderMovementTxResult, error) {
+	var result TransferOrderMovementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetTransferOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != from {
+			return fmt.Errorf("%w: the order is %s, not %s", ErrTransferOrderStatus, before.Status, from)
+		}
+
+		result.Lines, err = q.ListTransferOrderLines(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if len(result.Lines) == 0 {
+			return fmt.Errorf("%w: the order has no lines", ErrTransferOrderStatus)
+		}
+
+		// goods are locked in the order of their id
+		lines := append([]TransferOrderLine(nil), result.Lines...)
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].GoodID < lines[j].GoodID
+		})
+
+		// goods deleted while in transit still arrive at the destination
+		getGood := q.GetGoodForUpdate
+		if to == TransferOrderStatusReceived {
+			getGood = q.GetGoodIncludingDeletedForUpdate
+		}
+
+		result.Movements = make([]StockMovement, 0, len(lines))
+		for _, line := range lines {
+			good, err := getGood(ctx, line.GoodID)
+			if err != nil {
+				return err
+			}
+
+			err = checkNotSerialized(good)
+			if err != nil {
+				return err
+			}
+
+			movement := StockMovementTxParams{
+				GoodID: good.ID,
+				Actor:  arg.Actor,
+			}
+			inTransit := line.Amount
+			if to == TransferOrderStatusInTransit {
+				movement.WarehouseID = before.FromWarehouseID
+				movement.LocationID = line.FromLocationID
+				movement.MovementType = MovementTypeTransferOut
+				movement.Amount = -line.Amount
+			} else {
+				movement.WarehouseID = before.ToWarehouseID
+				movement.LocationID = line.ToLocationID
+				movement.MovementType = MovementTypeTransferIn
+				movement.Amount = line.Amount
+				inTransit = -line.Amount
+
+				lineLots, err := q.ListTransferOrderLineLots(ctx, line.ID)
+				if err != nil {
+					return err
+				}
+				for _, lineLot := range lineLots {
+					movement.IntoLots = append(movement.IntoLots, LotAmount{LotID: lineLot.LotID, Amount: lineLot.Amount})
+				}
+			}
+
+			moved, err := moveStock(ctx, q, good, movement)
+			if err != nil {
+				return err
+			}
+
+			if to == TransferOrderStatusInTransit {
+				for _, lot := range moved.Lots {
+					_, err = q.CreateTransferOrderLineLot(ctx, CreateTransferOrderLineLotParams{
+						TransferOrderLineID: line.ID,
+						LotID:               lot.LotID,
+						Amount:              -lot.Amount,
+					})
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			_, err = q.AddGoodInTransit(ctx, AddGoodInTransitParams{
+				ID:     good.ID,
+				Amount: inTransit,
+			})
+			if err != nil {
+				return err
+			}
+
+			err = recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityStockMovement, moved.Movement.ID, nil, moved.Movement)
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, moved.Movement)
+		}
+
+		result.TransferOrder, err = q.UpdateTransferOrderStatus(ctx, UpdateTransferOrderStatusParams{
+			ID:     arg.ID,
+			Status: to,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityTransferOrder, arg.ID, before, result.TransferOrder)
+	})
+
+	return result, err
+}
+
+// CancelTransferOrderTx cancels a transfer order that has not been shipped within a single database transaction.
+func (store *SQLStore) CancelTransferOrderTx(ctx context.Context, arg TransferOrderStatusTxParams) (TransferOrder, error) {
+	var result TransferOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetTransferOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Status != TransferOrderStatusDraft {
+			return fmt.Errorf("%w: a %s order cannot be cancelled", ErrTransferOrderStatus, before.Status)
+		}
+
+		result, err = q.UpdateTransferOrderStatus(ctx, UpdateTransferOrderStatusParams{
+			ID:     arg.ID,
+			Status: TransferOrderStatusCancelled,
+		})
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityTransferOrder, result.ID, before, result)
+	})
+
+	return result, err
+}
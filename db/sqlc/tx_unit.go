@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrUnitInUse is returned when the family or the value of a unit would change while amounts are kept in it
+var ErrUnitInUse = errors.New("unit is in use, its family and value cannot change")
+
+// CreateUnitTxParams contains the input parameters of the create unit transaction
+type CreateUnitTxParams struct {
+	CreateUnitParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateUnitTx creates a unit and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateUnitTx(ctx context.Context, arg CreateUnitTxParams) (Unit, error) {
+	var result Unit
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateUnit(ctx, arg.CreateUnitParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityUnit, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateUnitTxParams contains the input parameters of the update unit transaction
+type UpdateUnitTxParams struct {
+	UpdateUnitParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateUnitTx updates a unit and records both versions of it in the audit log within a single database transaction.
+// ErrVersionMismatch is returned when the unit has been changed since the given version, ErrUnitInUse when its
+// family or value would change while goods or purchase order lines keep amounts in it.
+func (store *SQLStore) UpdateUnitTx(ctx context.Context, arg UpdateUnitTxParams) (Unit, error) {
+	var result Unit
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetUnit(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Version != arg.Version {
+			return ErrVersionMismatch
+		}
+
+		// the stored amounts would silently change their meaning
+		if before.UnitFamily != arg.UnitFamily || before.UnitValue != arg.UnitValue {
+			inUse, err := q.UnitInUse(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+			if inUse {
+				return ErrUnitInUse
+			}
+		}
+
+		result, err = q.UpdateUnit(ctx, arg.UpdateUnitParams)
+		if err == sql.ErrNoRows {
+			// a concurrent transaction changed the version since it was read
+			return ErrVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityUnit, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteUnitTx marks a unit as deleted and records it in the audit log within a single database transaction.
+func (store *SQLStore) DeleteUnitTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetUnit(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		after, err := q.DeleteUnit(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityUnit, arg.ID, before, after)
+	})
+}
+
+// RestoreUnitTx brings back a deleted unit and records it in the audit log within a single database transaction.
+func (store *SQLStore) RestoreUnitTx(ctx context.Context, arg RestoreTxParams) (Unit, error) {
+	var result Unit
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetUnitIncludingDeleted(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.RestoreUnit(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionRestore, EntityUnit, arg.ID, before, result)
+	})
+
+	return result, err
+}
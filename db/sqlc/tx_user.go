@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Roles a user can have, the permissions of each role are defined by the api.
+// New users have RoleNone, which has no permissions, until an admin gives them a role.
+const (
+	RoleAdmin            = "admin"
+	RoleWarehouseManager = "warehouse_manager"
+	RoleClerk            = "clerk"
+	RoleAuditor          = "auditor"
+	RoleNone             = "none"
+)
+
+// userAccess is the snapshot of a user kept in the audit log, it leaves out the password
+type userAccess struct {
+	Username string      `json:"username"`
+	FullName string      `json:"full_name"`
+	Email    string      `json:"email"`
+	Role     string      `json:"role"`
+	Scopes   []UserScope `json:"scopes,omitempty"`
+}
+
+func newUserAccess(user User, scopes []UserScope) userAccess {
+	return userAccess{
+		Username: user.Username,
+		FullName: user.FullName,
+		Email:    user.Email,
+		Role:     user.Role,
+		Scopes:   scopes,
+	}
+}
+
+// CreateUserTxParams contains the input parameters of the create user transaction
+type CreateUserTxParams struct {
+	CreateUserParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateUserTx creates a user and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateUserTx(ctx context.Context, arg CreateUserTxParams) (User, error) {
+	var result User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateUser(ctx, arg.CreateUserParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityUser, result.Username, nil, newUserAccess(result, nil))
+	})
+
+	return result, err
+}
+
+// CreateFirstAdminTx creates the admin of a new database and records it in the audit log within a single
+// database transaction. Nobody can create users before, so the new admin is the actor.
+// sql.ErrNoRows is returned when there is an admin already.
+func (store *SQLStore) CreateFirstAdminTx(ctx context.Context, arg CreateFirstAdminParams) (User, error) {
+	var result User
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateFirstAdmin(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, result.Username, AuditActionCreate, EntityUser, result.Username, nil, newUserAccess(result, nil))
+	})
+
+	return result, err
+}
+
+// UpdateUserAccessTxParams contains the input parameters of the update user access transaction
+type UpdateUserAccessTxParams struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+	// categories and sections the user is restricted to, none means every category
+	Categories []int64  `json:"categories"`
+	Sections   []string `json:"sections"`
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateUserAccessTxResult is the result of the update user access transaction
+type UpdateUserAccessTxResult struct {
+	User   User        `json:"user"`
+	Scopes []UserScope `json:"scopes"`
+}
+
+// UpdateUserAccessTx changes the role of a user and replaces all of its scopes within a single database transaction.
+// Both versions of the access are recorded in the audit log.
+func (store *SQLStore) UpdateUserAccessTx(ctx context.Context, arg UpdateUserAccessTxParams) (UpdateUserAccessTxResult, error) {
+	var result UpdateUserAccessTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		user, err := q.GetUser(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		scopes, err := q.ListUserScopes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.User, err = q.UpdateUserRole(ctx, UpdateUserRoleParams{
+			Username: arg.Username,
+			Role:     arg.Role,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteUserScopes(ctx, arg.Username)
+		if err != nil {
+			return err
+		}
+
+		result.Scopes = []UserScope{}
+		for _, categoryID := range arg.Categories {
+			scope, err := q.CreateUserScope(ctx, CreateUserScopeParams{
+				Username:   arg.Username,
+				CategoryID: sql.NullInt64{Int64: categoryID, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			result.Scopes = append(result.Scopes, scope)
+		}
+
+		for _, section := range arg.Sections {
+			scope, err := q.CreateUserScope(ctx, CreateUserScopeParams{
+				Username:    arg.Username,
+				SectionName: sql.NullString{String: section, Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+			result.Scopes = append(result.Scopes, scope)
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityUser, arg.Username,
+			newUserAccess(user, scopes), newUserAccess(result.User, result.Scopes))
+	})
+
+	return result, err
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+)
+
+// Costing methods of a category, how the goods of the category are valued when they are issued
+const (
+	CostingMethodFIFO    = "fifo"
+	CostingMethodAverage = "average"
+)
+
+// moveValue values a movement and applies it to the cost layers and the stock value of its good.
+// Increases open a cost layer at the value given with the movement, or at the average cost of the good without one.
+// Decreases take the layers oldest first and are valued at the value of the layers taken under FIFO
+// or at the average cost of the good under moving weighted average. Transfers keep their value,
+// the stock in transit is still owned. The good is the one before the movement.
+// It returns the value of the movement, signed like its amount.
+func moveValue(ctx context.Context, q *Queries, good Good, arg StockMovementTxParams, movementID int64) (int64, error) {
+	if arg.MovementType == MovementTypeTransferOut || arg.MovementType == MovementTypeTransferIn || arg.Amount == 0 {
+		return 0, nil
+	}
+
+	quantity := good.Amount + good.InTransit
+
+	if arg.Amount > 0 {
+		value := arg.Value.Int64
+		if !arg.Value.Valid {
+			value = averageValue(good.StockValue, quantity, arg.Amount)
+		}
+
+		_, err := q.CreateCostLayer(ctx, CreateCostLayerParams{
+			GoodID:          good.ID,
+			StockMovementID: sql.NullInt64{Int64: movementID, Valid: true},
+			Quantity:        arg.Amount,
+			Value:           value,
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = q.AddGoodStockValue(ctx, AddGoodStockValueParams{
+			ID:     good.ID,
+			Amount: value,
+		})
+		return value, err
+	}
+
+	layered, err := consumeCostLayers(ctx, q, good.ID, -arg.Amount)
+	if err != nil {
+		return 0, err
+	}
+
+	category, err := q.GetCategoryIncludingDeleted(ctx, good.Category)
+	if err != nil {
+		return 0, err
+	}
+
+	value := layered
+	if category.CostingMethod == CostingMethodAverage {
+		value = averageValue(good.StockValue, quantity, -arg.Amount)
+	}
+
+	_, err = q.AddGoodStockValue(ctx, AddGoodStockValueParams{
+		ID:     good.ID,
+		Amount: -value,
+	})
+	return -value, err
+}
+
+// consumeCostLayers takes the amount from the open cost layers of the good, the oldest first,
+// and returns the value taken. A layer that is taken up completely gives all of its remaining value.
+func consumeCostLayers(ctx context.Context, q *Queries, goodID int64, amount int64) (int64, error) {
+	layers, err := q.ListOpenCostLayers(ctx, goodID)
+	if err != nil {
+		return 0, err
+	}
+
+	var value int64
+	for _, layer := range layers {
+		if amount == 0 {
+			break
+		}
+
+		taken := amount
+		if layer.Remaining < taken {
+			taken = layer.Remaining
+		}
+		takenValue := averageValue(layer.RemainingValue, layer.Remaining, taken)
+
+		_, err = q.ConsumeCostLayer(ctx, ConsumeCostLayerParams{
+			ID:       layer.ID,
+			Quantity: taken,
+			Value:    takenValue,
+		})
+		if err != nil {
+			return 0, err
+		}
+		value += takenValue
+		amount -= taken
+	}
+
+	return value, nil
+}
+
+// averageValue is the part of the value of a quantity that falls on the amount, rounded down.
+// The whole quantity gets the whole value, so nothing is left behind by the rounding.
+// The product of the value and the amount may not fit into 64 bits, the part of the value always does.
+func averageValue(value, quantity, amount int64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+	if amount >= quantity {
+		return value
+	}
+	part := new(big.Int).Mul(big.NewInt(value), big.NewInt(amount))
+	return part.Quo(part, big.NewInt(quantity)).Int64()
+}
+
+// MultiplyUnitCost is the value of an amount at the cost of one unit.
+// ErrAmountOverflow is returned when the value does not fit into the value columns.
+func MultiplyUnitCost(unitCost, amount int64) (int64, error) {
+	value := new(big.Int).Mul(big.NewInt(unitCost), big.NewInt(amount))
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("%w: %d at a unit cost of %d cannot be valued", ErrAmountOverflow, amount, unitCost)
+	}
+	return value.Int64(), nil
+}
+
+// SetCategoryCostingMethodTxParams contains the input parameters of the set category costing method transaction
+type SetCategoryCostingMethodTxParams struct {
+	SetCategoryCostingMethodParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// SetCategoryCostingMethodTx changes how the goods of a category are valued and records both versions
+// of the category in the audit log within a single database transaction. Goods switching to FIFO are
+// revalued at the value left in their cost layers, goods switching to average keep their value.
+// ErrVersionMismatch is returned when the category has been changed since the given version.
+func (store *SQLStore) SetCategoryCostingMethodTx(ctx context.Context, arg SetCategoryCostingMethodTxParams) (Category, error) {
+	var result Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetCategory(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if before.Version != arg.Version {
+			return ErrVersionMismatch
+		}
+
+		// the value of a good only changes under its row lock
+		_, err = q.LockCategoryGoods(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.SetCategoryCostingMethod(ctx, arg.SetCategoryCostingMethodParams)
+		if err == sql.ErrNoRows {
+			// a concurrent transaction changed the version since it was read
+			return ErrVersionMismatch
+		}
+		if err != nil {
+			return err
+		}
+
+		if result.CostingMethod == CostingMethodFIFO && before.CostingMethod != CostingMethodFIFO {
+			_, err = q.RevalueCategoryGoods(ctx, arg.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityCategory, result.ID, before, result)
+	})
+
+	return result, err
+}
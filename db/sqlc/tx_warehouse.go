@@ -0,0 +1,73 @@
+package db
+
+import "context"
+
+// CreateWarehouseTxParams contains the input parameters of the create warehouse transaction
+type CreateWarehouseTxParams struct {
+	CreateWarehouseParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// CreateWarehouseTx creates a warehouse and records it in the audit log within a single database transaction.
+func (store *SQLStore) CreateWarehouseTx(ctx context.Context, arg CreateWarehouseTxParams) (Warehouse, error) {
+	var result Warehouse
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+
+		result, err = q.CreateWarehouse(ctx, arg.CreateWarehouseParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionCreate, EntityWarehouse, result.ID, nil, result)
+	})
+
+	return result, err
+}
+
+// UpdateWarehouseTxParams contains the input parameters of the update warehouse transaction
+type UpdateWarehouseTxParams struct {
+	UpdateWarehouseParams
+	// username of the user making the change, recorded in the audit log
+	Actor string `json:"actor"`
+}
+
+// UpdateWarehouseTx updates a warehouse and records both versions of it in the audit log within a single database transaction.
+func (store *SQLStore) UpdateWarehouseTx(ctx context.Context, arg UpdateWarehouseTxParams) (Warehouse, error) {
+	var result Warehouse
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetWarehouse(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateWarehouse(ctx, arg.UpdateWarehouseParams)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionUpdate, EntityWarehouse, result.ID, before, result)
+	})
+
+	return result, err
+}
+
+// DeleteWarehouseTx deletes a warehouse and keeps its last version in the audit log within a single database transaction.
+func (store *SQLStore) DeleteWarehouseTx(ctx context.Context, arg DeleteTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		before, err := q.GetWarehouse(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteWarehouse(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return recordAudit(ctx, q, arg.Actor, AuditActionDelete, EntityWarehouse, arg.ID, before, nil)
+	})
+}
@@ -12,48 +12,156 @@ import (
 const createUnit = `-- name: CreateUnit :one
 INSERT INTO units (
   unit_name,
-  unit_value
+  unit_value,
+  unit_family
 ) VALUES (
-  $1, $2
-) RETURNING id, unit_name, unit_value
+  $1, $2, $3
+) RETURNING id, unit_name, unit_value, unit_family, deleted_at, version
 `
 
 type CreateUnitParams struct {
-	UnitName  string `json:"unit_name"`
-	UnitValue int64  `json:"unit_value"`
+	UnitName   string `json:"unit_name"`
+	UnitValue  int64  `json:"unit_value"`
+	UnitFamily string `json:"unit_family"`
 }
 
 func (q *Queries) CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, error) {
-	row := q.db.QueryRowContext(ctx, createUnit, arg.UnitName, arg.UnitValue)
+	row := q.db.QueryRowContext(ctx, createUnit, arg.UnitName, arg.UnitValue, arg.UnitFamily)
 	var i Unit
-	err := row.Scan(&i.ID, &i.UnitName, &i.UnitValue)
+	err := row.Scan(
+		&i.ID,
+		&i.UnitName,
+		&i.UnitValue,
+		&i.UnitFamily,
+		&i.DeletedAt,
+		&i.Version,
+	)
 	return i, err
 }
 
-const deleteUnit = `-- name: DeleteUnit :exec
-DELETE FROM units
-WHERE id = $1
+const deleteUnit = `-- name: DeleteUnit :one
+UPDATE units
+  set deleted_at = now(),
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, unit_name, unit_value, unit_family, deleted_at, version
+`
+
+// rows are only marked as deleted, so they can be restored
+func (q *Queries) DeleteUnit(ctx context.Context, id int64) (Unit, error) {
+	row := q.db.QueryRowContext(ctx, deleteUnit, id)
+	var i Unit
+	err := row.Scan(
+		&i.ID,
+		&i.UnitName,
+		&i.UnitValue,
+		&i.UnitFamily,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const exportUnits = `-- name: ExportUnits :many
+SELECT id, unit_name, unit_value, unit_family, deleted_at, version FROM units
+WHERE
+    id > $1 AND
+    ($2::bool OR deleted_at IS NULL)
+ORDER BY id
+LIMIT $3
+`
+
+type ExportUnitsParams struct {
+	AfterID        int64 `json:"after_id"`
+	IncludeDeleted bool  `json:"include_deleted"`
+	Limit          int32 `json:"limit"`
+}
+
+// the units a batch at a time after the unit after_id
+func (q *Queries) ExportUnits(ctx context.Context, arg ExportUnitsParams) ([]Unit, error) {
+	rows, err := q.db.QueryContext(ctx, exportUnits, arg.AfterID, arg.IncludeDeleted, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Unit{}
+	for rows.Next() {
+		var i Unit
+		if err := rows.Scan(
+			&i.ID,
+			&i.UnitName,
+			&i.UnitValue,
+			&i.UnitFamily,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnit = `-- name: GetUnit :one
+SELECT id, unit_name, unit_value, unit_family, deleted_at, version FROM units
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
+`
+
+func (q *Queries) GetUnit(ctx context.Context, id int64) (Unit, error) {
+	row := q.db.QueryRowContext(ctx, getUnit, id)
+	var i Unit
+	err := row.Scan(
+		&i.ID,
+		&i.UnitName,
+		&i.UnitValue,
+		&i.UnitFamily,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getUnitIncludingDeleted = `-- name: GetUnitIncludingDeleted :one
+SELECT id, unit_name, unit_value, unit_family, deleted_at, version FROM units
+WHERE id = $1 LIMIT 1
 `
 
-func (q *Queries) DeleteUnit(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, deleteUnit, id)
-	return err
+func (q *Queries) GetUnitIncludingDeleted(ctx context.Context, id int64) (Unit, error) {
+	row := q.db.QueryRowContext(ctx, getUnitIncludingDeleted, id)
+	var i Unit
+	err := row.Scan(
+		&i.ID,
+		&i.UnitName,
+		&i.UnitValue,
+		&i.UnitFamily,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
 }
 
 const listUnits = `-- name: ListUnits :many
-SELECT id, unit_name, unit_value FROM units
+SELECT id, unit_name, unit_value, unit_family, deleted_at, version FROM units
+WHERE $1::bool OR deleted_at IS NULL
 ORDER BY id
-LIMIT $1
-OFFSET $2
+LIMIT $2
+OFFSET $3
 `
 
 type ListUnitsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	IncludeDeleted bool  `json:"include_deleted"`
+	Limit          int32 `json:"limit"`
+	Offset         int32 `json:"offset"`
 }
 
 func (q *Queries) ListUnits(ctx context.Context, arg ListUnitsParams) ([]Unit, error) {
-	rows, err := q.db.QueryContext(ctx, listUnits, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listUnits, arg.IncludeDeleted, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +169,14 @@ func (q *Queries) ListUnits(ctx context.Context, arg ListUnitsParams) ([]Unit, e
 	items := []Unit{}
 	for rows.Next() {
 		var i Unit
-		if err := rows.Scan(&i.ID, &i.UnitName, &i.UnitValue); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.UnitName,
+			&i.UnitValue,
+			&i.UnitFamily,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -75,23 +190,113 @@ func (q *Queries) ListUnits(ctx context.Context, arg ListUnitsParams) ([]Unit, e
 	return items, nil
 }
 
+const listUnitsByName = `-- name: ListUnitsByName :many
+SELECT id, unit_name, unit_value, unit_family, deleted_at, version FROM units
+WHERE unit_name = $1 AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListUnitsByName(ctx context.Context, unitName string) ([]Unit, error) {
+	rows, err := q.db.QueryContext(ctx, listUnitsByName, unitName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Unit{}
+	for rows.Next() {
+		var i Unit
+		if err := rows.Scan(
+			&i.ID,
+			&i.UnitName,
+			&i.UnitValue,
+			&i.UnitFamily,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreUnit = `-- name: RestoreUnit :one
+UPDATE units
+  set deleted_at = NULL,
+      version = version + 1
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, unit_name, unit_value, unit_family, deleted_at, version
+`
+
+func (q *Queries) RestoreUnit(ctx context.Context, id int64) (Unit, error) {
+	row := q.db.QueryRowContext(ctx, restoreUnit, id)
+	var i Unit
+	err := row.Scan(
+		&i.ID,
+		&i.UnitName,
+		&i.UnitValue,
+		&i.UnitFamily,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const unitInUse = `-- name: UnitInUse :one
+SELECT (
+    EXISTS (SELECT 1 FROM goods WHERE goods.unit = $1) OR
+    EXISTS (SELECT 1 FROM purchase_order_lines WHERE purchase_order_lines.unit_id = $1)
+)::bool AS in_use
+`
+
+// whether amounts are kept in the unit, by goods or by purchase order lines, deleted goods included
+func (q *Queries) UnitInUse(ctx context.Context, id int64) (bool, error) {
+	row := q.db.QueryRowContext(ctx, unitInUse, id)
+	var inUse bool
+	err := row.Scan(&inUse)
+	return inUse, err
+}
+
 const updateUnit = `-- name: UpdateUnit :one
 UPDATE units
   set unit_name = $2,
-      unit_value = $3
-WHERE id = $1
-RETURNING id, unit_name, unit_value
+      unit_value = $3,
+      unit_family = $4,
+      version = version + 1
+WHERE id = $1 AND version = $5 AND deleted_at IS NULL
+RETURNING id, unit_name, unit_value, unit_family, deleted_at, version
 `
 
 type UpdateUnitParams struct {
-	ID        int64  `json:"id"`
-	UnitName  string `json:"unit_name"`
-	UnitValue int64  `json:"unit_value"`
+	ID         int64  `json:"id"`
+	UnitName   string `json:"unit_name"`
+	UnitValue  int64  `json:"unit_value"`
+	UnitFamily string `json:"unit_family"`
+	Version    int64  `json:"version"`
 }
 
 func (q *Queries) UpdateUnit(ctx context.Context, arg UpdateUnitParams) (Unit, error) {
-	row := q.db.QueryRowContext(ctx, updateUnit, arg.ID, arg.UnitName, arg.UnitValue)
+	row := q.db.QueryRowContext(ctx, updateUnit,
+		arg.ID,
+		arg.UnitName,
+		arg.UnitValue,
+		arg.UnitFamily,
+		arg.Version,
+	)
 	var i Unit
-	err := row.Scan(&i.ID, &i.UnitName, &i.UnitValue)
+	err := row.Scan(
+		&i.ID,
+		&i.UnitName,
+		&i.UnitValue,
+		&i.UnitFamily,
+		&i.DeletedAt,
+		&i.Version,
+	)
 	return i, err
 }
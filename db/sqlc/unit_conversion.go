@@ -0,0 +1,84 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Families of units, amounts can only be converted between units of the same family
+const (
+	UnitFamilyCount  = "count"
+	UnitFamilyMass   = "mass"
+	UnitFamilyLength = "length"
+	UnitFamilyVolume = "volume"
+)
+
+// ErrIncompatibleUnits is returned when an amount is given in a unit that cannot be converted into the unit of the good
+var ErrIncompatibleUnits = errors.New("incompatible units")
+
+// ErrFractionalAmount is returned when a converted amount is not a whole number of the target unit
+var ErrFractionalAmount = errors.New("amount is not a whole number of the unit")
+
+// ErrAmountOverflow is returned when a converted amount does not fit into the amount columns
+var ErrAmountOverflow = errors.New("amount is out of range")
+
+// ConvertAmount converts an amount from one unit into another unit of the same family.
+// The unit_value of a unit is the number of the smallest units of its family it holds,
+// so 1500 of a gram unit with value 1 is 1.5 of a kilogram unit with value 1000.
+// Amounts are whole numbers, conversions that would leave a fraction fail.
+func ConvertAmount(amount int64, from, to Unit) (int64, error) {
+	if from.UnitFamily != to.UnitFamily {
+		return 0, fmt.Errorf("%w: %s measures %s but %s measures %s",
+			ErrIncompatibleUnits, from.UnitName, from.UnitFamily, to.UnitName, to.UnitFamily)
+	}
+	if from.UnitValue <= 0 || to.UnitValue <= 0 {
+		return 0, fmt.Errorf("%w: %s and %s need a positive unit value", ErrIncompatibleUnits, from.UnitName, to.UnitName)
+	}
+	if from.UnitValue == to.UnitValue {
+		return amount, nil
+	}
+
+	// reduce the factor first so that the multiplication overflows as late as possible
+	d := gcd(from.UnitValue, to.UnitValue)
+	num, den := from.UnitValue/d, to.UnitValue/d
+
+	if amount%den != 0 {
+		return 0, fmt.Errorf("%w: %d %s is not a whole number of %s", ErrFractionalAmount, amount, from.UnitName, to.UnitName)
+	}
+
+	amount /= den
+	if amount > math.MaxInt64/num || amount < -math.MaxInt64/num {
+		return 0, fmt.Errorf("%w: %d %s is too large to express in %s", ErrAmountOverflow, amount*den, from.UnitName, to.UnitName)
+	}
+	return amount * num, nil
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// toGoodUnit converts an amount given in the unit with the given id into the unit of the good.
+// A zero unit id means the amount is already in the unit of the good.
+func toGoodUnit(ctx context.Context, q *Queries, good Good, unitID int64, amount int64) (int64, error) {
+	if unitID == 0 || unitID == good.Unit {
+		return amount, nil
+	}
+
+	from, err := q.GetUnit(ctx, unitID)
+	if err != nil {
+		return 0, err
+	}
+
+	// the good keeps counting in its unit even after the unit is deleted
+	to, err := q.GetUnitIncludingDeleted(ctx, good.Unit)
+	if err != nil {
+		return 0, err
+	}
+
+	return ConvertAmount(amount, from, to)
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"inventory_management/util"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createUnitOfFamily(t *testing.T, family string, value int64) Unit {
+	unit, err := testQueries.CreateUnit(context.Background(), CreateUnitParams{
+		UnitName:   util.RandomName(),
+		UnitValue:  value,
+		UnitFamily: family,
+	})
+	require.NoError(t, err)
+	return unit
+}
+
+func TestConvertAmount(t *testing.T) {
+	milligram := Unit{UnitName: "mg", UnitValue: 1, UnitFamily: UnitFamilyMass}
+	gram := Unit{UnitName: "g", UnitValue: 1000, UnitFamily: UnitFamilyMass}
+	kilogram := Unit{UnitName: "kg", UnitValue: 1000000, UnitFamily: UnitFamilyMass}
+	pound := Unit{UnitName: "lb", UnitValue: 453592370, UnitFamily: UnitFamilyMass}
+	piece := Unit{UnitName: "pcs", UnitValue: 1, UnitFamily: UnitFamilyCount}
+	dozen := Unit{UnitName: "dozen", UnitValue: 12, UnitFamily: UnitFamilyCount}
+
+	testCases := []struct {
+		name   string
+		amount int64
+		from   Unit
+		to     Unit
+		result int64
+		err    error
+	}{
+		{"SameUnit", 7, gram, gram, 7, nil},
+		{"Up", 3, kilogram, gram, 3000, nil},
+		{"Down", 3000, gram, kilogram, 3, nil},
+		{"Negative", -2, dozen, piece, -24, nil},
+		{"Fraction", 1500, gram, kilogram, 0, ErrFractionalAmount},
+		{"NegativeFraction", -5, piece, dozen, 0, ErrFractionalAmount},
+		{"NotDivisible", 1, pound, kilogram, 0, ErrFractionalAmount},
+		{"NoWholePounds", 1000, kilogram, pound, 0, ErrFractionalAmount},
+		{"OtherFamily", 1, kilogram, piece, 0, ErrIncompatibleUnits},
+		{"Overflow", math.MaxInt64 / 10, kilogram, milligram, 0, ErrAmountOverflow},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ConvertAmount(tc.amount, tc.from, tc.to)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.result, result)
+		})
+	}
+}
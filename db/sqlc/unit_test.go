@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"inventory_management/util"
 	"testing"
 
@@ -12,6 +13,7 @@ func createRandomUnit(t *testing.T) Unit {
 	arg := CreateUnitParams {
 		UnitName: util.RandomName(),
 		UnitValue: util.RandomInt(1, 3),
+		UnitFamily: UnitFamilyCount,
 	}
 	unit, err := testQueries.CreateUnit(context.Background(), arg)
 	require.NoError(t, err)
@@ -19,6 +21,7 @@ func createRandomUnit(t *testing.T) Unit {
 
 	require.Equal(t, arg.UnitName, unit.UnitName)
 	require.Equal(t, arg.UnitValue, unit.UnitValue)
+	require.Equal(t, arg.UnitFamily, unit.UnitFamily)
 	require.NotZero(t, unit.ID)
 
 	return unit
@@ -28,6 +31,14 @@ func TestCreateUnit(t *testing.T) {
 	createRandomUnit(t)
 }
 
+func TestGetUnit(t *testing.T) {
+	unit1 := createRandomUnit(t)
+	unit2, err := testQueries.GetUnit(context.Background(), unit1.ID)
+
+	require.NoError(t, err)
+	require.Equal(t, unit1, unit2)
+}
+
 func TestListUnits(t *testing.T) {
 
 	for i:=0;i<10;i++{
@@ -54,6 +65,8 @@ func TestUpdateUnit(t *testing.T) {
 		ID: unit1.ID,
 		UnitName: util.RandomName(),
 		UnitValue: util.RandomInt(4, 6),
+		UnitFamily: UnitFamilyMass,
+		Version: unit1.Version,
 	}
 	unit2, err := testQueries.UpdateUnit(context.Background(), arg)
 
@@ -61,14 +74,66 @@ func TestUpdateUnit(t *testing.T) {
 	require.NotEmpty(t, unit2)
 	require.NotEqual(t, unit1.UnitName, unit2.UnitName)
 	require.NotEqual(t, unit1.UnitValue, unit2.UnitValue)
+	require.Equal(t, arg.UnitFamily, unit2.UnitFamily)
+	require.Equal(t, unit1.Version+1, unit2.Version)
+
+	_, err = testQueries.UpdateUnit(context.Background(), arg)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
 }
 
 func TestDeleteUnit(t *testing.T) {
 	unit1 := createRandomUnit(t) 
 
-	err2 := testQueries.DeleteUnit(context.Background(), unit1.ID)
+	deleted, err2 := testQueries.DeleteUnit(context.Background(), unit1.ID)
 
 	require.NoError(t, err2)
+	require.True(t, deleted.DeletedAt.Valid)
+
+	_, err3 := testQueries.GetUnit(context.Background(), unit1.ID)
+	require.EqualError(t, err3, sql.ErrNoRows.Error())
+
+	unit2, err4 := testQueries.RestoreUnit(context.Background(), unit1.ID)
+	require.NoError(t, err4)
+	require.Equal(t, unit1, unit2)
+}
+func TestListUnitsByName(t *testing.T) {
+	unit1 := createRandomUnit(t)
+
+	units, err := testQueries.ListUnitsByName(context.Background(), unit1.UnitName)
+	require.NoError(t, err)
+	require.Len(t, units, 1)
+	require.Equal(t, unit1, units[0])
+
+	_, err = testQueries.DeleteUnit(context.Background(), unit1.ID)
+	require.NoError(t, err)
 
-	// Must be edited 
-}
\ No newline at end of file
+	units, err = testQueries.ListUnitsByName(context.Background(), unit1.UnitName)
+	require.NoError(t, err)
+	require.Empty(t, units)
+}
+
+func TestExportUnits(t *testing.T) {
+	unit1 := createRandomUnit(t)
+	unit2 := createRandomUnit(t)
+
+	_, err := testQueries.DeleteUnit(context.Background(), unit2.ID)
+	require.NoError(t, err)
+
+	arg := ExportUnitsParams{
+		AfterID: unit1.ID - 1,
+		Limit:   5,
+	}
+	units, err := testQueries.ExportUnits(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, units)
+	require.Equal(t, unit1, units[0])
+	for _, unit := range units {
+		require.NotEqual(t, unit2.ID, unit.ID)
+	}
+
+	arg.IncludeDeleted = true
+	units, err = testQueries.ExportUnits(context.Background(), arg)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(units), 2)
+	require.Equal(t, unit2.ID, units[1].ID)
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserScope(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomCategory(t)
+
+	arg := CreateUserScopeParams{
+		Username:   user.Username,
+		CategoryID: sql.NullInt64{Int64: category.ID, Valid: true},
+	}
+	scope, err := testQueries.CreateUserScope(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotZero(t, scope.ID)
+	require.Equal(t, arg.Username, scope.Username)
+	require.Equal(t, arg.CategoryID, scope.CategoryID)
+	require.False(t, scope.SectionName.Valid)
+}
+
+func TestCreateUserScopeTargetCheck(t *testing.T) {
+	user := createRandomUser(t)
+	category := createRandomCategory(t)
+
+	// a scope is either a category or a section
+	arg := CreateUserScopeParams{
+		Username:    user.Username,
+		CategoryID:  sql.NullInt64{Int64: category.ID, Valid: true},
+		SectionName: sql.NullString{String: category.SectionName, Valid: true},
+	}
+	_, err := testQueries.CreateUserScope(context.Background(), arg)
+	require.Error(t, err)
+
+	_, err = testQueries.CreateUserScope(context.Background(), CreateUserScopeParams{Username: user.Username})
+	require.Error(t, err)
+}
+
+func TestListAndDeleteUserScopes(t *testing.T) {
+	user := createRandomUser(t)
+
+	for i := 0; i < 3; i++ {
+		arg := CreateUserScopeParams{
+			Username:    user.Username,
+			SectionName: sql.NullString{String: util.RandomName(), Valid: true},
+		}
+		_, err := testQueries.CreateUserScope(context.Background(), arg)
+		require.NoError(t, err)
+	}
+
+	scopes, err := testQueries.ListUserScopes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Len(t, scopes, 3)
+	for _, scope := range scopes {
+		require.Equal(t, user.Username, scope.Username)
+		require.True(t, scope.SectionName.Valid)
+	}
+
+	err = testQueries.DeleteUserScopes(context.Background(), user.Username)
+	require.NoError(t, err)
+
+	scopes, err = testQueries.ListUserScopes(context.Background(), user.Username)
+	require.NoError(t, err)
+	require.Empty(t, scopes)
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomUser(t *testing.T) User {
+	hashedPassword, err := util.HashPassword(util.RandomString(6))
+	require.NoError(t, err)
+
+	arg := CreateUserParams{
+		Username:       util.RandomString(10),
+		HashedPassword: hashedPassword,
+		FullName:       util.RandomName(),
+		Email:          util.RandomString(10) + "@email.com",
+	}
+
+	user, err := testQueries.CreateUser(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, user)
+
+	require.Equal(t, arg.Username, user.Username)
+	require.Equal(t, arg.HashedPassword, user.HashedPassword)
+	require.Equal(t, arg.FullName, user.FullName)
+	require.Equal(t, arg.Email, user.Email)
+	require.Equal(t, RoleNone, user.Role)
+	require.True(t, user.PasswordChangedAt.IsZero())
+	require.NotZero(t, user.CreatedAt)
+
+	return user
+}
+
+func TestCreateUser(t *testing.T) {
+	createRandomUser(t)
+}
+
+func TestGetUser(t *testing.T) {
+	user1 := createRandomUser(t)
+	user2, err := testQueries.GetUser(context.Background(), user1.Username)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, user2)
+
+	require.Equal(t, user1.Username, user2.Username)
+	require.Equal(t, user1.HashedPassword, user2.HashedPassword)
+	require.Equal(t, user1.FullName, user2.FullName)
+	require.Equal(t, user1.Email, user2.Email)
+	require.Equal(t, user1.Role, user2.Role)
+	require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
+	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
+}
+
+func TestGetUserNotFound(t *testing.T) {
+	_, err := testQueries.GetUser(context.Background(), util.RandomString(10))
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	user1 := createRandomUser(t)
+
+	arg := UpdateUserRoleParams{
+		Username: user1.Username,
+		Role:     RoleWarehouseManager,
+	}
+	user2, err := testQueries.UpdateUserRole(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, user2)
+
+	require.Equal(t, user1.Username, user2.Username)
+	require.Equal(t, arg.Role, user2.Role)
+	require.Equal(t, user1.HashedPassword, user2.HashedPassword)
+}
+
+func TestUpdateUserRoleInvalid(t *testing.T) {
+	user := createRandomUser(t)
+
+	arg := UpdateUserRoleParams{
+		Username: user.Username,
+		Role:     "owner",
+	}
+	_, err := testQueries.UpdateUserRole(context.Background(), arg)
+	require.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// createValuedMovement records a movement of the good with the given value
+func createValuedMovement(t *testing.T, good Good, movementType string, amount, value int64) StockMovement {
+	movement, err := testQueries.CreateStockMovement(context.Background(), CreateStockMovementParams{
+		GoodID:       good.ID,
+		WarehouseID:  createRandomWarehouse(t).ID,
+		MovementType: movementType,
+		Amount:       amount,
+	})
+	require.NoError(t, err)
+
+	movement, err = testQueries.SetStockMovementValue(context.Background(), SetStockMovementValueParams{
+		ID:    movement.ID,
+		Value: value,
+	})
+	require.NoError(t, err)
+	require.Equal(t, value, movement.Value)
+
+	return movement
+}
+
+func TestListValuations(t *testing.T) {
+	category := createRandomCategory(t)
+	unit := createRandomUnit(t)
+	good1 := createRandomGood(t, category, unit)
+	good2 := createRandomGood(t, category, unit)
+
+	for _, good := range []Good{good1, good2} {
+		updated, err := testQueries.AddGoodStockValue(context.Background(), AddGoodStockValueParams{
+			ID:     good.ID,
+			Amount: 1000,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), updated.StockValue)
+		require.Equal(t, good.Version, updated.Version)
+	}
+
+	createValuedMovement(t, good1, MovementTypeIssue, -2, -300)
+	createValuedMovement(t, good1, MovementTypeIssue, -1, -150)
+	// only issues count as cost of goods issued
+	createValuedMovement(t, good1, MovementTypeAdjustment, -1, -150)
+	createValuedMovement(t, good2, MovementTypeReceipt, 4, 400)
+
+	now := time.Now()
+	goods, err := testQueries.ListGoodValuations(context.Background(), ListGoodValuationsParams{
+		FromTime: now.Add(-time.Hour),
+		ToTime:   now.Add(time.Hour),
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
+		Limit:    5,
+		Offset:   0,
+	})
+	require.NoError(t, err)
+	require.Len(t, goods, 2)
+	require.Equal(t, good1.ID, goods[0].GoodID)
+	require.Equal(t, CostingMethodFIFO, goods[0].CostingMethod)
+	require.Equal(t, good1.Amount+good1.InTransit, goods[0].Quantity)
+	require.Equal(t, int64(1000), goods[0].Value)
+	require.Equal(t, int64(450), goods[0].CostOfGoodsIssued)
+	require.Equal(t, good2.ID, goods[1].GoodID)
+	require.Zero(t, goods[1].CostOfGoodsIssued)
+
+	categories, err := testQueries.ListCategoryValuations(context.Background(), ListCategoryValuationsParams{
+		FromTime: now.Add(-time.Hour),
+		ToTime:   now.Add(time.Hour),
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Equal(t, category.ID, categories[0].Category)
+	require.Equal(t, int64(2000), categories[0].Value)
+	require.Equal(t, int64(450), categories[0].CostOfGoodsIssued)
+
+	// issues outside of the period are left out
+	categories, err = testQueries.ListCategoryValuations(context.Background(), ListCategoryValuationsParams{
+		FromTime: now.Add(time.Hour),
+		ToTime:   now.Add(2 * time.Hour),
+		Category: sql.NullInt64{Int64: category.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, categories, 1)
+	require.Zero(t, categories[0].CostOfGoodsIssued)
+}
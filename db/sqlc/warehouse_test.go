@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"inventory_management/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomWarehouse(t *testing.T) Warehouse {
+	arg := CreateWarehouseParams{
+		WarehouseName: util.RandomName(),
+		Address:       util.RandomString(12),
+	}
+
+	warehouse, err := testQueries.CreateWarehouse(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, warehouse)
+
+	require.Equal(t, arg.WarehouseName, warehouse.WarehouseName)
+	require.Equal(t, arg.Address, warehouse.Address)
+	require.NotZero(t, warehouse.ID)
+	require.NotZero(t, warehouse.CreatedAt)
+
+	return warehouse
+}
+
+func TestCreateWarehouse(t *testing.T) {
+	createRandomWarehouse(t)
+}
+
+func TestGetWarehouse(t *testing.T) {
+	warehouse1 := createRandomWarehouse(t)
+	warehouse2, err := testQueries.GetWarehouse(context.Background(), warehouse1.ID)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, warehouse2)
+
+	require.Equal(t, warehouse1.ID, warehouse2.ID)
+	require.Equal(t, warehouse1.WarehouseName, warehouse2.WarehouseName)
+	require.Equal(t, warehouse1.Address, warehouse2.Address)
+	require.WithinDuration(t, warehouse1.CreatedAt, warehouse2.CreatedAt, time.Second)
+}
+
+func TestListWarehouses(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		createRandomWarehouse(t)
+	}
+
+	arg := ListWarehousesParams{
+		Limit:  5,
+		Offset: 5,
+	}
+	warehouses, err := testQueries.ListWarehouses(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.Len(t, warehouses, 5)
+
+	for _, warehouse := range warehouses {
+		require.NotEmpty(t, warehouse)
+	}
+}
+
+func TestUpdateWarehouse(t *testing.T) {
+	warehouse1 := createRandomWarehouse(t)
+
+	arg := UpdateWarehouseParams{
+		ID:            warehouse1.ID,
+		WarehouseName: util.RandomName(),
+		Address:       util.RandomString(12),
+	}
+	warehouse2, err := testQueries.UpdateWarehouse(context.Background(), arg)
+
+	require.NoError(t, err)
+	require.NotEmpty(t, warehouse2)
+	require.Equal(t, warehouse1.ID, warehouse2.ID)
+	require.Equal(t, arg.WarehouseName, warehouse2.WarehouseName)
+	require.Equal(t, arg.Address, warehouse2.Address)
+}
+
+func TestDeleteWarehouse(t *testing.T) {
+	warehouse1 := createRandomWarehouse(t)
+
+	err := testQueries.DeleteWarehouse(context.Background(), warehouse1.ID)
+	require.NoError(t, err)
+
+	warehouse2, err := testQueries.GetWarehouse(context.Background(), warehouse1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, warehouse2)
+}
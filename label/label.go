@@ -0,0 +1,114 @@
+// Package label renders the labels of goods as PNG images, A4 PDF sheets and ZPL for label printers.
+package label
+
+import (
+	"errors"
+	"fmt"
+	"inventory_management/util"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Code types of a template, what kind of code is printed on the label
+const (
+	CodeTypeBarcode = "barcode"
+	CodeTypeQR      = "qr"
+)
+
+// The layout of a label in millimetres. The text lines are at the top and the code fills the rest.
+const (
+	marginMM     = 2.0
+	lineHeightMM = 3.5
+	minCodeMM    = 5.0
+)
+
+// ErrLabelTooSmall is returned when the text lines leave no room for the code, or the code does not fit
+var ErrLabelTooSmall = errors.New("the label is too small for its content")
+
+// Template is the size and the content of a label
+type Template struct {
+	WidthMM  int
+	HeightMM int
+	// DPI is the resolution of the PNG and ZPL output
+	DPI          int
+	CodeType     string
+	ShowModel    bool
+	ShowCategory bool
+	ShowUnit     bool
+}
+
+// DefaultTemplate is used when no template is chosen, a 50x30 mm label for a 203 dpi printer
+func DefaultTemplate() Template {
+	return Template{
+		WidthMM:      50,
+		HeightMM:     30,
+		DPI:          203,
+		CodeType:     CodeTypeBarcode,
+		ShowModel:    true,
+		ShowCategory: true,
+		ShowUnit:     true,
+	}
+}
+
+// Label is what is printed for one good
+type Label struct {
+	Model    string
+	Category string
+	Unit     string
+	// Code is encoded in the symbology, or in a QR code when the template asks for one
+	Code      string
+	Symbology string
+}
+
+// lines are the text lines of the label in the order they are printed
+func (t Template) lines(l Label) []string {
+	var lines []string
+	if t.ShowModel {
+		lines = append(lines, l.Model)
+	}
+	if t.ShowCategory {
+		lines = append(lines, l.Category)
+	}
+	if t.ShowUnit {
+		lines = append(lines, l.Unit)
+	}
+	return lines
+}
+
+// codeArea is the space left for the code below the text lines, in millimetres
+func (t Template) codeArea(lines int) (x, y, width, height float64, err error) {
+	x = marginMM
+	y = marginMM + float64(lines)*lineHeightMM
+	width = float64(t.WidthMM) - 2*marginMM
+	height = float64(t.HeightMM) - marginMM - y
+	if width < minCodeMM || height < minCodeMM {
+		return 0, 0, 0, 0, ErrLabelTooSmall
+	}
+	return x, y, width, height, nil
+}
+
+// dots converts millimetres to printer dots or pixels at the resolution of the template
+func (t Template) dots(mm float64) int {
+	return int(mm * float64(t.DPI) / 25.4)
+}
+
+// encode encodes the code of the label in the code type of the template.
+// UPC-A is encoded as the EAN-13 with a leading zero, which has the same bars.
+func (t Template) encode(l Label) (barcode.Barcode, error) {
+	if t.CodeType == CodeTypeQR {
+		return qr.Encode(l.Code, qr.M, qr.Auto)
+	}
+
+	switch l.Symbology {
+	case util.BarcodeEAN13:
+		return ean.Encode(l.Code)
+	case util.BarcodeUPCA:
+		return ean.Encode("0" + l.Code)
+	case util.BarcodeCode128:
+		return code128.Encode(l.Code)
+	}
+	return nil, fmt.Errorf("unknown symbology %q", l.Symbology)
+}
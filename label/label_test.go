@@ -0,0 +1,159 @@
+package label
+
+import (
+	"bytes"
+	"image/png"
+	"inventory_management/util"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomLabel() Label {
+	return Label{
+		Model:     util.RandomName(),
+		Category:  util.RandomName(),
+		Unit:      util.RandomName(),
+		Code:      util.RandomEAN13(),
+		Symbology: util.BarcodeEAN13,
+	}
+}
+
+func TestPNG(t *testing.T) {
+	testCases := []struct {
+		name     string
+		template func() Template
+		label    Label
+		check    func(t *testing.T, output []byte, err error)
+	}{
+		{
+			name:     "Barcode",
+			template: DefaultTemplate,
+			label:    randomLabel(),
+			check: func(t *testing.T, output []byte, err error) {
+				require.NoError(t, err)
+				img, err := png.Decode(bytes.NewReader(output))
+				require.NoError(t, err)
+				// 50x30 mm at 203 dpi
+				require.Equal(t, 399, img.Bounds().Dx())
+				require.Equal(t, 239, img.Bounds().Dy())
+			},
+		},
+		{
+			name: "QR",
+			template: func() Template {
+				template := DefaultTemplate()
+				template.CodeType = CodeTypeQR
+				template.DPI = 300
+				return template
+			},
+			label: Label{Model: util.RandomName(), Code: "PART-0042", Symbology: util.BarcodeCode128},
+			check: func(t *testing.T, output []byte, err error) {
+				require.NoError(t, err)
+				img, err := png.Decode(bytes.NewReader(output))
+				require.NoError(t, err)
+				require.Equal(t, 590, img.Bounds().Dx())
+			},
+		},
+		{
+			name: "UPCA",
+			template: func() Template {
+				template := DefaultTemplate()
+				template.ShowCategory = false
+				return template
+			},
+			label: Label{Model: util.RandomName(), Code: "036000291452", Symbology: util.BarcodeUPCA},
+			check: func(t *testing.T, output []byte, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "NoRoomForCode",
+			template: func() Template {
+				template := DefaultTemplate()
+				template.HeightMM = 10
+				return template
+			},
+			label: randomLabel(),
+			check: func(t *testing.T, output []byte, err error) {
+				require.ErrorIs(t, err, ErrLabelTooSmall)
+			},
+		},
+		{
+			name: "CodeTooWide",
+			template: func() Template {
+				template := DefaultTemplate()
+				template.WidthMM = 10
+				return template
+			},
+			label: randomLabel(),
+			check: func(t *testing.T, output []byte, err error) {
+				require.ErrorIs(t, err, ErrLabelTooSmall)
+			},
+		},
+		{
+			name:     "UnknownSymbology",
+			template: DefaultTemplate,
+			label:    Label{Code: "4006381333931", Symbology: "qr"},
+			check: func(t *testing.T, output []byte, err error) {
+				require.Error(t, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var output bytes.Buffer
+			err := PNG(&output, tc.template(), tc.label)
+			tc.check(t, output.Bytes(), err)
+		})
+	}
+}
+
+func TestPDF(t *testing.T) {
+	labels := make([]Label, 50)
+	for i := range labels {
+		labels[i] = randomLabel()
+	}
+
+	var output bytes.Buffer
+	err := PDF(&output, DefaultTemplate(), labels)
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(output.Bytes(), []byte("%PDF")))
+	// 3 columns of 9 labels on a sheet
+	require.Equal(t, 2, bytes.Count(output.Bytes(), []byte("/Type /Page\n")))
+
+	template := DefaultTemplate()
+	template.WidthMM = 200
+	err = PDF(&output, template, labels)
+	require.ErrorIs(t, err, ErrLabelTooLarge)
+}
+
+func TestZPL(t *testing.T) {
+	template := DefaultTemplate()
+	labels := []Label{
+		{Model: "Drill ^1~", Category: "Tools", Unit: "piece", Code: "4006381333931", Symbology: util.BarcodeEAN13},
+		{Model: "Saw", Category: "Tools", Unit: "piece", Code: "036000291452", Symbology: util.BarcodeUPCA},
+		{Model: "Nail", Category: "Tools", Unit: "box", Code: "A>B_1", Symbology: util.BarcodeCode128},
+	}
+
+	var output bytes.Buffer
+	err := ZPL(&output, template, labels)
+	require.NoError(t, err)
+
+	zpl := output.String()
+	require.Equal(t, 3, strings.Count(zpl, "^XA"))
+	require.Equal(t, 3, strings.Count(zpl, "^XZ"))
+	require.Contains(t, zpl, "^PW399\n^LL239\n")
+	require.Contains(t, zpl, "^FDDrill _5E1_7E^FS")
+	require.Contains(t, zpl, "^FD400638133393^FS")
+	require.Contains(t, zpl, "^FD03600029145^FS")
+	require.Contains(t, zpl, "^FDA><B_5F1^FS")
+
+	template.CodeType = CodeTypeQR
+	output.Reset()
+	err = ZPL(&output, template, labels[:1])
+	require.NoError(t, err)
+	require.Contains(t, output.String(), "^FDMA,4006381333931^FS")
+}
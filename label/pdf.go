@@ -0,0 +1,78 @@
+package label
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// The size of an A4 sheet and the margin left around the labels, in millimetres
+const (
+	sheetWidthMM  = 210.0
+	sheetHeightMM = 297.0
+	sheetMarginMM = 10.0
+)
+
+// ErrLabelTooLarge is returned when not even one label fits on a sheet
+var ErrLabelTooLarge = errors.New("the label does not fit on an A4 sheet")
+
+// PDF renders the labels on A4 sheets for batch printing, as many labels on a sheet as fit on it,
+// row by row. The text is set in Helvetica and the codes are images at the resolution of the template.
+func PDF(w io.Writer, t Template, labels []Label) error {
+	columns := int((sheetWidthMM - 2*sheetMarginMM) / float64(t.WidthMM))
+	rows := int((sheetHeightMM - 2*sheetMarginMM) / float64(t.HeightMM))
+	if columns == 0 || rows == 0 {
+		return ErrLabelTooLarge
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	// the font size in points that fills most of a line
+	pdf.SetFont("Helvetica", "", lineHeightMM*0.8*72/25.4)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for i, l := range labels {
+		cell := i % (columns * rows)
+		if cell == 0 {
+			pdf.AddPage()
+		}
+		left := sheetMarginMM + float64(cell%columns*t.WidthMM)
+		top := sheetMarginMM + float64(cell/columns*t.HeightMM)
+
+		lines := t.lines(l)
+		x, y, width, height, err := t.codeArea(len(lines))
+		if err != nil {
+			return err
+		}
+
+		for j, line := range lines {
+			text := translate(line)
+			for text != "" && pdf.GetStringWidth(text) > width {
+				text = text[:len(text)-1]
+			}
+			pdf.SetXY(left+marginMM, top+marginMM+float64(j)*lineHeightMM)
+			pdf.CellFormat(width, lineHeightMM, text, "", 0, "L", false, 0, "")
+		}
+
+		code, err := t.codeImage(l, width, height)
+		if err != nil {
+			return err
+		}
+		var image bytes.Buffer
+		err = png.Encode(&image, code)
+		if err != nil {
+			return err
+		}
+
+		name := fmt.Sprintf("code%d", i)
+		options := fpdf.ImageOptions{ImageType: "PNG"}
+		pdf.RegisterImageOptionsReader(name, options, &image)
+		pdf.ImageOptions(name, left+x, top+y, width, height, false, options, 0, "")
+	}
+
+	return pdf.Output(w)
+}
@@ -0,0 +1,104 @@
+package label
+
+import (
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/boombuler/barcode"
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// PNG renders one label as a PNG image at the resolution of the template
+func PNG(w io.Writer, t Template, l Label) error {
+	img, err := t.render(l)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// render draws the label in black on white at the resolution of the template
+func (t Template) render(l Label) (*image.Gray, error) {
+	lines := t.lines(l)
+	x, y, width, height, err := t.codeArea(len(lines))
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := t.codeImage(l, width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewGray(image.Rect(0, 0, t.dots(float64(t.WidthMM)), t.dots(float64(t.HeightMM))))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	for i, line := range lines {
+		t.drawText(img, line, marginMM, marginMM+float64(i)*lineHeightMM, width)
+	}
+	draw.Draw(img, code.Bounds().Add(image.Pt(t.dots(x), t.dots(y))), code, image.Point{}, draw.Src)
+
+	return img, nil
+}
+
+// codeImage is the code of the label scaled to the area given in millimetres.
+// Bars and modules are only scaled by whole dots, so they stay sharp.
+func (t Template) codeImage(l Label, width, height float64) (image.Image, error) {
+	code, err := t.encode(l)
+	if err != nil {
+		return nil, err
+	}
+
+	scaled, err := barcode.Scale(code, t.dots(width), t.dots(height))
+	if err != nil {
+		return nil, ErrLabelTooSmall
+	}
+	return scaled, nil
+}
+
+// drawText draws a line of text with its top left corner at the position given in millimetres.
+// The fixed size font is scaled by whole dots up to the line height and the text is cut at the width.
+func (t Template) drawText(img draw.Image, text string, x, y, width float64) {
+	face := basicfont.Face7x13
+	scale := t.dots(lineHeightMM*0.8) / face.Height
+	if scale < 1 {
+		scale = 1
+	}
+
+	text = truncate(text, t.dots(width)/(face.Advance*scale))
+	if text == "" {
+		return
+	}
+
+	line := image.NewGray(image.Rect(0, 0, face.Advance*len([]rune(text)), face.Height))
+	draw.Draw(line, line.Bounds(), image.White, image.Point{}, draw.Src)
+	drawer := font.Drawer{
+		Dst:  line,
+		Src:  image.Black,
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(text)
+
+	origin := image.Pt(t.dots(x), t.dots(y))
+	target := image.Rectangle{
+		Min: origin,
+		Max: origin.Add(line.Bounds().Size().Mul(scale)),
+	}
+	draw.NearestNeighbor.Scale(img, target, line, line.Bounds(), draw.Src, nil)
+}
+
+// truncate cuts the text after the given number of characters
+func truncate(text string, length int) string {
+	runes := []rune(text)
+	if length < 0 {
+		length = 0
+	}
+	if len(runes) > length {
+		runes = runes[:length]
+	}
+	return string(runes)
+}
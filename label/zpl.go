@@ -0,0 +1,81 @@
+package label
+
+import (
+	"fmt"
+	"inventory_management/util"
+	"io"
+	"strings"
+)
+
+// maxModuleDots is the widest module ZPL accepts for barcodes and QR codes
+const maxModuleDots = 10
+
+// zplEscaper hex escapes the characters ZPL reads as commands, for fields that start with ^FH
+var zplEscaper = strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E")
+
+// ZPL renders the labels as ZPL for label printers, one label format after the other.
+// Printers compute the check digits of EAN-13 and UPC-A themselves, so they are left out.
+func ZPL(w io.Writer, t Template, labels []Label) error {
+	var b strings.Builder
+
+	for _, l := range labels {
+		lines := t.lines(l)
+		x, y, width, height, err := t.codeArea(len(lines))
+		if err != nil {
+			return err
+		}
+
+		code, err := t.encode(l)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&b, "^XA\n^CI28\n^PW%d\n^LL%d\n", t.dots(float64(t.WidthMM)), t.dots(float64(t.HeightMM)))
+
+		fontDots := t.dots(lineHeightMM * 0.8)
+		for i, line := range lines {
+			fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,1,0,L,0^FH^FD%s^FS\n",
+				t.dots(marginMM), t.dots(marginMM+float64(i)*lineHeightMM), fontDots, fontDots,
+				t.dots(width), zplEscaper.Replace(line))
+		}
+
+		// the modules are scaled by whole dots like in the images and the code is centered
+		modules := code.Bounds().Dx()
+		size := t.dots(width)
+		if t.CodeType == CodeTypeQR && t.dots(height) < size {
+			size = t.dots(height)
+		}
+		module := size / modules
+		if module < 1 {
+			return ErrLabelTooSmall
+		}
+		if module > maxModuleDots {
+			module = maxModuleDots
+		}
+		left := t.dots(x) + (t.dots(width)-module*modules)/2
+
+		if t.CodeType == CodeTypeQR {
+			fmt.Fprintf(&b, "^FO%d,%d^BQN,2,%d^FH^FDMA,%s^FS\n", left, t.dots(y), module, zplEscaper.Replace(l.Code))
+		} else {
+			command, data := zplBarcode(l, t.dots(height))
+			fmt.Fprintf(&b, "^FO%d,%d^BY%d^%s^FH^FD%s^FS\n", left, t.dots(y), module, command, data)
+		}
+
+		b.WriteString("^XZ\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// zplBarcode is the barcode command of the symbology of the label and its field data
+func zplBarcode(l Label, height int) (string, string) {
+	switch l.Symbology {
+	case util.BarcodeEAN13:
+		return fmt.Sprintf("BEN,%d,N,N", height), l.Code[:12]
+	case util.BarcodeUPCA:
+		return fmt.Sprintf("BUN,%d,N,N", height), l.Code[:11]
+	}
+	// > starts a subset switch in Code 128 data, >< is the character itself
+	return fmt.Sprintf("BCN,%d,N,N,N", height), strings.ReplaceAll(zplEscaper.Replace(l.Code), ">", "><")
+}
@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"inventory_management/api"
 	db "inventory_management/db/sqlc"
 	"inventory_management/util"
+	"inventory_management/worker"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 
 	"log"
 )
@@ -24,10 +27,55 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
-	server := api.NewServer(store)
+
+	if config.AdminUsername != "" {
+		err = createFirstAdmin(store, config)
+		if err != nil {
+			log.Fatal("cannot create the first admin:", err)
+		}
+	}
+
+	// the database notifies the goods falling below their reorder point on its own connection
+	listener := pq.NewListener(config.DBSource, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("low stock listener:", err)
+		}
+	})
+	err = listener.Listen(worker.LowStockChannel)
+	if err != nil {
+		log.Fatal("cannot listen for low stock:", err)
+	}
+
+	checker := worker.NewLowStockChecker(store, config.LowStockCheckInterval)
+	go checker.Run(context.Background(), listener.Notify)
+
+	server, err := api.NewServer(config, store)
+	if err != nil {
+		log.Fatal("connot create server:", err)
+	}
+
 	err = server.Start(config.ServerAddress)
 	if err != nil {
 		log.Fatal("connot start server:", err)
 	}
 
 }
+
+// createFirstAdmin sets up the admin of the config unless the database has an admin already
+func createFirstAdmin(store db.Store, config util.Config) error {
+	hashedPassword, err := util.HashPassword(config.AdminPassword)
+	if err != nil {
+		return err
+	}
+
+	_, err = store.CreateFirstAdminTx(context.Background(), db.CreateFirstAdminParams{
+		Username:       config.AdminUsername,
+		HashedPassword: hashedPassword,
+		FullName:       config.AdminUsername,
+		Email:          config.AdminEmail,
+	})
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Different types of error returned by the VerifyToken function
+var (
+	ErrInvalidToken = errors.New("token is invalid")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Scope restricts the permissions of a token to some categories and to the categories of some sections.
+// An empty scope does not restrict anything.
+type Scope struct {
+	Categories []int64  `json:"categories,omitempty"`
+	Sections   []string `json:"sections,omitempty"`
+}
+
+// IsEmpty reports whether the scope allows every category
+func (scope Scope) IsEmpty() bool {
+	return len(scope.Categories) == 0 && len(scope.Sections) == 0
+}
+
+// Payload contains the payload data of the token
+type Payload struct {
+	ID        uuid.UUID `json:"id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	Scope     Scope     `json:"scope"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
+// NewPayload creates a new token payload with a specific username, role, scope and duration
+func NewPayload(username string, role string, scope Scope, duration time.Duration) (*Payload, error) {
+	tokenID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &Payload{
+		ID:        tokenID,
+		Username:  username,
+		Role:      role,
+		Scope:     scope,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(duration),
+	}
+	return payload, nil
+}
+
+// Valid checks if the token payload is valid or not
+func (payload *Payload) Valid() error {
+	if time.Now().After(payload.ExpiredAt) {
+		return ErrExpiredToken
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Barcode symbologies a good can be labelled with
+const (
+	BarcodeEAN13   = "ean13"
+	BarcodeUPCA    = "upca"
+	BarcodeCode128 = "code128"
+)
+
+// maxCode128Length is the longest Code 128 barcode that still fits on a label
+const maxCode128Length = 48
+
+// ErrInvalidBarcode is returned when a barcode does not follow its symbology
+var ErrInvalidBarcode = errors.New("invalid barcode")
+
+// ValidateBarcode checks a barcode against its symbology. EAN-13 and UPC-A barcodes are all digits
+// and end in a check digit. Code 128 carries its checksum in the printed symbol only,
+// so its text just has to be printable ASCII of a length that fits on a label.
+func ValidateBarcode(symbology string, barcode string) error {
+	switch symbology {
+	case BarcodeEAN13:
+		return validateCheckDigit(barcode, 13)
+	case BarcodeUPCA:
+		return validateCheckDigit(barcode, 12)
+	case BarcodeCode128:
+		if len(barcode) == 0 || len(barcode) > maxCode128Length {
+			return fmt.Errorf("%w: a code128 barcode has 1 to %d characters", ErrInvalidBarcode, maxCode128Length)
+		}
+		for _, c := range barcode {
+			if c < ' ' || c > '~' {
+				return fmt.Errorf("%w: a code128 barcode is printable ASCII", ErrInvalidBarcode)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unknown symbology %s", ErrInvalidBarcode, symbology)
+}
+
+// validateCheckDigit checks a barcode of the given number of digits whose last digit is the GTIN check digit
+func validateCheckDigit(barcode string, length int) error {
+	if len(barcode) != length {
+		return fmt.Errorf("%w: expected %d digits", ErrInvalidBarcode, length)
+	}
+	for _, c := range barcode {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("%w: expected %d digits", ErrInvalidBarcode, length)
+		}
+	}
+
+	if checkDigit(barcode[:length-1]) != barcode[length-1] {
+		return fmt.Errorf("%w: wrong check digit", ErrInvalidBarcode)
+	}
+	return nil
+}
+
+// checkDigit computes the GTIN check digit of the digits, which are weighted 3 and 1 from the right
+func checkDigit(digits string) byte {
+	sum := 0
+	for i := range digits {
+		weight := 1
+		if (len(digits)-i)%2 == 1 {
+			weight = 3
+		}
+		sum += int(digits[i]-'0') * weight
+	}
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBarcode(t *testing.T) {
+	testCases := []struct {
+		name      string
+		symbology string
+		barcode   string
+		valid     bool
+	}{
+		{name: "EAN13", symbology: BarcodeEAN13, barcode: "4006381333931", valid: true},
+		{name: "EAN13WrongCheckDigit", symbology: BarcodeEAN13, barcode: "4006381333932"},
+		{name: "EAN13TooShort", symbology: BarcodeEAN13, barcode: "400638133393"},
+		{name: "EAN13NotDigits", symbology: BarcodeEAN13, barcode: "40063813339a1"},
+		{name: "UPCA", symbology: BarcodeUPCA, barcode: "036000291452", valid: true},
+		{name: "UPCAWrongCheckDigit", symbology: BarcodeUPCA, barcode: "036000291453"},
+		{name: "UPCAAsEAN13", symbology: BarcodeEAN13, barcode: "0036000291452", valid: true},
+		{name: "Code128", symbology: BarcodeCode128, barcode: "PART-0042/b", valid: true},
+		{name: "Code128Empty", symbology: BarcodeCode128, barcode: ""},
+		{name: "Code128TooLong", symbology: BarcodeCode128, barcode: strings.Repeat("a", maxCode128Length+1)},
+		{name: "Code128NotPrintable", symbology: BarcodeCode128, barcode: "PART\t42"},
+		{name: "UnknownSymbology", symbology: "qr", barcode: "4006381333931"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateBarcode(tc.symbology, tc.barcode)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidBarcode)
+			}
+		})
+	}
+}
+
+func TestRandomEAN13(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		require.NoError(t, ValidateBarcode(BarcodeEAN13, RandomEAN13()))
+	}
+}
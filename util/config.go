@@ -1,6 +1,10 @@
 package util
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 // Config stores all configuration of the application.
 // The values are read from viper from a config file or enviroment variables.
@@ -8,6 +12,20 @@ type Config struct {
 	DBDriver      string `mapstructure:"DB_DRIVER"`
 	DBSource      string `mapstructure:"DB_SOURCE"`
 	ServerAddress string `mapstructure:"SERVER_ADDRESS"`
+	// TokenSymmetricKey signs the access tokens, it must be exactly 32 characters long
+	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	// LowStockCheckInterval is the time between the checks of every good for low stock, zero disables them.
+	// Goods falling below their reorder point are checked as soon as they do.
+	LowStockCheckInterval time.Duration `mapstructure:"LOW_STOCK_CHECK_INTERVAL"`
+	// InventoryCurrency is the ISO 4217 code of the currency the stock is valued in,
+	// purchase orders are only placed with suppliers paid in it
+	InventoryCurrency string `mapstructure:"INVENTORY_CURRENCY"`
+	// AdminUsername, AdminPassword and AdminEmail set up the first admin when the server starts on a database
+	// without an admin, the admin then creates the other users. They are left empty once there is an admin.
+	AdminUsername string `mapstructure:"ADMIN_USERNAME"`
+	AdminPassword string `mapstructure:"ADMIN_PASSWORD"`
+	AdminEmail    string `mapstructure:"ADMIN_EMAIL"`
 }
 
 // LoadConfig reads configurations from file or enviroment variables.
@@ -31,4 +31,25 @@ func RandomString(n int) string {
 // RandomName generate a random name
 func RandomName() string {
 	return RandomString(6)
-}
\ No newline at end of file
+}
+
+// RandomEmail generate a random email
+func RandomEmail() string {
+	return RandomString(6) + "@email.com"
+}
+
+// RandomCurrency generate a random currency code
+func RandomCurrency() string {
+	currencies := []string{"EUR", "USD", "GBP"}
+	return currencies[rand.Intn(len(currencies))]
+}
+
+// RandomEAN13 generate a random EAN-13 barcode with a valid check digit
+func RandomEAN13() string {
+	var sb strings.Builder
+	for i := 0; i < 12; i++ {
+		sb.WriteByte(byte('0' + rand.Intn(10)))
+	}
+	sb.WriteByte(checkDigit(sb.String()))
+	return sb.String()
+}
@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	db "inventory_management/db/sqlc"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// LowStockChannel is the channel the database notifies with the id of every good
+// that falls below or comes back to its reorder point
+const LowStockChannel = "low_stock"
+
+// LowStockChecker raises stock alerts for the goods falling below their reorder point
+// and resolves them once the goods are restocked
+type LowStockChecker struct {
+	store db.Store
+	// interval between the checks of every good, zero disables them
+	interval time.Duration
+}
+
+// NewLowStockChecker creates a new low stock checker
+func NewLowStockChecker(store db.Store, interval time.Duration) *LowStockChecker {
+	return &LowStockChecker{
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run checks the goods named by the notifications until the context is done or the channel is closed.
+// Every good is checked when it starts, after the nil notification the listener sends once it has
+// reconnected, and at every interval, so notifications lost on the way still raise their alerts.
+func (checker *LowStockChecker) Run(ctx context.Context, notifications <-chan *pq.Notification) {
+	checker.check(ctx, sql.NullInt64{})
+
+	var tick <-chan time.Time
+	if checker.interval > 0 {
+		ticker := time.NewTicker(checker.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification, ok := <-notifications:
+			if !ok {
+				return
+			}
+			if notification == nil {
+				checker.check(ctx, sql.NullInt64{})
+				continue
+			}
+
+			goodID, err := strconv.ParseInt(notification.Extra, 10, 64)
+			if err != nil {
+				log.Printf("low stock checker: invalid notification %q: %v", notification.Extra, err)
+				continue
+			}
+			checker.check(ctx, sql.NullInt64{Int64: goodID, Valid: true})
+		case <-tick:
+			checker.check(ctx, sql.NullInt64{})
+		}
+	}
+}
+
+// check raises and resolves the alerts of the good, or of every good when none is given
+func (checker *LowStockChecker) check(ctx context.Context, goodID sql.NullInt64) {
+	raised, err := checker.store.RaiseStockAlerts(ctx, goodID)
+	if err != nil {
+		log.Printf("low stock checker: cannot raise alerts: %v", err)
+		return
+	}
+	for _, alert := range raised {
+		log.Printf("low stock: good %d has %d available, below its reorder point of %d (below safety stock: %t)",
+			alert.GoodID, alert.Available, alert.ReorderPoint, alert.BelowSafetyStock)
+	}
+
+	resolved, err := checker.store.ResolveStockAlerts(ctx, goodID)
+	if err != nil {
+		log.Printf("low stock checker: cannot resolve alerts: %v", err)
+		return
+	}
+	for _, alert := range resolved {
+		log.Printf("low stock: good %d is back at its reorder point", alert.GoodID)
+	}
+}
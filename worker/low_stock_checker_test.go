@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	mockdb "inventory_management/db/mock"
+	db "inventory_management/db/sqlc"
+	"inventory_management/util"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+)
+
+func TestLowStockChecker(t *testing.T) {
+	goodID := util.RandomInt(1, 1000)
+	alert := db.StockAlert{
+		ID:           util.RandomInt(1, 1000),
+		GoodID:       goodID,
+		Available:    1,
+		ReorderPoint: 5,
+	}
+
+	testCases := []struct {
+		name         string
+		notification *pq.Notification
+		buildStubs   func(store *mockdb.MockStore)
+	}{
+		{
+			name:         "Good",
+			notification: &pq.Notification{Channel: LowStockChannel, Extra: strconv.FormatInt(goodID, 10)},
+			buildStubs: func(store *mockdb.MockStore) {
+				good := sql.NullInt64{Int64: goodID, Valid: true}
+				store.EXPECT().RaiseStockAlerts(gomock.Any(), gomock.Eq(good)).Times(1).Return([]db.StockAlert{alert}, nil)
+				store.EXPECT().ResolveStockAlerts(gomock.Any(), gomock.Eq(good)).Times(1).Return([]db.StockAlert{}, nil)
+			},
+		},
+		{
+			name:         "Reconnected",
+			notification: nil,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().RaiseStockAlerts(gomock.Any(), gomock.Eq(sql.NullInt64{})).Times(1).Return([]db.StockAlert{}, nil)
+				store.EXPECT().ResolveStockAlerts(gomock.Any(), gomock.Eq(sql.NullInt64{})).Times(1).Return([]db.StockAlert{alert}, nil)
+			},
+		},
+		{
+			name:         "InvalidNotification",
+			notification: &pq.Notification{Channel: LowStockChannel, Extra: "good"},
+			buildStubs:   func(store *mockdb.MockStore) {},
+		},
+		{
+			name:         "RaiseError",
+			notification: &pq.Notification{Channel: LowStockChannel, Extra: strconv.FormatInt(goodID, 10)},
+			buildStubs: func(store *mockdb.MockStore) {
+				good := sql.NullInt64{Int64: goodID, Valid: true}
+				store.EXPECT().RaiseStockAlerts(gomock.Any(), gomock.Eq(good)).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().ResolveStockAlerts(gomock.Any(), gomock.Eq(good)).Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			// every good is checked when the checker starts
+			all := sql.NullInt64{}
+			first := store.EXPECT().RaiseStockAlerts(gomock.Any(), gomock.Eq(all)).Times(1).Return([]db.StockAlert{}, nil)
+			store.EXPECT().ResolveStockAlerts(gomock.Any(), gomock.Eq(all)).Times(1).After(first).Return([]db.StockAlert{}, nil)
+			tc.buildStubs(store)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			notifications := make(chan *pq.Notification)
+			done := make(chan struct{})
+
+			checker := NewLowStockChecker(store, 0)
+			go func() {
+				checker.Run(ctx, notifications)
+				close(done)
+			}()
+
+			notifications <- tc.notification
+			cancel()
+			<-done
+		})
+	}
+}
+
+func TestLowStockCheckerInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	checked := make(chan struct{}, 2)
+	store.EXPECT().RaiseStockAlerts(gomock.Any(), gomock.Eq(sql.NullInt64{})).MinTimes(2).Return([]db.StockAlert{}, nil)
+	store.EXPECT().ResolveStockAlerts(gomock.Any(), gomock.Eq(sql.NullInt64{})).MinTimes(2).
+		DoAndReturn(func(ctx context.Context, goodID sql.NullInt64) ([]db.StockAlert, error) {
+			select {
+			case checked <- struct{}{}:
+			default:
+			}
+			return []db.StockAlert{}, nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	notifications := make(chan *pq.Notification)
+	done := make(chan struct{})
+
+	checker := NewLowStockChecker(store, 10*time.Millisecond)
+	go func() {
+		checker.Run(ctx, notifications)
+		close(done)
+	}()
+
+	// the check at the start and the first one of the interval
+	<-checked
+	<-checked
+	cancel()
+	<-done
+}
+
+func TestLowStockCheckerClosed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().RaiseStockAlerts(gomock.Any(), gomock.Any()).Times(1).Return([]db.StockAlert{}, nil)
+	store.EXPECT().ResolveStockAlerts(gomock.Any(), gomock.Any()).Times(1).Return([]db.StockAlert{}, nil)
+
+	notifications := make(chan *pq.Notification)
+	close(notifications)
+
+	NewLowStockChecker(store, 0).Run(context.Background(), notifications)
+}